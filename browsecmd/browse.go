@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package browsecmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/browser"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/readonly"
+)
+
+func New(cnfg *config.Config) *cobra.Command {
+	var confirm bool
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: "Start the object browser",
+		Long:  "Start the object browser, which lists the distributed objects grouped by their types and shows the contents of the selected object",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			client, err := internal.ConnectToCluster(ctx, &cnfg.Hazelcast)
+			if err != nil {
+				return err
+			}
+			if err := browser.InitObjectBrowser(ctx, client, readonly.Enabled(cmd, cnfg), confirm).Start(); err != nil {
+				return hzcerrors.NewLoggableError(err, "Could not run the object browser")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&confirm, defaults.ConfirmFlag, true, "ask before clearing or destroying an object")
+	return cmd
+}
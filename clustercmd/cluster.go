@@ -22,67 +22,152 @@ import (
 	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/spf13/cobra"
 
+	"github.com/hazelcast/hazelcast-commandline-client/config"
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/confirmation"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/member"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
 )
 
 const invocationOnCloudInfoMessage = "Cluster operations on cloud are not supported. Checkout https://github.com/hazelcast/hazelcast-cloud-cli for cluster management on cloud."
 
-func New(config *hazelcast.Config) *cobra.Command {
+func New(cnfg *config.Config) *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "cluster {get-state | change-state | shutdown | query} [--state new-state]",
+		Use:   "cluster {get-state | change-state | shutdown | version | members | change-version | rolling-upgrade | force-start | partial-start | backup | license | events} [--state new-state | --version new-version]",
 		Short: "Administrative cluster operations",
 		Long:  `Administrative cluster operations which controls a Hazelcast cluster by manipulating its state and other features`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// check if it is a cloud invocation
-			if config.Cluster.Cloud.Enabled {
+			if cnfg.Hazelcast.Cluster.Cloud.Enabled {
 				return hzcerrors.NewLoggableError(nil, invocationOnCloudInfoMessage)
 			}
 			return nil
 		},
 	}
 	subCmds := []struct {
-		command string
-		info    string
+		command  string
+		info     string
+		readOnly bool
+		// question is asked before running the operation, for the operations which lose data
+		question string
 	}{
 		{
 			command: "shutdown",
 			info:    "shuts down the cluster",
 		},
 		{
-			command: "version",
-			info:    "retrieve information from the cluster",
+			command:  "version",
+			info:     "retrieve information from the cluster",
+			readOnly: true,
 		},
 		{
-			command: "get-state",
-			info:    "get state of the cluster",
+			command:  "get-state",
+			info:     "get state of the cluster",
+			readOnly: true,
+		},
+		{
+			command:  "members",
+			info:     "list members of the cluster",
+			readOnly: true,
+		},
+		{
+			command:  "force-start",
+			info:     "start the cluster when the persisted data cannot be restored, the persisted data of all the members is deleted",
+			question: "Force start the cluster? The persisted data of all the members is deleted",
+		},
+		{
+			command:  "partial-start",
+			info:     "start the cluster with the members which restored their persisted data, the data of the missing members is lost",
+			question: "Partially start the cluster? The data of the missing members is lost",
 		},
 	}
 	for _, sc := range subCmds {
-		// copy to use it in the inner func
-		sc := sc
-		cmd.AddCommand(&cobra.Command{
-			Use:   sc.command,
-			Short: sc.info,
-			RunE: func(cmd *cobra.Command, args []string) error {
-				defer hzcerrors.ErrorRecover()
-				result, err := internal.CallClusterOperation(config, sc.command)
-				if err != nil {
-					return err
-				}
-				fmt.Println(*result)
-				return nil
-			},
-		})
+		c := newOperationCommand(cnfg, sc.command, sc.info, sc.command, sc.readOnly)
+		if sc.question != "" {
+			c = confirmable(c, sc.question)
+		}
+		cmd.AddCommand(member.Targetable(c))
 	}
 	// adding this explicitly, since it is a bit different from the rest
-	cmd.AddCommand(NewChangeState(config))
+	cmd.AddCommand(NewChangeState(cnfg))
+	cmd.AddCommand(NewBackup(cnfg))
+	cmd.AddCommand(NewChangeVersion(cnfg))
+	cmd.AddCommand(NewRollingUpgrade(cnfg))
+	cmd.AddCommand(NewLicense(cnfg))
+	cmd.AddCommand(NewEvents(cnfg))
 	return &cmd
 }
 
+// newOperationCommand returns the command which runs the cluster operation, the read only operations can be watched and the others support the dry run.
+func newOperationCommand(cnfg *config.Config, use, info, operation string, readOnly bool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: info,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer hzcerrors.ErrorRecover()
+			if dryrun.Enabled(cmd) {
+				return printClusterOperation(cmd, cnfg, operation, "")
+			}
+			if constants.IsPersistenceOperation(operation) {
+				warnEnterpriseFeature(cmd, cnfg, internal.FeaturePersistence)
+			}
+			result, err := callClusterOperation(cmd, cnfg, operation, "")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), *result)
+			return nil
+		},
+	}
+	if readOnly {
+		return watch.Watchable(cmd)
+	}
+	return dryrun.Supported(cmd)
+}
+
+// confirmable asks the question before running the command unless --confirm=false is given, nothing is asked in the dry run mode.
+func confirmable(cmd *cobra.Command, question string) *cobra.Command {
+	var confirm bool
+	cmd.Use += " [--confirm=false]"
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if confirm && !dryrun.Enabled(cmd) {
+			ok, err := confirmation.Ask(cmd.InOrStdin(), cmd.ErrOrStderr(), question)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				quiet.Println(cmd, "The operation is not run")
+				return nil
+			}
+		}
+		return runE(cmd, args)
+	}
+	cmd.Flags().BoolVar(&confirm, defaults.ConfirmFlag, true, "ask before running the operation")
+	return cmd
+}
+
+func NewBackup(cnfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup {trigger | interrupt}",
+		Short: "Persistence backup operations",
+		Long:  `Persistence backup operations, which copy the persisted data of the members to their backup directories. Persistence must be enabled on the cluster`,
+	}
+	cmd.AddCommand(
+		member.Targetable(newOperationCommand(cnfg, "trigger", "start a backup of the persisted data on all the members", constants.ClusterBackup, false)),
+		member.Targetable(newOperationCommand(cnfg, "interrupt", "interrupt the running backup on all the members", constants.ClusterBackupInterrupt, false)),
+	)
+	return cmd
+}
+
 var states = []string{"active", "no_migration", "frozen", "passive"}
 
-func NewChangeState(config *hazelcast.Config) *cobra.Command {
+func NewChangeState(cnfg *config.Config) *cobra.Command {
 	// monitored flag variable
 	var newState string
 	cmd := &cobra.Command{
@@ -90,11 +175,14 @@ func NewChangeState(config *hazelcast.Config) *cobra.Command {
 		Short: "Change state of the cluster",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			defer hzcerrors.ErrorRecover()
-			result, err := internal.CallClusterOperationWithState(config, "change-state", &newState)
+			if dryrun.Enabled(cmd) {
+				return printClusterOperation(cmd, cnfg, "change-state", newState)
+			}
+			result, err := callClusterOperation(cmd, cnfg, "change-state", newState)
 			if err != nil {
 				return err
 			}
-			fmt.Println(*result)
+			fmt.Fprintln(cmd.OutOrStdout(), *result)
 			return nil
 		},
 	}
@@ -103,5 +191,54 @@ func NewChangeState(config *hazelcast.Config) *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("state", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return states, cobra.ShellCompDirectiveDefault
 	})
-	return cmd
+	return member.Targetable(dryrun.Supported(cmd))
+}
+
+// callClusterOperation routes the operation through the Management Center if it is configured and supports the operation,
+// unless a member is given with the member flag.
+func callClusterOperation(cmd *cobra.Command, cnfg *config.Config, operation string, state string) (*string, error) {
+	if isManagementCenterRoute(cmd, cnfg, operation) {
+		return internal.CallManagementCenterOperation(&cnfg.ManagementCenter, &cnfg.Hazelcast, operation, state)
+	}
+	conf, err := restConfig(cmd, cnfg)
+	if err != nil {
+		return nil, err
+	}
+	return internal.CallClusterOperationWithState(conf, operation, &state)
+}
+
+// printClusterOperation prints the request which would run the operation, using the same route as callClusterOperation.
+func printClusterOperation(cmd *cobra.Command, cnfg *config.Config, operation string, state string) error {
+	var (
+		req string
+		err error
+	)
+	if isManagementCenterRoute(cmd, cnfg, operation) {
+		req, err = internal.DescribeManagementCenterOperation(&cnfg.ManagementCenter, &cnfg.Hazelcast, operation, state)
+	} else {
+		var conf *hazelcast.Config
+		if conf, err = restConfig(cmd, cnfg); err == nil {
+			req, err = internal.DescribeClusterOperation(conf, operation, state)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	dryrun.Print(cmd, "run the %s operation with %s", operation, req)
+	return nil
+}
+
+func isManagementCenterRoute(cmd *cobra.Command, cnfg *config.Config, operation string) bool {
+	return cnfg.ManagementCenter.URL != "" && internal.IsManagementCenterOperation(operation) && member.Value(cmd) == ""
+}
+
+// restConfig returns the configuration of the REST calls, which are sent to the member given with the member flag instead of the first configured address.
+func restConfig(cmd *cobra.Command, cnfg *config.Config) (*hazelcast.Config, error) {
+	address, err := member.Address(cmd.Context(), &cnfg.Hazelcast, cmd)
+	if err != nil || address == "" {
+		return &cnfg.Hazelcast, err
+	}
+	conf := cnfg.Hazelcast.Clone()
+	conf.Cluster.Network.SetAddresses(address)
+	return &conf, nil
 }
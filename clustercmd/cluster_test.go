@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+)
+
+func TestConfirmable(t *testing.T) {
+	for _, tc := range []struct {
+		info  string
+		args  []string
+		ran   bool
+		isErr bool
+	}{
+		// the question cannot be asked since the input of the test is not a terminal
+		{info: "confirm", isErr: true},
+		{info: "without confirmation", args: []string{"--confirm=false"}, ran: true},
+		{info: "dry run", args: []string{"--dry-run"}, ran: true},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			var ran bool
+			cmd := confirmable(&cobra.Command{
+				Use: "force-start",
+				RunE: func(cmd *cobra.Command, args []string) error {
+					ran = true
+					return nil
+				},
+			}, "Force start the cluster?")
+			cmd.Flags().Bool(dryrun.Flag, false, "")
+			cmd.SetIn(strings.NewReader("y\n"))
+			cmd.SetArgs(tc.args)
+			cmd.SilenceErrors, cmd.SilenceUsage = true, true
+			err := cmd.Execute()
+			if (err != nil) != tc.isErr {
+				t.Fatalf("want error %t got %v", tc.isErr, err)
+			}
+			if ran != tc.ran {
+				t.Fatalf("want ran %t got %t", tc.ran, ran)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+// the kinds of the cluster events
+const (
+	eventMember               = "member"
+	eventMemberAdded          = "member-added"
+	eventMemberRemoved        = "member-removed"
+	eventClientConnected      = "client-connected"
+	eventClientDisconnected   = "client-disconnected"
+	eventClientChangedCluster = "client-changed-cluster"
+	eventObjectCreated        = "object-created"
+	eventObjectDestroyed      = "object-destroyed"
+)
+
+// eventsOutputTypes are the output types of the events, pretty prints each event on a line with its fields
+var eventsOutputTypes = []string{output.TypePretty, output.TypeJSON}
+
+// clusterEvent is an event with its fields, the fields are in the order they are printed.
+type clusterEvent struct {
+	time   time.Time
+	kind   string
+	names  []string
+	values []interface{}
+}
+
+func memberEvent(kind string, m cluster.MemberInfo) clusterEvent {
+	v := m.Version
+	return clusterEvent{
+		time:   time.Now(),
+		kind:   kind,
+		names:  []string{"address", "uuid", "version", "liteMember"},
+		values: []interface{}{string(m.Address), m.UUID.String(), fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch), m.LiteMember},
+	}
+}
+
+func objectEvent(e hazelcast.DistributedObjectNotified) clusterEvent {
+	kind := eventObjectCreated
+	if e.EventType == hazelcast.DistributedObjectDestroyed {
+		kind = eventObjectDestroyed
+	}
+	return clusterEvent{time: time.Now(), kind: kind, names: []string{"service", "name"}, values: []interface{}{e.ServiceName, e.ObjectName}}
+}
+
+// lifecycleEvent returns the event of the connection of this client, ok is false for the states of the client itself, such as starting.
+func lifecycleEvent(e hazelcast.LifecycleStateChanged) (ev clusterEvent, ok bool) {
+	var kind string
+	switch e.State {
+	case hazelcast.LifecycleStateConnected:
+		kind = eventClientConnected
+	case hazelcast.LifecycleStateDisconnected:
+		kind = eventClientDisconnected
+	case hazelcast.LifecycleStateChangedCluster:
+		kind = eventClientChangedCluster
+	default:
+		return ev, false
+	}
+	return clusterEvent{time: time.Now(), kind: kind}, true
+}
+
+// write writes the event on a line, as the time, the kind and the fields, or as a JSON object with the json output type.
+func (e clusterEvent) write(w io.Writer, outputType string) error {
+	t := e.time.Format(time.RFC3339Nano)
+	if outputType == output.TypeJSON {
+		names := append([]string{"time", "event"}, e.names...)
+		values := append([]interface{}{t, e.kind}, e.values...)
+		b, err := output.DefaultOptions().MarshalJSONObject(names, values)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
+		return err
+	}
+	fields := []string{t, e.kind}
+	for i, name := range e.names {
+		fields = append(fields, fmt.Sprintf("%s=%s", name, output.String(e.values[i])))
+	}
+	_, err := fmt.Fprintln(w, strings.Join(fields, "  "))
+	return err
+}
+
+func NewEvents(cnfg *config.Config) *cobra.Command {
+	var (
+		follow     bool
+		outputType string
+		count      int
+	)
+	cmd := &cobra.Command{
+		Use:   "events [--follow] [--output-type pretty|json] [--count count]",
+		Short: "Print the members and follow the cluster events",
+		Long: `Print the current members of the cluster, and with --follow, the cluster events as they happen until Ctrl+C is pressed:
+the members which join and leave the cluster, the connection changes of this client, and the distributed objects which are created and destroyed.
+The migration and partition lost events are not available to the client.`,
+		Example: `  # Follow the events until Ctrl+C is pressed
+  hzc cluster events --follow
+  # Follow the events as JSON lines, such as to process them with jq
+  hzc cluster events --follow -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isEventsOutputType(outputType) {
+				return hzcerrors.NewLoggableError(nil, "Provided output type parameter (%s) is not a known type. Provide either '%s'",
+					outputType, strings.Join(eventsOutputTypes, "' or '"))
+			}
+			if count < 0 {
+				return hzcerrors.NewLoggableError(nil, "Count must not be negative")
+			}
+			ctx := cmd.Context()
+			ci, err := internal.ConnectToCluster(ctx, &cnfg.Hazelcast)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			for _, m := range internal.ClusterMembers() {
+				if err := memberEvent(eventMember, m).write(out, outputType); err != nil {
+					return err
+				}
+			}
+			if !follow {
+				return nil
+			}
+			return followEvents(cmd, ci, outputType, count)
+		},
+	}
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "print the events as they happen until Ctrl+C is pressed")
+	cmd.Flags().StringVarP(&outputType, defaults.OutputTypeFlag, "o", output.TypePretty, strings.Join(eventsOutputTypes, ", "))
+	defaults.SetValues(cmd, defaults.OutputTypeFlag, eventsOutputTypes)
+	if err := cmd.RegisterFlagCompletionFunc(defaults.OutputTypeFlag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return eventsOutputTypes, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		panic(err)
+	}
+	cmd.Flags().IntVar(&count, "count", 0, "number of events to follow before stopping, 0 means until Ctrl+C is pressed")
+	return cmd
+}
+
+func isEventsOutputType(outputType string) bool {
+	for _, t := range eventsOutputTypes {
+		if t == outputType {
+			return true
+		}
+	}
+	return false
+}
+
+func followEvents(cmd *cobra.Command, ci *hazelcast.Client, outputType string, count int) error {
+	ctx := cmd.Context()
+	// the events are printed in the command goroutine, so that the output is not written concurrently
+	events := make(chan clusterEvent, 1024)
+	// done stops the events received after the command returns from blocking the listeners
+	done := make(chan struct{})
+	defer close(done)
+	send := func(e clusterEvent) {
+		select {
+		case events <- e:
+		case <-done:
+		}
+	}
+	memberID, err := ci.AddMembershipListener(func(e cluster.MembershipStateChanged) {
+		kind := eventMemberAdded
+		if e.State == cluster.MembershipStateRemoved {
+			kind = eventMemberRemoved
+		}
+		send(memberEvent(kind, e.Member))
+	})
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot listen to the membership events")
+	}
+	defer ci.RemoveMembershipListener(memberID)
+	lifecycleID, err := ci.AddLifecycleListener(func(e hazelcast.LifecycleStateChanged) {
+		if ev, ok := lifecycleEvent(e); ok {
+			send(ev)
+		}
+	})
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot listen to the connection events")
+	}
+	defer ci.RemoveLifecycleListener(lifecycleID)
+	objectID, err := ci.AddDistributedObjectListener(ctx, func(e hazelcast.DistributedObjectNotified) {
+		send(objectEvent(e))
+	})
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot listen to the distributed object events")
+	}
+	internal.RegisterListener("distributed object", "cluster", objectID, func(ctx context.Context) error {
+		return ci.RemoveDistributedObjectListener(ctx, objectID)
+	})
+	defer func() {
+		// a listener which cannot be removed stays listed, so that it can be removed later with "listener remove"
+		if err := ci.RemoveDistributedObjectListener(context.Background(), objectID); err != nil {
+			cmd.PrintErrf("Cannot remove the distributed object listener %s, remove it with \"hzc listener remove %s\"\n", objectID, objectID)
+			return
+		}
+		internal.UnregisterListener(objectID)
+	}()
+	quiet.Printf(cmd, "Following the cluster events, press Ctrl+C to stop\n")
+	for n := 0; count == 0 || n < count; n++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-events:
+			if err := e.write(cmd.OutOrStdout(), outputType); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot print the event")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+func TestClusterEventWrite(t *testing.T) {
+	member := cluster.MemberInfo{
+		Address: "127.0.0.1:5701",
+		UUID:    types.NewUUIDWith(1, 2),
+		Version: cluster.MemberVersion{Major: 5, Minor: 1, Patch: 2},
+	}
+	e := memberEvent(eventMemberAdded, member)
+	e.time = time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	uuid := member.UUID.String()
+	for _, tc := range []struct {
+		outputType string
+		want       string
+	}{
+		{
+			outputType: output.TypePretty,
+			want:       "2022-06-01T10:00:00Z  member-added  address=127.0.0.1:5701  uuid=" + uuid + "  version=5.1.2  liteMember=false\n",
+		},
+		{
+			outputType: output.TypeJSON,
+			want:       `{"time":"2022-06-01T10:00:00Z","event":"member-added","address":"127.0.0.1:5701","uuid":"` + uuid + `","version":"5.1.2","liteMember":false}` + "\n",
+		},
+	} {
+		var b bytes.Buffer
+		if err := e.write(&b, tc.outputType); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.outputType, b.String(), tc.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/member"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
+)
+
+// featuresNotReported is shown for the features if the members do not report the features of the license
+const featuresNotReported = "not reported by the members"
+
+func NewLicense(cnfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "license",
+		Short: "Show the license of the cluster and its enterprise features",
+		Long: `Show the Hazelcast Enterprise license of the cluster: its type, owner, maximum number of members, expiry date and enterprise features.
+It uses the REST API of the members.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := restConfig(cmd, cnfg)
+			if err != nil {
+				return err
+			}
+			info, err := internal.GetLicenseInfo(conf)
+			if err != nil {
+				return err
+			}
+			if info == nil {
+				quiet.Println(cmd, "The cluster does not have an enterprise license, the enterprise features such as WAN replication, persistence and security are not available")
+				return nil
+			}
+			names, values := licenseFields(info, time.Now())
+			f, err := output.NewFormatter(output.TypeVertical, cmd.OutOrStdout(), output.DefaultOptions())
+			if err != nil {
+				return err
+			}
+			if err := f.WriteHeader(names); err != nil {
+				return err
+			}
+			if err := f.WriteRow(values); err != nil {
+				return err
+			}
+			return f.Close()
+		},
+	}
+	return member.Targetable(watch.Watchable(cmd))
+}
+
+func licenseFields(info *internal.LicenseInfo, now time.Time) ([]string, []interface{}) {
+	names := []string{"type", "company", "owner email", "max members", "expiry date", "days left", "features"}
+	var expiry, daysLeft interface{}
+	if !info.ExpiryDate.IsZero() {
+		expiry = info.ExpiryDate.Format("2006-01-02")
+		if info.Expired(now) {
+			daysLeft = "expired"
+		} else {
+			daysLeft = int64(info.ExpiryDate.Sub(now).Hours() / 24)
+		}
+	}
+	features := featuresNotReported
+	if info.Features != nil {
+		features = strings.Join(info.Features, ", ")
+	}
+	values := []interface{}{info.Type, info.CompanyName, info.OwnerEmail, info.MaxNodeCount, expiry, daysLeft, features}
+	return names, values
+}
+
+// warnEnterpriseFeature prints a warning if the license of the cluster shows that the feature the command requires is not available.
+func warnEnterpriseFeature(cmd *cobra.Command, cnfg *config.Config, feature string) {
+	conf, err := restConfig(cmd, cnfg)
+	if err != nil {
+		return
+	}
+	if w := internal.LicenseWarning(conf, feature); w != "" {
+		cmd.PrintErrf("warning  %s\n", w)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/member"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
+)
+
+func NewChangeVersion(cnfg *config.Config) *cobra.Command {
+	var version string
+	cmd := &cobra.Command{
+		Use:   "change-version --version major.minor",
+		Short: "Change the version of the cluster to finalize a rolling upgrade",
+		Long: `Change the version of the cluster to finalize a rolling upgrade, after all the members are upgraded.
+Check if it is safe with the "rolling-upgrade status" command first.`,
+		Example: `  # Finalize the upgrade after all the members run 5.2
+  hzc cluster change-version --version 5.2`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer hzcerrors.ErrorRecover()
+			if dryrun.Enabled(cmd) {
+				return printClusterOperation(cmd, cnfg, constants.ClusterChangeVersion, version)
+			}
+			warnEnterpriseFeature(cmd, cnfg, internal.FeatureRollingUpgrade)
+			result, err := callClusterOperation(cmd, cnfg, constants.ClusterChangeVersion, version)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), *result)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&version, "version", "", "new version of the cluster with the major and the minor versions, such as 5.2")
+	cmd.MarkFlagRequired("version")
+	return member.Targetable(dryrun.Supported(cmd))
+}
+
+func NewRollingUpgrade(cnfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rolling-upgrade {status}",
+		Short: "Rolling upgrade helpers",
+	}
+	status := &cobra.Command{
+		Use:   "status",
+		Short: "Show the versions of the members and whether the upgrade can be finalized",
+		Long: `Show the versions of the members and the cluster during a rolling upgrade, and whether the cluster version can be changed to finalize it.
+Use it with --watch to track the members while they are upgraded.`,
+		Example: `  # Track the members during the upgrade
+  hzc cluster rolling-upgrade status --watch 5s`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := internal.ConnectToCluster(cmd.Context(), &cnfg.Hazelcast); err != nil {
+				return err
+			}
+			members := internal.ClusterMembers()
+			// the cluster version is only available through the REST API, the status is shown without it if the API is disabled
+			var clusterVersion string
+			if result, err := callClusterOperation(cmd, cnfg, constants.ClusterVersion, ""); err == nil {
+				clusterVersion = parseClusterVersion(*result)
+			}
+			out := cmd.OutOrStdout()
+			shown := clusterVersion
+			if shown == "" {
+				shown = "unknown, the REST API of the members is not enabled"
+			}
+			fmt.Fprintf(out, "Cluster version: %s\n", shown)
+			f, err := output.NewFormatter(output.TypePretty, out, output.DefaultOptions())
+			if err != nil {
+				return err
+			}
+			if err := f.WriteHeader([]string{"member", "uuid", "version", "lite member"}); err != nil {
+				return err
+			}
+			for _, m := range members {
+				v := m.Version
+				row := []interface{}{string(m.Address), m.UUID.String(), fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch), m.LiteMember}
+				if err := f.WriteRow(row); err != nil {
+					return err
+				}
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			fmt.Fprintln(out, rollingUpgradeStatus(clusterVersion, members))
+			return nil
+		},
+	}
+	cmd.AddCommand(watch.Watchable(status))
+	return cmd
+}
+
+// parseClusterVersion returns the version in the response of the version endpoint, such as {"status":"success","version":"5.1"}.
+// It is empty if the response cannot be parsed.
+func parseClusterVersion(body string) string {
+	var resp struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return ""
+	}
+	return resp.Version
+}
+
+// rollingUpgradeStatus tells how far the rolling upgrade is, and whether the cluster version can be changed.
+// The cluster version is empty if it is not known.
+func rollingUpgradeStatus(clusterVersion string, members []cluster.MemberInfo) string {
+	if len(members) == 0 {
+		return "There are no members in the cluster"
+	}
+	target := members[0].Version
+	for _, m := range members[1:] {
+		if m.Version.MajorMinor() > target.MajorMinor() {
+			target = m.Version
+		}
+	}
+	targetVersion := fmt.Sprintf("%d.%d", target.Major, target.Minor)
+	var old []string
+	for _, m := range members {
+		if m.Version.MajorMinor() < target.MajorMinor() {
+			old = append(old, string(m.Address))
+		}
+	}
+	if len(old) > 0 {
+		return fmt.Sprintf("%d of %d members run %s. Upgrade the remaining members before changing the cluster version: %s",
+			len(members)-len(old), len(members), targetVersion, strings.Join(old, ", "))
+	}
+	switch {
+	case clusterVersion == "":
+		return fmt.Sprintf("All the members run %s. If the cluster version is older, finalize the upgrade with: hzc cluster change-version --version %s", targetVersion, targetVersion)
+	case clusterVersion == targetVersion:
+		return fmt.Sprintf("All the members and the cluster run %s, there is no rolling upgrade in progress", targetVersion)
+	case strings.SplitN(clusterVersion, ".", 2)[0] != fmt.Sprint(target.Major):
+		return fmt.Sprintf("All the members run %s, but the cluster version is %s. Rolling upgrades are supported only between the minor versions of the same major version", targetVersion, clusterVersion)
+	}
+	return fmt.Sprintf("All the members run %s, it is safe to finalize the upgrade with: hzc cluster change-version --version %s", targetVersion, targetVersion)
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+)
+
+func TestRollingUpgradeStatus(t *testing.T) {
+	member := func(address string, major, minor byte) cluster.MemberInfo {
+		return cluster.MemberInfo{Address: cluster.Address(address), Version: cluster.MemberVersion{Major: major, Minor: minor, Patch: 1}}
+	}
+	for _, tc := range []struct {
+		info           string
+		clusterVersion string
+		members        []cluster.MemberInfo
+		want           string
+	}{
+		{info: "no members", want: "There are no members"},
+		{
+			info:           "in progress",
+			clusterVersion: "5.1",
+			members:        []cluster.MemberInfo{member("m1:5701", 5, 2), member("m2:5701", 5, 1), member("m3:5701", 5, 2)},
+			want:           "2 of 3 members run 5.2. Upgrade the remaining members before changing the cluster version: m2:5701",
+		},
+		{
+			info:           "safe to finalize",
+			clusterVersion: "5.1",
+			members:        []cluster.MemberInfo{member("m1:5701", 5, 2), member("m2:5701", 5, 2)},
+			want:           "it is safe to finalize the upgrade with: hzc cluster change-version --version 5.2",
+		},
+		{
+			info:           "finalized",
+			clusterVersion: "5.2",
+			members:        []cluster.MemberInfo{member("m1:5701", 5, 2)},
+			want:           "there is no rolling upgrade in progress",
+		},
+		{
+			info:    "unknown cluster version",
+			members: []cluster.MemberInfo{member("m1:5701", 5, 2)},
+			want:    "If the cluster version is older",
+		},
+		{
+			info:           "major version",
+			clusterVersion: "4.2",
+			members:        []cluster.MemberInfo{member("m1:5701", 5, 0)},
+			want:           "only between the minor versions",
+		},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if got := rollingUpgradeStatus(tc.clusterVersion, tc.members); !strings.Contains(got, tc.want) {
+				t.Errorf("want %q in %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseClusterVersion(t *testing.T) {
+	if v := parseClusterVersion(`{"status":"success","version":"5.1"}`); v != "5.1" {
+		t.Errorf("want 5.1 got %s", v)
+	}
+	if v := parseClusterVersion("forbidden"); v != "" {
+		t.Errorf("want no version got %s", v)
+	}
+}
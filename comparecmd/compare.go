@@ -0,0 +1,276 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package comparecmd
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const (
+	SourceFlag = "source"
+	TargetFlag = "target"
+	SampleFlag = "sample"
+)
+
+// compareBatchSize is the number of the entries fetched at once from each map
+const compareBatchSize = 1000
+
+const (
+	missingInTarget = "missing-in-target"
+	missingInSource = "missing-in-source"
+	mismatched      = "mismatched"
+)
+
+const compareMapExample = `  # Compare the map on the cluster of prod.yaml with the one on the cluster of dr.yaml
+  hzc compare map --source prod.yaml/users --target dr.yaml/users
+  # Compare 10000 random keys of two maps on the cluster of the current configuration
+  hzc compare map --source users --target users-migrated --sample 10000`
+
+func New(cnfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare {map} --source [config/]name --target [config/]name",
+		Short: "Compare the data of two clusters or two objects",
+	}
+	cmd.AddCommand(NewMap(cnfg))
+	return cmd
+}
+
+func NewMap(cnfg *config.Config) *cobra.Command {
+	var (
+		source,
+		target string
+		sample int
+	)
+	cmd := &cobra.Command{
+		Use:   "map --source [config/]name --target [config/]name [--sample count]",
+		Short: "Report the missing and mismatched keys of two maps",
+		Long: `Report the missing and mismatched keys of two maps, such as to validate the WAN replication or a migration.
+The maps are given as CONFIG/NAME, where CONFIG is the path of the configuration file of the cluster of the map.
+If the configuration is left out, the map is on the cluster of the current configuration.
+The values are compared by the hashes of their representations, each difference is printed on its own line,
+followed by the key. The command fails if there are differences.`,
+		Example: compareMapExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			src, err := parseMapLocation(source)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid --%s", SourceFlag)
+			}
+			tgt, err := parseMapLocation(target)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid --%s", TargetFlag)
+			}
+			if sample < 0 {
+				return hzcerrors.NewLoggableError(nil, "Sample cannot be negative")
+			}
+			ctx := cmd.Context()
+			sm, closeSource, err := openMap(ctx, cnfg, src)
+			if err != nil {
+				return err
+			}
+			defer closeSource()
+			tm, closeTarget, err := openMap(ctx, cnfg, tgt)
+			if err != nil {
+				return err
+			}
+			defer closeTarget()
+			rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+			res, err := compareMaps(ctx, sm, tm, sample, rnd)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot compare map %s with map %s", source, target)
+			}
+			counts := map[string]int{}
+			for _, d := range res.diffs {
+				counts[d.kind]++
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", d.kind, output.String(d.key))
+			}
+			quiet.Printf(cmd, "Compared %d keys: %d missing in target, %d missing in source, %d mismatched\n",
+				res.compared, counts[missingInTarget], counts[missingInSource], counts[mismatched])
+			if len(res.diffs) > 0 {
+				return hzcerrors.NewLoggableError(nil, "Map %s and map %s differ", source, target)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&source, SourceFlag, "", "source map, as config/name or only the name for the cluster of the current configuration")
+	cmd.Flags().StringVar(&target, TargetFlag, "", "target map, as config/name or only the name for the cluster of the current configuration")
+	cmd.Flags().IntVar(&sample, SampleFlag, 0, "number of the random source keys to compare, 0 means all the keys of both maps")
+	for _, f := range []string{SourceFlag, TargetFlag} {
+		if err := cmd.MarkFlagRequired(f); err != nil {
+			panic(err)
+		}
+	}
+	return cmd
+}
+
+// mapLocation is a map and the configuration file of its cluster, which is empty for the current configuration.
+type mapLocation struct {
+	configPath string
+	mapName    string
+}
+
+func parseMapLocation(s string) (mapLocation, error) {
+	var loc mapLocation
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		loc.configPath, loc.mapName = s[:i], s[i+1:]
+	} else {
+		loc.mapName = s
+	}
+	if loc.mapName == "" {
+		return mapLocation{}, fmt.Errorf("map name is missing in %q, use config/name or name", s)
+	}
+	return loc, nil
+}
+
+// openMap returns the map and the func which releases its client.
+func openMap(ctx context.Context, cnfg *config.Config, loc mapLocation) (*hazelcast.Map, func(), error) {
+	ci, release, err := internal.ClientForConfig(ctx, cnfg, loc.configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := ci.GetMap(ctx, loc.mapName)
+	if err != nil {
+		release()
+		return nil, nil, hzcerrors.NewLoggableError(err, "Cannot get map %s", loc.mapName)
+	}
+	return m, release, nil
+}
+
+type difference struct {
+	kind string
+	key  interface{}
+}
+
+type compareResult struct {
+	// compared is the number of the distinct keys compared
+	compared int
+	diffs    []difference
+}
+
+// compareMaps compares the sample of the source keys with the target, or all the keys of both maps if sample is 0.
+func compareMaps(ctx context.Context, source, target *hazelcast.Map, sample int, rnd *rand.Rand) (compareResult, error) {
+	var res compareResult
+	keys, err := source.GetKeySet(ctx)
+	if err != nil {
+		return res, fmt.Errorf("getting the keys of the source: %w", err)
+	}
+	var targetOnly []interface{}
+	if sample > 0 && sample < len(keys) {
+		keys = sampleKeys(keys, sample, rnd)
+	} else {
+		targetKeys, err := target.GetKeySet(ctx)
+		if err != nil {
+			return res, fmt.Errorf("getting the keys of the target: %w", err)
+		}
+		targetOnly = keysNotIn(targetKeys, keys)
+	}
+	for len(keys) > 0 {
+		n := compareBatchSize
+		if n > len(keys) {
+			n = len(keys)
+		}
+		batch := keys[:n]
+		keys = keys[n:]
+		sourceEntries, err := source.GetAll(ctx, batch...)
+		if err != nil {
+			return res, fmt.Errorf("getting the entries of the source: %w", err)
+		}
+		targetEntries, err := target.GetAll(ctx, batch...)
+		if err != nil {
+			return res, fmt.Errorf("getting the entries of the target: %w", err)
+		}
+		res.diffs = append(res.diffs, compareEntries(sourceEntries, targetEntries)...)
+		res.compared += n
+	}
+	for _, k := range targetOnly {
+		res.diffs = append(res.diffs, difference{kind: missingInSource, key: k})
+	}
+	res.compared += len(targetOnly)
+	sort.SliceStable(res.diffs, func(i, j int) bool {
+		return keyID(res.diffs[i].key) < keyID(res.diffs[j].key)
+	})
+	return res, nil
+}
+
+// compareEntries returns the source entries which are missing in the target entries, or have different values.
+func compareEntries(source, target []types.Entry) []difference {
+	hashes := make(map[string]uint64, len(target))
+	for _, e := range target {
+		hashes[keyID(e.Key)] = valueHash(e.Value)
+	}
+	var diffs []difference
+	for _, e := range source {
+		h, ok := hashes[keyID(e.Key)]
+		switch {
+		case !ok:
+			diffs = append(diffs, difference{kind: missingInTarget, key: e.Key})
+		case h != valueHash(e.Value):
+			diffs = append(diffs, difference{kind: mismatched, key: e.Key})
+		}
+	}
+	return diffs
+}
+
+// keysNotIn returns the keys which are not in the others.
+func keysNotIn(keys, others []interface{}) []interface{} {
+	ids := make(map[string]struct{}, len(others))
+	for _, k := range others {
+		ids[keyID(k)] = struct{}{}
+	}
+	var res []interface{}
+	for _, k := range keys {
+		if _, ok := ids[keyID(k)]; !ok {
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+// sampleKeys returns n random keys, the order of the keys is changed.
+func sampleKeys(keys []interface{}, n int, rnd *rand.Rand) []interface{} {
+	for i := 0; i < n; i++ {
+		j := i + rnd.Intn(len(keys)-i)
+		keys[i], keys[j] = keys[j], keys[i]
+	}
+	return keys[:n]
+}
+
+// keyID identifies the key with its type, since the keys such as JSON values cannot be used as map keys.
+func keyID(k interface{}) string {
+	return output.TypedString(k)
+}
+
+func valueHash(v interface{}) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(output.TypedString(v)))
+	return h.Sum64()
+}
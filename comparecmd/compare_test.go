@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package comparecmd
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestParseMapLocation(t *testing.T) {
+	for _, tc := range []struct {
+		in     string
+		want   mapLocation
+		hasErr bool
+	}{
+		{in: "users", want: mapLocation{mapName: "users"}},
+		{in: "prod.yaml/users", want: mapLocation{configPath: "prod.yaml", mapName: "users"}},
+		{in: "/etc/hzc/dr.yaml/users", want: mapLocation{configPath: "/etc/hzc/dr.yaml", mapName: "users"}},
+		{in: "prod.yaml/", hasErr: true},
+		{in: "", hasErr: true},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := parseMapLocation(tc.in)
+			if (err != nil) != tc.hasErr {
+				t.Fatalf("want error %t got %v", tc.hasErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("want %+v got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCompareEntries(t *testing.T) {
+	source := []types.Entry{
+		{Key: "same", Value: serialization.JSON(`{"a":1}`)},
+		{Key: "changed", Value: "v1"},
+		{Key: "missing", Value: "v"},
+		{Key: int64(1), Value: "v"},
+	}
+	target := []types.Entry{
+		{Key: "same", Value: serialization.JSON(`{"a":1}`)},
+		{Key: "changed", Value: "v2"},
+		{Key: "1", Value: "v"},
+	}
+	want := []difference{
+		{kind: mismatched, key: "changed"},
+		{kind: missingInTarget, key: "missing"},
+		{kind: missingInTarget, key: int64(1)},
+	}
+	if got := compareEntries(source, target); !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v got %v", want, got)
+	}
+}
+
+func TestKeysNotIn(t *testing.T) {
+	got := keysNotIn([]interface{}{"a", "b", int32(3)}, []interface{}{"b", "3"})
+	if want := []interface{}{"a", int32(3)}; !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v got %v", want, got)
+	}
+}
+
+func TestSampleKeys(t *testing.T) {
+	keys := []interface{}{"a", "b", "c", "d", "e"}
+	got := sampleKeys(keys, 3, rand.New(rand.NewSource(1)))
+	if len(got) != 3 {
+		t.Fatalf("want 3 keys got %v", got)
+	}
+	seen := map[interface{}]bool{}
+	for _, k := range got {
+		if seen[k] {
+			t.Fatalf("duplicate key %v in %v", k, got)
+		}
+		seen[k] = true
+	}
+}
@@ -22,13 +22,17 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
 	"github.com/hazelcast/hazelcast-go-client/logger"
+	"github.com/hazelcast/hazelcast-go-client/types"
 	"gopkg.in/yaml.v2"
 
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/rawdata"
 )
 
 const defaultConfigFilename = "config.yaml"
@@ -48,17 +52,86 @@ type SSLConfig struct {
 	KeyPassword        string
 }
 
+type SQLConfig struct {
+	// Timing enables printing the elapsed time after each statement
+	Timing bool
+	// Expanded enables printing each row as a block of "column | value" lines by default
+	Expanded bool
+}
+
+// ManagementCenterConfig configures routing the administrative cluster operations through the Management Center REST API.
+// It is used for clusters which do not expose the REST endpoints of the members.
+type ManagementCenterConfig struct {
+	// URL is the address of the Management Center, such as http://localhost:8080
+	URL string
+}
+
+// TimeoutConfig limits the time spent on each step of a command, zero means no timeout.
+type TimeoutConfig struct {
+	// Connect is the maximum time to connect to the cluster
+	Connect time.Duration
+	// Invocation is the maximum time to wait for the response of an operation, the client default of 120 seconds is used if it is zero
+	Invocation time.Duration
+	// SQL is the maximum time to execute an SQL statement, including reading its results
+	SQL time.Duration
+}
+
+// DefaultsConfig sets the defaults of the command flags of this configuration, the flags given on the command line override them.
+type DefaultsConfig struct {
+	// OutputType is the default of the --output-type flag, it is used only by the commands which support that output type
+	OutputType string
+	// Confirm is the default of the --confirm flag, the commands ask before the irreversible changes if it is true
+	Confirm bool
+}
+
+// RawDataConfig configures reading the values which the client cannot deserialize as their serialized bytes, to inspect them.
+type RawDataConfig struct {
+	// TypeIDs are the type IDs of the custom serializers of the other clients, the values written with the Java serialization are always read as raw data
+	TypeIDs []int32
+}
+
 type Config struct {
-	Hazelcast hazelcast.Config
-	SSL       SSLConfig
+	Hazelcast           hazelcast.Config
+	SSL                 SSLConfig
+	CredentialsProvider CredentialsProviderConfig
+	SQL                 SQLConfig
+	ManagementCenter    ManagementCenterConfig
+	Timeout             TimeoutConfig
+	Defaults            DefaultsConfig
+	RawData             RawDataConfig
+	Theme               string
+	// Prompt is the template of the interactive mode prompt, such as "{config}@{cluster}[{state}]> "
+	Prompt string
+	// ReadOnly blocks the commands which change data, such as map put and the SQL DML statements
+	ReadOnly bool
+	// UpdateNotice enables the notice of the new releases when the interactive mode starts
+	UpdateNotice bool
+	// UsageStats enables recording the names of the commands run, they are only kept on this computer
+	UsageStats bool
+	// Path is the file the configuration is read from
+	Path string `yaml:"-"`
 }
 
 type GlobalFlagValues struct {
-	CfgFile string
-	Cluster string
-	Token   string
-	Address string
-	Verbose bool
+	CfgFile       string
+	Cluster       string
+	Token         string
+	Address       string
+	Username      string
+	Password      string
+	MCURL         string
+	TraceProtocol string
+	Record        string
+	Timeout       time.Duration
+	LogLevel      string
+	ReadOnly      bool
+	// retry flags are zero if they are not given
+	RedoOperation     bool
+	InvocationTimeout time.Duration
+	RetryMaxBackoff   time.Duration
+	RetryMultiplier   float64
+	RetryJitter       float64
+	Verbose           bool
 }
 
 func DefaultConfig() *Config {
@@ -67,7 +140,9 @@ func DefaultConfig() *Config {
 	hz.Logger.Level = logger.ErrorLevel
 	hz.Cluster.Name = DefaultClusterName
 	hz.Stats.Enabled = true
-	return &Config{Hazelcast: hz}
+	// without type IDs, it only reads the values of the Java serialization as raw data, which cannot fail
+	_ = rawdata.Register(&hz.Serialization, nil)
+	return &Config{Hazelcast: hz, Defaults: DefaultsConfig{Confirm: true}, UpdateNotice: true}
 }
 
 const defaultUserConfig = `hazelcast:
@@ -86,11 +161,27 @@ const defaultUserConfig = `hazelcast:
     discovery:
       usepublicip: false
     unisocket: true
-  network:
-    addresses:
-      - "localhost:5701"
-    # 0s means infinite timeout (no timeout)
-    connectiontimeout: 0s
+    # retry the failed operations even if they may run twice, such as map puts
+    redooperation: false
+    # backoff between the attempts to connect to the cluster, it is multiplied after each attempt up to the maximum
+    # jitter is the ratio of randomness added to the backoff, between 0 and 1
+    connectionstrategy:
+      retry:
+        initialbackoff: 1s
+        maxbackoff: 30s
+        multiplier: 1.05
+        jitter: 0
+    network:
+      addresses:
+        - "localhost:5701"
+      # timeout of each connection attempt, 0s means the client default of 5s
+      connectiontimeout: 0s
+  # fallback clusters to connect in the given order when the cluster above is not reachable
+  # only the connection details are required, the rest of the settings is the same as the cluster above
+  failover:
+    enabled: false
+    trycount: 0
+    configs: []
 ssl:
   enabled: false
   servername: ""
@@ -98,7 +189,48 @@ ssl:
   certpath: ""
   keypath: ""
   keypassword: ""
-disableautocompletion: false
+# external program that prints {"username": "...", "password": "..."} to obtain the credentials
+credentialsprovider:
+  command: ""
+  args: []
+sql:
+  # print the elapsed time after each statement, can be toggled with \timing in interactive mode
+  timing: false
+  # print each row as a block of "column | value" lines, can be toggled with \x in interactive mode
+  expanded: false
+# Management Center to run the cluster state and member list operations through, such as http://localhost:8080
+# the member REST endpoints are used if it is not set
+managementcenter:
+  url: ""
+# timeouts of connecting to the cluster, waiting for the operations and executing the SQL statements
+# 0s means no timeout, the client default of 120s is used for the operations in that case
+timeout:
+  connect: 0s
+  invocation: 0s
+  sql: 0s
+# defaults of the command flags, the flags given on the command line override them
+defaults:
+  # output type of the commands with the --output-type flag, such as json, each command uses its own default if it is empty
+  # it is ignored by the commands which do not support it, and sql.expanded takes precedence for the SQL queries
+  outputtype: ""
+  # ask before the irreversible changes, such as destroying an object in the object browser
+  confirm: true
+# values which cannot be deserialized are shown as hex dumps in the object browser instead of failing
+# the values of the Java serialization always are, add the type IDs of the custom serializers of the other clients here
+rawdata:
+  typeids: []
+# color theme: dark, light, solarized or mono, colors are disabled if NO_COLOR environment variable is set
+theme: dark
+# prompt of the interactive mode, the variables are replaced with their live values:
+# {config}, {cluster}, {address}, {state}, {members}, {map} and {names}, the names set with the use commands
+prompt: "hzc {address}@{cluster}{names}> "
+# block the commands which change data, such as map put and the SQL DML statements
+readonly: false
+# print a notice when the interactive mode starts if a new release is available, it is checked on GitHub once a day
+updatenotice: true
+# record how often each command is run, to show them with hzc stats local
+# only the names of the commands are recorded, without their arguments, and they are never sent anywhere
+usagestats: false
 `
 
 func writeToFile(config string, confPath string) error {
@@ -116,11 +248,27 @@ func ReadAndMergeWithFlags(flags *GlobalFlagValues, c *Config) error {
 	return nil
 }
 
+// Load reads the configuration file at path without the global flags, such as the configuration of a second cluster.
+// Unlike the configuration of the command, the file must exist.
+func Load(path string) (*Config, error) {
+	c := DefaultConfig()
+	if err := readConfig(path, c, ""); err != nil {
+		return nil, err
+	}
+	if err := mergeFlagsWithConfig(&GlobalFlagValues{}, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
 func mergeFlagsWithConfig(flags *GlobalFlagValues, config *Config) error {
 	if flags.Token != "" {
 		config.Hazelcast.Cluster.Cloud.Token = strings.TrimSpace(flags.Token)
 		config.Hazelcast.Cluster.Cloud.Enabled = true
 	}
+	if err := updateConfigWithCredentials(flags, config); err != nil {
+		return err
+	}
 	if err := updateConfigWithSSL(&config.Hazelcast, &config.SSL); err != nil {
 		return hzcerrors.NewLoggableError(err, "can not configure ssl")
 	}
@@ -132,13 +280,35 @@ func mergeFlagsWithConfig(flags *GlobalFlagValues, config *Config) error {
 	if flags.Cluster != "" {
 		config.Hazelcast.Cluster.Name = strings.TrimSpace(flags.Cluster)
 	}
+	if flags.MCURL != "" {
+		config.ManagementCenter.URL = strings.TrimSpace(flags.MCURL)
+	}
+	if flags.ReadOnly {
+		config.ReadOnly = true
+	}
+	if flags.Timeout > 0 {
+		config.Timeout = TimeoutConfig{Connect: flags.Timeout, Invocation: flags.Timeout, SQL: flags.Timeout}
+	}
+	if err := updateConfigWithRetry(flags, config); err != nil {
+		return err
+	}
+	if config.Timeout.Invocation > 0 {
+		config.Hazelcast.Cluster.InvocationTimeout = types.Duration(config.Timeout.Invocation)
+	}
+	updateConfigWithFailover(&config.Hazelcast)
+	if err := rawdata.Register(&config.Hazelcast.Serialization, config.RawData.TypeIDs); err != nil {
+		return hzcerrors.NewLoggableError(err, "Invalid raw data configuration")
+	}
+	if flags.LogLevel != "" {
+		config.Hazelcast.Logger.Level = logger.Level(strings.ToLower(strings.TrimSpace(flags.LogLevel)))
+	}
 	// must return nil err
 	verboseWeight, _ := logger.WeightForLogLevel(logger.DebugLevel)
 	confLevel := config.Hazelcast.Logger.Level
 	confWeight, err := logger.WeightForLogLevel(confLevel)
 	if err != nil {
 		validLogLevels := []logger.Level{logger.OffLevel, logger.FatalLevel, logger.ErrorLevel, logger.WarnLevel, logger.InfoLevel, logger.DebugLevel, logger.TraceLevel}
-		return hzcerrors.NewLoggableError(err, "Invalid log level (%s) on configuration file, should be one of %s", confLevel, validLogLevels)
+		return hzcerrors.NewLoggableError(err, "Invalid log level (%s), should be one of %s", confLevel, validLogLevels)
 	}
 	if flags.Verbose && verboseWeight > confWeight {
 		config.Hazelcast.Logger.Level = logger.DebugLevel
@@ -170,6 +340,7 @@ func readConfig(path string, config *Config, defaultConfPath string) error {
 	if err = yaml.Unmarshal(confBytes, config); err != nil {
 		return hzcerrors.NewLoggableError(err, "configuration file(%s) is not in yaml format", path)
 	}
+	config.Path = path
 	return nil
 }
 
@@ -212,12 +383,16 @@ func updateConfigWithSSL(config *hazelcast.Config, sslc *SSLConfig) error {
 }
 
 func GetClusterAddress(c *hazelcast.Config) string {
+	return GetClusterConfigAddress(&c.Cluster)
+}
+
+func GetClusterConfigAddress(c *cluster.Config) string {
 	var address string
 	switch {
-	case c.Cluster.Cloud.Enabled:
+	case c.Cloud.Enabled:
 		address = "hazelcast-cloud"
-	case len(c.Cluster.Network.Addresses) > 0:
-		address = c.Cluster.Network.Addresses[0]
+	case len(c.Network.Addresses) > 0:
+		address = c.Network.Addresses[0]
 	default:
 		address = DefaultClusterAddress
 	}
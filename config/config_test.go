@@ -21,9 +21,12 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/alecthomas/assert"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
 	"github.com/hazelcast/hazelcast-go-client/logger"
+	"github.com/hazelcast/hazelcast-go-client/types"
 	"gopkg.in/yaml.v2"
 )
 
@@ -34,6 +37,13 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, true, conf.Hazelcast.Cluster.Unisocket)
 }
 
+func TestDefaultUserConfig(t *testing.T) {
+	// every key of the configuration file written on the first run must be a setting
+	conf := DefaultConfig()
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(defaultUserConfig), conf))
+	assert.Equal(t, []string{DefaultClusterAddress}, conf.Hazelcast.Cluster.Network.Addresses)
+}
+
 func TestReadConfig(t *testing.T) {
 	defaultConf := DefaultConfig()
 	customConfig := *defaultConf
@@ -95,6 +105,22 @@ func TestReadConfig(t *testing.T) {
 	}
 }
 
+func TestLoad(t *testing.T) {
+	f, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	_, err = f.WriteString("hazelcast:\n  cluster:\n    name: target\n")
+	assert.Nil(t, err)
+	conf, err := Load(f.Name())
+	assert.Nil(t, err)
+	assert.Equal(t, "target", conf.Hazelcast.Cluster.Name)
+	_, err = Load(f.Name() + "non_existing")
+	assert.NotNil(t, err)
+}
+
 func TestMergeFlagsWithConfig(t *testing.T) {
 	tests := []struct {
 		flags          GlobalFlagValues
@@ -136,6 +162,81 @@ func TestMergeFlagsWithConfig(t *testing.T) {
 				return c
 			}(),
 		},
+		{
+			flags: GlobalFlagValues{
+				ReadOnly: true,
+			},
+			expectedConfig: func() *Config {
+				c := DefaultConfig()
+				c.ReadOnly = true
+				return c
+			}(),
+		},
+		{
+			flags: GlobalFlagValues{
+				Timeout: 5 * time.Second,
+			},
+			expectedConfig: func() *Config {
+				c := DefaultConfig()
+				c.Timeout = TimeoutConfig{Connect: 5 * time.Second, Invocation: 5 * time.Second, SQL: 5 * time.Second}
+				c.Hazelcast.Cluster.InvocationTimeout = types.Duration(5 * time.Second)
+				return c
+			}(),
+		},
+		{
+			flags: GlobalFlagValues{
+				Timeout:           5 * time.Second,
+				InvocationTimeout: 10 * time.Second,
+				RedoOperation:     true,
+				RetryMaxBackoff:   3 * time.Second,
+				RetryMultiplier:   2,
+				RetryJitter:       0.5,
+			},
+			expectedConfig: func() *Config {
+				c := DefaultConfig()
+				c.Timeout = TimeoutConfig{Connect: 5 * time.Second, Invocation: 10 * time.Second, SQL: 5 * time.Second}
+				c.Hazelcast.Cluster.InvocationTimeout = types.Duration(10 * time.Second)
+				c.Hazelcast.Cluster.RedoOperation = true
+				c.Hazelcast.Cluster.ConnectionStrategy.Retry = cluster.ConnectionRetryConfig{
+					MaxBackoff: types.Duration(3 * time.Second),
+					Multiplier: 2,
+					Jitter:     0.5,
+				}
+				return c
+			}(),
+		},
+		{
+			flags: GlobalFlagValues{
+				LogLevel: "WARN",
+				Verbose:  true,
+			},
+			expectedConfig: func() *Config {
+				c := DefaultConfig()
+				c.Hazelcast.Logger.Level = logger.DebugLevel
+				return c
+			}(),
+		},
+		{
+			flags: GlobalFlagValues{
+				LogLevel: "info",
+			},
+			expectedConfig: func() *Config {
+				c := DefaultConfig()
+				c.Hazelcast.Logger.Level = logger.InfoLevel
+				return c
+			}(),
+		},
+		{
+			flags: GlobalFlagValues{
+				RetryJitter: 1.5,
+			},
+			expectedConfig: func() *Config {
+				c := DefaultConfig()
+				c.Hazelcast.Cluster.ConnectionStrategy.Retry.Jitter = 1.5
+				return c
+			}(),
+			wantErr: true,
+		},
 	}
 	for i, tt := range tests {
 		t.Run(fmt.Sprintf("testcase-%d", i+1), func(t *testing.T) {
@@ -149,3 +250,25 @@ func TestMergeFlagsWithConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateConfigWithFailover(t *testing.T) {
+	c := DefaultConfig()
+	c.Hazelcast.Cluster.Network.Addresses = []string{"blue:5701"}
+	c.Hazelcast.Failover.Enabled = true
+	green := cluster.Config{Name: "green"}
+	green.Network.Addresses = []string{"green:5701"}
+	green.Security.Credentials.Username = "green-user"
+	c.Hazelcast.Failover.Configs = []cluster.Config{green}
+	updateConfigWithFailover(&c.Hazelcast)
+	fc := c.Hazelcast.Failover.Configs[0]
+	assert.Equal(t, "green", fc.Name)
+	assert.Equal(t, []string{"green:5701"}, fc.Network.Addresses)
+	assert.Equal(t, "green-user", fc.Security.Credentials.Username)
+	// copied from the main cluster config
+	assert.Equal(t, true, fc.Unisocket)
+	cc, ok := FailoverClusterConfig(&c.Hazelcast, "green")
+	assert.True(t, ok)
+	assert.Equal(t, "green:5701", GetClusterConfigAddress(cc))
+	_, ok = FailoverClusterConfig(&c.Hazelcast, "red")
+	assert.False(t, ok)
+}
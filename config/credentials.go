@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"golang.org/x/term"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+// CredentialsProviderConfig configures an external program which supplies the cluster credentials.
+// The program must print a JSON object with "username" and "password" fields to its standard output.
+// This allows the credentials to be obtained from enterprise security systems, such as LDAP, without storing them in the configuration file.
+type CredentialsProviderConfig struct {
+	Command string
+	Args    []string
+}
+
+type providedCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+// readPassword reads the password from the terminal without echoing it.
+var readPassword = func() (string, error) {
+	fmt.Fprint(os.Stderr, "Password: ")
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return string(b), err
+}
+
+var isTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+func updateConfigWithCredentials(flags *GlobalFlagValues, config *Config) error {
+	creds := &config.Hazelcast.Cluster.Security.Credentials
+	if config.CredentialsProvider.Command != "" {
+		if err := runCredentialsProvider(&config.CredentialsProvider, creds); err != nil {
+			return err
+		}
+	}
+	if flags.Username != "" {
+		creds.Username = strings.TrimSpace(flags.Username)
+	}
+	if flags.Password != "" {
+		creds.Password = flags.Password
+	}
+	if creds.Username == "" || creds.Password != "" || !isTerminal() {
+		return nil
+	}
+	// username is given without a password, ask for it interactively
+	password, err := readPassword()
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot read the password")
+	}
+	creds.Password = password
+	return nil
+}
+
+func runCredentialsProvider(pc *CredentialsProviderConfig, creds *cluster.CredentialsConfig) error {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(pc.Command, pc.Args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return hzcerrors.NewLoggableError(err, "Credentials provider %s failed: %s", pc.Command, strings.TrimSpace(stderr.String()))
+	}
+	var pcs providedCredentials
+	if err := json.Unmarshal(stdout.Bytes(), &pcs); err != nil {
+		return hzcerrors.NewLoggableError(err, "Credentials provider %s returned malformed output, a JSON object with username and password fields is expected", pc.Command)
+	}
+	if pcs.Token != "" {
+		return hzcerrors.NewLoggableError(errors.New("token credentials are not supported"), "Credentials provider %s returned a token, but only username and password credentials are supported", pc.Command)
+	}
+	creds.Username = pcs.Username
+	creds.Password = pcs.Password
+	return nil
+}
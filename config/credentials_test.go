@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestUpdateConfigWithCredentials(t *testing.T) {
+	defer func(rp func() (string, error), it func() bool) {
+		readPassword = rp
+		isTerminal = it
+	}(readPassword, isTerminal)
+	readPassword = func() (string, error) {
+		return "prompted", nil
+	}
+	tests := []struct {
+		name             string
+		flags            GlobalFlagValues
+		provider         CredentialsProviderConfig
+		terminal         bool
+		expectedUsername string
+		expectedPassword string
+		wantErr          bool
+	}{
+		{
+			name: "no credentials",
+		},
+		{
+			name:             "username and password flags",
+			flags:            GlobalFlagValues{Username: "user", Password: "pass"},
+			terminal:         true,
+			expectedUsername: "user",
+			expectedPassword: "pass",
+		},
+		{
+			name:             "username flag on terminal, password is prompted",
+			flags:            GlobalFlagValues{Username: "user"},
+			terminal:         true,
+			expectedUsername: "user",
+			expectedPassword: "prompted",
+		},
+		{
+			name:             "username flag without terminal, password is not prompted",
+			flags:            GlobalFlagValues{Username: "user"},
+			expectedUsername: "user",
+		},
+		{
+			name:             "credentials provider",
+			provider:         CredentialsProviderConfig{Command: "echo", Args: []string{`{"username": "ldap-user", "password": "ldap-pass"}`}},
+			expectedUsername: "ldap-user",
+			expectedPassword: "ldap-pass",
+		},
+		{
+			name:             "flags override credentials provider",
+			flags:            GlobalFlagValues{Username: "user"},
+			provider:         CredentialsProviderConfig{Command: "echo", Args: []string{`{"username": "ldap-user", "password": "ldap-pass"}`}},
+			expectedUsername: "user",
+			expectedPassword: "ldap-pass",
+		},
+		{
+			name:     "credentials provider with token",
+			provider: CredentialsProviderConfig{Command: "echo", Args: []string{`{"token": "kerberos-ticket"}`}},
+			wantErr:  true,
+		},
+		{
+			name:     "credentials provider with malformed output",
+			provider: CredentialsProviderConfig{Command: "echo", Args: []string{"user:pass"}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terminal := tt.terminal
+			isTerminal = func() bool {
+				return terminal
+			}
+			c := DefaultConfig()
+			c.CredentialsProvider = tt.provider
+			err := updateConfigWithCredentials(&tt.flags, c)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			creds := c.Hazelcast.Cluster.Security.Credentials
+			assert.Equal(t, tt.expectedUsername, creds.Username)
+			assert.Equal(t, tt.expectedPassword, creds.Password)
+		})
+	}
+}
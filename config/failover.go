@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+)
+
+// updateConfigWithFailover completes the failover cluster configurations using the main cluster configuration.
+// The client requires failover clusters to be identical to the main cluster except the connection details,
+// so only those are expected in the configuration file and the rest is copied over.
+func updateConfigWithFailover(config *hazelcast.Config) {
+	if !config.Failover.Enabled {
+		return
+	}
+	for i, fc := range config.Failover.Configs {
+		c := config.Cluster.Clone()
+		c.Name = fc.Name
+		c.Security = fc.Security
+		c.Cloud = fc.Cloud
+		c.Network.Addresses = fc.Network.Addresses
+		c.Network.SSL = fc.Network.SSL
+		if fc.ConnectionStrategy != (cluster.ConnectionStrategyConfig{}) {
+			c.ConnectionStrategy = fc.ConnectionStrategy
+		}
+		config.Failover.Configs[i] = c
+	}
+}
+
+// FailoverClusterConfig returns the configuration of the cluster with the given name among the main and failover clusters.
+func FailoverClusterConfig(c *hazelcast.Config, name string) (*cluster.Config, bool) {
+	if c.Cluster.Name == name {
+		return &c.Cluster, true
+	}
+	for i := range c.Failover.Configs {
+		if c.Failover.Configs[i].Name == name {
+			return &c.Failover.Configs[i], true
+		}
+	}
+	return nil, false
+}
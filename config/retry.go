@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"fmt"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+// updateConfigWithRetry applies the retry flags on top of the retry settings in the configuration file.
+// Zero flag values mean the flag is not given.
+func updateConfigWithRetry(flags *GlobalFlagValues, config *Config) error {
+	cc := &config.Hazelcast.Cluster
+	if flags.RedoOperation {
+		cc.RedoOperation = true
+	}
+	if flags.InvocationTimeout > 0 {
+		config.Timeout.Invocation = flags.InvocationTimeout
+	}
+	retry := &cc.ConnectionStrategy.Retry
+	if flags.RetryMaxBackoff > 0 {
+		retry.MaxBackoff = types.Duration(flags.RetryMaxBackoff)
+	}
+	if flags.RetryMultiplier > 0 {
+		retry.Multiplier = flags.RetryMultiplier
+	}
+	if flags.RetryJitter > 0 {
+		retry.Jitter = flags.RetryJitter
+	}
+	// the client validates these as well, but only after it starts
+	if retry.Multiplier != 0 && retry.Multiplier < 1 {
+		return hzcerrors.NewLoggableError(nil, "Invalid retry multiplier (%s), it should be greater than or equal to 1", formatFloat(retry.Multiplier))
+	}
+	if retry.Jitter < 0 || retry.Jitter > 1 {
+		return hzcerrors.NewLoggableError(nil, "Invalid retry jitter (%s), it should be between 0 and 1", formatFloat(retry.Jitter))
+	}
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return fmt.Sprintf("%g", f)
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package configcmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/types/mapcmd"
+)
+
+const ApplyClusterExample = `  # Add the indexes in the file to the maps of the cluster
+  hzc config apply-cluster --file indexes.yaml
+  # Print the indexes which would be added
+  hzc config apply-cluster --file indexes.yaml --dry-run
+
+  # indexes.yaml
+  maps:
+    employees:
+      indexes:
+        - attributes: [age]
+        - attributes: [name, city]
+          type: hash
+          name: name_city
+        - attributes: [gender]
+          type: bitmap
+          unique-key: id
+          unique-key-transformation: long`
+
+// clusterConfig is the dynamic configuration in the file, the keys are the map names.
+type clusterConfig struct {
+	Maps map[string]map[string]interface{} `yaml:"maps"`
+}
+
+type indexSpec struct {
+	Name                    string   `yaml:"name"`
+	Attributes              []string `yaml:"attributes"`
+	Type                    string   `yaml:"type"`
+	UniqueKey               *string  `yaml:"unique-key"`
+	UniqueKeyTransformation *string  `yaml:"unique-key-transformation"`
+}
+
+// mapIndex is an index to add to a map.
+type mapIndex struct {
+	mapName string
+	config  types.IndexConfig
+}
+
+func (mi mapIndex) String() string {
+	return fmt.Sprintf("add %s index on %s to map %s", indexTypeName(mi.config.Type), strings.Join(mi.config.Attributes, ", "), mi.mapName)
+}
+
+// New creates the config command, the configuration of hzc itself is given with the --config flag.
+func New(cnfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "config {apply-cluster | doctor | convert}",
+		Short:   "Cluster configuration operations, and checking and converting the configurations of hzc",
+		Example: ApplyClusterExample + "\n" + DoctorExample + "\n" + ConvertExample,
+	}
+	cmd.AddCommand(newApplyCluster(&cnfg.Hazelcast), newDoctor(cnfg), newConvert())
+	return cmd
+}
+
+func newApplyCluster(config *hazelcast.Config) *cobra.Command {
+	var path string
+	cmd := &cobra.Command{
+		Use:   "apply-cluster --file file",
+		Short: "Add the dynamic configuration in the file to the running cluster",
+		Long: `Add the dynamic configuration in the YAML file to the running cluster, without restarting the members.
+Only the indexes of the maps are supported, since the Go client cannot add the configurations of the data structures.
+The whole file is validated before anything is added, and adding an index which already exists has no effect.`,
+		Example: ApplyClusterExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot read the configuration file %s", path)
+			}
+			indexes, err := parseClusterConfig(b)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid configuration file %s", path)
+			}
+			if dryrun.Enabled(cmd) {
+				for _, mi := range indexes {
+					dryrun.Print(cmd, "%s", mi)
+				}
+				return nil
+			}
+			ctx := cmd.Context()
+			client, err := internal.ConnectToCluster(ctx, config)
+			if err != nil {
+				return err
+			}
+			for _, mi := range indexes {
+				m, err := client.GetMap(ctx, mi.mapName)
+				if err == nil {
+					err = m.AddIndex(ctx, mi.config)
+				}
+				if err != nil {
+					if msg, handled := hzcerrors.TranslateNetworkError(err, config.Cluster.Cloud.Enabled); handled {
+						return hzcerrors.NewLoggableError(err, msg)
+					}
+					return hzcerrors.NewLoggableError(err, "Cannot %s", mi)
+				}
+				quiet.Printf(cmd, "Applied: %s\n", mi)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&path, "file", "f", "", "YAML file of the configuration to add")
+	if err := cmd.MarkFlagRequired("file"); err != nil {
+		panic(err)
+	}
+	return dryrun.Supported(cmd)
+}
+
+// parseClusterConfig returns the indexes in the configuration, ordered by the map names and in the order they are given for each map.
+func parseClusterConfig(b []byte) ([]mapIndex, error) {
+	var cc clusterConfig
+	if err := yaml.UnmarshalStrict(b, &cc); err != nil {
+		return nil, err
+	}
+	if len(cc.Maps) == 0 {
+		return nil, fmt.Errorf("there are no maps in the configuration")
+	}
+	names := make([]string, 0, len(cc.Maps))
+	for name := range cc.Maps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var indexes []mapIndex
+	for _, name := range names {
+		keys := make([]string, 0, len(cc.Maps[name]))
+		for k := range cc.Maps[name] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if k != "indexes" {
+				return nil, fmt.Errorf("%s of map %s cannot be configured, only the indexes of the maps can be added to a running cluster", k, name)
+			}
+		}
+		// the raw value is decoded again, so that the unknown index options are reported
+		raw, err := yaml.Marshal(cc.Maps[name]["indexes"])
+		if err != nil {
+			return nil, err
+		}
+		var specs []indexSpec
+		if err := yaml.UnmarshalStrict(raw, &specs); err != nil {
+			return nil, fmt.Errorf("indexes of map %s: %w", name, err)
+		}
+		for i, s := range specs {
+			ic, err := s.indexConfig()
+			if err != nil {
+				return nil, fmt.Errorf("index %d of map %s: %w", i+1, name, err)
+			}
+			indexes = append(indexes, mapIndex{mapName: name, config: ic})
+		}
+	}
+	return indexes, nil
+}
+
+func (s indexSpec) indexConfig() (types.IndexConfig, error) {
+	indexType, uniqueKey, transformation := "sorted", "__key", "object"
+	if s.Type != "" {
+		indexType = s.Type
+	}
+	if s.UniqueKey != nil {
+		uniqueKey = *s.UniqueKey
+	}
+	if s.UniqueKeyTransformation != nil {
+		transformation = *s.UniqueKeyTransformation
+	}
+	return mapcmd.IndexConfig(s.Name, s.Attributes, indexType, uniqueKey, transformation,
+		s.UniqueKey != nil || s.UniqueKeyTransformation != nil)
+}
+
+func indexTypeName(t types.IndexType) string {
+	switch t {
+	case types.IndexTypeHash:
+		return "hash"
+	case types.IndexTypeBitmap:
+		return "bitmap"
+	}
+	return "sorted"
+}
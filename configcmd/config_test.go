@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package configcmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseClusterConfig(t *testing.T) {
+	indexes, err := parseClusterConfig([]byte(`
+maps:
+  people:
+    indexes:
+      - attributes: [name, city]
+        type: hash
+        name: name_city
+      - attributes: [gender]
+        type: bitmap
+        unique-key: id
+  employees:
+    indexes:
+      - attributes: [age]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, mi := range indexes {
+		got = append(got, mi.String())
+	}
+	want := []string{
+		"add sorted index on age to map employees",
+		"add hash index on name, city to map people",
+		"add bitmap index on gender to map people",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected indexes: %v", got)
+	}
+	if indexes[1].config.Name != "name_city" || indexes[2].config.BitmapIndexOptions.UniqueKey != "id" {
+		t.Fatalf("unexpected index options: %+v", indexes)
+	}
+	for _, tc := range []struct {
+		info string
+		in   string
+	}{
+		{"no maps", "maps: {}"},
+		{"unknown section", "caches:\n  c: {}"},
+		{"map options", "maps:\n  m:\n    backup-count: 2"},
+		{"unknown index option", "maps:\n  m:\n    indexes:\n      - attributes: [a]\n        order: asc"},
+		{"bitmap options for sorted", "maps:\n  m:\n    indexes:\n      - attributes: [a]\n        unique-key: id"},
+		{"no attributes", "maps:\n  m:\n    indexes:\n      - type: hash"},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if _, err := parseClusterConfig([]byte(tc.in)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package configcmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const ConvertExample = `  # Print the configuration converted from the Java client configuration
+  hzc config convert --from hazelcast-client.xml
+  # Write it to prod.yaml next to the default configuration, and use it
+  hzc config convert --from hazelcast-client.yaml --out ~/.local/share/hz-cli/prod.yaml
+  hzc -c ~/.local/share/hz-cli/prod.yaml`
+
+const javaClientRoot = "hazelcast-client"
+
+func newConvert() *cobra.Command {
+	var from, out string
+	cmd := &cobra.Command{
+		Use:   "convert --from file [--out file]",
+		Short: "Convert a Java client configuration to a configuration of hzc",
+		Long: `Convert the hazelcast-client.xml or hazelcast-client.yaml configuration of the Java client to a configuration of hzc.
+The connection settings are converted, such as the cluster name, the addresses, the credentials, Hazelcast Cloud and the retry settings.
+The settings which cannot be converted, such as the Java key stores and the near caches, are listed as warnings.`,
+		Example: ConvertExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := ioutil.ReadFile(from)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot read the Java client configuration %s", from)
+			}
+			src, err := parseJavaClientConfig(b, isXML(from, b))
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot parse the Java client configuration %s", from)
+			}
+			converted, warnings := convertJavaClientConfig(src)
+			for _, w := range warnings {
+				cmd.PrintErrf("warning  %s\n", w)
+			}
+			if problems := checkConfig(converted); len(problems) > 0 {
+				writeProblems(cmd.ErrOrStderr(), problems)
+			}
+			if out == "-" {
+				_, err := cmd.OutOrStdout().Write(converted)
+				return err
+			}
+			if err := file.CreateMissingDirsAndFileWithRWPerms(out, converted); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot write the configuration to %s", out)
+			}
+			quiet.Printf(cmd, "Wrote the configuration to %s, %d setting(s) are not converted\n", out, len(warnings))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "hazelcast-client.xml or hazelcast-client.yaml file of the Java client")
+	if err := cmd.MarkFlagRequired("from"); err != nil {
+		panic(err)
+	}
+	cmd.Flags().StringVar(&out, "out", "-", `file to write the configuration to, "-" is the standard output`)
+	return cmd
+}
+
+func isXML(path string, b []byte) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xml":
+		return true
+	case ".yaml", ".yml":
+		return false
+	}
+	return bytes.HasPrefix(bytes.TrimSpace(b), []byte("<"))
+}
+
+// javaValues are the settings of the Java client configuration, the XML attributes and elements are both keys.
+// The values are strings, lists or nested javaValues.
+type javaValues map[string]interface{}
+
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Text    string     `xml:",chardata"`
+	Nodes   []xmlNode  `xml:",any"`
+}
+
+// value returns the element in the shape of the YAML configuration, the repeated elements become lists.
+func (n xmlNode) value() interface{} {
+	if n.XMLName.Local == "properties" {
+		props := javaValues{}
+		for _, p := range n.Nodes {
+			for _, a := range p.Attrs {
+				if a.Name.Local == "name" {
+					props[a.Value] = strings.TrimSpace(p.Text)
+				}
+			}
+		}
+		return props
+	}
+	var attrs []xml.Attr
+	for _, a := range n.Attrs {
+		// the namespace declarations and the schema locations are not settings
+		if a.Name.Space == "" && a.Name.Local != "xmlns" {
+			attrs = append(attrs, a)
+		}
+	}
+	if len(attrs) == 0 && len(n.Nodes) == 0 {
+		return strings.TrimSpace(n.Text)
+	}
+	v := javaValues{}
+	for _, a := range attrs {
+		v[a.Name.Local] = a.Value
+	}
+	for _, c := range n.Nodes {
+		name := c.XMLName.Local
+		switch prev := v[name].(type) {
+		case nil:
+			v[name] = c.value()
+		case []interface{}:
+			v[name] = append(prev, c.value())
+		default:
+			v[name] = []interface{}{prev, c.value()}
+		}
+	}
+	return v
+}
+
+// parseJavaClientConfig returns the settings under the hazelcast-client root.
+func parseJavaClientConfig(b []byte, isXML bool) (javaValues, error) {
+	if isXML {
+		var root xmlNode
+		if err := xml.Unmarshal(b, &root); err != nil {
+			return nil, err
+		}
+		if root.XMLName.Local != javaClientRoot {
+			return nil, fmt.Errorf("the root element is %s instead of %s, it is not a client configuration", root.XMLName.Local, javaClientRoot)
+		}
+		v, ok := root.value().(javaValues)
+		if !ok {
+			return javaValues{}, nil
+		}
+		return v, nil
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	root, ok := doc[javaClientRoot]
+	if !ok {
+		return nil, fmt.Errorf("there is no %s key, it is not a client configuration", javaClientRoot)
+	}
+	v, ok := normalizeYAML(root).(javaValues)
+	if !ok {
+		return javaValues{}, nil
+	}
+	return v, nil
+}
+
+// normalizeYAML converts the YAML maps to javaValues and the scalars to strings, as they are in the XML configuration.
+func normalizeYAML(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := javaValues{}
+		for k, v := range t {
+			m[fmt.Sprint(k)] = normalizeYAML(v)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(t))
+		for i, v := range t {
+			l[i] = normalizeYAML(v)
+		}
+		return l
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// take removes the setting at the keys and returns it.
+func (v javaValues) take(keys ...string) (interface{}, bool) {
+	m := v
+	for _, k := range keys[:len(keys)-1] {
+		sub, ok := m[k].(javaValues)
+		if !ok {
+			return nil, false
+		}
+		m = sub
+	}
+	last := keys[len(keys)-1]
+	value, ok := m[last]
+	if ok {
+		delete(m, last)
+	}
+	return value, ok
+}
+
+// leftovers returns the paths of the settings which are not taken, in order.
+func (v javaValues) leftovers(prefix string) []string {
+	var paths []string
+	for k, value := range v {
+		path := joinKey(prefix, k)
+		if sub, ok := value.(javaValues); ok {
+			paths = append(paths, sub.leftovers(path)...)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// javaConverter converts the settings of the Java client to an hzc configuration in the order of the default one.
+type javaConverter struct {
+	src      javaValues
+	out      yaml.MapSlice
+	warnings []string
+}
+
+func (c *javaConverter) warn(format string, args ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+// set sets the hzc setting at the dot separated path.
+func (c *javaConverter) set(path string, v interface{}) {
+	c.out = setIn(c.out, strings.Split(path, "."), v)
+}
+
+func setIn(ms yaml.MapSlice, keys []string, v interface{}) yaml.MapSlice {
+	for i, item := range ms {
+		if item.Key != keys[0] {
+			continue
+		}
+		if len(keys) == 1 {
+			ms[i].Value = v
+			return ms
+		}
+		sub, _ := item.Value.(yaml.MapSlice)
+		ms[i].Value = setIn(sub, keys[1:], v)
+		return ms
+	}
+	if len(keys) == 1 {
+		return append(ms, yaml.MapItem{Key: keys[0], Value: v})
+	}
+	return append(ms, yaml.MapItem{Key: keys[0], Value: setIn(nil, keys[1:], v)})
+}
+
+// convert takes the Java setting at the keys, converts it with f and sets it at the path, the invalid values are warned about.
+func (c *javaConverter) convert(path string, f func(string) (interface{}, error), keys ...string) {
+	v, ok := c.src.take(keys...)
+	if !ok {
+		return
+	}
+	s, ok := v.(string)
+	if !ok {
+		c.warn("%s: %v is not a single value, it is not converted", strings.Join(keys, "."), v)
+		return
+	}
+	converted, err := f(s)
+	if err != nil {
+		c.warn("%s: invalid value %s, it is not converted", strings.Join(keys, "."), s)
+		return
+	}
+	c.set(path, converted)
+}
+
+// list takes the Java setting which is a list, such as the addresses, the XML lists are nested in the elements of the items.
+func (c *javaConverter) list(path, item string, keys ...string) {
+	v, ok := c.src.take(keys...)
+	if !ok {
+		return
+	}
+	if m, ok := v.(javaValues); ok {
+		v = m[item]
+	}
+	var values []string
+	switch t := v.(type) {
+	case string:
+		values = []string{t}
+	case []interface{}:
+		for _, i := range t {
+			values = append(values, fmt.Sprint(i))
+		}
+	}
+	c.set(path, values)
+}
+
+func asString(s string) (interface{}, error) {
+	return s, nil
+}
+
+func asBool(s string) (interface{}, error) {
+	return strconv.ParseBool(s)
+}
+
+func asFloat(s string) (interface{}, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+func durationOf(unit time.Duration) func(string) (interface{}, error) {
+	return func(s string) (interface{}, error) {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return (time.Duration(n) * unit).String(), nil
+	}
+}
+
+// convertJavaClientConfig returns the hzc configuration and the warnings about the settings which are not converted.
+func convertJavaClientConfig(src javaValues) ([]byte, []string) {
+	c := &javaConverter{src: src}
+	c.convert("hazelcast.clientname", asString, "instance-name")
+	c.list("hazelcast.labels", "label", "labels")
+	c.convert("hazelcast.cluster.name", asString, "cluster-name")
+	c.convert("hazelcast.cluster.security.credentials.username", asString, "security", "username-password", "username")
+	c.convert("hazelcast.cluster.security.credentials.password", asString, "security", "username-password", "password")
+	c.convert("hazelcast.cluster.cloud.enabled", asBool, "network", "hazelcast-cloud", "enabled")
+	c.convert("hazelcast.cluster.cloud.token", asString, "network", "hazelcast-cloud", "discovery-token")
+	c.convert("hazelcast.cluster.unisocket", func(s string) (interface{}, error) {
+		smart, err := strconv.ParseBool(s)
+		return !smart, err
+	}, "network", "smart-routing")
+	c.convert("hazelcast.cluster.redooperation", asBool, "network", "redo-operation")
+	c.convert("hazelcast.cluster.heartbeatinterval", durationOf(time.Millisecond), "properties", "hazelcast.client.heartbeat.interval")
+	c.convert("hazelcast.cluster.heartbeattimeout", durationOf(time.Millisecond), "properties", "hazelcast.client.heartbeat.timeout")
+	c.convert("hazelcast.cluster.connectionstrategy.reconnectmode", func(s string) (interface{}, error) {
+		// YAML 1.1 parses the unquoted ON and OFF as booleans
+		switch strings.ToUpper(s) {
+		case "ON", "TRUE":
+			return "on", nil
+		case "OFF", "FALSE":
+			return "off", nil
+		case "ASYNC":
+			c.warn("connection-strategy.reconnect-mode: reconnecting asynchronously is not supported, it is converted to on")
+			return "on", nil
+		}
+		return nil, fmt.Errorf("unknown reconnect mode %s", s)
+	}, "connection-strategy", "reconnect-mode")
+	// -1 is no timeout, which is the default of the Go client as well
+	if v, ok := c.src["connection-strategy"].(javaValues); ok {
+		if r, ok := v["connection-retry"].(javaValues); ok && r["cluster-connect-timeout-millis"] == "-1" {
+			delete(r, "cluster-connect-timeout-millis")
+		}
+	}
+	c.convert("hazelcast.cluster.connectionstrategy.timeout", durationOf(time.Millisecond), "connection-strategy", "connection-retry", "cluster-connect-timeout-millis")
+	c.convert("hazelcast.cluster.connectionstrategy.retry.initialbackoff", durationOf(time.Millisecond), "connection-strategy", "connection-retry", "initial-backoff-millis")
+	c.convert("hazelcast.cluster.connectionstrategy.retry.maxbackoff", durationOf(time.Millisecond), "connection-strategy", "connection-retry", "max-backoff-millis")
+	c.convert("hazelcast.cluster.connectionstrategy.retry.multiplier", asFloat, "connection-strategy", "connection-retry", "multiplier")
+	c.convert("hazelcast.cluster.connectionstrategy.retry.jitter", asFloat, "connection-strategy", "connection-retry", "jitter")
+	c.list("hazelcast.cluster.network.addresses", "address", "network", "cluster-members")
+	c.convert("hazelcast.cluster.network.connectiontimeout", durationOf(time.Millisecond), "network", "connection-timeout")
+	c.convert("hazelcast.stats.enabled", asBool, "properties", "hazelcast.client.statistics.enabled")
+	c.convert("hazelcast.stats.period", durationOf(time.Second), "properties", "hazelcast.client.statistics.period.seconds")
+	c.convert("ssl.enabled", asBool, "network", "ssl", "enabled")
+	if _, ok := c.src.take("network", "ssl", "properties"); ok {
+		c.warn("network.ssl.properties: the Java key stores cannot be used, convert them to PEM files and set ssl.capath, ssl.certpath and ssl.keypath")
+	}
+	c.src.take("network", "ssl", "factory-class-name")
+	c.convert("timeout.invocation", durationOf(time.Second), "properties", "hazelcast.client.invocation.timeout.seconds")
+	for _, path := range c.src.leftovers("") {
+		c.warn("%s is not supported, it is not converted", path)
+	}
+	b, err := yaml.Marshal(c.out)
+	if err != nil {
+		// the values are strings, booleans, numbers and lists of strings
+		panic(err)
+	}
+	return b, c.warnings
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package configcmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"gopkg.in/yaml.v2"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+)
+
+const javaClientXML = `<?xml version="1.0" encoding="UTF-8"?>
+<hazelcast-client xmlns="http://www.hazelcast.com/schema/client-config"
+                  xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+                  xsi:schemaLocation="http://www.hazelcast.com/schema/client-config hazelcast-client-config-5.1.xsd">
+    <cluster-name>prod</cluster-name>
+    <instance-name>reports</instance-name>
+    <labels>
+        <label>reporting</label>
+    </labels>
+    <properties>
+        <property name="hazelcast.client.invocation.timeout.seconds">30</property>
+        <property name="hazelcast.client.statistics.enabled">true</property>
+    </properties>
+    <network>
+        <cluster-members>
+            <address>10.0.0.1:5701</address>
+            <address>10.0.0.2:5701</address>
+        </cluster-members>
+        <smart-routing>false</smart-routing>
+        <connection-timeout>7000</connection-timeout>
+        <ssl enabled="true">
+            <properties>
+                <property name="keyStore">client.keystore</property>
+            </properties>
+        </ssl>
+    </network>
+    <security>
+        <username-password username="reports" password="secret"/>
+    </security>
+    <connection-strategy async-start="true" reconnect-mode="ON">
+        <connection-retry>
+            <initial-backoff-millis>500</initial-backoff-millis>
+            <max-backoff-millis>10000</max-backoff-millis>
+            <multiplier>2</multiplier>
+            <cluster-connect-timeout-millis>-1</cluster-connect-timeout-millis>
+        </connection-retry>
+    </connection-strategy>
+    <near-cache name="orders">
+        <max-idle-seconds>100</max-idle-seconds>
+    </near-cache>
+</hazelcast-client>
+`
+
+const javaClientYAML = `
+hazelcast-client:
+  cluster-name: prod
+  instance-name: reports
+  labels:
+    - reporting
+  properties:
+    hazelcast.client.invocation.timeout.seconds: 30
+    hazelcast.client.statistics.enabled: true
+  network:
+    cluster-members:
+      - 10.0.0.1:5701
+      - 10.0.0.2:5701
+    smart-routing: false
+    connection-timeout: 7000
+    ssl:
+      enabled: true
+      properties:
+        keyStore: client.keystore
+  security:
+    username-password:
+      username: reports
+      password: secret
+  connection-strategy:
+    async-start: true
+    reconnect-mode: ON
+    connection-retry:
+      initial-backoff-millis: 500
+      max-backoff-millis: 10000
+      multiplier: 2
+      cluster-connect-timeout-millis: -1
+  near-cache:
+    orders:
+      max-idle-seconds: 100
+`
+
+func TestConvertJavaClientConfig(t *testing.T) {
+	wantWarnings := []string{
+		"network.ssl.properties: the Java key stores cannot be used, convert them to PEM files and set ssl.capath, ssl.certpath and ssl.keypath",
+		"connection-strategy.async-start is not supported, it is not converted",
+	}
+	for _, tc := range []struct {
+		info  string
+		in    string
+		isXML bool
+	}{
+		{"xml", javaClientXML, true},
+		{"yaml", javaClientYAML, false},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			src, err := parseJavaClientConfig([]byte(tc.in), tc.isXML)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, warnings := convertJavaClientConfig(src)
+			// the near caches are named elements in XML and keys in YAML
+			nearCache := []string{"near-cache.max-idle-seconds is not supported, it is not converted", "near-cache.name is not supported, it is not converted"}
+			if !tc.isXML {
+				nearCache = []string{"near-cache.orders.max-idle-seconds is not supported, it is not converted"}
+			}
+			if want := append(append([]string{}, wantWarnings...), nearCache...); !reflect.DeepEqual(warnings, want) {
+				t.Fatalf("unexpected warnings:\n%q", warnings)
+			}
+			if problems := checkConfig(b); len(problems) != 0 {
+				t.Fatalf("unexpected problems: %+v\n%s", problems, b)
+			}
+			c := config.DefaultConfig()
+			if err := yaml.UnmarshalStrict(b, c); err != nil {
+				t.Fatal(err)
+			}
+			hz := c.Hazelcast
+			if hz.ClientName != "reports" || !reflect.DeepEqual(hz.Labels, []string{"reporting"}) || hz.Cluster.Name != "prod" || !hz.Stats.Enabled {
+				t.Fatalf("unexpected client settings:\n%s", b)
+			}
+			if hz.Cluster.Security.Credentials != (cluster.CredentialsConfig{Username: "reports", Password: "secret"}) {
+				t.Fatalf("unexpected credentials:\n%s", b)
+			}
+			if !hz.Cluster.Unisocket || !c.SSL.Enabled || c.Timeout.Invocation != 30*time.Second {
+				t.Fatalf("unexpected connection settings:\n%s", b)
+			}
+			if !reflect.DeepEqual(hz.Cluster.Network.Addresses, []string{"10.0.0.1:5701", "10.0.0.2:5701"}) || hz.Cluster.Network.ConnectionTimeout != types.Duration(7*time.Second) {
+				t.Fatalf("unexpected network settings:\n%s", b)
+			}
+			cs := hz.Cluster.ConnectionStrategy
+			if cs.Timeout != 0 || cs.ReconnectMode != cluster.ReconnectModeOn {
+				t.Fatalf("unexpected connection strategy:\n%s", b)
+			}
+			wantRetry := cluster.ConnectionRetryConfig{
+				InitialBackoff: types.Duration(500 * time.Millisecond),
+				MaxBackoff:     types.Duration(10 * time.Second),
+				Multiplier:     2,
+			}
+			if cs.Retry != wantRetry {
+				t.Fatalf("unexpected retry settings:\n%s", b)
+			}
+		})
+	}
+}
+
+func TestParseJavaClientConfig_NotClient(t *testing.T) {
+	if _, err := parseJavaClientConfig([]byte(`<hazelcast><cluster-name>dev</cluster-name></hazelcast>`), true); err == nil {
+		t.Fatal("expected an error for a member configuration")
+	}
+	if _, err := parseJavaClientConfig([]byte("hazelcast:\n  cluster-name: dev\n"), false); err == nil {
+		t.Fatal("expected an error for a member configuration")
+	}
+}
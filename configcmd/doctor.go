@@ -0,0 +1,383 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package configcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/logger"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/theme"
+)
+
+const DoctorExample = `  # Check the configuration given with --config, or the default one
+  hzc config doctor
+  # Check prod.yaml next to the default configuration, and try connecting to its cluster
+  hzc config doctor prod --connect`
+
+// defaultProbeTimeout limits connecting to the cluster with --connect, if the configuration has no connect timeout
+const defaultProbeTimeout = 10 * time.Second
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// problem is an issue in the configuration, with a suggestion to fix it.
+type problem struct {
+	severity string
+	// key is the path of the setting, such as ssl.capath, it is empty if the problem is not about a setting
+	key     string
+	message string
+	fix     string
+}
+
+func newDoctor(cnfg *config.Config) *cobra.Command {
+	var connect bool
+	cmd := &cobra.Command{
+		Use:   "doctor [NAME] [--connect]",
+		Short: "Check the configuration file for problems",
+		Long: `Check the configuration file for unknown keys, invalid values, missing files and conflicting options, and print how to fix them.
+NAME is the path of the configuration file, or the name of a configuration next to the default one, such as prod for prod.yaml.
+The configuration given with --config is checked if NAME is not given. With --connect, connecting to the cluster is tried as well.
+The command fails if there are errors.`,
+		Example: DoctorExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := cmd.Flag("config").Value.String()
+			if len(args) > 0 {
+				path = namedConfigPath(args[0])
+			}
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot read the configuration file %s", path)
+			}
+			problems := checkConfig(b)
+			if connect && !hasErrors(problems) {
+				if p, ok := probeCluster(cmd, path); !ok {
+					problems = append(problems, p)
+				}
+			}
+			return reportProblems(cmd, path, problems)
+		},
+	}
+	cmd.Flags().BoolVar(&connect, "connect", false, "try connecting to the cluster if the configuration has no errors")
+	return cmd
+}
+
+// namedConfigPath returns the path of the configuration with the name next to the default configuration,
+// names with a directory or an extension are paths themselves.
+func namedConfigPath(name string) string {
+	if filepath.Ext(name) != "" || strings.ContainsRune(name, filepath.Separator) {
+		return name
+	}
+	return filepath.Join(file.HZCHomePath(), name+".yaml")
+}
+
+var unknownFieldRegexp = regexp.MustCompile(`^line (\d+): field (\S+) not found in type (\S+)$`)
+
+// checkConfig returns the problems of the configuration file, without connecting to the cluster.
+func checkConfig(b []byte) []problem {
+	c := config.DefaultConfig()
+	var problems []problem
+	err := yaml.UnmarshalStrict(b, c)
+	var te *yaml.TypeError
+	if err != nil && !errors.As(err, &te) {
+		return []problem{{
+			severity: severityError,
+			message:  fmt.Sprintf("the file is not valid YAML: %s", err),
+			fix:      "Correct the syntax, such as the indentation, which must use spaces",
+		}}
+	}
+	if te != nil {
+		paths := settingPaths()
+		for _, e := range te.Errors {
+			m := unknownFieldRegexp.FindStringSubmatch(e)
+			if m == nil {
+				problems = append(problems, problem{severity: severityError, message: e, fix: "Correct the type of the value"})
+				continue
+			}
+			key := joinKey(paths[m[3]], m[2])
+			fix := "Remove it, or correct its spelling or indentation"
+			if p, ok := movedSettingPath(m[3], m[2]); ok {
+				fix = fmt.Sprintf("Move it under %s", p)
+			}
+			problems = append(problems, problem{
+				severity: severityWarning,
+				key:      key,
+				message:  fmt.Sprintf("unknown key on line %s, it is ignored", m[1]),
+				fix:      fix,
+			})
+		}
+	}
+	return append(problems, checkSettings(c)...)
+}
+
+// checkSettings returns the invalid values and the conflicting options of the configuration.
+func checkSettings(c *config.Config) []problem {
+	var problems []problem
+	add := func(severity, key, message, fix string) {
+		problems = append(problems, problem{severity: severity, key: key, message: message, fix: fix})
+	}
+	cc := &c.Hazelcast.Cluster
+	if cc.Cloud.Enabled && cc.Cloud.Token == "" {
+		add(severityError, "hazelcast.cluster.cloud.token", "Hazelcast Cloud is enabled without a token",
+			"Set the discovery token of the cluster, or give it with --cloud-token")
+	}
+	if !cc.Cloud.Enabled && cc.Cloud.Token != "" {
+		add(severityWarning, "hazelcast.cluster.cloud.token", "the token is ignored, since Hazelcast Cloud is not enabled",
+			"Set hazelcast.cluster.cloud.enabled to true, or remove the token")
+	}
+	if cc.Cloud.Enabled && len(cc.Network.Addresses) > 0 {
+		add(severityWarning, "hazelcast.cluster.network.addresses", "the addresses are ignored, since the members of Hazelcast Cloud clusters are discovered",
+			"Remove the addresses")
+	}
+	ssl := c.SSL
+	for _, f := range []struct{ key, path string }{
+		{"ssl.capath", ssl.CAPath},
+		{"ssl.certpath", ssl.CertPath},
+		{"ssl.keypath", ssl.KeyPath},
+	} {
+		if f.path == "" {
+			continue
+		}
+		if _, err := os.Stat(f.path); err != nil {
+			add(severityError, f.key, fmt.Sprintf("cannot access %s: %s", f.path, errors.Unwrap(err)),
+				"Correct the path, the relative paths are relative to the working directory")
+		}
+	}
+	if (ssl.CertPath == "") != (ssl.KeyPath == "") {
+		add(severityError, "ssl", "only one of the client certificate and its key is given", "Set both ssl.certpath and ssl.keypath, or neither")
+	}
+	if !ssl.Enabled && (ssl.CAPath != "" || ssl.CertPath != "" || ssl.KeyPath != "" || ssl.ServerName != "") {
+		add(severityWarning, "ssl.enabled", "the SSL settings are ignored, since SSL is not enabled", "Set ssl.enabled to true, or remove the settings")
+	}
+	if command := c.CredentialsProvider.Command; command != "" {
+		if _, err := exec.LookPath(command); err != nil {
+			add(severityError, "credentialsprovider.command", fmt.Sprintf("cannot find %s", command),
+				"Install the program, or give its full path")
+		}
+		if creds := cc.Security.Credentials; creds.Username != "" || creds.Password != "" {
+			add(severityWarning, "hazelcast.cluster.security.credentials", "the credentials are replaced by the ones of the credentials provider",
+				"Remove the username and the password")
+		}
+	}
+	if _, err := logger.WeightForLogLevel(c.Hazelcast.Logger.Level); err != nil {
+		add(severityError, "hazelcast.logger.level", fmt.Sprintf("unknown log level %s", c.Hazelcast.Logger.Level),
+			"Set one of off, fatal, error, warn, info, debug or trace")
+	}
+	if c.Theme != "" && !isTheme(c.Theme) {
+		add(severityError, "theme", fmt.Sprintf("unknown theme %s", c.Theme), fmt.Sprintf("Set one of %s", strings.Join(theme.Names(), ", ")))
+	}
+	if fc := c.Hazelcast.Failover; fc.Enabled && len(fc.Configs) == 0 {
+		add(severityError, "hazelcast.failover.configs", "failover is enabled without the clusters to fail over to",
+			"Add the connection details of the clusters, or set hazelcast.failover.enabled to false")
+	} else if !fc.Enabled && len(fc.Configs) > 0 {
+		add(severityWarning, "hazelcast.failover.enabled", "the failover clusters are ignored, since failover is not enabled",
+			"Set hazelcast.failover.enabled to true, or remove the clusters")
+	}
+	retry := cc.ConnectionStrategy.Retry
+	if retry.Multiplier != 0 && retry.Multiplier < 1 {
+		add(severityError, "hazelcast.cluster.connectionstrategy.retry.multiplier", fmt.Sprintf("the multiplier %g is less than 1", retry.Multiplier),
+			"Set a multiplier greater than or equal to 1")
+	}
+	if retry.Jitter < 0 || retry.Jitter > 1 {
+		add(severityError, "hazelcast.cluster.connectionstrategy.retry.jitter", fmt.Sprintf("the jitter %g is not between 0 and 1", retry.Jitter),
+			"Set a jitter between 0 and 1")
+	}
+	for _, t := range []struct {
+		key string
+		d   time.Duration
+	}{
+		{"timeout.connect", c.Timeout.Connect},
+		{"timeout.invocation", c.Timeout.Invocation},
+		{"timeout.sql", c.Timeout.SQL},
+	} {
+		if t.d < 0 {
+			add(severityError, t.key, fmt.Sprintf("the timeout %s is negative", t.d), "Set a positive timeout, or 0s for no timeout")
+		}
+	}
+	if mc := c.ManagementCenter.URL; mc != "" {
+		if u, err := url.Parse(mc); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add(severityError, "managementcenter.url", fmt.Sprintf("%s is not an HTTP URL", mc), "Set the URL of the Management Center, such as http://localhost:8080")
+		}
+	}
+	return problems
+}
+
+func isTheme(name string) bool {
+	for _, n := range theme.Names() {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// settingPaths returns the paths of the settings by the names of their types, such as hazelcast.cluster for cluster.Config.
+// A type which is used in more than one place, such as the failover clusters, gets the path closest to the root.
+func settingPaths() map[string]string {
+	paths := map[string]string{}
+	type node struct {
+		t    reflect.Type
+		path string
+	}
+	queue := []node{{reflect.TypeOf(config.Config{}), ""}}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if _, ok := paths[n.t.String()]; ok {
+			continue
+		}
+		paths[n.t.String()] = n.path
+		for i := 0; i < n.t.NumField(); i++ {
+			f := n.t.Field(i)
+			t := f.Type
+			for t.Kind() == reflect.Slice || t.Kind() == reflect.Ptr || t.Kind() == reflect.Map {
+				t = t.Elem()
+			}
+			if f.PkgPath != "" || t.Kind() != reflect.Struct {
+				continue
+			}
+			queue = append(queue, node{t, joinKey(n.path, strings.ToLower(f.Name))})
+		}
+	}
+	return paths
+}
+
+// movedSettingPath returns the path of the setting if it is a setting of the cluster given at the top level of the client configuration,
+// such as hazelcast.network instead of hazelcast.cluster.network.
+func movedSettingPath(typeName, key string) (string, bool) {
+	if typeName != reflect.TypeOf(hazelcast.Config{}).String() {
+		return "", false
+	}
+	t := reflect.TypeOf(cluster.Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if strings.ToLower(t.Field(i).Name) == key {
+			return "hazelcast.cluster", true
+		}
+	}
+	return "", false
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// probeCluster tries connecting to the cluster of the configuration, it returns the problem if it cannot.
+func probeCluster(cmd *cobra.Command, path string) (problem, bool) {
+	c, err := config.Load(path)
+	if err != nil {
+		return problem{severity: severityError, message: fmt.Sprintf("the configuration cannot be loaded: %s", errorMessage(err)),
+			fix: "Correct the settings in the message"}, false
+	}
+	timeout := c.Timeout.Connect
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+	start := time.Now()
+	cli, err := internal.StartClient(ctx, &c.Hazelcast)
+	if err != nil {
+		fix := "Check that the members are running and reachable at the addresses, and the cluster name, the credentials and the SSL settings are the ones of the cluster"
+		if c.Hazelcast.Cluster.Cloud.Enabled {
+			fix = "Check the discovery token and the SSL settings of the cluster"
+		}
+		msg := errorMessage(err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			msg = fmt.Sprintf("cannot connect in %s", timeout)
+		}
+		return problem{severity: severityError, key: "hazelcast.cluster", message: msg, fix: fix}, false
+	}
+	defer cli.Shutdown(context.Background())
+	quiet.Printf(cmd, "Connected to %s in %s\n", config.GetClusterAddress(&c.Hazelcast), time.Since(start).Round(time.Millisecond))
+	return problem{}, true
+}
+
+func errorMessage(err error) string {
+	var le hzcerrors.LoggableError
+	if errors.As(err, &le) && le.Unwrap() != nil {
+		return fmt.Sprintf("%s: %s", le.Error(), le.Unwrap())
+	}
+	return err.Error()
+}
+
+func hasErrors(problems []problem) bool {
+	for _, p := range problems {
+		if p.severity == severityError {
+			return true
+		}
+	}
+	return false
+}
+
+// reportProblems prints the problems with their fixes, it returns an error if there are errors among them.
+func reportProblems(cmd *cobra.Command, path string, problems []problem) error {
+	if len(problems) == 0 {
+		quiet.Printf(cmd, "No problems found in %s\n", path)
+		return nil
+	}
+	writeProblems(cmd.OutOrStdout(), problems)
+	var errs int
+	for _, p := range problems {
+		if p.severity == severityError {
+			errs++
+		}
+	}
+	quiet.Printf(cmd, "---\n%d error(s), %d warning(s) in %s\n", errs, len(problems)-errs, path)
+	if errs > 0 {
+		return hzcerrors.NewLoggableError(nil, "The configuration has errors")
+	}
+	return nil
+}
+
+func writeProblems(w io.Writer, problems []problem) {
+	for _, p := range problems {
+		msg := p.message
+		if p.key != "" {
+			msg = fmt.Sprintf("%s: %s", p.key, msg)
+		}
+		fmt.Fprintf(w, "%-8s %s\n", p.severity, msg)
+		if p.fix != "" {
+			fmt.Fprintf(w, "%-8s Fix: %s\n", "", p.fix)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package configcmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCheckConfig(t *testing.T) {
+	problems := checkConfig([]byte(`
+hazelcast:
+  cluster:
+    name: dev
+    cloud:
+      enabled: true
+    connectionstrategy:
+      retry:
+        jitter: 2
+  network:
+    addresses:
+      - "localhost:5701"
+  logger:
+    level: loud
+ssl:
+  capath: /does/not/exist/ca.pem
+  certpath: client.pem
+theme: neon
+timeout:
+  sql: -1s
+managementcenter:
+  url: localhost:8080
+disableautocompletion: false
+`))
+	var b bytes.Buffer
+	writeProblems(&b, problems)
+	out := b.String()
+	for _, want := range []string{
+		"warning  hazelcast.network: unknown key on line 10, it is ignored\n         Fix: Move it under hazelcast.cluster\n",
+		"warning  disableautocompletion: unknown key on line 23, it is ignored\n",
+		"error    hazelcast.cluster.cloud.token: Hazelcast Cloud is enabled without a token\n",
+		"error    hazelcast.cluster.connectionstrategy.retry.jitter: the jitter 2 is not between 0 and 1\n",
+		"error    hazelcast.logger.level: unknown log level loud\n",
+		"error    ssl.capath: cannot access /does/not/exist/ca.pem",
+		"error    ssl: only one of the client certificate and its key is given\n",
+		"warning  ssl.enabled: the SSL settings are ignored, since SSL is not enabled\n",
+		"error    theme: unknown theme neon\n",
+		"error    timeout.sql: the timeout -1s is negative\n",
+		"error    managementcenter.url: localhost:8080 is not an HTTP URL\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%q is not in the output:\n%s", want, out)
+		}
+	}
+	if !hasErrors(problems) {
+		t.Fatal("expected errors")
+	}
+	problems = checkConfig([]byte(`
+hazelcast:
+  cluster:
+    name: dev
+    network:
+      addresses: ["localhost:5701"]
+ssl:
+  enabled: false
+theme: Dark
+`))
+	if len(problems) != 0 {
+		t.Fatalf("unexpected problems: %+v", problems)
+	}
+	problems = checkConfig([]byte("hazelcast:\n\tcluster: {}\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].message, "not valid YAML") {
+		t.Fatalf("unexpected problems: %+v", problems)
+	}
+}
+
+func TestNamedConfigPath(t *testing.T) {
+	if p := namedConfigPath("prod"); !strings.HasSuffix(p, "/prod.yaml") || p == "prod.yaml" {
+		t.Fatalf("unexpected path: %s", p)
+	}
+	for _, p := range []string{"prod.yaml", "configs/prod", "/etc/hzc/prod.yml"} {
+		if got := namedConfigPath(p); got != p {
+			t.Fatalf("want %s got %s", p, got)
+		}
+	}
+}
@@ -41,6 +41,10 @@ To enable REST API, follow the instructions in the member log or see the documen
 For operations that change state/configuration of the cluster (e.g. "cluster change-state"), you need to have "CLUSTER_WRITE" permission on the REST API to prevent unauthorized changes.
 
 To change CLUSTER_WRITE permission, see the documentation: https://docs.hazelcast.com/hazelcast/latest/maintain-cluster/rest-api#using-rest-endpoint-groups`
+	restOrPersistenceEnabledMsg = restEnabledMsg + "\n\n" + `- If yes, is PERSISTENCE endpoint group enabled?
+The persistence operations (e.g. "cluster backup trigger" and "cluster force-start") require the "PERSISTENCE" endpoint group on the REST API, and persistence must be enabled on the members.
+
+To enable the PERSISTENCE endpoint group, see the documentation: https://docs.hazelcast.com/hazelcast/latest/maintain-cluster/rest-api#using-rest-endpoint-groups`
 )
 
 func ErrorRecover() {
@@ -62,9 +66,12 @@ func TranslateError(err error, isCloudCluster bool, op ...string) (string, bool)
 func TranslateClusterError(err error, operation string) (string, bool) {
 	var urlErr *url.Error
 	if errors.As(err, &urlErr) && strings.Contains(urlErr.Error(), "EOF") {
-		if operation == internal.ClusterShutdown || operation == internal.ClusterChangeState {
+		if operation == internal.ClusterShutdown || operation == internal.ClusterChangeState || operation == internal.ClusterChangeVersion {
 			return restOrClusterWriteEnabledMsg, true
 		}
+		if internal.IsPersistenceOperation(operation) {
+			return restOrPersistenceEnabledMsg, true
+		}
 		return restEnabledMsg, true
 	}
 	if errors.Is(err, syscall.ECONNRESET) {
@@ -0,0 +1,204 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package findcmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
+)
+
+const (
+	KeyPatternFlag      = "key-pattern"
+	ValuePatternFlag    = "value-pattern"
+	LimitFlag           = "limit"
+	ParallelismFlag     = "parallelism"
+	IncludeInternalFlag = "include-internal"
+)
+
+const defaultParallelism = 4
+
+const FindExample = `  # Find the maps which have keys starting with "user:"
+  hzc find --key-pattern 'user:*'
+  # Find the maps which have values containing "jane", searching at most 50 maps
+  hzc find --value-pattern '*jane*' --limit 50`
+
+type findResult struct {
+	mapName string
+	count   int
+	err     error
+}
+
+func New(config *hazelcast.Config) *cobra.Command {
+	var (
+		keyPattern,
+		valuePattern string
+		limit,
+		parallelism int
+		includeInternal bool
+	)
+	cmd := &cobra.Command{
+		Use:   "find {--key-pattern pattern | --value-pattern pattern} [--limit count] [--parallelism count]",
+		Short: "Find the maps which contain matching keys or values",
+		Long: `Find the maps which contain matching keys or values, and print the number of matching entries in each.
+Patterns match the whole key or value. Use * to match any characters, and ? to match a single character.
+Only string keys and values can be matched. The matching is done on the cluster, so the entries are not transferred.`,
+		Example: FindExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keyPattern == "" && valuePattern == "" {
+				return hzcerrors.NewLoggableError(nil, "One of --%s and --%s must be given", KeyPatternFlag, ValuePatternFlag)
+			}
+			if parallelism < 1 {
+				return hzcerrors.NewLoggableError(nil, "Parallelism must be positive")
+			}
+			ctx := cmd.Context()
+			client, err := internal.ConnectToCluster(ctx, config)
+			if err != nil {
+				return err
+			}
+			infos, err := client.GetDistributedObjectsInfo(ctx)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot list the maps")
+			}
+			names := mapNames(infos, includeInternal)
+			if limit > 0 && len(names) > limit {
+				names = names[:limit]
+			}
+			pred := patternPredicate(keyPattern, valuePattern)
+			results := findInMaps(ctx, client, names, pred, parallelism)
+			var found int
+			for _, r := range results {
+				if r.err != nil {
+					cmd.PrintErrf("Cannot search map %s: %s\n", r.mapName, r.err)
+					continue
+				}
+				if r.count > 0 {
+					found++
+					fmt.Fprintf(cmd.OutOrStdout(), "%s\t%d\n", r.mapName, r.count)
+				}
+			}
+			if found == 0 {
+				cmd.PrintErrf("No matches in %d maps\n", len(names))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&keyPattern, KeyPatternFlag, "", "pattern of the keys, such as 'user:*'")
+	cmd.Flags().StringVar(&valuePattern, ValuePatternFlag, "", "pattern of the values, such as '*jane*'")
+	cmd.Flags().IntVar(&limit, LimitFlag, 0, "maximum number of maps to search, 0 means no limit")
+	cmd.Flags().IntVar(&parallelism, ParallelismFlag, defaultParallelism, "number of maps to search at the same time")
+	cmd.Flags().BoolVar(&includeInternal, IncludeInternalFlag, false, "search the internal maps, which have names starting with __, as well")
+	return watch.Watchable(cmd)
+}
+
+// mapNames returns the sorted names of the maps.
+func mapNames(infos []types.DistributedObjectInfo, includeInternal bool) []string {
+	var names []string
+	for _, info := range infos {
+		if info.ServiceName != hazelcast.ServiceNameMap {
+			continue
+		}
+		if !includeInternal && strings.HasPrefix(info.Name, "__") {
+			continue
+		}
+		names = append(names, info.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func patternPredicate(keyPattern, valuePattern string) predicate.Predicate {
+	var preds []predicate.Predicate
+	if keyPattern != "" {
+		preds = append(preds, predicate.Like("__key", globToLike(keyPattern)))
+	}
+	if valuePattern != "" {
+		preds = append(preds, predicate.Like("this", globToLike(valuePattern)))
+	}
+	if len(preds) == 1 {
+		return preds[0]
+	}
+	return predicate.And(preds...)
+}
+
+// globToLike converts the glob pattern to the pattern of the LIKE predicate.
+func globToLike(pattern string) string {
+	var sb strings.Builder
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteByte('%')
+		case '?':
+			sb.WriteByte('_')
+		case '%', '_', '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// findInMaps counts the matching entries in the maps using the given number of workers.
+// The results are in the order of the map names.
+func findInMaps(ctx context.Context, client *hazelcast.Client, names []string, pred predicate.Predicate, parallelism int) []findResult {
+	results := make([]findResult, len(names))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = countMatches(ctx, client, names[i], pred)
+			}
+		}()
+	}
+	for i := range names {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+func countMatches(ctx context.Context, client *hazelcast.Client, name string, pred predicate.Predicate) findResult {
+	r := findResult{mapName: name}
+	m, err := client.GetMap(ctx, name)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	keys, err := m.GetKeySetWithPredicate(ctx, pred)
+	if err != nil {
+		r.err = fmt.Errorf("matching keys: %w", err)
+		return r
+	}
+	r.count = len(keys)
+	return r
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package findcmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestGlobToLike(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: "user:*", want: "user:%"},
+		{pattern: "?at", want: "_at"},
+		{pattern: "100%_off", want: `100\%\_off`},
+		{pattern: `a\b`, want: `a\\b`},
+	} {
+		t.Run(tc.pattern, func(t *testing.T) {
+			if got := globToLike(tc.pattern); got != tc.want {
+				t.Errorf("want %s got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMapNames(t *testing.T) {
+	infos := []types.DistributedObjectInfo{
+		{Name: "users", ServiceName: hazelcast.ServiceNameMap},
+		{Name: "__sql.catalog", ServiceName: hazelcast.ServiceNameMap},
+		{Name: "orders", ServiceName: hazelcast.ServiceNameMap},
+		{Name: "events", ServiceName: hazelcast.ServiceNameQueue},
+	}
+	if got, want := mapNames(infos, false), []string{"orders", "users"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v got %v", want, got)
+	}
+	if got, want := mapNames(infos, true), []string{"__sql.catalog", "orders", "users"}; !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v got %v", want, got)
+	}
+}
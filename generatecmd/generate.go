@@ -0,0 +1,307 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package generatecmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/progress"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/ratelimit"
+)
+
+const (
+	NameFlag        = "name"
+	CountFlag       = "count"
+	TemplateFlag    = "template"
+	KeyTemplateFlag = "key-template"
+	KeyTypeFlag     = "key-type"
+	ValueTypeFlag   = "value-type"
+	ParallelismFlag = "parallelism"
+	RateFlag        = "rate"
+	SeedFlag        = "seed"
+)
+
+const (
+	defaultCount         = "1000"
+	defaultKeyTemplate   = "{{index}}"
+	defaultValueTemplate = `{"id":{{index}},"name":"{{name}}","email":"{{email}}","age":{{int 18 80}},"city":"{{city}}"}`
+	defaultParallelism   = 4
+	// generateBatchSize is the maximum number of entries each writer puts at once
+	generateBatchSize = 1000
+	// dryRunSamples is the number of the generated entries printed in the dry run mode
+	dryRunSamples = 3
+)
+
+const generateMapExample = `  # Put 1 million people to the map
+  hzc generate map --name people --count 1M --template '{"name":"{{firstName}}","age":{{int 18 80}}}'
+  # Put 500 entries per second with int64 keys, the same data is generated every time
+  hzc generate map --name orders --count 10K --rate 500 --key-type int64 --seed 42 \
+    --template '{"customer":"{{email}}","total":{{float 1 500}},"status":"{{pick "new" "paid" "shipped"}}"}'`
+
+const templateHelp = `The templates are Go templates, which can use the following functions:
+  index             index of the entry, starting from 0
+  firstName         a first name, such as Mary
+  lastName          a last name, such as Smith
+  name              a first name and a last name
+  email             an e-mail address, such as mary.smith42@example.com
+  city              a city, such as Istanbul
+  country           a country, such as Japan
+  word              a word, such as partition
+  int MIN MAX       an integer between MIN and MAX, MAX included
+  float MIN MAX     a floating point number between MIN and MAX
+  bool              true or false
+  uuid              a random UUID
+  date              a date in the last 10 years, such as 2021-06-27
+  pick A B ...      one of the given strings`
+
+func New(config *hazelcast.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate {map} [--count count] [--template template]",
+		Short: "Generate data for load tests and demos",
+	}
+	cmd.AddCommand(NewMap(config))
+	return cmd
+}
+
+func NewMap(config *hazelcast.Config) *cobra.Command {
+	var (
+		mapName,
+		count,
+		keyTemplate,
+		valueTemplate,
+		keyType,
+		valueType string
+		parallelism,
+		rate int
+		seed int64
+	)
+	cmd := &cobra.Command{
+		Use:   "map --name mapname [--count count] [--template template] [--parallelism count] [--rate entries]",
+		Short: "Put generated entries to the map",
+		Long: `Put generated entries to the map, the keys and the values are rendered from the templates for each entry.
+` + templateHelp,
+		Example: generateMapExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := parseCount(count)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid --%s", CountFlag)
+			}
+			if parallelism < 1 {
+				return hzcerrors.NewLoggableError(nil, "Parallelism must be positive")
+			}
+			if rate < 0 {
+				return hzcerrors.NewLoggableError(nil, "Rate cannot be negative")
+			}
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+			g := entryGenerator{keyTemplate: keyTemplate, valueTemplate: valueTemplate, keyType: keyType, valueType: valueType, seed: seed}
+			// the templates are checked by rendering the first entry before connecting
+			w, err := g.newWriter(0)
+			if err == nil && n > 0 {
+				_, err = w.entry(0)
+			}
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid template")
+			}
+			if dryrun.Enabled(cmd) {
+				return printGenerateDryRun(cmd, g, mapName, n)
+			}
+			ctx := cmd.Context()
+			ci, err := internal.ConnectToCluster(ctx, config)
+			if err != nil {
+				return err
+			}
+			m, err := ci.GetMap(ctx, mapName)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get map %s", mapName)
+			}
+			start := time.Now()
+			if err := generate(ctx, cmd, m, g, n, parallelism, rate); err != nil {
+				if msg, handled := hzcerrors.TranslateNetworkError(err, config.Cluster.Cloud.Enabled); handled {
+					return hzcerrors.NewLoggableError(err, msg)
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot generate the entries of map %s", mapName)
+			}
+			quiet.Printf(cmd, "Put %d entries to map %s in %s\n", n, mapName, time.Since(start).Round(time.Millisecond))
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&mapName, NameFlag, "n", "", "name of the map")
+	if err := cmd.MarkFlagRequired(NameFlag); err != nil {
+		panic(err)
+	}
+	cmd.Flags().StringVar(&count, CountFlag, defaultCount, "number of the entries, K, M and G suffixes can be used, such as 1M")
+	cmd.Flags().StringVar(&valueTemplate, TemplateFlag, defaultValueTemplate, "template of the values")
+	cmd.Flags().StringVar(&keyTemplate, KeyTemplateFlag, defaultKeyTemplate, "template of the keys")
+	cmd.Flags().StringVar(&keyType, KeyTypeFlag, internal.TypeNameString, fmt.Sprintf("type of the keys, one of: %s", strings.Join(internal.SupportedTypeNames, ",")))
+	cmd.Flags().StringVar(&valueType, ValueTypeFlag, internal.TypeNameJSON, fmt.Sprintf("type of the values, one of: %s", strings.Join(internal.SupportedTypeNames, ",")))
+	cmd.Flags().IntVar(&parallelism, ParallelismFlag, defaultParallelism, "number of the writers putting the entries at the same time")
+	cmd.Flags().IntVar(&rate, RateFlag, 0, "maximum number of the entries put per second, 0 means no limit")
+	cmd.Flags().Int64Var(&seed, SeedFlag, 0, "seed of the random data, the same data is generated for the same seed and parallelism, 0 means a random seed")
+	return dryrun.Supported(cmd)
+}
+
+func printGenerateDryRun(cmd *cobra.Command, g entryGenerator, mapName string, n int64) error {
+	dryrun.Print(cmd, "put %d generated entries to map %s, such as", n, mapName)
+	w, err := g.newWriter(0)
+	if err != nil {
+		return err
+	}
+	for i := int64(0); i < n && i < dryRunSamples; i++ {
+		e, err := w.entry(i)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s: %s\n", output.String(e.Key), output.String(e.Value))
+	}
+	return nil
+}
+
+// entryGenerator creates the generators of the writers.
+type entryGenerator struct {
+	keyTemplate,
+	valueTemplate,
+	keyType,
+	valueType string
+	seed int64
+}
+
+func (eg entryGenerator) newWriter(n int) (*entryWriter, error) {
+	g, err := newGenerator(eg.keyTemplate, eg.valueTemplate, eg.seed+int64(n))
+	if err != nil {
+		return nil, err
+	}
+	return &entryWriter{g: g, keyType: eg.keyType, valueType: eg.valueType}, nil
+}
+
+type entryWriter struct {
+	g         *generator
+	keyType   string
+	valueType string
+}
+
+func (w *entryWriter) entry(index int64) (types.Entry, error) {
+	k, v, err := w.g.entry(index)
+	if err != nil {
+		return types.Entry{}, err
+	}
+	key, err := internal.ConvertString(k, w.keyType)
+	if err != nil {
+		return types.Entry{}, fmt.Errorf("converting key %s to %s: %w", k, w.keyType, err)
+	}
+	value, err := internal.ConvertString(v, w.valueType)
+	if err != nil {
+		return types.Entry{}, fmt.Errorf("converting value %s to %s: %w", v, w.valueType, err)
+	}
+	return types.Entry{Key: key, Value: value}, nil
+}
+
+// generate puts n entries to the map, each writer puts a contiguous range of the entries.
+func generate(ctx context.Context, cmd *cobra.Command, m *hazelcast.Map, g entryGenerator, n int64, parallelism, rate int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	batchSize := generateBatchSize
+	if rate > 0 && rate < batchSize {
+		// smaller batches keep the rate steady
+		batchSize = rate
+	}
+	limiter := ratelimit.New(rate)
+	tracker := progress.New(cmd.ErrOrStderr(), "Putting entries", n)
+	defer tracker.Done()
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for i := 0; i < parallelism; i++ {
+		from, to := n*int64(i)/int64(parallelism), n*int64(i+1)/int64(parallelism)
+		w, err := g.newWriter(i)
+		if err != nil {
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.put(ctx, m, from, to, batchSize, limiter, tracker); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func (w *entryWriter) put(ctx context.Context, m *hazelcast.Map, from, to int64, batchSize int, limiter *ratelimit.Limiter, tracker *progress.Tracker) error {
+	batch := make([]types.Entry, 0, batchSize)
+	for i := from; i < to; {
+		batch = batch[:0]
+		for ; i < to && len(batch) < batchSize; i++ {
+			e, err := w.entry(i)
+			if err != nil {
+				return err
+			}
+			batch = append(batch, e)
+		}
+		if err := limiter.Wait(ctx, len(batch)); err != nil {
+			return err
+		}
+		if err := m.PutAll(ctx, batch...); err != nil {
+			return err
+		}
+		tracker.Add(int64(len(batch)))
+	}
+	return nil
+}
+
+var countSuffixes = map[byte]float64{'K': 1e3, 'M': 1e6, 'G': 1e9}
+
+// parseCount parses the number of the entries, which can have a K, M or G suffix, such as 1.5M.
+func parseCount(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("count is empty")
+	}
+	number, multiplier := s, 1.0
+	if m, ok := countSuffixes[strings.ToUpper(s[len(s)-1:])[0]]; ok {
+		number, multiplier = s[:len(s)-1], m
+	}
+	f, err := strconv.ParseFloat(number, 64)
+	n := f * multiplier
+	if err != nil || n < 0 || n != math.Trunc(n) || n > math.MaxInt64 {
+		return 0, fmt.Errorf("%s is not a valid count, such as 1000 or 1.5M", s)
+	}
+	return int64(n), nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package generatecmd
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseCount(t *testing.T) {
+	for _, tc := range []struct {
+		in     string
+		want   int64
+		hasErr bool
+	}{
+		{in: "1000", want: 1000},
+		{in: "10K", want: 10000},
+		{in: "1m", want: 1000000},
+		{in: "1.5M", want: 1500000},
+		{in: "2G", want: 2000000000},
+		{in: "0", want: 0},
+		{in: "", hasErr: true},
+		{in: "M", hasErr: true},
+		{in: "1.5", hasErr: true},
+		{in: "-1K", hasErr: true},
+		{in: "10x", hasErr: true},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := parseCount(tc.in)
+			if (err != nil) != tc.hasErr {
+				t.Fatalf("want error %t got %v", tc.hasErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("want %d got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	tmpl := `{"i":{{index}},"name":"{{name}}","age":{{int 18 18}},"f":{{float 1 1}},"s":"{{pick "a"}}","b":{{bool}},"u":"{{uuid}}","d":"{{date}}"}`
+	g1, err := newGenerator("k-{{index}}", tmpl, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2, err := newGenerator("k-{{index}}", tmpl, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := int64(0); i < 3; i++ {
+		k1, v1, err := g1.entry(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k2, v2, _ := g2.entry(i)
+		if k1 != k2 || v1 != v2 {
+			t.Fatalf("the same seed generated different entries: %s=%s and %s=%s", k1, v1, k2, v2)
+		}
+		var value struct {
+			I   int64
+			Age int
+			F   float64
+			S   string
+		}
+		if err := json.Unmarshal([]byte(v1), &value); err != nil {
+			t.Fatalf("invalid JSON %s: %s", v1, err)
+		}
+		if value.I != i || value.Age != 18 || value.F != 1 || value.S != "a" {
+			t.Errorf("unexpected value %s", v1)
+		}
+	}
+	if _, err := newGenerator("{{index}", "", 1); err == nil {
+		t.Errorf("want parse error")
+	}
+	g, _ := newGenerator("{{index}}", "{{int 5 1}}", 1)
+	if _, _, err := g.entry(0); err == nil {
+		t.Errorf("want render error")
+	}
+}
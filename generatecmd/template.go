@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package generatecmd
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+)
+
+var (
+	firstNames = []string{"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda", "David", "Elizabeth",
+		"William", "Barbara", "Richard", "Susan", "Joseph", "Jessica", "Thomas", "Sarah", "Ahmet", "Ayse", "Mehmet", "Fatma",
+		"Hans", "Anna", "Luca", "Giulia", "Hiroshi", "Yuki", "Wei", "Mei"}
+	lastNames = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez",
+		"Wilson", "Anderson", "Taylor", "Thomas", "Moore", "Jackson", "Martin", "Lee", "Yilmaz", "Kaya", "Demir", "Schmidt",
+		"Muller", "Rossi", "Ferrari", "Sato", "Suzuki", "Wang", "Li", "Zhang"}
+	cities = []string{"New York", "London", "Paris", "Berlin", "Istanbul", "Tokyo", "Madrid", "Rome", "Amsterdam", "Prague",
+		"Toronto", "Sydney", "Singapore", "Dubai", "Seoul", "Mumbai", "Sao Paulo", "Mexico City", "Cairo", "Stockholm"}
+	countries = []string{"United States", "United Kingdom", "France", "Germany", "Turkey", "Japan", "Spain", "Italy",
+		"Netherlands", "Czechia", "Canada", "Australia", "Singapore", "United Arab Emirates", "South Korea", "India",
+		"Brazil", "Mexico", "Egypt", "Sweden"}
+	words = []string{"alpha", "bravo", "cache", "data", "edge", "fast", "grid", "hazel", "index", "jet", "key", "latency",
+		"map", "node", "offset", "partition", "query", "replica", "stream", "topic", "update", "value", "wan", "yield", "zone"}
+	domains = []string{"example.com", "example.org", "example.net"}
+)
+
+// dateRange is the span of the random dates, which end today.
+const dateRange = 10 * 365 * 24 * time.Hour
+
+// generator renders the key and value templates of the entries.
+// It is not safe for concurrent use, each writer has its own generator.
+type generator struct {
+	rnd   *rand.Rand
+	index int64
+	key   *template.Template
+	value *template.Template
+	now   time.Time
+	sb    strings.Builder
+}
+
+func newGenerator(keyTemplate, valueTemplate string, seed int64) (*generator, error) {
+	g := &generator{rnd: rand.New(rand.NewSource(seed)), now: time.Now()}
+	var err error
+	if g.key, err = template.New("key").Funcs(g.funcs()).Option("missingkey=error").Parse(keyTemplate); err != nil {
+		return nil, fmt.Errorf("parsing the key template: %w", err)
+	}
+	if g.value, err = template.New("value").Funcs(g.funcs()).Option("missingkey=error").Parse(valueTemplate); err != nil {
+		return nil, fmt.Errorf("parsing the value template: %w", err)
+	}
+	return g, nil
+}
+
+// entry renders the key and the value of the entry with the index.
+func (g *generator) entry(index int64) (key, value string, err error) {
+	g.index = index
+	if key, err = g.render(g.key); err != nil {
+		return "", "", fmt.Errorf("rendering the key template: %w", err)
+	}
+	if value, err = g.render(g.value); err != nil {
+		return "", "", fmt.Errorf("rendering the value template: %w", err)
+	}
+	return key, value, nil
+}
+
+func (g *generator) render(t *template.Template) (string, error) {
+	g.sb.Reset()
+	if err := t.Execute(&g.sb, nil); err != nil {
+		return "", err
+	}
+	return g.sb.String(), nil
+}
+
+func (g *generator) funcs() template.FuncMap {
+	return template.FuncMap{
+		"index":     func() int64 { return g.index },
+		"firstName": func() string { return g.pick(firstNames) },
+		"lastName":  func() string { return g.pick(lastNames) },
+		"name":      func() string { return g.pick(firstNames) + " " + g.pick(lastNames) },
+		"email": func() string {
+			return fmt.Sprintf("%s.%s%d@%s", strings.ToLower(g.pick(firstNames)), strings.ToLower(g.pick(lastNames)), g.rnd.Intn(100), g.pick(domains))
+		},
+		"city":    func() string { return g.pick(cities) },
+		"country": func() string { return g.pick(countries) },
+		"word":    func() string { return g.pick(words) },
+		"int": func(min, max int) (int, error) {
+			if max < min {
+				return 0, fmt.Errorf("int: max %d is less than min %d", max, min)
+			}
+			return min + g.rnd.Intn(max-min+1), nil
+		},
+		"float": func(min, max float64) (float64, error) {
+			if max < min {
+				return 0, fmt.Errorf("float: max %g is less than min %g", max, min)
+			}
+			return min + g.rnd.Float64()*(max-min), nil
+		},
+		"bool": func() bool { return g.rnd.Intn(2) == 1 },
+		"uuid": func() string { return types.NewUUIDWith(g.rnd.Uint64(), g.rnd.Uint64()).String() },
+		"date": func() string {
+			return g.now.Add(-time.Duration(g.rnd.Int63n(int64(dateRange)))).Format(internal.LayoutDate)
+		},
+		"pick": func(values ...string) (string, error) {
+			if len(values) == 0 {
+				return "", fmt.Errorf("pick: no values given")
+			}
+			return g.pick(values), nil
+		},
+	}
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.rnd.Intn(len(values))]
+}
@@ -12,8 +12,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
 
-	"github.com/hazelcast/hazelcast-commandline-client/internal/browser/layout/vertical"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/browser/multiline"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/layout"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/viewer"
 )
@@ -24,12 +24,27 @@ type TableResultMsg *sql.Rows
 type controller struct {
 	tea.Model
 	driver *sql.DB
+	// readOnly allows only the queries
+	readOnly bool
 }
 
 type table struct {
 	termdbmsTable viewer.TuiModel
 	keyboardFocus bool
 	lastIteration *SQLIterator
+	// headerHeight is the number of the lines above the rows in the view
+	headerHeight int
+	// detail shows the columns of the selected row instead of the table while it is open
+	detail *rowDetail
+	height int
+	// all is the fetched rows by column, the rows which match the filter are shown
+	all    map[string][]interface{}
+	filter string
+	search string
+	prompt resultPrompt
+	input  tuiutil.TextInputModel
+	// pinnedColumns is the number of the leftmost columns which stay visible while scrolling, until p is pressed
+	pinnedColumns int
 }
 
 func (t *table) Init() tea.Cmd {
@@ -46,12 +61,16 @@ func (t *table) Init() tea.Cmd {
 	viewer.GlobalCommands["up"] = viewer.GlobalCommands["w"]
 	t.termdbmsTable = viewer.GetNewModel("", nil)
 	t.termdbmsTable.UI.BorderToggle = true
+	t.termdbmsTable.Scroll.PinnedColumns = t.pinnedColumns
 	viewer.HeaderStyle.Bold(true)
 	return t.termdbmsTable.Init()
 }
 
 type FetchMoreRowsMsg struct{}
 
+// cancelQueryMsg is sent to all the panes when Ctrl+C is pressed, whichever pane is focused.
+type cancelQueryMsg struct{}
+
 func (t *table) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m := msg.(type) {
 	case StringResultMsg:
@@ -73,6 +92,14 @@ func (t *table) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			Data:     make(map[string]interface{}),
 		}
 		t.termdbmsTable.MouseData = tea.MouseEvent{}
+		// the columns pinned for the last result stay pinned for the next one
+		t.termdbmsTable.Scroll = viewer.ScrollData{PinnedColumns: t.termdbmsTable.Scroll.PinnedColumns}
+		t.termdbmsTable.UI.VisualSelection = false
+		t.detail = nil
+		t.all = nil
+		t.filter = ""
+		t.clearSearch()
+		t.closePrompt()
 		var err error
 		if t.lastIteration, err = NewSqlIterator(50, m); err != nil {
 			return t, nil
@@ -81,7 +108,6 @@ func (t *table) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case NewRowsMessage:
 		t.PopulateDataForResult(m)
 		t.termdbmsTable.UI.CurrentTable = 1
-		_ = t.termdbmsTable.NumHeaders() // to set maxHeaders global var, for side effect
 		t.termdbmsTable.SetViewSlices()
 		var cmd tea.Cmd
 		if !t.lastIteration.rowsFinished {
@@ -94,36 +120,79 @@ func (t *table) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		go t.lastIteration.Iterate(50)
 		return t, t.lastIteration.ConsumeRowsCmd(50 * time.Millisecond)
+	case layout.FocusMsg:
+		t.keyboardFocus = true
+		tuiutil.Faint = false
+		return t, nil
+	case layout.BlurMsg:
+		t.keyboardFocus = false
+		tuiutil.Faint = true
+		return t, nil
+	case cancelQueryMsg:
+		if t.lastIteration != nil {
+			t.lastIteration.rows.Close()
+		}
+		return t, nil
 	case tea.KeyMsg:
-		switch m.Type {
-		case tea.KeyTab:
-			t.keyboardFocus = !t.keyboardFocus
-			tuiutil.Faint = !tuiutil.Faint
+		if m.Type == tea.KeyCtrlC || !t.keyboardFocus {
 			return t, nil
-		case tea.KeyCtrlC:
-			if t.lastIteration != nil {
-				t.lastIteration.rows.Close()
+		}
+		if t.detail != nil {
+			cmd, closed := t.detail.Update(m)
+			if closed {
+				t.detail = nil
 			}
-			return t, nil
+			return t, cmd
 		}
-		if !t.keyboardFocus {
+		if cmd, ok := t.searchKey(m); ok {
+			return t, cmd
+		}
+		if m.Type == tea.KeyEnter {
+			t.openRowDetail()
 			return t, nil
 		}
+		if cmd, ok := t.resultKey(m); ok {
+			return t, cmd
+		}
 	case tea.MouseMsg:
-		// disable all mouse events
-		return t, nil
+		if t.detail != nil {
+			return t, t.detailMouse(m)
+		}
+		switch m.Type {
+		case tea.MouseWheelUp, tea.MouseWheelDown:
+			// the viewer scrolls the rows
+		case tea.MouseRelease:
+			// a click selects the cell under the pointer
+			t.selectCell(m.X, m.Y)
+			return t, nil
+		default:
+			return t, nil
+		}
 	case tea.WindowSizeMsg:
+		t.height = m.Height
+		if t.detail != nil {
+			t.detail.height = m.Height
+			t.detail.scroll()
+		}
 		if m.Height >= 2 {
 			m.Height -= 2 // footer, header height offset
 		}
 		msg = m
+	default:
+		if t.prompt != noPrompt {
+			// the cursor of the prompt blinks
+			var cmd tea.Cmd
+			t.input, cmd = t.input.Update(msg)
+			return t, cmd
+		}
 	}
 	oldYOffset := t.termdbmsTable.Viewport.YOffset + t.termdbmsTable.GetRow()
 	updatedTable, cmd := t.termdbmsTable.Update(msg)
 	t.termdbmsTable = updatedTable.(viewer.TuiModel)
 	newYOffset := t.termdbmsTable.Viewport.YOffset + t.termdbmsTable.GetRow()
 	if t.lastIteration != nil {
-		userOnLastPage := newYOffset > t.lastIteration.totalProcessedLines-t.termdbmsTable.Viewport.Height
+		// the rows which are shown are counted, since the filter may hide some of the fetched rows
+		userOnLastPage := newYOffset > t.rowCount()-t.termdbmsTable.Viewport.Height
 		if newYOffset > oldYOffset && userOnLastPage {
 			cmd = tea.Batch(cmd, func() tea.Msg {
 				return FetchMoreRowsMsg{}
@@ -134,6 +203,53 @@ func (t *table) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return t, cmd
 }
 
+// selectCell moves the selection to the cell at the position of the view, the clicks outside the rows are ignored.
+func (t *table) selectCell(x, y int) {
+	row := y - t.headerHeight
+	tt := &t.termdbmsTable
+	if row < 0 || row >= tt.Viewport.Height || tt.Viewport.YOffset+row >= len(tt.GetColumnData()) {
+		return
+	}
+	tt.MouseData.X = x
+	tt.MouseData.Y = viewer.HeaderHeight + row
+}
+
+// openRowDetail shows the columns of the selected row, there is no row to show while a message is shown instead of the rows.
+func (t *table) openRowDetail() {
+	tt := &t.termdbmsTable
+	if tt.UI.RenderSelection {
+		return
+	}
+	row := tt.Viewport.YOffset + tt.GetRow()
+	headers := tt.GetHeaders()
+	data := tt.GetSchemaData()
+	values := make([]interface{}, len(headers))
+	for i, h := range headers {
+		if row >= len(data[h]) {
+			return
+		}
+		values[i] = data[h][row]
+	}
+	if len(headers) == 0 {
+		return
+	}
+	t.detail = newRowDetail(headers, values)
+	t.detail.height = t.height
+}
+
+// detailMouse scrolls the row detail with the wheel, the other mouse events are ignored while it is open.
+func (t *table) detailMouse(m tea.MouseMsg) tea.Cmd {
+	switch m.Type {
+	case tea.MouseWheelUp:
+		cmd, _ := t.detail.Update(tea.KeyMsg{Type: tea.KeyUp})
+		return cmd
+	case tea.MouseWheelDown:
+		cmd, _ := t.detail.Update(tea.KeyMsg{Type: tea.KeyDown})
+		return cmd
+	}
+	return nil
+}
+
 type NewRowsMessage [][]interface{}
 
 const (
@@ -218,18 +334,22 @@ func (si *SQLIterator) ConsumeRowsCmd(deadline time.Duration) func() tea.Msg {
 
 func (m *table) PopulateDataForResult(rows [][]interface{}) {
 	columnNames := m.lastIteration.columnNames
-	columnValues := make(map[string][]interface{})
-	if m.termdbmsTable.QueryResult != nil && m.termdbmsTable.QueryData != nil && m.termdbmsTable.QueryResult.Data["0"] != nil {
-		columnValues = m.termdbmsTable.QueryResult.Data["0"].(map[string][]interface{})
+	if m.all == nil {
+		m.all = make(map[string][]interface{})
 	}
-
 	for _, row := range rows {
 		for i, colName := range columnNames {
 			val := row[i].(*interface{})
-			columnValues[colName] = append(columnValues[colName], *val)
+			m.all[colName] = append(m.all[colName], *val)
 		}
 	}
+	m.showRows()
+}
 
+// showRows passes the rows which match the filter to the viewer.
+func (m *table) showRows() {
+	columnNames := m.lastIteration.columnNames
+	columnValues := m.filteredRows()
 	// onto the next schema
 	if m.termdbmsTable.QueryResult != nil && m.termdbmsTable.QueryData != nil {
 		m.termdbmsTable.QueryResult.Data["0"] = columnValues
@@ -244,6 +364,9 @@ func (m *table) PopulateDataForResult(rows [][]interface{}) {
 }
 
 func (t *table) View() string {
+	if t.detail != nil {
+		return t.detail.View()
+	}
 	var wg sync.WaitGroup
 	wg.Add(2)
 	var header, content string
@@ -258,10 +381,11 @@ func (t *table) View() string {
 		wg.Done()
 	}()
 	wg.Wait()
+	t.headerHeight = lipgloss.Height(header)
 	if content == "" && t.termdbmsTable.Viewport.Height > 0 {
 		content = strings.Repeat("\n", t.termdbmsTable.Viewport.Height)
 	}
-	return fmt.Sprintf("%s\n%s", header, content)
+	return fmt.Sprintf("%s\n%s\n%s", header, content, t.promptView())
 }
 
 type Shortcut struct {
@@ -273,19 +397,51 @@ type Help struct {
 	width  int
 	values []Shortcut
 	align  lipgloss.Position
+	// status is shown after the shortcuts for a while, such as after copying the selected text
+	status   string
+	statusID int
 }
 
+type clearHelpStatusMsg int
+
+// helpStatusDuration is how long the status is shown
+const helpStatusDuration = 3 * time.Second
+
 func (h Help) Init() tea.Cmd {
 	return nil
 }
 
 func (h Help) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
 		h.width = msg.Width
+	case tuiutil.ClipboardMsg:
+		status := fmt.Sprintf("Copied %d characters", len([]rune(msg.Text)))
+		if msg.Err != nil {
+			status = fmt.Sprintf("Cannot copy the text: %s", msg.Err)
+		}
+		return h.showStatus(status)
+	case statusMsg:
+		return h.showStatus(string(msg))
+	case clearHelpStatusMsg:
+		// a newer status is kept until its own time is up
+		if int(msg) == h.statusID {
+			h.status = ""
+		}
 	}
 	return h, nil
 }
 
+// showStatus shows the status until helpStatusDuration passes.
+func (h Help) showStatus(status string) (tea.Model, tea.Cmd) {
+	h.status = status
+	h.statusID++
+	id := h.statusID
+	return h, tea.Tick(helpStatusDuration, func(time.Time) tea.Msg {
+		return clearHelpStatusMsg(id)
+	})
+}
+
 func (h Help) View() string {
 	base := lipgloss.NewStyle()
 	sh := base.Copy().
@@ -299,32 +455,22 @@ func (h Help) View() string {
 		b.WriteString(def.Render(v.description))
 		b.WriteString("      ")
 	}
+	b.WriteString(h.status)
 	return b.String()
 }
 
-type Separator int
-
-func (s Separator) Init() tea.Cmd {
-	return nil
-}
-
-func (s Separator) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(tea.WindowSizeMsg); ok {
-		s = Separator(msg.Width - 2)
-	}
-	return s, nil
-}
-
-func (s Separator) View() string {
-	return strings.Repeat("─", max(0, int(s)))
-}
-
 func (c controller) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m := msg.(type) {
+	case tea.KeyMsg:
+		if m.Type == tea.KeyCtrlC {
+			return c, func() tea.Msg {
+				return cancelQueryMsg{}
+			}
+		}
 	case multiline.SubmitMsg:
 		return c, func() tea.Msg {
 			lt := strings.TrimSpace(string(m))
-			rows, err := execSQL(c.driver, lt)
+			rows, err := execSQL(c.driver, lt, c.readOnly)
 			if err != nil {
 				return StringResultMsg(err.Error())
 			}
@@ -336,47 +482,50 @@ func (c controller) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return c, cmd
 }
 
-func InitSQLBrowser(driver *sql.DB) *tea.Program {
-	var s Separator
-	textArea := multiline.InitTextArea()
-	c := &controller{vertical.InitialModel([]tea.Model{
-		&table{},
-		s,
-		textArea,
-		Help{
+// sidePanelWidth is the width of the schema browser on the left of the SQL browser
+const sidePanelWidth = 32
+
+// InitSQLBrowser creates the SQL browser program, only SELECT and SHOW statements can be run if readOnly is true.
+// The mappings and their columns are listed in a pane on the left of the results and the editor, Tab moves the focus between the panes.
+// If mouse is true, the panes are resized and collapsed with the mouse, and the text selected in the results is copied to the clipboard.
+// The given number of the leftmost columns of the wide results stay visible while scrolling the columns.
+func InitSQLBrowser(driver *sql.DB, readOnly bool, schema SchemaSource, mouse bool, pinnedColumns int) *tea.Program {
+	editor := &layout.Pane{Title: "Query", Model: multiline.InitTextArea(), Focusable: true, Border: true}
+	root := layout.Split(layout.Vertical,
+		layout.Split(layout.Horizontal,
+			layout.NewPane(&layout.Pane{Title: "Mappings", Model: newSchemaBrowser(schema), Focusable: true, Border: true}).Fixed(sidePanelWidth),
+			layout.Split(layout.Vertical,
+				layout.NewPane(&layout.Pane{Title: "Results", Model: &table{pinnedColumns: pinnedColumns}, Focusable: true, Border: true, Selectable: true}).Weight(3),
+				layout.NewPane(editor).Weight(1),
+			),
+		),
+		layout.NewPane(&layout.Pane{Model: Help{
 			values: []Shortcut{
-				{
-					"^E",
-					"execute",
-				},
-				{
-					"^Q",
-					"quit",
-				},
-				{
-					"Tab",
-					"toggle focus",
-				},
-				{
-					"^V",
-					"paste",
-				},
-				{
-					"^C",
-					"cancel query",
-				},
-				{
-					"^U",
-					"clear query",
-				},
+				{"^E", "execute"},
+				{"^Q", "quit"},
+				{"Tab", "next pane"},
+				{"^V", "paste"},
+				{"^C", "cancel query"},
+				{"^U", "clear query"},
+				{"p", "pin columns"},
+				{"Enter", "row detail"},
+				{"y", "copy cell"},
+				{"v", "select rows"},
+				{"e", "export"},
+				{"/", "search"},
+				{"f", "filter"},
 			},
 			align: lipgloss.Left,
-		},
-	}, []int{3, -1, 1, -1}), driver}
-	p := tea.NewProgram(
-		c,
+		}}).Fixed(1).Locked(),
 	)
-	return p
+	l := layout.New(root)
+	// the statements are typed first
+	l.Focus(editor)
+	var opts []tea.ProgramOption
+	if mouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	return tea.NewProgram(&controller{Model: l, driver: driver, readOnly: readOnly}, opts...)
 }
 
 func max(a, b int) int {
@@ -385,3 +534,10 @@ func max(a, b int) int {
 	}
 	return b
 }
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,49 @@
+package browser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/layout"
+)
+
+func TestTableKeepsHeaderAndPinnedColumnWhileScrolling(t *testing.T) {
+	names := []string{"__key"}
+	for i := 1; i < 10; i++ {
+		names = append(names, fmt.Sprintf("col%d", i))
+	}
+	tb := &table{pinnedColumns: 1}
+	tb.Init()
+	tb.lastIteration = &SQLIterator{columnNames: names, rowsFinished: true}
+	tb.all = make(map[string][]interface{})
+	for r := 0; r < 30; r++ {
+		for i, n := range names {
+			tb.all[n] = append(tb.all[n], fmt.Sprintf("r%dc%d", r, i))
+		}
+	}
+	tb.Update(tea.WindowSizeMsg{Width: 140, Height: 15})
+	tb.Update(NewRowsMessage(nil))
+	tb.Update(layout.FocusMsg{})
+	for i := 0; i < 20; i++ {
+		tb.Update(keyMsg("j"))
+	}
+	for i := 0; i < 5; i++ {
+		tb.Update(keyMsg("right"))
+	}
+	// the header is styled by character
+	view := regexp.MustCompile("\x1b\\[[0-9;]*m").ReplaceAllString(tb.View(), "")
+	for _, want := range []string{"__key", "col6", "r20c0", "r20c6"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("want %q in the view:\n%s", want, view)
+		}
+	}
+	for _, hidden := range []string{"col1", "r0c0"} {
+		if strings.Contains(view, hidden) {
+			t.Fatalf("want %q to be scrolled out of the view:\n%s", hidden, view)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+// DashboardWidget is a pane of the dashboard, which is rendered again at its interval.
+type DashboardWidget struct {
+	Title    string
+	Interval time.Duration
+	// Render returns the content of the widget, which must fit in the width and the height
+	Render func(width, height int) (string, error)
+}
+
+type widgetTickMsg struct {
+	widget int
+}
+
+type widgetContentMsg struct {
+	widget  int
+	content string
+	err     error
+	at      time.Time
+	// scheduled is true if the widget is rendered at its interval, rather than when r is pressed
+	scheduled bool
+}
+
+// widgetState is the last content of a widget.
+type widgetState struct {
+	content   string
+	err       error
+	updatedAt time.Time
+}
+
+type dashboardModel struct {
+	title   string
+	columns int
+	widgets []DashboardWidget
+	states  []widgetState
+	width   int
+	height  int
+}
+
+func newDashboardModel(title string, columns int, widgets []DashboardWidget) *dashboardModel {
+	if columns <= 0 {
+		columns = 1
+	}
+	if columns > len(widgets) && len(widgets) > 0 {
+		columns = len(widgets)
+	}
+	return &dashboardModel{title: title, columns: columns, widgets: widgets, states: make([]widgetState, len(widgets))}
+}
+
+func (m *dashboardModel) Init() tea.Cmd {
+	// the widgets are rendered once the size of the screen is known
+	return nil
+}
+
+// render returns the command which renders the widget in the size of its pane.
+func (m *dashboardModel) render(i int, scheduled bool) tea.Cmd {
+	w := m.widgets[i]
+	width, height := m.contentSize()
+	return func() tea.Msg {
+		content, err := w.Render(width, height)
+		return widgetContentMsg{widget: i, content: content, err: err, at: time.Now(), scheduled: scheduled}
+	}
+}
+
+func (m *dashboardModel) renderAll(scheduled bool) tea.Cmd {
+	cmds := make([]tea.Cmd, len(m.widgets))
+	for i := range m.widgets {
+		cmds[i] = m.render(i, scheduled)
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		first := m.width == 0
+		m.width, m.height = msg.Width, msg.Height
+		// only the first render schedules the next ones, so that resizing does not add another schedule
+		return m, m.renderAll(first)
+	case widgetTickMsg:
+		return m, m.render(msg.widget, true)
+	case widgetContentMsg:
+		// the last content is kept if the widget cannot be rendered, the error is shown in its title
+		s := &m.states[msg.widget]
+		s.err = msg.err
+		if msg.err == nil {
+			s.content = msg.content
+			s.updatedAt = msg.at
+		}
+		if !msg.scheduled {
+			return m, nil
+		}
+		i := msg.widget
+		return m, tea.Tick(m.widgets[i].Interval, func(time.Time) tea.Msg {
+			return widgetTickMsg{widget: i}
+		})
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "r":
+			return m, m.renderAll(false)
+		}
+	}
+	return m, nil
+}
+
+// rows returns the number of the rows of the grid.
+func (m *dashboardModel) rows() int {
+	return (len(m.widgets) + m.columns - 1) / m.columns
+}
+
+// paneSize returns the size of each pane including its border, the title and the help lines are left out of the screen.
+func (m *dashboardModel) paneSize() (int, int) {
+	rows := m.rows()
+	if rows == 0 {
+		return 0, 0
+	}
+	return m.width / m.columns, (m.height - 2) / rows
+}
+
+// contentSize returns the size of the content of each pane, inside its border.
+func (m *dashboardModel) contentSize() (int, int) {
+	w, h := m.paneSize()
+	return clampSize(w - 2), clampSize(h - 2)
+}
+
+func clampSize(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+func (m *dashboardModel) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	w, h := m.paneSize()
+	cw, ch := m.contentSize()
+	var rows []string
+	for r := 0; r < m.rows(); r++ {
+		var panes []string
+		for c := 0; c < m.columns; c++ {
+			i := r*m.columns + c
+			if i >= len(m.widgets) {
+				break
+			}
+			panes = append(panes, m.pane(i, w, h, cw, ch))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, panes...))
+	}
+	help := []Shortcut{{"r", "refresh"}, {"q", "quit"}}
+	return strings.Join([]string{m.title, lipgloss.JoinVertical(lipgloss.Left, rows...), Help{values: help}.View()}, "\n")
+}
+
+// pane returns the widget in a border with its title, the content is cut to fit the pane.
+func (m *dashboardModel) pane(i, width, height, contentWidth, contentHeight int) string {
+	s := m.states[i]
+	title := m.widgets[i].Title
+	switch {
+	case s.err != nil:
+		title += fmt.Sprintf(" - error: %s", s.err)
+	case !s.updatedAt.IsZero():
+		title += " - " + s.updatedAt.Format("15:04:05")
+	}
+	content := strings.Split(s.content, "\n")
+	if s.updatedAt.IsZero() && s.err == nil {
+		content = []string{"Loading..."}
+	}
+	lines := make([]string, contentHeight)
+	lines[0] = lipgloss.NewStyle().Bold(true).Render(cutLine(title, contentWidth))
+	for j := 1; j < contentHeight && j-1 < len(content); j++ {
+		lines[j] = cutLine(content[j-1], contentWidth)
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(tuiutil.Highlight())).
+		Width(contentWidth).
+		Height(contentHeight).
+		MaxWidth(width).
+		MaxHeight(height).
+		Render(strings.Join(lines, "\n"))
+}
+
+// cutLine cuts the line to the width, the lines of the content are not wrapped so that the tables stay aligned.
+func cutLine(line string, width int) string {
+	r := []rune(line)
+	if len(r) <= width {
+		return line
+	}
+	if width <= 1 {
+		return string(r[:width])
+	}
+	return string(r[:width-1]) + "…"
+}
+
+// RunDashboard starts the dashboard, which shows the widgets in a grid with the given number of columns and renders each widget again at its interval.
+func RunDashboard(title string, columns int, widgets []DashboardWidget) error {
+	return tea.NewProgram(newDashboardModel(title, columns, widgets), tea.WithAltScreen()).Start()
+}
@@ -0,0 +1,57 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDashboardRendersWidgets(t *testing.T) {
+	var sizes [][2]int
+	widget := func(content string) DashboardWidget {
+		return DashboardWidget{Title: content, Interval: time.Second, Render: func(width, height int) (string, error) {
+			sizes = append(sizes, [2]int{width, height})
+			return content, nil
+		}}
+	}
+	m := newDashboardModel("Orders", 2, []DashboardWidget{widget("first"), widget("second"), widget("third")})
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 22})
+	for i := range m.widgets {
+		_, cmd := m.Update(m.render(i, true)())
+		if cmd == nil {
+			t.Fatal("the scheduled renders must schedule the next ones")
+		}
+	}
+	// 2 columns and 2 rows of panes, the title and the help lines take 2 lines
+	if len(sizes) != 3 || sizes[0] != [2]int{38, 8} {
+		t.Fatalf("unexpected content sizes %v", sizes)
+	}
+	view := m.View()
+	for _, s := range []string{"Orders", "first", "second", "third"} {
+		if !strings.Contains(view, s) {
+			t.Fatalf("want %s in the view", s)
+		}
+	}
+	// pressing r renders the widgets without scheduling them again
+	if _, cmd := m.Update(m.render(0, false)()); cmd != nil {
+		t.Fatal("the renders on r must not schedule the next ones")
+	}
+}
+
+func TestCutLine(t *testing.T) {
+	for _, tc := range []struct {
+		line  string
+		width int
+		want  string
+	}{
+		{line: "hello", width: 10, want: "hello"},
+		{line: "hello world", width: 6, want: "hello…"},
+		{line: "hello", width: 1, want: "h"},
+	} {
+		if got := cutLine(tc.line, tc.width); got != tc.want {
+			t.Errorf("want %q got %q", tc.want, got)
+		}
+	}
+}
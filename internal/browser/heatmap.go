@@ -0,0 +1,210 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+// PartitionUsage is the size of a map in a partition.
+type PartitionUsage struct {
+	ID      int
+	Entries int64
+	// Size is the serialized size of the keys and the values in bytes, it is set only if the sizes are loaded
+	Size int64
+	// Keys are some of the keys in the partition, "..." follows the first MaxPartitionKeys of them if there are more
+	Keys []interface{}
+}
+
+const (
+	// heatmapColumns is the number of the partitions on a row of the heatmap
+	heatmapColumns = 24
+	// MaxPartitionKeys is the number of the keys shown for the selected partition
+	MaxPartitionKeys = 5
+)
+
+// heatLevels are the characters of the cells from the coldest to the hottest, so that the heatmap is readable without colors
+const heatLevels = " .:-=+*#%@"
+
+// heatColors are the background colors of the cells from the coldest to the hottest
+var heatColors = []string{"#1a9850", "#66bd63", "#a6d96a", "#d9ef8b", "#ffffbf", "#fee08b", "#fdae61", "#f46d43", "#d73027", "#a50026"}
+
+type partitionsMsg struct {
+	mapName    string
+	partitions []PartitionUsage
+	err        error
+}
+
+type heatmapModel struct {
+	src     TopSource
+	mapName string
+	// bySize colors the partitions by their sizes instead of their entry counts
+	bySize     bool
+	partitions []PartitionUsage
+	cursor     int
+	loading    bool
+	status     string
+}
+
+func newHeatmapModel(src TopSource, mapName string) *heatmapModel {
+	return &heatmapModel{src: src, mapName: mapName, loading: true}
+}
+
+func (h *heatmapModel) load() tea.Msg {
+	partitions, err := h.src.Partitions(h.mapName, h.bySize)
+	return partitionsMsg{mapName: h.mapName, partitions: partitions, err: err}
+}
+
+func (h *heatmapModel) update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case partitionsMsg:
+		if msg.mapName != h.mapName {
+			return nil
+		}
+		h.loading = false
+		if msg.err != nil {
+			h.status = fmt.Sprintf("Cannot load the partitions of %s: %s", h.mapName, msg.err)
+			return nil
+		}
+		h.status = ""
+		h.partitions = msg.partitions
+		if h.cursor >= len(h.partitions) {
+			h.cursor = 0
+		}
+	case tea.KeyMsg:
+		return h.handleKey(msg)
+	}
+	return nil
+}
+
+func (h *heatmapModel) handleKey(key tea.KeyMsg) tea.Cmd {
+	switch key.String() {
+	case "left", "h":
+		h.moveCursor(-1)
+	case "right", "l":
+		h.moveCursor(1)
+	case "up", "k":
+		h.moveCursor(-heatmapColumns)
+	case "down", "j":
+		h.moveCursor(heatmapColumns)
+	case "s":
+		h.bySize = !h.bySize
+		// the sizes are loaded only when they are shown, since it requires reading the values
+		if h.bySize && !h.hasSizes() {
+			h.loading = true
+			return h.load
+		}
+	case "u":
+		h.loading = true
+		return h.load
+	}
+	return nil
+}
+
+func (h *heatmapModel) moveCursor(delta int) {
+	c := h.cursor + delta
+	if c >= 0 && c < len(h.partitions) {
+		h.cursor = c
+	}
+}
+
+func (h *heatmapModel) hasSizes() bool {
+	for _, p := range h.partitions {
+		if p.Size > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *heatmapModel) value(p PartitionUsage) int64 {
+	if h.bySize {
+		return p.Size
+	}
+	return p.Entries
+}
+
+// heatLevel returns the level of the value between 0 and levels-1, relative to the hottest partition.
+func heatLevel(value, max int64, levels int) int {
+	if max <= 0 || value <= 0 {
+		return 0
+	}
+	l := int(value * int64(levels) / (max + 1))
+	if l >= levels {
+		return levels - 1
+	}
+	return l
+}
+
+func (h *heatmapModel) view() string {
+	measure := "entry count"
+	if h.bySize {
+		measure = "size"
+	}
+	lines := []string{fmt.Sprintf("Partitions of %s by %s", h.mapName, measure), ""}
+	var max, total int64
+	for _, p := range h.partitions {
+		v := h.value(p)
+		total += v
+		if v > max {
+			max = v
+		}
+	}
+	var row strings.Builder
+	for i, p := range h.partitions {
+		level := heatLevel(h.value(p), max, len(heatLevels))
+		cell := strings.Repeat(string(heatLevels[level]), 2)
+		style := lipgloss.NewStyle().
+			Background(lipgloss.Color(heatColors[level])).
+			Foreground(lipgloss.Color("#000000"))
+		if i == h.cursor {
+			cell = "[]"
+			style = style.Reverse(true)
+		}
+		row.WriteString(style.Render(cell))
+		row.WriteString(" ")
+		if (i+1)%heatmapColumns == 0 || i == len(h.partitions)-1 {
+			lines = append(lines, row.String())
+			row.Reset()
+		}
+	}
+	lines = append(lines, "")
+	switch {
+	case h.loading:
+		lines = append(lines, "Loading the partitions...")
+	case h.cursor < len(h.partitions):
+		lines = append(lines, h.describePartition(h.partitions[h.cursor], total)...)
+	}
+	help := []Shortcut{{"Arrows", "select"}, {"s", "entries/size"}, {"u", "update"}, {"Esc", "back"}}
+	lines = append(lines, "", h.status, Help{values: help}.View())
+	return strings.Join(lines, "\n")
+}
+
+// describePartition returns the lines which describe the partition, with its share of the total and some of its keys.
+func (h *heatmapModel) describePartition(p PartitionUsage, total int64) []string {
+	line := fmt.Sprintf("Partition %d: %d entries", p.ID, p.Entries)
+	if h.bySize {
+		line += ", " + formatBytes(p.Size)
+	}
+	if total > 0 && len(h.partitions) > 0 {
+		average := float64(total) / float64(len(h.partitions))
+		line += fmt.Sprintf(", %.1fx the average", float64(h.value(p))/average)
+	}
+	lines := []string{line}
+	if len(p.Keys) > 0 {
+		keys := make([]string, 0, MaxPartitionKeys)
+		for i, k := range p.Keys {
+			if i == MaxPartitionKeys {
+				keys = append(keys, "...")
+				break
+			}
+			keys = append(keys, output.String(k))
+		}
+		lines = append(lines, "Keys: "+strings.Join(keys, ", "))
+	}
+	return lines
+}
@@ -0,0 +1,60 @@
+package browser
+
+import (
+	"testing"
+)
+
+func TestHeatLevel(t *testing.T) {
+	for _, tc := range []struct {
+		value, max int64
+		want       int
+	}{
+		{value: 0, max: 100, want: 0},
+		{value: 1, max: 100, want: 0},
+		{value: 50, max: 100, want: 4},
+		{value: 100, max: 100, want: 9},
+		{value: 5, max: 0, want: 0},
+	} {
+		if got := heatLevel(tc.value, tc.max, len(heatLevels)); got != tc.want {
+			t.Errorf("%d of %d: want %d got %d", tc.value, tc.max, tc.want, got)
+		}
+	}
+}
+
+func TestTopOpensHeatmap(t *testing.T) {
+	var bySize bool
+	m := newTopModel(TopSource{
+		Partitions: func(mapName string, size bool) ([]PartitionUsage, error) {
+			bySize = size
+			partitions := make([]PartitionUsage, 30)
+			for i := range partitions {
+				partitions[i].ID = i
+			}
+			return partitions, nil
+		},
+	}, 0)
+	m.Update(topMapsMsg{maps: []MapUsage{{Name: "orders"}}})
+	_, cmd := m.Update(keyMsg("enter"))
+	if m.heatmap == nil || m.heatmap.mapName != "orders" {
+		t.Fatal("the heatmap of orders must be opened")
+	}
+	m.Update(cmd())
+	m.Update(keyMsg("down"))
+	m.Update(keyMsg("l"))
+	if m.heatmap.cursor != heatmapColumns+1 {
+		t.Fatalf("want partition %d got %d", heatmapColumns+1, m.heatmap.cursor)
+	}
+	// the sizes are loaded when they are shown
+	_, cmd = m.Update(keyMsg("s"))
+	if cmd == nil {
+		t.Fatal("the sizes must be loaded")
+	}
+	m.Update(cmd())
+	if !bySize {
+		t.Fatal("the partitions must be loaded with their sizes")
+	}
+	m.Update(keyMsg("esc"))
+	if m.heatmap != nil {
+		t.Fatal("Esc must close the heatmap")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/layout"
 )
 
 type SubmitMsg string
@@ -37,17 +39,14 @@ func (m model) Init() tea.Cmd {
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch tmsg := msg.(type) {
+	case layout.FocusMsg:
+		m.keyboardFocus = true
+		return m, m.textInput.Focus()
+	case layout.BlurMsg:
+		m.keyboardFocus = false
+		m.textInput.Blur()
+		return m, nil
 	case tea.KeyMsg:
-		if tmsg.Type == tea.KeyTab {
-			var teaCmd tea.Cmd
-			if m.keyboardFocus {
-				m.textInput.Blur()
-			} else {
-				teaCmd = m.textInput.Focus()
-			}
-			m.keyboardFocus = !m.keyboardFocus
-			return m, teaCmd
-		}
 		if !m.keyboardFocus {
 			return m, nil
 		}
@@ -628,7 +628,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			} else if m.posY != 0 { // not empty buffer
 				prevLineLength := len(m.value[m.posY-1])
 				m.value[m.posY-1] = append(m.value[m.posY-1], m.value[m.posY]...)
-				m.value = append(append(m.value[:m.posY]), m.value[m.posY+1:]...)
+				m.value = append(m.value[:m.posY], m.value[m.posY+1:]...)
 				m.posY--
 				m.pos += prevLineLength
 				m.handleOverflow()
@@ -0,0 +1,216 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/rawdata"
+)
+
+// maxViewedItems is the maximum number of entries or items shown for the selected object.
+const maxViewedItems = 100
+
+// objectGroups are the types of distributed objects in the object tree, in the order they are shown.
+var objectGroups = []struct {
+	title   string
+	service string
+}{
+	{"Maps", hazelcast.ServiceNameMap},
+	{"Replicated Maps", hazelcast.ServiceNameReplicatedMap},
+	{"MultiMaps", hazelcast.ServiceNameMultiMap},
+	{"Queues", hazelcast.ServiceNameQueue},
+	{"Lists", hazelcast.ServiceNameList},
+	{"Sets", hazelcast.ServiceNameSet},
+	{"Topics", hazelcast.ServiceNameTopic},
+	{"PN Counters", hazelcast.ServiceNamePNCounter},
+	{"Flake ID Generators", hazelcast.ServiceNameFlakeIDGenerator},
+}
+
+// objectRecord is an entry or an item of a distributed object.
+// Key is nil for the items of collections.
+type objectRecord struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// objectOps are the operations of the object browser on a distributed object.
+// Operations which are not supported for the type of the object are nil.
+type objectOps struct {
+	// records returns at most limit records, limit <= 0 means all of them
+	records func(ctx context.Context, limit int) ([]objectRecord, error)
+	size    func(ctx context.Context) (int, error)
+	clear   func(ctx context.Context) error
+	destroy func(ctx context.Context) error
+}
+
+func objectOperations(ctx context.Context, client *hazelcast.Client, service, name string) (objectOps, error) {
+	switch service {
+	case hazelcast.ServiceNameMap:
+		m, err := client.GetMap(ctx, name)
+		if err != nil {
+			return objectOps{}, err
+		}
+		return objectOps{
+			records: func(ctx context.Context, limit int) ([]objectRecord, error) {
+				keys, err := m.GetKeySet(ctx)
+				if err != nil {
+					return nil, err
+				}
+				if len(keys) == 0 {
+					return nil, nil
+				}
+				entries, err := m.GetAll(ctx, limitItems(keys, limit)...)
+				if err != nil {
+					return nil, err
+				}
+				return entryRecords(entries), nil
+			},
+			size:    m.Size,
+			clear:   m.Clear,
+			destroy: m.Destroy,
+		}, nil
+	case hazelcast.ServiceNameReplicatedMap:
+		m, err := client.GetReplicatedMap(ctx, name)
+		if err != nil {
+			return objectOps{}, err
+		}
+		return objectOps{
+			records: func(ctx context.Context, limit int) ([]objectRecord, error) {
+				entries, err := m.GetEntrySet(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return entryRecords(limitEntries(entries, limit)), nil
+			},
+			size:    m.Size,
+			clear:   m.Clear,
+			destroy: m.Destroy,
+		}, nil
+	case hazelcast.ServiceNameMultiMap:
+		m, err := client.GetMultiMap(ctx, name)
+		if err != nil {
+			return objectOps{}, err
+		}
+		return objectOps{
+			records: func(ctx context.Context, limit int) ([]objectRecord, error) {
+				entries, err := m.GetEntrySet(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return entryRecords(limitEntries(entries, limit)), nil
+			},
+			size:    m.Size,
+			clear:   m.Clear,
+			destroy: m.Destroy,
+		}, nil
+	case hazelcast.ServiceNameQueue:
+		q, err := client.GetQueue(ctx, name)
+		if err != nil {
+			return objectOps{}, err
+		}
+		// the items are peeked, they are not removed from the queue
+		return objectOps{records: itemRecords(q.GetAll), size: q.Size, clear: q.Clear, destroy: q.Destroy}, nil
+	case hazelcast.ServiceNameList:
+		l, err := client.GetList(ctx, name)
+		if err != nil {
+			return objectOps{}, err
+		}
+		return objectOps{records: itemRecords(l.GetAll), size: l.Size, clear: l.Clear, destroy: l.Destroy}, nil
+	case hazelcast.ServiceNameSet:
+		s, err := client.GetSet(ctx, name)
+		if err != nil {
+			return objectOps{}, err
+		}
+		return objectOps{records: itemRecords(s.GetAll), size: s.Size, clear: s.Clear, destroy: s.Destroy}, nil
+	case hazelcast.ServiceNameTopic:
+		t, err := client.GetTopic(ctx, name)
+		if err != nil {
+			return objectOps{}, err
+		}
+		return objectOps{destroy: t.Destroy}, nil
+	case hazelcast.ServiceNamePNCounter:
+		c, err := client.GetPNCounter(ctx, name)
+		if err != nil {
+			return objectOps{}, err
+		}
+		return objectOps{
+			records: func(ctx context.Context, limit int) ([]objectRecord, error) {
+				v, err := c.Get(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return []objectRecord{{Value: v}}, nil
+			},
+			destroy: c.Destroy,
+		}, nil
+	case hazelcast.ServiceNameFlakeIDGenerator:
+		g, err := client.GetFlakeIDGenerator(ctx, name)
+		if err != nil {
+			return objectOps{}, err
+		}
+		return objectOps{destroy: g.Destroy}, nil
+	}
+	return objectOps{}, fmt.Errorf("unknown object type: %s", service)
+}
+
+func itemRecords(getAll func(ctx context.Context) ([]interface{}, error)) func(ctx context.Context, limit int) ([]objectRecord, error) {
+	return func(ctx context.Context, limit int) ([]objectRecord, error) {
+		items, err := getAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = limitItems(items, limit)
+		records := make([]objectRecord, len(items))
+		for i, item := range items {
+			records[i] = objectRecord{Value: item}
+		}
+		return records, nil
+	}
+}
+
+func entryRecords(entries []types.Entry) []objectRecord {
+	records := make([]objectRecord, len(entries))
+	for i, e := range entries {
+		records[i] = objectRecord{Key: e.Key, Value: e.Value}
+	}
+	return records
+}
+
+func limitItems(items []interface{}, limit int) []interface{} {
+	if limit > 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+func limitEntries(entries []types.Entry, limit int) []types.Entry {
+	if limit > 0 && len(entries) > limit {
+		return entries[:limit]
+	}
+	return entries
+}
+
+// recordLines renders the records as "key: value" lines, or only the values for collections.
+// The keys and values which cannot be deserialized are followed by their hex dumps.
+func recordLines(records []objectRecord) []string {
+	var lines []string
+	for _, r := range records {
+		if r.Key == nil {
+			lines = append(lines, output.String(r.Value))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: %s", output.String(r.Key), output.String(r.Value)))
+		}
+		for _, v := range []interface{}{r.Key, r.Value} {
+			if d, ok := v.(rawdata.Data); ok {
+				for _, line := range rawdata.Dump(d) {
+					lines = append(lines, "  "+line)
+				}
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,446 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+type objectsMsg []types.DistributedObjectInfo
+
+type objectDetailMsg struct {
+	lines []string
+}
+
+type objectStatusMsg string
+
+// objectActionMsg is sent after an action on the selected object succeeds.
+type objectActionMsg struct {
+	status string
+	// destroyed is true if the object does not exist anymore, so the tree must be reloaded
+	destroyed bool
+}
+
+type treeGroup struct {
+	title    string
+	service  string
+	names    []string
+	expanded bool
+}
+
+// treeRow is a visible row of the tree, object is -1 for the group rows.
+type treeRow struct {
+	group  int
+	object int
+}
+
+type objectTree struct {
+	ctx    context.Context
+	client *hazelcast.Client
+	groups []treeGroup
+	cursor int
+	width  int
+	height int
+	detail []string
+	// detailStart is the first visible line of the detail, which is paged when it is longer than the screen
+	detailStart int
+	status      string
+	// pending is the action waiting for confirmation, either clear or destroy
+	pending string
+	// readOnly disables clearing and destroying the objects
+	readOnly bool
+	// confirm enables asking before clearing and destroying the objects
+	confirm bool
+}
+
+func newObjectTree(ctx context.Context, client *hazelcast.Client, readOnly, confirm bool) *objectTree {
+	t := &objectTree{ctx: ctx, client: client, readOnly: readOnly, confirm: confirm}
+	for _, g := range objectGroups {
+		t.groups = append(t.groups, treeGroup{title: g.title, service: g.service, expanded: true})
+	}
+	return t
+}
+
+func (t *objectTree) Init() tea.Cmd {
+	return t.loadObjects
+}
+
+func (t *objectTree) loadObjects() tea.Msg {
+	infos, err := t.client.GetDistributedObjectsInfo(t.ctx)
+	if err != nil {
+		return objectStatusMsg(fmt.Sprintf("Cannot list the objects: %s", err))
+	}
+	return objectsMsg(infos)
+}
+
+// setObjects replaces the objects in the tree, keeping the expanded groups.
+func (t *objectTree) setObjects(infos []types.DistributedObjectInfo) {
+	for i := range t.groups {
+		t.groups[i].names = nil
+	}
+	for _, info := range infos {
+		for i := range t.groups {
+			if t.groups[i].service == info.ServiceName {
+				t.groups[i].names = append(t.groups[i].names, info.Name)
+			}
+		}
+	}
+	for i := range t.groups {
+		sort.Strings(t.groups[i].names)
+	}
+	rows := t.rows()
+	if t.cursor >= len(rows) {
+		t.cursor = len(rows) - 1
+	}
+	if t.cursor < 0 && len(rows) > 0 {
+		t.cursor = 0
+	}
+}
+
+// rows returns the visible rows, groups without objects are hidden.
+func (t *objectTree) rows() []treeRow {
+	var rows []treeRow
+	for gi, g := range t.groups {
+		if len(g.names) == 0 {
+			continue
+		}
+		rows = append(rows, treeRow{group: gi, object: -1})
+		if !g.expanded {
+			continue
+		}
+		for oi := range g.names {
+			rows = append(rows, treeRow{group: gi, object: oi})
+		}
+	}
+	return rows
+}
+
+// selected returns the selected object, ok is false if a group or nothing is selected.
+func (t *objectTree) selected() (service, name string, ok bool) {
+	rows := t.rows()
+	if t.cursor < 0 || t.cursor >= len(rows) || rows[t.cursor].object < 0 {
+		return "", "", false
+	}
+	g := t.groups[rows[t.cursor].group]
+	return g.service, g.names[rows[t.cursor].object], true
+}
+
+func (t *objectTree) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m := msg.(type) {
+	case tea.WindowSizeMsg:
+		t.width, t.height = m.Width, m.Height
+	case objectsMsg:
+		t.setObjects(m)
+	case objectDetailMsg:
+		t.detail = m.lines
+		t.detailStart = 0
+	case objectStatusMsg:
+		t.status = string(m)
+	case objectActionMsg:
+		t.status = m.status
+		if m.destroyed {
+			t.detail = nil
+			return t, t.loadObjects
+		}
+		return t, t.viewSelected()
+	case tea.KeyMsg:
+		return t, t.handleKey(m.String())
+	}
+	return t, nil
+}
+
+func (t *objectTree) handleKey(key string) tea.Cmd {
+	if t.pending != "" {
+		action := t.pending
+		t.pending = ""
+		if key != "y" {
+			t.status = "Cancelled"
+			return nil
+		}
+		return t.runAction(action)
+	}
+	rows := t.rows()
+	switch key {
+	case "up", "k":
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case "down", "j":
+		if t.cursor < len(rows)-1 {
+			t.cursor++
+		}
+	case "enter", " ":
+		if t.cursor < 0 || t.cursor >= len(rows) {
+			return nil
+		}
+		if rows[t.cursor].object < 0 {
+			g := &t.groups[rows[t.cursor].group]
+			g.expanded = !g.expanded
+			return nil
+		}
+		return t.viewSelected()
+	case "pgdown", "ctrl+d":
+		t.pageDetail(1)
+	case "pgup", "ctrl+u":
+		t.pageDetail(-1)
+	case "r":
+		t.status = ""
+		return t.loadObjects
+	case "e":
+		return t.runAction("export")
+	case "c", "x":
+		_, name, ok := t.selected()
+		if !ok {
+			return nil
+		}
+		if t.readOnly {
+			t.status = "Clearing and destroying the objects is not allowed in the read-only mode"
+			return nil
+		}
+		prompt, action := "Clear", "clear"
+		if key == "x" {
+			prompt, action = "Destroy", "destroy"
+		}
+		if !t.confirm {
+			return t.runAction(action)
+		}
+		t.pending = action
+		t.status = fmt.Sprintf("%s %s? (y/n)", prompt, name)
+	case "q", "esc":
+		return tea.Quit
+	}
+	return nil
+}
+
+// pageDetail moves the visible lines of the detail by a page, in the given direction.
+func (t *objectTree) pageDetail(direction int) {
+	start := t.detailStart + direction*t.detailHeight()
+	if last := len(t.detail) - t.detailHeight(); start > last {
+		start = last
+	}
+	if start < 0 {
+		start = 0
+	}
+	t.detailStart = start
+}
+
+// detailHeight is the number of the lines of the tree and the detail, without the status line and the help.
+func (t *objectTree) detailHeight() int {
+	if h := t.height - 2; h > 0 {
+		return h
+	}
+	return 1
+}
+
+// viewSelected returns the command which loads the contents of the selected object.
+// The selection is read beforehand, since the command runs concurrently with the updates.
+func (t *objectTree) viewSelected() tea.Cmd {
+	service, name, ok := t.selected()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		return t.viewObject(service, name)
+	}
+}
+
+func (t *objectTree) viewObject(service, name string) tea.Msg {
+	ops, err := objectOperations(t.ctx, t.client, service, name)
+	if err != nil {
+		return objectStatusMsg(err.Error())
+	}
+	lines := []string{name}
+	if ops.size != nil {
+		size, err := ops.size(t.ctx)
+		if err != nil {
+			return objectStatusMsg(fmt.Sprintf("Cannot get the size of %s: %s", name, err))
+		}
+		lines[0] = fmt.Sprintf("%s (size: %d)", name, size)
+	}
+	if ops.records == nil {
+		return objectDetailMsg{lines: append(lines, "", "Viewing the contents of this type is not supported.")}
+	}
+	records, err := ops.records(t.ctx, maxViewedItems)
+	if err != nil {
+		return objectStatusMsg(fmt.Sprintf("Cannot view %s: %s", name, err))
+	}
+	lines = append(lines, "")
+	return objectDetailMsg{lines: append(lines, recordLines(records)...)}
+}
+
+func (t *objectTree) runAction(action string) tea.Cmd {
+	service, name, ok := t.selected()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		ops, err := objectOperations(t.ctx, t.client, service, name)
+		if err != nil {
+			return objectStatusMsg(err.Error())
+		}
+		switch action {
+		case "clear":
+			if ops.clear == nil {
+				return objectStatusMsg(fmt.Sprintf("%s cannot be cleared", name))
+			}
+			if err := ops.clear(t.ctx); err != nil {
+				return objectStatusMsg(fmt.Sprintf("Cannot clear %s: %s", name, err))
+			}
+			return objectActionMsg{status: fmt.Sprintf("Cleared %s", name)}
+		case "destroy":
+			if err := ops.destroy(t.ctx); err != nil {
+				return objectStatusMsg(fmt.Sprintf("Cannot destroy %s: %s", name, err))
+			}
+			return objectActionMsg{status: fmt.Sprintf("Destroyed %s", name), destroyed: true}
+		case "export":
+			if ops.records == nil {
+				return objectStatusMsg(fmt.Sprintf("%s cannot be exported", name))
+			}
+			path, err := exportRecords(t.ctx, ops, name)
+			if err != nil {
+				return objectStatusMsg(fmt.Sprintf("Cannot export %s: %s", name, err))
+			}
+			return objectStatusMsg(fmt.Sprintf("Exported %s to %s", name, path))
+		}
+		return nil
+	}
+}
+
+// exportRecords writes all records of the object to a file in the current directory, one JSON object per line.
+func exportRecords(ctx context.Context, ops objectOps, name string) (string, error) {
+	records, err := ops.records(ctx, 0)
+	if err != nil {
+		return "", err
+	}
+	path := exportFileName(name)
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	opts := output.DefaultOptions()
+	for _, r := range records {
+		var b []byte
+		if r.Key == nil {
+			b, err = opts.MarshalJSONObject([]string{"value"}, []interface{}{r.Value})
+		} else {
+			b, err = opts.MarshalJSONObject([]string{"key", "value"}, []interface{}{r.Key, r.Value})
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err = fmt.Fprintf(f, "%s\n", b); err != nil {
+			return "", err
+		}
+	}
+	return path, f.Close()
+}
+
+// exportFileName returns a file name for the object, replacing the characters which are not safe in file names.
+func exportFileName(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, name)
+	return safe + ".jsonl"
+}
+
+func (t *objectTree) View() string {
+	treeWidth := t.width / 3
+	if treeWidth < 20 {
+		treeWidth = 20
+	}
+	height := t.detailHeight()
+	tree := t.treeLines(treeWidth)
+	// keep the cursor visible
+	start := 0
+	if t.cursor >= height {
+		start = t.cursor - height + 1
+	}
+	tree = visibleLines(tree, start, height)
+	detail := visibleLines(t.detail, t.detailStart, height)
+	detailWidth := t.width - treeWidth - 3
+	for i := range detail {
+		detail[i] = runewidth.Truncate(detail[i], max(detailWidth, 0), "…")
+	}
+	border := lipgloss.NewStyle().Foreground(lipgloss.Color(tuiutil.BorderColor()))
+	sep := strings.TrimRight(strings.Repeat(border.Render(" │ ")+"\n", height), "\n")
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(treeWidth).Render(strings.Join(tree, "\n")),
+		sep,
+		strings.Join(detail, "\n"),
+	)
+	help := Help{values: []Shortcut{
+		{"Enter", "open"},
+		{"PgUp/PgDn", "page"},
+		{"e", "export"},
+		{"c", "clear"},
+		{"x", "destroy"},
+		{"r", "refresh"},
+		{"q", "quit"},
+	}}
+	return fmt.Sprintf("%s\n%s\n%s", body, t.status, help.View())
+}
+
+func (t *objectTree) treeLines(width int) []string {
+	expanded, collapsed := "▾", "▸"
+	if tuiutil.Ascii {
+		expanded, collapsed = "-", "+"
+	}
+	selected := lipgloss.NewStyle().
+		Background(lipgloss.Color(tuiutil.Highlight())).
+		Foreground(lipgloss.Color("#000000"))
+	rows := t.rows()
+	if len(rows) == 0 {
+		return []string{"No distributed objects"}
+	}
+	lines := make([]string, len(rows))
+	for i, r := range rows {
+		g := t.groups[r.group]
+		var line string
+		if r.object < 0 {
+			marker := collapsed
+			if g.expanded {
+				marker = expanded
+			}
+			line = fmt.Sprintf("%s %s (%d)", marker, g.title, len(g.names))
+		} else {
+			line = "  " + g.names[r.object]
+		}
+		line = runewidth.Truncate(line, width, "…")
+		if i == t.cursor {
+			line = selected.Render(line)
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+// visibleLines returns at most height lines starting from start, padded with empty lines.
+func visibleLines(lines []string, start, height int) []string {
+	visible := make([]string, height)
+	for i := 0; i < height && start+i < len(lines); i++ {
+		visible[i] = lines[start+i]
+	}
+	return visible
+}
+
+// InitObjectBrowser creates the program which lists the distributed objects grouped by their types.
+// The objects cannot be cleared or destroyed if readOnly is true, and they are cleared or destroyed without asking if confirm is false.
+func InitObjectBrowser(ctx context.Context, client *hazelcast.Client, readOnly, confirm bool) *tea.Program {
+	return tea.NewProgram(newObjectTree(ctx, client, readOnly, confirm), tea.WithAltScreen())
+}
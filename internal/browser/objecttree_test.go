@@ -0,0 +1,97 @@
+package browser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/rawdata"
+)
+
+func TestObjectTreeRows(t *testing.T) {
+	tree := newObjectTree(nil, nil, false, true)
+	tree.setObjects([]types.DistributedObjectInfo{
+		{Name: "orders", ServiceName: hazelcast.ServiceNameMap},
+		{Name: "events", ServiceName: hazelcast.ServiceNameQueue},
+		{Name: "customers", ServiceName: hazelcast.ServiceNameMap},
+	})
+	// Maps, customers, orders, Queues, events
+	if n := len(tree.rows()); n != 5 {
+		t.Fatalf("want 5 rows got %d", n)
+	}
+	tree.cursor = 1
+	if service, name, ok := tree.selected(); !ok || service != hazelcast.ServiceNameMap || name != "customers" {
+		t.Errorf("unexpected selection: %s %s %t", service, name, ok)
+	}
+	tree.cursor = 0
+	if _, _, ok := tree.selected(); ok {
+		t.Errorf("group row must not be selected as an object")
+	}
+	// collapse the maps
+	tree.handleKey("enter")
+	want := []treeRow{{group: 0, object: -1}, {group: 3, object: -1}, {group: 3, object: 0}}
+	if got := tree.rows(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v got %v", want, got)
+	}
+	tree.cursor = 4
+	tree.setObjects(nil)
+	if tree.cursor != -1 {
+		t.Errorf("cursor must be reset when there are no objects, got %d", tree.cursor)
+	}
+}
+
+func TestExportFileName(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{name: "orders", want: "orders.jsonl"},
+		{name: "a/b:c", want: "a_b_c.jsonl"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := exportFileName(tc.name); got != tc.want {
+				t.Errorf("want %s got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRecordLinesRawData(t *testing.T) {
+	raw := rawdata.Data{Header: true, PartitionHash: 1, TypeID: -100, Payload: []byte{0xac, 0xed}}
+	got := recordLines([]objectRecord{{Key: "k1", Value: "v1"}, {Key: "k2", Value: raw}})
+	want := []string{
+		"k1: v1",
+		"k2: <type -100, 2 bytes>",
+		"  partition hash  00 00 00 01  (1)",
+		"  type id         ff ff ff 9c  (-100)",
+		"  payload         2 bytes",
+		"  00000000  ac ed                                             |..|",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want\n%q\ngot\n%q", want, got)
+	}
+}
+
+func TestObjectTreePageDetail(t *testing.T) {
+	tree := newObjectTree(nil, nil, false, true)
+	tree.height = 12
+	tree.Update(objectDetailMsg{lines: make([]string, 25)})
+	for _, tc := range []struct {
+		key   string
+		start int
+	}{
+		{"pgdown", 10},
+		// the last page is full
+		{"pgdown", 15},
+		{"pgdown", 15},
+		{"pgup", 5},
+		{"pgup", 0},
+	} {
+		tree.handleKey(tc.key)
+		if tree.detailStart != tc.start {
+			t.Fatalf("%s: want start %d got %d", tc.key, tc.start, tree.detailStart)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package browser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+// QueryBuilderSource provides the mappings and their columns to the query builder.
+type QueryBuilderSource struct {
+	Mappings func() ([]string, error)
+	Columns  func(mapping string) ([]string, error)
+}
+
+type builderStep int
+
+const (
+	stepMapping builderStep = iota
+	stepColumns
+	stepClauses
+)
+
+// the text inputs of the clauses step, in the order they are shown
+const (
+	inputFilter = iota
+	inputOrderBy
+	inputLimit
+)
+
+type mappingsMsg []string
+
+type columnsMsg []string
+
+type builderStatusMsg string
+
+type queryBuilder struct {
+	src      QueryBuilderSource
+	step     builderStep
+	mappings []string
+	mapping  string
+	columns  []string
+	// selected are true for the columns in the select list, all the columns are selected if none is
+	selected []bool
+	cursor   int
+	inputs   []tuiutil.TextInputModel
+	focus    int
+	status   string
+	// query is set when the query is built, it is empty if the builder is quit before that
+	query string
+}
+
+func newQueryBuilder(src QueryBuilderSource) *queryBuilder {
+	b := &queryBuilder{src: src}
+	for _, p := range []struct{ prompt, placeholder string }{
+		{"WHERE    ", "age > 30 AND city = 'London'"},
+		{"ORDER BY ", "age DESC, name"},
+		{"LIMIT    ", "100"},
+	} {
+		in := tuiutil.NewModel()
+		in.Prompt = p.prompt
+		in.Placeholder = p.placeholder
+		b.inputs = append(b.inputs, in)
+	}
+	return b
+}
+
+func (b *queryBuilder) Init() tea.Cmd {
+	return b.loadMappings
+}
+
+func (b *queryBuilder) loadMappings() tea.Msg {
+	mappings, err := b.src.Mappings()
+	if err != nil {
+		return builderStatusMsg(fmt.Sprintf("Cannot list the mappings: %s", err))
+	}
+	return mappingsMsg(mappings)
+}
+
+// loadColumns returns the command which loads the columns of the mapping.
+func (b *queryBuilder) loadColumns(mapping string) tea.Cmd {
+	return func() tea.Msg {
+		columns, err := b.src.Columns(mapping)
+		if err != nil {
+			return builderStatusMsg(fmt.Sprintf("Cannot get the columns of %s: %s", mapping, err))
+		}
+		return columnsMsg(columns)
+	}
+}
+
+func (b *queryBuilder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m := msg.(type) {
+	case mappingsMsg:
+		b.mappings = m
+		b.cursor = 0
+		if len(m) == 0 {
+			b.status = "There are no mappings, create one with CREATE MAPPING first"
+		}
+		return b, nil
+	case columnsMsg:
+		b.columns = m
+		b.selected = make([]bool, len(m))
+		b.cursor = 0
+		b.step = stepColumns
+		b.status = ""
+		return b, nil
+	case builderStatusMsg:
+		b.status = string(m)
+		return b, nil
+	case tea.KeyMsg:
+		return b, b.handleKey(m)
+	}
+	if b.step == stepClauses {
+		// the cursor blinks of the focused input
+		var cmd tea.Cmd
+		b.inputs[b.focus], cmd = b.inputs[b.focus].Update(msg)
+		return b, cmd
+	}
+	return b, nil
+}
+
+func (b *queryBuilder) handleKey(key tea.KeyMsg) tea.Cmd {
+	if key.String() == "ctrl+c" {
+		return tea.Quit
+	}
+	switch b.step {
+	case stepMapping:
+		switch key.String() {
+		case "up", "k":
+			b.moveCursor(-1, len(b.mappings))
+		case "down", "j":
+			b.moveCursor(1, len(b.mappings))
+		case "enter":
+			if b.cursor < len(b.mappings) {
+				b.mapping = b.mappings[b.cursor]
+				return b.loadColumns(b.mapping)
+			}
+		case "r":
+			b.status = ""
+			return b.loadMappings
+		case "q", "esc":
+			return tea.Quit
+		}
+	case stepColumns:
+		switch key.String() {
+		case "up", "k":
+			b.moveCursor(-1, len(b.columns))
+		case "down", "j":
+			b.moveCursor(1, len(b.columns))
+		case " ":
+			if b.cursor < len(b.selected) {
+				b.selected[b.cursor] = !b.selected[b.cursor]
+			}
+		case "a":
+			all := !b.allSelected()
+			for i := range b.selected {
+				b.selected[i] = all
+			}
+		case "enter":
+			b.step = stepClauses
+			b.status = ""
+			return b.focusInput(inputFilter)
+		case "esc":
+			b.step = stepMapping
+			b.status = ""
+			b.cursor = indexOfString(b.mappings, b.mapping)
+		case "q":
+			return tea.Quit
+		}
+	case stepClauses:
+		switch key.String() {
+		case "tab", "down":
+			return b.focusInput((b.focus + 1) % len(b.inputs))
+		case "shift+tab", "up":
+			return b.focusInput((b.focus + len(b.inputs) - 1) % len(b.inputs))
+		case "enter":
+			if b.focus < len(b.inputs)-1 {
+				return b.focusInput(b.focus + 1)
+			}
+			if !b.isLimitValid() {
+				b.status = "The limit must be a positive number"
+				return nil
+			}
+			b.query = b.buildQuery()
+			return tea.Quit
+		case "esc":
+			b.inputs[b.focus].Blur()
+			b.step = stepColumns
+			b.status = ""
+			return nil
+		}
+		var cmd tea.Cmd
+		b.inputs[b.focus], cmd = b.inputs[b.focus].Update(key)
+		return cmd
+	}
+	return nil
+}
+
+func (b *queryBuilder) moveCursor(delta, n int) {
+	c := b.cursor + delta
+	if c >= 0 && c < n {
+		b.cursor = c
+	}
+}
+
+func (b *queryBuilder) focusInput(i int) tea.Cmd {
+	b.inputs[b.focus].Blur()
+	b.focus = i
+	return b.inputs[i].FocusCommand()
+}
+
+func (b *queryBuilder) allSelected() bool {
+	for _, s := range b.selected {
+		if !s {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *queryBuilder) isLimitValid() bool {
+	limit := strings.TrimSpace(b.inputs[inputLimit].Value())
+	if limit == "" {
+		return true
+	}
+	n, err := strconv.Atoi(limit)
+	return err == nil && n > 0
+}
+
+// buildQuery returns the SELECT statement of the current choices.
+func (b *queryBuilder) buildQuery() string {
+	var columns []string
+	for i, c := range b.columns {
+		if b.selected[i] {
+			columns = append(columns, c)
+		}
+	}
+	return buildSelect(b.mapping, columns,
+		b.inputs[inputFilter].Value(), b.inputs[inputOrderBy].Value(), b.inputs[inputLimit].Value())
+}
+
+// buildSelect returns the SELECT statement, all the columns are selected if none is given and the empty clauses are left out.
+func buildSelect(mapping string, columns []string, filter, orderBy, limit string) string {
+	list := "*"
+	if len(columns) > 0 {
+		quoted := make([]string, len(columns))
+		for i, c := range columns {
+			quoted[i] = output.QuoteIdentifier(c)
+		}
+		list = strings.Join(quoted, ", ")
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "SELECT %s FROM %s", list, output.QuoteIdentifier(mapping))
+	if s := strings.TrimSpace(filter); s != "" {
+		fmt.Fprintf(&sb, " WHERE %s", s)
+	}
+	if s := strings.TrimSpace(orderBy); s != "" {
+		fmt.Fprintf(&sb, " ORDER BY %s", s)
+	}
+	if s := strings.TrimSpace(limit); s != "" {
+		fmt.Fprintf(&sb, " LIMIT %s", s)
+	}
+	return sb.String()
+}
+
+func indexOfString(values []string, v string) int {
+	for i, s := range values {
+		if s == v {
+			return i
+		}
+	}
+	return 0
+}
+
+func (b *queryBuilder) View() string {
+	selected := lipgloss.NewStyle().
+		Background(lipgloss.Color(tuiutil.Highlight())).
+		Foreground(lipgloss.Color("#000000"))
+	var lines []string
+	var help []Shortcut
+	switch b.step {
+	case stepMapping:
+		lines = append(lines, "Select the mapping to query:", "")
+		for i, m := range b.mappings {
+			if i == b.cursor {
+				m = selected.Render(m)
+			}
+			lines = append(lines, "  "+m)
+		}
+		help = []Shortcut{{"Enter", "select"}, {"r", "refresh"}, {"q", "quit"}}
+	case stepColumns:
+		lines = append(lines, fmt.Sprintf("Select the columns of %s, all the columns are selected if none is:", b.mapping), "")
+		for i, c := range b.columns {
+			mark := "[ ]"
+			if b.selected[i] {
+				mark = "[x]"
+			}
+			line := fmt.Sprintf("%s %s", mark, c)
+			if i == b.cursor {
+				line = selected.Render(line)
+			}
+			lines = append(lines, "  "+line)
+		}
+		help = []Shortcut{{"Space", "toggle"}, {"a", "toggle all"}, {"Enter", "next"}, {"Esc", "back"}, {"q", "quit"}}
+	case stepClauses:
+		lines = append(lines, "Filter and sort the rows, leave the fields empty to skip them:", "")
+		for _, in := range b.inputs {
+			lines = append(lines, "  "+in.View())
+		}
+		lines = append(lines, "", "Columns: "+strings.Join(b.columns, ", "))
+		help = []Shortcut{{"Tab", "next field"}, {"Enter", "build"}, {"Esc", "back"}, {"Ctrl+C", "quit"}}
+	}
+	if b.mapping != "" && b.step != stepMapping {
+		lines = append(lines, "", "Query:", "  "+b.buildQuery())
+	}
+	lines = append(lines, "", b.status, Help{values: help}.View())
+	return strings.Join(lines, "\n")
+}
+
+// RunQueryBuilder starts the query builder, which walks through selecting a mapping, its columns, the filter and the sort order of a SELECT statement.
+// It returns the statement, which is empty if the builder is quit before building one.
+func RunQueryBuilder(src QueryBuilderSource) (string, error) {
+	m, err := tea.NewProgram(newQueryBuilder(src), tea.WithAltScreen()).StartReturningModel()
+	if err != nil {
+		return "", err
+	}
+	return m.(*queryBuilder).query, nil
+}
@@ -0,0 +1,84 @@
+package browser
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBuildSelect(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		columns []string
+		filter  string
+		orderBy string
+		limit   string
+		want    string
+	}{
+		{name: "all columns", want: `SELECT * FROM "employees"`},
+		{
+			name:    "all clauses",
+			columns: []string{"name", "age"},
+			filter:  " age > 30 ",
+			orderBy: "age DESC",
+			limit:   "10",
+			want:    `SELECT "name", "age" FROM "employees" WHERE age > 30 ORDER BY age DESC LIMIT 10`,
+		},
+		{name: "quoted column", columns: []string{`a"b`}, want: `SELECT "a""b" FROM "employees"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := buildSelect("employees", tc.columns, tc.filter, tc.orderBy, tc.limit); got != tc.want {
+				t.Errorf("want %s got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderSteps(t *testing.T) {
+	b := newQueryBuilder(QueryBuilderSource{})
+	b.Update(mappingsMsg{"customers", "employees"})
+	b.Update(keyMsg("down"))
+	cmd := b.handleKey(keyMsg("enter"))
+	if b.mapping != "employees" || cmd == nil {
+		t.Fatalf("employees must be selected and its columns loaded, got %s", b.mapping)
+	}
+	b.Update(columnsMsg{"__key", "name", "age"})
+	if b.step != stepColumns {
+		t.Fatalf("want the columns step got %d", b.step)
+	}
+	b.Update(keyMsg("down"))
+	b.Update(keyMsg(" "))
+	b.Update(keyMsg("enter"))
+	if b.step != stepClauses {
+		t.Fatalf("want the clauses step got %d", b.step)
+	}
+	for _, r := range "age > 30" {
+		b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	// to the limit
+	b.Update(keyMsg("tab"))
+	b.Update(keyMsg("tab"))
+	b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	b.Update(keyMsg("enter"))
+	if b.query != "" || b.status == "" {
+		t.Fatalf("an invalid limit must not build the query, got %q", b.query)
+	}
+	b.inputs[inputLimit].SetValue("5")
+	b.Update(keyMsg("enter"))
+	want := `SELECT "name" FROM "employees" WHERE age > 30 LIMIT 5`
+	if b.query != want {
+		t.Fatalf("want %s got %s", want, b.query)
+	}
+}
+
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
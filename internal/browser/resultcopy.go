@@ -0,0 +1,168 @@
+package browser
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+// statusMsg is shown by the help line for a while, such as after exporting the rows.
+type statusMsg string
+
+// resultKey handles the keys which copy and export the results, handled is false for the keys of the viewer.
+func (t *table) resultKey(key tea.KeyMsg) (cmd tea.Cmd, handled bool) {
+	tt := &t.termdbmsTable
+	if tt.UI.RenderSelection || len(tt.GetHeaders()) == 0 {
+		return nil, false
+	}
+	switch key.String() {
+	case "y":
+		return t.copyCell(), true
+	case "Y":
+		return t.copyRows(), true
+	case "C":
+		return t.copyColumn(), true
+	case "v":
+		tt.UI.VisualSelection = !tt.UI.VisualSelection
+		tt.UI.VisualAnchor = tt.Viewport.YOffset + tt.GetRow()
+		return nil, true
+	case "esc":
+		tt.UI.VisualSelection = false
+		t.clearSearch()
+		return nil, true
+	case "e":
+		return t.exportSelection(), true
+	}
+	return nil, false
+}
+
+// rowCount returns the number of the rows which are shown, the rows hidden by the filter are not counted.
+func (t *table) rowCount() int {
+	headers := t.termdbmsTable.GetHeaders()
+	if len(headers) == 0 {
+		return 0
+	}
+	return len(t.termdbmsTable.GetSchemaData()[headers[0]])
+}
+
+// rows returns the values of the rows from the first to the last one, both included.
+func (t *table) rows(from, to int) [][]interface{} {
+	headers := t.termdbmsTable.GetHeaders()
+	data := t.termdbmsTable.GetSchemaData()
+	to = min(to, t.rowCount()-1)
+	var rows [][]interface{}
+	for r := from; r <= to; r++ {
+		row := make([]interface{}, len(headers))
+		for i, h := range headers {
+			row[i] = data[h][r]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// copyCell copies the value of the selected cell.
+func (t *table) copyCell() tea.Cmd {
+	tt := &t.termdbmsTable
+	row := tt.Viewport.YOffset + tt.GetRow()
+	values := tt.GetColumnData()
+	if row >= len(values) {
+		return nil
+	}
+	return tuiutil.CopyCommand(output.DefaultOptions().Format(values[row]))
+}
+
+// copyRows copies the selected rows as JSON objects, one per line.
+func (t *table) copyRows() tea.Cmd {
+	headers := t.termdbmsTable.GetHeaders()
+	rows := t.rows(t.termdbmsTable.VisualRows())
+	if len(rows) == 0 {
+		return nil
+	}
+	opts := output.DefaultOptions()
+	lines := make([]string, len(rows))
+	for i, r := range rows {
+		b, err := opts.MarshalJSONObject(headers, r)
+		if err != nil {
+			return statusCommand(fmt.Sprintf("Cannot copy the row: %s", err))
+		}
+		lines[i] = string(b)
+	}
+	return tuiutil.CopyCommand(strings.Join(lines, "\n"))
+}
+
+// copyColumn copies the values of the selected column, one per line.
+// All the fetched rows are copied, unless some of them are selected.
+func (t *table) copyColumn() tea.Cmd {
+	tt := &t.termdbmsTable
+	values := tt.GetColumnData()
+	if len(values) == 0 {
+		return nil
+	}
+	if tt.UI.VisualSelection {
+		from, to := tt.VisualRows()
+		values = values[from:min(to+1, len(values))]
+	}
+	opts := output.DefaultOptions()
+	lines := make([]string, len(values))
+	for i, v := range values {
+		lines[i] = opts.Format(v)
+	}
+	return tuiutil.CopyCommand(strings.Join(lines, "\n"))
+}
+
+// exportSelection writes the selected rows to a CSV file in the current directory,
+// or all the fetched rows if none of them are selected.
+func (t *table) exportSelection() tea.Cmd {
+	tt := &t.termdbmsTable
+	headers := tt.GetHeaders()
+	from, to := 0, t.rowCount()-1
+	if tt.UI.VisualSelection {
+		from, to = tt.VisualRows()
+	}
+	// the rows are taken now, since more rows may be fetched while the file is written
+	rows := t.rows(from, to)
+	return func() tea.Msg {
+		path := fmt.Sprintf("results-%s.csv", time.Now().Format("20060102-150405"))
+		if err := writeCSV(path, headers, rows); err != nil {
+			return statusMsg(fmt.Sprintf("Cannot export the rows: %s", err))
+		}
+		return statusMsg(fmt.Sprintf("Exported %d row(s) to %s", len(rows), path))
+	}
+}
+
+func writeCSV(path string, headers []string, rows [][]interface{}) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w, err := output.NewFormatter(output.TypeCSV, f, output.DefaultOptions())
+	if err != nil {
+		return err
+	}
+	if err := w.WriteHeader(headers); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := w.WriteRow(r); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func statusCommand(status string) tea.Cmd {
+	return func() tea.Msg {
+		return statusMsg(status)
+	}
+}
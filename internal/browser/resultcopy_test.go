@@ -0,0 +1,72 @@
+package browser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/viewer"
+)
+
+func newTestTable() *table {
+	tb := &table{}
+	tb.Init()
+	tb.lastIteration = &SQLIterator{columnNames: []string{"__key", "name"}}
+	tb.all = map[string][]interface{}{
+		"__key": {int32(1), int32(2), int32(3)},
+		"name":  {"Alice", "Bob", nil},
+	}
+	tb.showRows()
+	tt := &tb.termdbmsTable
+	tt.UI.CurrentTable = 1
+	tt.MouseData.Y = viewer.HeaderHeight
+	return tb
+}
+
+func copiedText(t *testing.T, tb *table, key string) string {
+	cmd, ok := tb.resultKey(keyMsg(key))
+	if !ok || cmd == nil {
+		t.Fatalf("want %s to copy the text", key)
+	}
+	return cmd().(tuiutil.ClipboardMsg).Text
+}
+
+func TestTableCopiesCellRowAndColumn(t *testing.T) {
+	tb := newTestTable()
+	tb.termdbmsTable.MouseData.Y = viewer.HeaderHeight + 1
+	if text := copiedText(t, tb, "y"); text != "2" {
+		t.Fatalf("want the selected cell got %q", text)
+	}
+	if text, want := copiedText(t, tb, "Y"), `{"__key":2,"name":"Bob"}`; text != want {
+		t.Fatalf("want %s got %s", want, text)
+	}
+	// the rows from the one v is pressed at to the selected one are selected
+	tb.resultKey(keyMsg("v"))
+	tb.termdbmsTable.MouseData.Y = viewer.HeaderHeight + 2
+	if text, want := copiedText(t, tb, "Y"), "{\"__key\":2,\"name\":\"Bob\"}\n{\"__key\":3,\"name\":null}"; text != want {
+		t.Fatalf("want %s got %s", want, text)
+	}
+	if text, want := copiedText(t, tb, "C"), "2\n3"; text != want {
+		t.Fatalf("want %q got %q", want, text)
+	}
+	tb.resultKey(keyMsg("esc"))
+	if text, want := copiedText(t, tb, "C"), "1\n2\n3"; text != want {
+		t.Fatalf("want %q got %q", want, text)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	tb := newTestTable()
+	path := filepath.Join(t.TempDir(), "results.csv")
+	if err := writeCSV(path, tb.termdbmsTable.GetHeaders(), tb.rows(1, 5)); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "__key,name\n2,Bob\n3,NULL\n"; string(b) != want {
+		t.Fatalf("want %q got %q", want, string(b))
+	}
+}
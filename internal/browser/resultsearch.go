@@ -0,0 +1,215 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/viewer"
+)
+
+// resultPrompt is the text input shown below the results.
+type resultPrompt int
+
+const (
+	noPrompt resultPrompt = iota
+	searchPrompt
+	filterPrompt
+)
+
+// searchKey handles the keys which search and filter the results, handled is false for the other keys.
+func (t *table) searchKey(key tea.KeyMsg) (cmd tea.Cmd, handled bool) {
+	if t.prompt != noPrompt {
+		return t.promptKey(key), true
+	}
+	if t.termdbmsTable.UI.RenderSelection || len(t.termdbmsTable.GetHeaders()) == 0 {
+		return nil, false
+	}
+	switch key.String() {
+	case "/":
+		return t.openPrompt(searchPrompt, "/", t.search), true
+	case "f":
+		return t.openPrompt(filterPrompt, "filter: ", t.filter), true
+	case "n", "N":
+		// without a search, the keys are left to the viewer
+		if t.search == "" {
+			return nil, false
+		}
+		step := 1
+		if key.String() == "N" {
+			step = -1
+		}
+		return t.jumpToMatch(step, false), true
+	}
+	return nil, false
+}
+
+func (t *table) openPrompt(p resultPrompt, prompt, value string) tea.Cmd {
+	t.prompt = p
+	t.input = tuiutil.NewModel()
+	t.input.Prompt = prompt
+	t.input.SetValue(value)
+	return t.input.FocusCommand()
+}
+
+// promptKey handles the keys while the search or the filter is typed.
+func (t *table) promptKey(key tea.KeyMsg) tea.Cmd {
+	switch key.Type {
+	case tea.KeyEnter:
+		p, value := t.prompt, t.input.Value()
+		t.closePrompt()
+		if p == filterPrompt {
+			return t.setFilter(value)
+		}
+		t.search = value
+		t.termdbmsTable.UI.Search = value
+		if value == "" {
+			return nil
+		}
+		return t.jumpToMatch(1, true)
+	case tea.KeyEsc:
+		t.closePrompt()
+		return nil
+	}
+	var cmd tea.Cmd
+	t.input, cmd = t.input.Update(key)
+	return cmd
+}
+
+func (t *table) closePrompt() {
+	t.input.Blur()
+	t.prompt = noPrompt
+}
+
+// clearSearch removes the highlights of the matches.
+func (t *table) clearSearch() {
+	t.search = ""
+	t.termdbmsTable.UI.Search = ""
+}
+
+// rowMatches returns true if a value of the row contains the text, the case is ignored.
+func rowMatches(values []interface{}, text string) bool {
+	opts := output.DefaultOptions()
+	for _, v := range values {
+		if viewer.ContainsFold(opts.Format(v), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// jumpToMatch selects the next row which matches the search, or the previous one if step is negative.
+// The search wraps around at the ends, the selected row is checked first if fromCurrent is true.
+func (t *table) jumpToMatch(step int, fromCurrent bool) tea.Cmd {
+	n := t.rowCount()
+	if n == 0 {
+		return nil
+	}
+	current := t.termdbmsTable.Viewport.YOffset + t.termdbmsTable.GetRow()
+	var matches []int
+	matched := make(map[int]bool)
+	for r, values := range t.rows(0, n-1) {
+		if rowMatches(values, t.search) {
+			matches = append(matches, r)
+			matched[r] = true
+		}
+	}
+	if len(matches) == 0 {
+		return statusCommand(fmt.Sprintf("No rows match %s", t.search))
+	}
+	start := 1
+	if fromCurrent {
+		start = 0
+	}
+	next := current
+	for i := start; i <= n; i++ {
+		if r := ((current+step*i)%n + n) % n; matched[r] {
+			next = r
+			break
+		}
+	}
+	t.selectRow(next)
+	return statusCommand(fmt.Sprintf("Row %d of the %d rows matching %s", indexOf(matches, next)+1, len(matches), t.search))
+}
+
+func indexOf(rows []int, row int) int {
+	for i, r := range rows {
+		if r == row {
+			return i
+		}
+	}
+	return -1
+}
+
+// selectRow selects the row, scrolling the rows if it is not on the screen.
+func (t *table) selectRow(row int) {
+	tt := &t.termdbmsTable
+	height := tt.Viewport.Height
+	if row < tt.Viewport.YOffset || row >= tt.Viewport.YOffset+height {
+		tt.Viewport.YOffset = max(min(row, t.rowCount()-height), 0)
+	}
+	tt.MouseData.Y = viewer.HeaderHeight + row - tt.Viewport.YOffset
+	tt.SetViewSlices()
+}
+
+// setFilter shows only the rows which contain the text, the rows are not fetched again.
+func (t *table) setFilter(text string) tea.Cmd {
+	t.filter = text
+	tt := &t.termdbmsTable
+	tt.UI.VisualSelection = false
+	tt.Viewport.YOffset = 0
+	tt.MouseData.Y = viewer.HeaderHeight
+	t.showRows()
+	tt.SetViewSlices()
+	if text == "" {
+		return statusCommand("Showing all the rows")
+	}
+	return statusCommand(fmt.Sprintf("Showing %d of the %d rows", t.rowCount(), len(t.all[tt.GetHeaders()[0]])))
+}
+
+// filteredRows returns the fetched rows which match the filter, by column.
+func (t *table) filteredRows() map[string][]interface{} {
+	if t.filter == "" {
+		return t.all
+	}
+	names := t.lastIteration.columnNames
+	filtered := make(map[string][]interface{}, len(names))
+	for _, n := range names {
+		filtered[n] = []interface{}{}
+	}
+	if len(names) == 0 {
+		return filtered
+	}
+	values := make([]interface{}, len(names))
+	for r := range t.all[names[0]] {
+		for i, n := range names {
+			values[i] = t.all[n][r]
+		}
+		if !rowMatches(values, t.filter) {
+			continue
+		}
+		for i, n := range names {
+			filtered[n] = append(filtered[n], values[i])
+		}
+	}
+	return filtered
+}
+
+// promptView returns the line below the results, which shows the search and the filter.
+func (t *table) promptView() string {
+	if t.prompt != noPrompt {
+		return t.input.View()
+	}
+	var parts []string
+	if t.search != "" {
+		parts = append(parts, "/"+t.search+" (n/N next/previous match)")
+	}
+	if t.filter != "" {
+		parts = append(parts, "filter: "+t.filter)
+	}
+	return lipgloss.NewStyle().Faint(true).Render(strings.Join(parts, "   "))
+}
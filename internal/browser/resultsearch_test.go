@@ -0,0 +1,72 @@
+package browser
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeText(tb *table, text string) {
+	for _, r := range text {
+		tb.searchKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	tb.searchKey(keyMsg("enter"))
+}
+
+func TestTableSearchJumpsBetweenMatches(t *testing.T) {
+	tb := newTestTable()
+	tb.termdbmsTable.Viewport.Height = 10
+	tb.searchKey(keyMsg("/"))
+	if tb.prompt != searchPrompt {
+		t.Fatal("want the search prompt")
+	}
+	// the case is ignored
+	typeText(tb, "b")
+	if tb.search != "b" || tb.termdbmsTable.UI.Search != "b" {
+		t.Fatalf("want the search to be set got %q", tb.search)
+	}
+	if row := tb.termdbmsTable.GetRow(); row != 1 {
+		t.Fatalf("want row 1 got %d", row)
+	}
+	tb.searchKey(keyMsg("n"))
+	// the search wraps around to the first match, since it is the only one
+	if row := tb.termdbmsTable.GetRow(); row != 1 {
+		t.Fatalf("want row 1 got %d", row)
+	}
+	tb.resultKey(keyMsg("esc"))
+	if tb.search != "" {
+		t.Fatal("want the search to be cleared")
+	}
+	// without a search, n is left to the viewer
+	if _, ok := tb.searchKey(keyMsg("n")); ok {
+		t.Fatal("want n to be left to the viewer")
+	}
+}
+
+func TestTableFilterHidesRows(t *testing.T) {
+	tb := newTestTable()
+	tb.searchKey(keyMsg("f"))
+	typeText(tb, "ALI")
+	if n := tb.rowCount(); n != 1 {
+		t.Fatalf("want 1 row got %d", n)
+	}
+	if v := tb.rows(0, 0)[0][1]; v != "Alice" {
+		t.Fatalf("want Alice got %v", v)
+	}
+	// the rows which arrive later are filtered too
+	tb.all["__key"] = append(tb.all["__key"], int32(4))
+	tb.all["name"] = append(tb.all["name"], "Alicia")
+	tb.showRows()
+	if n := tb.rowCount(); n != 2 {
+		t.Fatalf("want 2 rows got %d", n)
+	}
+	// clearing the filter shows all the rows
+	tb.searchKey(keyMsg("f"))
+	for i := 0; i < 3; i++ {
+		tb.searchKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	tb.searchKey(keyMsg("enter"))
+	if n := tb.rowCount(); n != 4 {
+		t.Fatalf("want 4 rows got %d", n)
+	}
+}
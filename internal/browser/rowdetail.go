@@ -0,0 +1,139 @@
+package browser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+// maxDetailNameWidth is the widest the column names of the row detail are, the longer names are cut
+const maxDetailNameWidth = 24
+
+// rowDetail shows the columns of a row one under the other, so that the long values can be read in full.
+type rowDetail struct {
+	names  []string
+	values []string
+	cursor int
+	offset int
+	height int
+}
+
+func newRowDetail(names []string, values []interface{}) *rowDetail {
+	d := &rowDetail{names: names, values: make([]string, len(values))}
+	opts := output.DefaultOptions()
+	for i, v := range values {
+		d.values[i] = prettyJSON(opts.Format(v))
+	}
+	return d
+}
+
+// prettyJSON indents the value if it is a JSON object or array, the other values are returned as they are.
+func prettyJSON(s string) string {
+	t := strings.TrimSpace(s)
+	if !strings.HasPrefix(t, "{") && !strings.HasPrefix(t, "[") {
+		return s
+	}
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(t), "", "  "); err != nil {
+		return s
+	}
+	return b.String()
+}
+
+// Update handles the keys while the detail is shown, closed is true once it is closed.
+func (d *rowDetail) Update(key tea.KeyMsg) (cmd tea.Cmd, closed bool) {
+	switch key.String() {
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j":
+		if d.cursor < len(d.values)-1 {
+			d.cursor++
+		}
+	case "y", "c":
+		if d.cursor < len(d.values) {
+			return tuiutil.CopyCommand(d.values[d.cursor]), false
+		}
+	case "esc", "q", "enter":
+		return nil, true
+	}
+	d.scroll()
+	return nil, false
+}
+
+// lines returns the lines of the detail and the line each column starts at.
+func (d *rowDetail) lines() ([]string, []int) {
+	width := 0
+	for _, n := range d.names {
+		width = max(width, lipgloss.Width(n))
+	}
+	if width > maxDetailNameWidth {
+		width = maxDetailNameWidth
+	}
+	selected := lipgloss.NewStyle().
+		Background(lipgloss.Color(tuiutil.Highlight())).
+		Foreground(lipgloss.Color("#000000"))
+	bold := lipgloss.NewStyle().Bold(true)
+	var lines []string
+	starts := make([]int, len(d.names))
+	for i, n := range d.names {
+		starts[i] = len(lines)
+		name := fmt.Sprintf("%-*s", width, cutLine(n, width))
+		if i == d.cursor {
+			name = selected.Render(name)
+		} else {
+			name = bold.Render(name)
+		}
+		for j, l := range strings.Split(d.values[i], "\n") {
+			if j > 0 {
+				// the next lines of a value are aligned with its first line
+				name = strings.Repeat(" ", width)
+			}
+			lines = append(lines, name+" │ "+l)
+		}
+	}
+	return lines, starts
+}
+
+// scroll keeps the selected column on the screen, the first line is left to the title.
+func (d *rowDetail) scroll() {
+	height := d.height - 1
+	if height < 1 {
+		return
+	}
+	lines, starts := d.lines()
+	if d.cursor >= len(starts) {
+		return
+	}
+	start := starts[d.cursor]
+	end := len(lines)
+	if d.cursor < len(starts)-1 {
+		end = starts[d.cursor+1]
+	}
+	if end > d.offset+height {
+		d.offset = end - height
+	}
+	if start < d.offset {
+		d.offset = start
+	}
+}
+
+func (d *rowDetail) View() string {
+	lines, _ := d.lines()
+	title := lipgloss.NewStyle().Faint(true).Render("Row detail - ↑/↓ select, y copy the value, esc close")
+	if d.offset < len(lines) {
+		lines = lines[d.offset:]
+	}
+	if d.height > 1 && len(lines) > d.height-1 {
+		lines = lines[:d.height-1]
+	}
+	return strings.Join(append([]string{title}, lines...), "\n")
+}
@@ -0,0 +1,61 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+func TestRowDetailRendersColumns(t *testing.T) {
+	d := newRowDetail(
+		[]string{"__key", "name", "address"},
+		[]interface{}{int32(1), nil, serialization.JSON(`{"city":"Istanbul","zip":"34000"}`)},
+	)
+	view := d.View()
+	for _, want := range []string{
+		"__key",
+		"address",
+		" │ 1\n",
+		" │ NULL\n",
+		" │ {\n",
+		"        │   \"city\": \"Istanbul\",",
+		"        │ }",
+	} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("want %q in the view:\n%s", want, view)
+		}
+	}
+}
+
+func TestRowDetailCopiesSelectedValue(t *testing.T) {
+	d := newRowDetail([]string{"__key", "tags"}, []interface{}{int64(7), `["a","b"]`})
+	d.Update(keyMsg("down"))
+	cmd, closed := d.Update(keyMsg("y"))
+	if closed || cmd == nil {
+		t.Fatal("want the value to be copied")
+	}
+	msg := cmd().(tuiutil.ClipboardMsg)
+	if want := "[\n  \"a\",\n  \"b\"\n]"; msg.Text != want {
+		t.Fatalf("want %q got %q", want, msg.Text)
+	}
+	if _, closed := d.Update(keyMsg("esc")); !closed {
+		t.Fatal("want the detail to be closed")
+	}
+}
+
+func TestRowDetailScrollsToSelectedColumn(t *testing.T) {
+	d := newRowDetail([]string{"a", "b", "c", "d"}, []interface{}{"1", "2", "3", "4"})
+	d.height = 3
+	for i := 0; i < 3; i++ {
+		d.Update(keyMsg("down"))
+	}
+	if d.offset != 2 {
+		t.Fatalf("want offset 2 got %d", d.offset)
+	}
+	if view := d.View(); !strings.Contains(view, " │ 4") || strings.Contains(view, " │ 2") {
+		t.Fatalf("want the last columns in the view:\n%s", view)
+	}
+}
@@ -0,0 +1,261 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/layout"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+// SchemaSource provides the mappings and their columns to the schema browser of the SQL browser.
+type SchemaSource struct {
+	Mappings func() ([]string, error)
+	// Columns returns the columns of the mapping with their types, such as "age INTEGER"
+	Columns func(mapping string) ([]string, error)
+}
+
+type schemaMappingsMsg struct {
+	mappings []string
+	err      error
+}
+
+type schemaColumnsMsg struct {
+	mapping string
+	columns []string
+	err     error
+}
+
+type schemaMapping struct {
+	name     string
+	columns  []string
+	expanded bool
+}
+
+// schemaRow is a line of the schema browser, column is -1 for the lines of the mappings.
+type schemaRow struct {
+	mapping int
+	column  int
+}
+
+// schemaBrowser lists the mappings, which are expanded to show their columns.
+type schemaBrowser struct {
+	src      SchemaSource
+	mappings []schemaMapping
+	cursor   int
+	offset   int
+	height   int
+	focused  bool
+	status   string
+}
+
+func newSchemaBrowser(src SchemaSource) *schemaBrowser {
+	return &schemaBrowser{src: src, status: "Loading..."}
+}
+
+func (s *schemaBrowser) Init() tea.Cmd {
+	return s.loadMappings
+}
+
+func (s *schemaBrowser) loadMappings() tea.Msg {
+	mappings, err := s.src.Mappings()
+	return schemaMappingsMsg{mappings: mappings, err: err}
+}
+
+func (s *schemaBrowser) loadColumns(mapping string) tea.Cmd {
+	return func() tea.Msg {
+		columns, err := s.src.Columns(mapping)
+		return schemaColumnsMsg{mapping: mapping, columns: columns, err: err}
+	}
+}
+
+func (s *schemaBrowser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m := msg.(type) {
+	case schemaMappingsMsg:
+		if m.err != nil {
+			s.status = fmt.Sprintf("Cannot list the mappings: %s", m.err)
+			return s, nil
+		}
+		s.setMappings(m.mappings)
+	case schemaColumnsMsg:
+		for i := range s.mappings {
+			if s.mappings[i].name != m.mapping {
+				continue
+			}
+			if m.err != nil {
+				s.status = fmt.Sprintf("Cannot get the columns of %s: %s", m.mapping, m.err)
+				return s, nil
+			}
+			s.mappings[i].columns = m.columns
+			s.mappings[i].expanded = true
+		}
+	case layout.FocusMsg:
+		s.focused = true
+	case layout.BlurMsg:
+		s.focused = false
+	case tea.WindowSizeMsg:
+		s.height = m.Height
+		s.scroll()
+	case tea.KeyMsg:
+		return s, s.handleKey(m)
+	case tea.MouseMsg:
+		return s, s.handleMouse(m)
+	}
+	return s, nil
+}
+
+// handleMouse moves the cursor to the clicked line, clicking the selected mapping shows or hides its columns.
+func (s *schemaBrowser) handleMouse(m tea.MouseMsg) tea.Cmd {
+	switch m.Type {
+	case tea.MouseWheelUp:
+		return s.handleKey(tea.KeyMsg{Type: tea.KeyUp})
+	case tea.MouseWheelDown:
+		return s.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	case tea.MouseRelease:
+		row := s.offset + m.Y
+		if row >= len(s.rows()) {
+			return nil
+		}
+		if row != s.cursor {
+			s.cursor = row
+			return nil
+		}
+		return s.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	return nil
+}
+
+// setMappings replaces the mappings, the mappings which are still there stay expanded.
+func (s *schemaBrowser) setMappings(names []string) {
+	old := make(map[string]schemaMapping, len(s.mappings))
+	for _, m := range s.mappings {
+		old[m.name] = m
+	}
+	s.mappings = make([]schemaMapping, len(names))
+	for i, n := range names {
+		s.mappings[i] = schemaMapping{name: n}
+		if m, ok := old[n]; ok {
+			s.mappings[i] = m
+		}
+	}
+	s.status = ""
+	if len(names) == 0 {
+		s.status = "There are no mappings"
+	}
+	if rows := len(s.rows()); s.cursor >= rows {
+		s.cursor = max(rows-1, 0)
+	}
+	s.scroll()
+}
+
+// rows returns the lines of the mappings and the columns of the expanded mappings.
+func (s *schemaBrowser) rows() []schemaRow {
+	var rows []schemaRow
+	for i, m := range s.mappings {
+		rows = append(rows, schemaRow{mapping: i, column: -1})
+		if m.expanded {
+			for j := range m.columns {
+				rows = append(rows, schemaRow{mapping: i, column: j})
+			}
+		}
+	}
+	return rows
+}
+
+func (s *schemaBrowser) handleKey(key tea.KeyMsg) tea.Cmd {
+	rows := s.rows()
+	switch key.String() {
+	case "up", "k":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	case "down", "j":
+		if s.cursor < len(rows)-1 {
+			s.cursor++
+		}
+	case "enter", "right", "l":
+		if s.cursor >= len(rows) {
+			return nil
+		}
+		m := &s.mappings[rows[s.cursor].mapping]
+		if m.expanded && key.String() == "enter" {
+			m.expanded = false
+			return nil
+		}
+		if m.columns == nil {
+			return s.loadColumns(m.name)
+		}
+		m.expanded = true
+	case "left", "h":
+		if s.cursor >= len(rows) {
+			return nil
+		}
+		r := rows[s.cursor]
+		s.mappings[r.mapping].expanded = false
+		// the cursor moves from the column to its mapping
+		s.cursor = s.rowOf(r.mapping)
+	case "r":
+		s.status = "Loading..."
+		return s.loadMappings
+	}
+	s.scroll()
+	return nil
+}
+
+// rowOf returns the line of the mapping.
+func (s *schemaBrowser) rowOf(mapping int) int {
+	for i, r := range s.rows() {
+		if r.mapping == mapping && r.column < 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// scroll keeps the cursor on the screen, the last line is left to the status.
+func (s *schemaBrowser) scroll() {
+	height := s.height - 1
+	if height < 1 {
+		return
+	}
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+height {
+		s.offset = s.cursor - height + 1
+	}
+}
+
+func (s *schemaBrowser) View() string {
+	selected := lipgloss.NewStyle().
+		Background(lipgloss.Color(tuiutil.Highlight())).
+		Foreground(lipgloss.Color("#000000"))
+	faint := lipgloss.NewStyle().Faint(true)
+	var lines []string
+	rows := s.rows()
+	for i := s.offset; i < len(rows) && (s.height <= 1 || i < s.offset+s.height-1); i++ {
+		r := rows[i]
+		m := s.mappings[r.mapping]
+		line := "▸ " + m.name
+		if m.expanded {
+			line = "▾ " + m.name
+		}
+		if r.column >= 0 {
+			line = "    " + m.columns[r.column]
+		}
+		switch {
+		case i == s.cursor && s.focused:
+			line = selected.Render(line)
+		case r.column >= 0:
+			line = faint.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if s.status != "" {
+		lines = append(lines, s.status)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,43 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchemaBrowserExpandsMappings(t *testing.T) {
+	s := newSchemaBrowser(SchemaSource{
+		Columns: func(mapping string) ([]string, error) {
+			return []string{"__key INTEGER", "name VARCHAR"}, nil
+		},
+	})
+	s.Update(schemaMappingsMsg{mappings: []string{"customers", "employees"}})
+	s.Update(keyMsg("down"))
+	_, cmd := s.Update(keyMsg("enter"))
+	if cmd == nil {
+		t.Fatal("want the columns to be loaded")
+	}
+	s.Update(cmd())
+	view := s.View()
+	for _, want := range []string{"▸ customers", "▾ employees", "    name VARCHAR"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("want %q in the view:\n%s", want, view)
+		}
+	}
+	// the expanded mappings stay expanded when the mappings are refreshed
+	s.Update(keyMsg("down"))
+	s.Update(schemaMappingsMsg{mappings: []string{"employees", "orders"}})
+	if len(s.rows()) != 4 {
+		t.Fatalf("want 4 rows got %d", len(s.rows()))
+	}
+	// left collapses the mapping and moves the cursor from its column to it
+	s.cursor = 2
+	s.Update(keyMsg("left"))
+	if s.cursor != 0 || s.mappings[0].expanded {
+		t.Fatalf("want the mapping collapsed, the cursor at %d", s.cursor)
+	}
+	// the columns are kept, so they are not loaded again
+	if _, cmd := s.Update(keyMsg("right")); cmd != nil || !s.mappings[0].expanded {
+		t.Fatal("want the mapping expanded without loading its columns")
+	}
+}
@@ -2,11 +2,12 @@ package browser
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 )
 
-func execSQL(db *sql.DB, text string) (*sql.Rows, error) {
+func execSQL(db *sql.DB, text string, readOnly bool) (*sql.Rows, error) {
 	text = strings.TrimSpace(text)
 	if text == "" {
 		return nil, nil
@@ -15,6 +16,9 @@ func execSQL(db *sql.DB, text string) (*sql.Rows, error) {
 	if strings.HasPrefix(lt, "select") || strings.HasPrefix(lt, "show") {
 		return query(db, text)
 	}
+	if readOnly {
+		return nil, errors.New("only SELECT and SHOW statements can be run in the read-only mode")
+	}
 	return nil, exec(db, text)
 }
 
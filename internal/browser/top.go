@@ -0,0 +1,339 @@
+package browser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+// MapUsage is the size of a map summed over the members.
+type MapUsage struct {
+	Name          string
+	Entries       int64
+	BackupEntries int64
+	// Cost is the estimated heap cost of the owned and the backup entries in bytes
+	Cost    int64
+	Members int
+}
+
+// TopSource provides the usage of the maps and their partitions to the top view.
+type TopSource struct {
+	Maps func() ([]MapUsage, error)
+	// Partitions returns the partitions of the map, with their sizes if bySize is true
+	Partitions func(mapName string, bySize bool) ([]PartitionUsage, error)
+}
+
+// the columns of the top view, in the order they are shown
+const (
+	topColumnName = iota
+	topColumnEntries
+	topColumnBackupEntries
+	topColumnCost
+	topColumnMembers
+)
+
+var topColumns = []struct {
+	title string
+	width int
+}{
+	{"MAP", 32},
+	{"ENTRIES", 14},
+	{"BACKUP ENTRIES", 16},
+	{"HEAP COST", 12},
+	{"MEMBERS", 9},
+}
+
+type topTickMsg time.Time
+
+type topMapsMsg struct {
+	maps []MapUsage
+	err  error
+	at   time.Time
+	// scheduled is true if the maps are loaded at the interval, rather than when u is pressed
+	scheduled bool
+}
+
+type topModel struct {
+	src      TopSource
+	interval time.Duration
+	maps     []MapUsage
+	// sortColumn is the column the maps are sorted by, the largest first unless ascending is set
+	sortColumn int
+	ascending  bool
+	cursor     int
+	offset     int
+	height     int
+	updatedAt  time.Time
+	status     string
+	// heatmap shows the partitions of the selected map, it is nil unless Enter is pressed
+	heatmap *heatmapModel
+}
+
+func newTopModel(src TopSource, interval time.Duration) *topModel {
+	return &topModel{src: src, interval: interval, sortColumn: topColumnCost}
+}
+
+func (m *topModel) Init() tea.Cmd {
+	return m.load(true)
+}
+
+// load returns the command which loads the maps, only the scheduled loads schedule the next one, so that pressing u does not add another schedule.
+func (m *topModel) load(scheduled bool) tea.Cmd {
+	return func() tea.Msg {
+		maps, err := m.src.Maps()
+		return topMapsMsg{maps: maps, err: err, at: time.Now(), scheduled: scheduled}
+	}
+}
+
+func (m *topModel) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(t time.Time) tea.Msg {
+		return topTickMsg(t)
+	})
+}
+
+func (m *topModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		m.scroll()
+	case topTickMsg:
+		return m, m.load(true)
+	case topMapsMsg:
+		// the last maps are kept if they cannot be refreshed
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Cannot refresh the maps: %s", msg.err)
+		} else {
+			m.status = ""
+			m.setMaps(msg.maps)
+			m.updatedAt = msg.at
+		}
+		if msg.scheduled {
+			return m, m.tick()
+		}
+		return m, nil
+	case partitionsMsg:
+		if m.heatmap != nil {
+			return m, m.heatmap.update(msg)
+		}
+	case tea.KeyMsg:
+		if m.heatmap != nil {
+			switch msg.String() {
+			case "esc":
+				m.heatmap = nil
+				return m, nil
+			case "q", "ctrl+c":
+				return m, tea.Quit
+			}
+			return m, m.heatmap.update(msg)
+		}
+		return m, m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// setMaps replaces the maps, keeping the selected map selected.
+func (m *topModel) setMaps(maps []MapUsage) {
+	selected := m.selected()
+	m.maps = maps
+	sortMapUsages(m.maps, m.sortColumn, m.ascending)
+	m.cursor = 0
+	for i, mu := range m.maps {
+		if mu.Name == selected {
+			m.cursor = i
+		}
+	}
+	m.scroll()
+}
+
+// selected returns the name of the selected map, it is empty if there are no maps.
+func (m *topModel) selected() string {
+	if m.cursor < len(m.maps) {
+		return m.maps[m.cursor].Name
+	}
+	return ""
+}
+
+func (m *topModel) handleKey(key tea.KeyMsg) tea.Cmd {
+	switch key.String() {
+	case "q", "esc", "ctrl+c":
+		return tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.maps)-1 {
+			m.cursor++
+		}
+	case "left", "<":
+		m.sortBy((m.sortColumn + len(topColumns) - 1) % len(topColumns))
+	case "right", ">":
+		m.sortBy((m.sortColumn + 1) % len(topColumns))
+	case "1", "2", "3", "4", "5":
+		m.sortBy(int(key.String()[0] - '1'))
+	case "r":
+		m.ascending = !m.ascending
+		m.setMaps(m.maps)
+	case "u":
+		return m.load(false)
+	case "enter":
+		if name := m.selected(); name != "" && m.src.Partitions != nil {
+			m.heatmap = newHeatmapModel(m.src, name)
+			return m.heatmap.load
+		}
+	}
+	m.scroll()
+	return nil
+}
+
+func (m *topModel) sortBy(column int) {
+	if column != m.sortColumn {
+		m.sortColumn = column
+		// the names are listed alphabetically, the sizes the largest first
+		m.ascending = column == topColumnName
+	}
+	m.setMaps(m.maps)
+}
+
+// rows returns the number of the maps which fit on the screen, all of them if the height is not known yet.
+func (m *topModel) rows() int {
+	if m.height == 0 {
+		return len(m.maps)
+	}
+	// the title, the header, the status and the help lines
+	if rows := m.height - 5; rows > 0 {
+		return rows
+	}
+	return 1
+}
+
+// scroll keeps the cursor on the screen.
+func (m *topModel) scroll() {
+	rows := m.rows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+// sortMapUsages sorts the maps by the column, ties are sorted by the name.
+func sortMapUsages(maps []MapUsage, column int, ascending bool) {
+	less := func(a, b MapUsage) bool {
+		switch column {
+		case topColumnEntries:
+			return a.Entries < b.Entries
+		case topColumnBackupEntries:
+			return a.BackupEntries < b.BackupEntries
+		case topColumnCost:
+			return a.Cost < b.Cost
+		case topColumnMembers:
+			return a.Members < b.Members
+		}
+		return a.Name < b.Name
+	}
+	sort.SliceStable(maps, func(i, j int) bool {
+		a, b := maps[i], maps[j]
+		if less(a, b) == less(b, a) {
+			return a.Name < b.Name
+		}
+		if ascending {
+			return less(a, b)
+		}
+		return less(b, a)
+	})
+}
+
+// formatBytes returns the size with the largest binary unit which keeps it at least 1, such as 1.5 KiB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func (m *topModel) View() string {
+	if m.heatmap != nil {
+		return m.heatmap.view()
+	}
+	selected := lipgloss.NewStyle().
+		Background(lipgloss.Color(tuiutil.Highlight())).
+		Foreground(lipgloss.Color("#000000"))
+	var entries, cost int64
+	for _, mu := range m.maps {
+		entries += mu.Entries
+		cost += mu.Cost
+	}
+	title := fmt.Sprintf("%d maps, %d entries, %s", len(m.maps), entries, formatBytes(cost))
+	if !m.updatedAt.IsZero() {
+		title += fmt.Sprintf(", updated at %s every %s", m.updatedAt.Format("15:04:05"), m.interval)
+	}
+	lines := []string{title}
+	header := make([]string, len(topColumns))
+	for i, c := range topColumns {
+		t := c.title
+		if i == m.sortColumn {
+			t += " ▼"
+			if m.ascending {
+				t = c.title + " ▲"
+			}
+		}
+		header[i] = t
+	}
+	lines = append(lines, lipgloss.NewStyle().Bold(true).Render(topRow(header)))
+	for i := m.offset; i < len(m.maps) && i < m.offset+m.rows(); i++ {
+		mu := m.maps[i]
+		line := topRow([]string{
+			mu.Name,
+			fmt.Sprint(mu.Entries),
+			fmt.Sprint(mu.BackupEntries),
+			formatBytes(mu.Cost),
+			fmt.Sprint(mu.Members),
+		})
+		if i == m.cursor {
+			line = selected.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if len(m.maps) == 0 && m.status == "" {
+		lines = append(lines, "There are no maps")
+	}
+	help := []Shortcut{{"←/→", "sort column"}, {"r", "reverse"}, {"Enter", "partitions"}, {"u", "update"}, {"q", "quit"}}
+	lines = append(lines, m.status, Help{values: help}.View())
+	return strings.Join(lines, "\n")
+}
+
+// topRow pads the cells to the widths of the columns, the name is aligned left and the numbers right.
+func topRow(cells []string) string {
+	var sb strings.Builder
+	for i, c := range cells {
+		w := topColumns[i].width
+		if len([]rune(c)) > w-1 {
+			c = string([]rune(c)[:w-2]) + "…"
+		}
+		if i == topColumnName {
+			fmt.Fprintf(&sb, "%-*s", w, c)
+			continue
+		}
+		fmt.Fprintf(&sb, "%*s", w, c)
+	}
+	return sb.String()
+}
+
+// RunTop starts the top view, which lists the maps by their sizes and refreshes them at every interval.
+func RunTop(src TopSource, interval time.Duration) error {
+	return tea.NewProgram(newTopModel(src, interval), tea.WithAltScreen()).Start()
+}
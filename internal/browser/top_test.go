@@ -0,0 +1,73 @@
+package browser
+
+import (
+	"testing"
+)
+
+func TestSortMapUsages(t *testing.T) {
+	maps := func() []MapUsage {
+		return []MapUsage{
+			{Name: "b", Entries: 10, Cost: 300},
+			{Name: "a", Entries: 20, Cost: 100},
+			{Name: "c", Entries: 10, Cost: 200},
+		}
+	}
+	names := func(maps []MapUsage) string {
+		var s string
+		for _, m := range maps {
+			s += m.Name
+		}
+		return s
+	}
+	for _, tc := range []struct {
+		name      string
+		column    int
+		ascending bool
+		want      string
+	}{
+		{name: "cost", column: topColumnCost, want: "bca"},
+		{name: "entries ties by name", column: topColumnEntries, want: "abc"},
+		{name: "entries ascending", column: topColumnEntries, ascending: true, want: "bca"},
+		{name: "name", column: topColumnName, ascending: true, want: "abc"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := maps()
+			sortMapUsages(m, tc.column, tc.ascending)
+			if got := names(m); got != tc.want {
+				t.Errorf("want %s got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+		3 << 30:         "3.0 GiB",
+	} {
+		if got := formatBytes(n); got != want {
+			t.Errorf("%d: want %s got %s", n, want, got)
+		}
+	}
+}
+
+func TestTopKeepsSelection(t *testing.T) {
+	m := newTopModel(TopSource{}, 0)
+	m.Update(topMapsMsg{maps: []MapUsage{{Name: "a", Cost: 1}, {Name: "b", Cost: 2}}})
+	m.Update(keyMsg("down"))
+	if m.selected() != "a" {
+		t.Fatalf("want a got %s", m.selected())
+	}
+	// a grows larger than b, it is still selected after the refresh
+	m.Update(topMapsMsg{maps: []MapUsage{{Name: "a", Cost: 3}, {Name: "b", Cost: 2}}})
+	if m.selected() != "a" || m.cursor != 0 {
+		t.Fatalf("want a at 0 got %s at %d", m.selected(), m.cursor)
+	}
+	m.Update(keyMsg("1"))
+	if m.sortColumn != topColumnName || !m.ascending || m.selected() != "a" {
+		t.Fatalf("want sorted by the name got column %d", m.sortColumn)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cobraprompt
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	goprompt "github.com/hazelcast/hazelcast-commandline-client/internal/go-prompt"
+	"github.com/hazelcast/hazelcast-commandline-client/sqlcmd"
+)
+
+const (
+	palettePrefix = "palette> "
+	// maxRecentPaletteItems is the number of the recent statements listed in the command palette
+	maxRecentPaletteItems = 20
+)
+
+// paletteItems returns the commands of the tree, the saved snippets and the recent statements from the history, in this order.
+func paletteItems(root *cobra.Command, snippets []sqlcmd.Snippet, history []string) []goprompt.Suggest {
+	var items []goprompt.Suggest
+	seen := map[string]bool{}
+	add := func(text, description string) {
+		if text == "" || seen[text] {
+			return
+		}
+		seen[text] = true
+		items = append(items, goprompt.Suggest{Text: text, Description: description})
+	}
+	prefix := root.CommandPath()
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, c := range cmd.Commands() {
+			if !c.IsAvailableCommand() {
+				continue
+			}
+			if c.Runnable() {
+				add(strings.TrimSpace(strings.TrimPrefix(c.CommandPath(), prefix)), c.Short)
+			}
+			walk(c)
+		}
+	}
+	walk(root)
+	for _, s := range snippets {
+		add("snippet run "+s.Name, strings.Join(strings.Fields(s.Statement), " "))
+	}
+	recent := 0
+	for i := len(history) - 1; i >= 0 && recent < maxRecentPaletteItems; i-- {
+		text := strings.TrimSpace(history[i])
+		if text == "" || seen[text] {
+			continue
+		}
+		add(text, "recent")
+		recent++
+	}
+	return items
+}
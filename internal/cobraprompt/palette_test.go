@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cobraprompt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	goprompt "github.com/hazelcast/hazelcast-commandline-client/internal/go-prompt"
+	"github.com/hazelcast/hazelcast-commandline-client/sqlcmd"
+)
+
+func TestPaletteItems(t *testing.T) {
+	run := func(cmd *cobra.Command, args []string) {}
+	root := &cobra.Command{Use: "hzc"}
+	m := &cobra.Command{Use: "map", Short: "map operations"}
+	m.AddCommand(&cobra.Command{Use: "get", Short: "get the value", Run: run})
+	m.AddCommand(&cobra.Command{Use: "secret", Hidden: true, Run: run})
+	root.AddCommand(m, &cobra.Command{Use: "version", Short: "print the version", Run: run})
+	snippets := []sqlcmd.Snippet{{Name: "adults", Statement: "SELECT *\nFROM employees"}}
+	history := []string{"map get -k k1", "version", "", "sql 'SELECT 1'", "map get -k k1"}
+	want := []goprompt.Suggest{
+		{Text: "map get", Description: "get the value"},
+		{Text: "version", Description: "print the version"},
+		{Text: "snippet run adults", Description: "SELECT * FROM employees"},
+		{Text: "map get -k k1", Description: "recent"},
+		{Text: "sql 'SELECT 1'", Description: "recent"},
+	}
+	if got := paletteItems(root, snippets, history); !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v got %v", want, got)
+	}
+}
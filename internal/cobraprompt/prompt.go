@@ -24,16 +24,21 @@ import (
 	"os/signal"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/google/shlex"
-	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/hazelcast/hazelcast-commandline-client/config"
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal"
 	goprompt "github.com/hazelcast/hazelcast-commandline-client/internal/go-prompt"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/session"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/theme"
+	"github.com/hazelcast/hazelcast-commandline-client/plugincmd"
 	"github.com/hazelcast/hazelcast-commandline-client/rootcmd"
+	"github.com/hazelcast/hazelcast-commandline-client/sqlcmd"
 )
 
 // DynamicSuggestionsAnnotation for dynamic suggestions.
@@ -92,16 +97,9 @@ func handleExit() {
 	}
 }
 
-var SuggestionColorOptions = []goprompt.Option{
-	goprompt.OptionSelectedSuggestionTextColor(goprompt.White), goprompt.OptionSuggestionTextColor(goprompt.White),
-	goprompt.OptionSelectedDescriptionTextColor(goprompt.LightGray), goprompt.OptionDescriptionTextColor(goprompt.LightGray),
-	goprompt.OptionSelectedSuggestionBGColor(goprompt.Blue), goprompt.OptionSuggestionBGColor(goprompt.DarkGray),
-	goprompt.OptionSelectedDescriptionBGColor(goprompt.Blue), goprompt.OptionDescriptionBGColor(goprompt.DarkGray),
-}
-
 // Run will automatically generate suggestions for all cobra commands and flags defined by RootCmd and execute the selected commands.
 // Run will also reset all given flags by default, see PersistFlagValues
-func (co CobraPrompt) Run(ctx context.Context, root *cobra.Command, cnfg *hazelcast.Config, cmdHistoryPath string) {
+func (co CobraPrompt) Run(ctx context.Context, root *cobra.Command, cnfg *config.Config, cmdHistoryPath string) {
 	defer handleExit()
 	// let ctrl+c exit goprompt
 	co.GoPromptOptions = append(co.GoPromptOptions, goprompt.OptionAddKeyBind(goprompt.KeyBind{
@@ -122,7 +120,7 @@ func (co CobraPrompt) Run(ctx context.Context, root *cobra.Command, cnfg *hazelc
 			b.CursorRight(to)
 		},
 	}))
-	co.GoPromptOptions = append(co.GoPromptOptions, SuggestionColorOptions...)
+	co.GoPromptOptions = append(co.GoPromptOptions, theme.Current().PromptOptions()...)
 	history := goprompt.NewHistory()
 	f, err := os.OpenFile(cmdHistoryPath, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0600)
 	defer func() {
@@ -140,7 +138,19 @@ func (co CobraPrompt) Run(ctx context.Context, root *cobra.Command, cnfg *hazelc
 			history.Clear()
 		}
 	}
+	// ctrl+p opens the command palette instead of showing the previous command, up arrow still does that
+	co.GoPromptOptions = append(co.GoPromptOptions, goprompt.OptionPalette(goprompt.ControlP, palettePrefix, func() []goprompt.Suggest {
+		// the snippets are optional in the palette, they are not listed if they cannot be read
+		snippets, _ := sqlcmd.ListSnippets()
+		return paletteItems(root, snippets, history.Commands)
+	}))
+	// the suggestion cannot be told apart from the input without colors
+	if !theme.Current().Colorless {
+		co.GoPromptOptions = append(co.GoPromptOptions, goprompt.OptionHistorySuggestion())
+	}
 	ctx = internal.ContextWithPersistedNames(ctx, co.Persister)
+	meta := sqlcmd.NewMetaCommandSession(cnfg)
+	defer meta.Close()
 	var p *goprompt.Prompt
 	p = goprompt.New(
 		func(in string) {
@@ -159,6 +169,37 @@ func (co CobraPrompt) Run(ctx context.Context, root *cobra.Command, cnfg *hazelc
 			if in == "" {
 				return
 			}
+			line := in
+			in, redir, err := splitRedirection(in)
+			if err != nil {
+				handleError(co, root, hzcerrors.NewLoggableError(err, "Cannot parse the output redirection"))
+				return
+			}
+			out, release, err := redir.open()
+			if err != nil {
+				handleError(co, root, err)
+				return
+			}
+			defer func() {
+				if err := release(); err != nil {
+					handleError(co, root, hzcerrors.NewLoggableError(err, "Cannot complete the output redirection to %s", redir.target))
+				}
+			}()
+			if out == nil {
+				out = meta.Out()
+			}
+			if sqlcmd.IsMetaCommand(in) {
+				if _, writeErr := f.WriteString(fmt.Sprintln(line)); writeErr != nil {
+					// todo log this once we have a logging solution
+				}
+				if out == nil {
+					out = root.OutOrStdout()
+				}
+				if err := meta.Run(ctx, in, out); err != nil {
+					handleError(co, root, err)
+				}
+				return
+			}
 			promptArgs, err := shlex.Split(in)
 			if err != nil {
 				fmt.Println("unable to parse commands")
@@ -168,13 +209,21 @@ func (co CobraPrompt) Run(ctx context.Context, root *cobra.Command, cnfg *hazelc
 			// ignore global flags, they are already parsed
 			root, _ = rootcmd.New(cnfg)
 			prepareRootCmdForPrompt(co, root)
+			if out != nil {
+				root.SetOut(out)
+			}
 			root.SetArgs(promptArgs)
+			plugincmd.EnableGlobalFlags(root, promptArgs)
 			root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
 				return hzcerrors.FlagError(err)
 			})
 			os.Args = promptArgs
+			start := time.Now()
 			err = root.ExecuteContext(ctx)
-			if _, writeErr := f.WriteString(fmt.Sprintln(in)); writeErr != nil {
+			if recordErr := session.Record(promptArgs, start, err); recordErr != nil {
+				root.PrintErrf("Cannot record the command: %s\n", recordErr)
+			}
+			if _, writeErr := f.WriteString(fmt.Sprintln(line)); writeErr != nil {
 				// todo log this once we have a logging solution
 			}
 			if err != nil {
@@ -187,12 +236,7 @@ func (co CobraPrompt) Run(ctx context.Context, root *cobra.Command, cnfg *hazelc
 					// todo make this applicable for all data types
 					err = fmt.Errorf(`%w. Add it or consider "map use <name>"`, err)
 				}
-				if co.OnErrorFunc != nil {
-					co.OnErrorFunc(err)
-				} else {
-					root.PrintErrln(err)
-					exitPromptSafely()
-				}
+				handleError(co, root, err)
 			}
 			// clear screen only after sql browser command executed successfully
 			if strings.Trim(in, " ") == "sql" {
@@ -213,6 +257,15 @@ func (co CobraPrompt) Run(ctx context.Context, root *cobra.Command, cnfg *hazelc
 	p.Run()
 }
 
+func handleError(co CobraPrompt, root *cobra.Command, err error) {
+	if co.OnErrorFunc != nil {
+		co.OnErrorFunc(err)
+		return
+	}
+	root.PrintErrln(err)
+	exitPromptSafely()
+}
+
 func prepareRootCmdForPrompt(co CobraPrompt, root *cobra.Command) {
 	if co.ShowHelpCommandAndFlags {
 		root.InitDefaultHelpCmd()
@@ -242,7 +295,7 @@ func findSuggestions(co *CobraPrompt, cmd *cobra.Command, d *goprompt.Document)
 	upToCursor := d.CurrentLineBeforeCursor()
 	// use line before cursor for command suggestion
 	bArgs := strings.Fields(upToCursor)
-	command, _, err := cmd.Find(bArgs)
+	command, rest, err := cmd.Find(bArgs)
 	if err != nil && strings.Contains(upToCursor, " ") {
 		return nil
 	}
@@ -260,6 +313,12 @@ func findSuggestions(co *CobraPrompt, cmd *cobra.Command, d *goprompt.Document)
 			}
 		}
 	}
+	if command.ValidArgsFunction != nil && !strings.HasPrefix(wordBeforeCursor, "-") {
+		// suggest the positional arguments, such as the snippet names
+		for _, v := range validArgs(command, rest, wordBeforeCursor) {
+			suggestions = append(suggestions, goprompt.Suggest{Text: v})
+		}
+	}
 	annotation := command.Annotations[DynamicSuggestionsAnnotation]
 	if co.DynamicSuggestionsFunc != nil && annotation != "" {
 		suggestions = append(suggestions, co.DynamicSuggestionsFunc(annotation, d)...)
@@ -267,6 +326,22 @@ func findSuggestions(co *CobraPrompt, cmd *cobra.Command, d *goprompt.Document)
 	return goprompt.FilterHasPrefix(suggestions, wordBeforeCursor, true)
 }
 
+// validArgs returns the completions of the positional argument being typed, args are the arguments of the command before the cursor.
+func validArgs(command *cobra.Command, args []string, toComplete string) []string {
+	if toComplete != "" && len(args) > 0 {
+		// the last argument is the one being typed
+		args = args[:len(args)-1]
+	}
+	var positional []string
+	for _, a := range args {
+		if !strings.HasPrefix(a, "-") {
+			positional = append(positional, a)
+		}
+	}
+	values, _ := command.ValidArgsFunction(command, positional, toComplete)
+	return values
+}
+
 func traverseForFlagSuggestions(wordBeforeCursor string, words []string, co *CobraPrompt, command *cobra.Command) []goprompt.Suggest {
 	var suggestions []goprompt.Suggest
 	noWordTyped := wordBeforeCursor == ""
@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cobraprompt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/google/shlex"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+type redirectionKind int
+
+const (
+	redirectNone redirectionKind = iota
+	// redirectWrite truncates the file, "cmd > file"
+	redirectWrite
+	// redirectAppend appends to the file, "cmd >> file"
+	redirectAppend
+	// redirectPipe writes to the standard input of a shell command, "cmd | command"
+	redirectPipe
+)
+
+type redirection struct {
+	kind   redirectionKind
+	target string
+}
+
+var errMissingRedirectTarget = errors.New("missing redirection target")
+
+// splitRedirection separates the output redirection suffix from the input.
+// Redirection operators within quotes or escaped with a backslash are part of the command.
+func splitRedirection(in string) (string, redirection, error) {
+	var quote rune
+	escaped := false
+	for i, r := range in {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '|':
+			target := strings.TrimSpace(in[i+1:])
+			if target == "" {
+				return "", redirection{}, errMissingRedirectTarget
+			}
+			return strings.TrimSpace(in[:i]), redirection{kind: redirectPipe, target: target}, nil
+		case r == '>':
+			kind, rest := redirectWrite, in[i+1:]
+			if strings.HasPrefix(rest, ">") {
+				kind, rest = redirectAppend, rest[1:]
+			}
+			words, err := shlex.Split(rest)
+			if err != nil {
+				return "", redirection{}, err
+			}
+			if len(words) != 1 {
+				if len(words) == 0 {
+					return "", redirection{}, errMissingRedirectTarget
+				}
+				return "", redirection{}, fmt.Errorf("expected a single file name after the redirection, got: %s", strings.TrimSpace(rest))
+			}
+			return strings.TrimSpace(in[:i]), redirection{kind: kind, target: words[0]}, nil
+		}
+	}
+	return in, redirection{}, nil
+}
+
+// open returns the writer the command output should go to and a function which releases it once the command is done.
+// The writer is nil if there is no redirection.
+func (r redirection) open() (io.Writer, func() error, error) {
+	switch r.kind {
+	case redirectWrite, redirectAppend:
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if r.kind == redirectAppend {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		}
+		f, err := os.OpenFile(r.target, flags, 0600)
+		if err != nil {
+			return nil, nil, hzcerrors.NewLoggableError(err, "Cannot open %s for writing", r.target)
+		}
+		return f, f.Close, nil
+	case redirectPipe:
+		cmd := shellCommand(r.target)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		w, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, nil, hzcerrors.NewLoggableError(err, "Cannot pipe the output to %s", r.target)
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, nil, hzcerrors.NewLoggableError(err, "Cannot run %s", r.target)
+		}
+		return w, func() error {
+			w.Close()
+			return cmd.Wait()
+		}, nil
+	}
+	return nil, func() error { return nil }, nil
+}
+
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
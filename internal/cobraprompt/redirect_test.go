@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cobraprompt
+
+import (
+	"testing"
+)
+
+func TestSplitRedirection(t *testing.T) {
+	for _, tc := range []struct {
+		info   string
+		in     string
+		cmd    string
+		redir  redirection
+		hasErr bool
+	}{
+		{info: "no redirection", in: "map get -k k1", cmd: "map get -k k1"},
+		{info: "write", in: "map get -k k1 > out.txt", cmd: "map get -k k1", redir: redirection{kind: redirectWrite, target: "out.txt"}},
+		{info: "append", in: "map get -k k1 >> out.txt", cmd: "map get -k k1", redir: redirection{kind: redirectAppend, target: "out.txt"}},
+		{info: "write without spaces", in: "map get -k k1>out.txt", cmd: "map get -k k1", redir: redirection{kind: redirectWrite, target: "out.txt"}},
+		{info: "quoted file", in: `map get -k k1 > "my out.txt"`, cmd: "map get -k k1", redir: redirection{kind: redirectWrite, target: "my out.txt"}},
+		{info: "pipe", in: "sql -o csv 'SELECT * FROM m' | sort -r | head", cmd: "sql -o csv 'SELECT * FROM m'", redir: redirection{kind: redirectPipe, target: "sort -r | head"}},
+		{info: "operator in double quotes", in: `sql "SELECT * FROM m WHERE this > 1"`, cmd: `sql "SELECT * FROM m WHERE this > 1"`},
+		{info: "operator in single quotes", in: `map put -k k1 -v 'a|b'`, cmd: `map put -k k1 -v 'a|b'`},
+		{info: "escaped operator", in: `map put -k k1 -v a\>b`, cmd: `map put -k k1 -v a\>b`},
+		{info: "missing file", in: "map get -k k1 >", hasErr: true},
+		{info: "missing command", in: "map get -k k1 | ", hasErr: true},
+		{info: "multiple files", in: "map get -k k1 > a b", hasErr: true},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			cmd, redir, err := splitRedirection(tc.in)
+			if (err != nil) != tc.hasErr {
+				t.Fatalf("want error %t got %v", tc.hasErr, err)
+			}
+			if tc.hasErr {
+				return
+			}
+			if cmd != tc.cmd {
+				t.Errorf("want command %q got %q", tc.cmd, cmd)
+			}
+			if redir != tc.redir {
+				t.Errorf("want redirection %+v got %+v", tc.redir, redir)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package confirmation
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	for _, tc := range []struct {
+		answer string
+		ok     bool
+		isErr  bool
+	}{
+		{answer: "y\n", ok: true},
+		{answer: " YES\n", ok: true},
+		{answer: "yes", ok: true},
+		{answer: "n\n"},
+		{answer: "\n"},
+		{answer: "yep\n"},
+		{answer: "", isErr: true},
+	} {
+		t.Run(tc.answer, func(t *testing.T) {
+			var out bytes.Buffer
+			ok, err := read(strings.NewReader(tc.answer), &out, "Remove 2 entries?")
+			if (err != nil) != tc.isErr {
+				t.Fatalf("want error %t got %v", tc.isErr, err)
+			}
+			if ok != tc.ok {
+				t.Fatalf("want %t got %t", tc.ok, ok)
+			}
+			if !strings.HasPrefix(out.String(), "Remove 2 entries? [y/N]: ") {
+				t.Fatalf("unexpected question %q", out.String())
+			}
+		})
+	}
+}
+
+func TestAskWithoutTerminal(t *testing.T) {
+	var out bytes.Buffer
+	if _, err := Ask(strings.NewReader("y\n"), &out, "Remove 2 entries?"); err == nil {
+		t.Fatal("want an error since the input is not a terminal")
+	}
+}
@@ -22,23 +22,42 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/logger"
 	"github.com/hazelcast/hazelcast-go-client/sql/driver"
 
 	"github.com/hazelcast/hazelcast-commandline-client/config"
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/progress"
 )
 
 var InvalidStateErr = errors.New("invalid new state")
 
-//todo add protection for concurrent access
-var (
+// conn is the shared connection to the cluster, the prompt connects and closes it while the latency monitor reads it
+var conn struct {
+	mu        sync.Mutex
 	client    *hazelcast.Client
 	sqlDriver *sql.DB
-)
+}
+
+// sharedClient returns the client started by ConnectToCluster, or nil if there is none.
+func sharedClient() *hazelcast.Client {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	return conn.client
+}
+
+func setSharedClient(cli *hazelcast.Client) {
+	conn.mu.Lock()
+	conn.client = cli
+	conn.mu.Unlock()
+}
 
 type RESTCall struct {
 	url    string
@@ -66,10 +85,9 @@ func CallClusterOperationWithState(config *hazelcast.Config, operation string, s
 		TLSClientConfig: config.Cluster.Network.SSL.TLSConfig(),
 	}
 	client := &http.Client{Transport: tr}
-	switch operation {
-	case constants.ClusterGetState, constants.ClusterChangeState, constants.ClusterShutdown:
+	if isPostOperation(operation) {
 		resp, err = client.Post(urlStr, "application/x-www-form-urlencoded", pr)
-	case constants.ClusterVersion:
+	} else {
 		resp, err = client.Get(urlStr)
 	}
 	if err != nil {
@@ -87,6 +105,31 @@ func CallClusterOperationWithState(config *hazelcast.Config, operation string, s
 	return &sb, nil
 }
 
+// DescribeClusterOperation returns the HTTP method and the URL of the request which runs the operation, without sending it.
+func DescribeClusterOperation(config *hazelcast.Config, operation string, state string) (string, error) {
+	obj, err := NewRESTCall(config, operation, state)
+	if err != nil {
+		if errors.Is(err, InvalidStateErr) {
+			err = hzcerrors.NewLoggableError(err, "Invalid new state. It should be one the following: %s, %s, %s, %s\n", constants.ClusterStateActive, constants.ClusterStateFrozen, constants.ClusterStateNoMigration, constants.ClusterStatePassive)
+		}
+		return "", err
+	}
+	method := http.MethodGet
+	if isPostOperation(operation) {
+		method = http.MethodPost
+	}
+	return fmt.Sprintf("%s %s", method, obj.url), nil
+}
+
+// isPostOperation returns true if the operation is sent with the cluster name and the password in a POST request.
+func isPostOperation(operation string) bool {
+	switch operation {
+	case constants.ClusterGetState, constants.ClusterChangeState, constants.ClusterShutdown, constants.ClusterChangeVersion:
+		return true
+	}
+	return constants.IsPersistenceOperation(operation)
+}
+
 func NewRESTCall(conf *hazelcast.Config, operation string, state string) (*RESTCall, error) {
 	var member, url string
 	var params string
@@ -108,6 +151,23 @@ func NewRESTCall(conf *hazelcast.Config, operation string, state string) (*RESTC
 		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterShutdownEndpoint)
 	case constants.ClusterVersion:
 		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterVersionEndpoint)
+	case constants.ClusterChangeVersion:
+		if !IsClusterVersion(state) {
+			return nil, hzcerrors.NewLoggableError(nil, "Invalid cluster version %s. It should be the major and the minor versions, such as 5.2", state)
+		}
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterVersionEndpoint)
+	case constants.ClusterMembers:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterMembersEndpoint)
+	case constants.ClusterLicense:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterLicenseEndpoint)
+	case constants.ClusterBackup:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterBackupEndpoint)
+	case constants.ClusterBackupInterrupt:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterBackupInterruptEndpoint)
+	case constants.ClusterForceStart:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterForceStartEndpoint)
+	case constants.ClusterPartialStart:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterPartialStartEndpoint)
 	default:
 		panic("Invalid operation to set connection obj.")
 	}
@@ -118,11 +178,12 @@ func NewRESTCall(conf *hazelcast.Config, operation string, state string) (*RESTC
 func NewParams(config *hazelcast.Config, operation string, state string) string {
 	var params string
 	switch operation {
-	case constants.ClusterGetState, constants.ClusterShutdown:
+	case constants.ClusterGetState, constants.ClusterShutdown,
+		constants.ClusterBackup, constants.ClusterBackupInterrupt, constants.ClusterForceStart, constants.ClusterPartialStart:
 		params = fmt.Sprintf("%s&%s", config.Cluster.Name, config.Cluster.Security.Credentials.Password)
-	case constants.ClusterChangeState:
+	case constants.ClusterChangeState, constants.ClusterChangeVersion:
 		params = fmt.Sprintf("%s&%s&%s", config.Cluster.Name, config.Cluster.Security.Credentials.Password, state)
-	case constants.ClusterVersion:
+	case constants.ClusterVersion, constants.ClusterMembers, constants.ClusterLicense:
 		params = ""
 	default:
 		panic("invalid operation to set params.")
@@ -130,6 +191,13 @@ func NewParams(config *hazelcast.Config, operation string, state string) string
 	return params
 }
 
+var clusterVersionRe = regexp.MustCompile(`^\d+\.\d+$`)
+
+// IsClusterVersion returns true if the version is a cluster version, which consists of the major and the minor versions.
+func IsClusterVersion(version string) bool {
+	return clusterVersionRe.MatchString(version)
+}
+
 func EnsureState(state string) bool {
 	switch strings.ToLower(state) {
 	case constants.ClusterStateActive, constants.ClusterStateFrozen, constants.ClusterStateNoMigration, constants.ClusterStatePassive:
@@ -139,14 +207,22 @@ func EnsureState(state string) bool {
 }
 
 func ConnectToCluster(ctx context.Context, clientConfig *hazelcast.Config) (cli *hazelcast.Client, err error) {
-	if client != nil {
-		return client, nil
+	if shared := sharedClient(); shared != nil {
+		if state, _, _ := ConnectionStatus(); state != StateShutdown {
+			return shared, checkConnectionStatus()
+		}
+		// the client gave up reconnecting, start over with a new one
+		setSharedClient(nil)
 	}
 	defer func() {
 		obj := recover()
 		if panicErr, ok := obj.(error); ok {
 			err = panicErr
 		}
+		if errors.Is(err, context.Canceled) {
+			err = hzcerrors.NewLoggableError(err, "Connecting to the cluster is cancelled")
+			return
+		}
 		if err != nil {
 			if msg, handled := hzcerrors.TranslateError(err, clientConfig.Cluster.Cloud.Enabled); handled {
 				err = hzcerrors.NewLoggableError(err, msg)
@@ -154,18 +230,167 @@ func ConnectToCluster(ctx context.Context, clientConfig *hazelcast.Config) (cli
 		}
 	}()
 	configCopy := clientConfig.Clone()
+	if configCopy.Logger.CustomLogger == nil {
+		var lg *clientLogger
+		if lg, err = newClientLogger(configCopy.Logger.Level); err != nil {
+			return nil, err
+		}
+		configCopy.Logger = logger.Config{CustomLogger: lg}
+	}
+	trackConnectionStatus(&configCopy)
+	ctx, stop := startConnecting(ctx, clientConfig)
+	defer stop()
 	cli, err = hazelcast.StartNewClientWithConfig(ctx, configCopy)
 	if err == nil {
-		client = cli
+		setSharedClient(cli)
 	}
 	return
 }
 
+// SwitchConnection connects to the cluster of the configuration and makes the new client the shared one.
+// The previous connection is closed only after the new client is connected, so that it is kept if connecting fails.
+// closeErr is the error of closing the previous connection, which does not prevent using the new one.
+func SwitchConnection(ctx context.Context, clientConfig *hazelcast.Config) (closeErr error, err error) {
+	conn.mu.Lock()
+	prevClient, prevDB := conn.client, conn.sqlDriver
+	conn.client, conn.sqlDriver = nil, nil
+	conn.mu.Unlock()
+	prevStatus := saveConnectionStatus()
+	if _, err = ConnectToCluster(ctx, clientConfig); err != nil {
+		conn.mu.Lock()
+		conn.client, conn.sqlDriver = prevClient, prevDB
+		conn.mu.Unlock()
+		restoreConnectionStatus(prevStatus)
+		return nil, err
+	}
+	if prevClient != nil {
+		closeErr = prevClient.Shutdown(ctx)
+	}
+	if prevDB != nil {
+		if dbErr := prevDB.Close(); closeErr == nil {
+			closeErr = dbErr
+		}
+	}
+	return closeErr, nil
+}
+
+// StartClient starts a client which is independent of the one returned by ConnectToCluster, such as for a second cluster.
+// The caller must shut the client down.
+func StartClient(ctx context.Context, clientConfig *hazelcast.Config) (*hazelcast.Client, error) {
+	configCopy := clientConfig.Clone()
+	if configCopy.Logger.CustomLogger == nil {
+		lg, err := newClientLogger(configCopy.Logger.Level)
+		if err != nil {
+			return nil, err
+		}
+		configCopy.Logger = logger.Config{CustomLogger: lg}
+	}
+	ctx, stop := startConnecting(ctx, clientConfig)
+	defer stop()
+	cli, err := hazelcast.StartNewClientWithConfig(ctx, configCopy)
+	if errors.Is(err, context.Canceled) {
+		return nil, hzcerrors.NewLoggableError(err, "Connecting to the cluster is cancelled")
+	}
+	if err != nil {
+		if msg, handled := hzcerrors.TranslateError(err, clientConfig.Cluster.Cloud.Enabled); handled {
+			return nil, hzcerrors.NewLoggableError(err, msg)
+		}
+		return nil, err
+	}
+	return cli, nil
+}
+
+// ClientForConfig returns the shared client of the current configuration if path is empty,
+// otherwise it starts a client with the configuration file at path, such as for a second cluster.
+// The returned func releases the client, the shared one is not shut down.
+func ClientForConfig(ctx context.Context, current *config.Config, path string) (*hazelcast.Client, func(), error) {
+	if path == "" {
+		cli, err := ConnectToCluster(ctx, &current.Hazelcast)
+		return cli, func() {}, err
+	}
+	c, err := config.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	cli, err := StartClient(ctx, &c.Hazelcast)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cli, func() {
+		_ = cli.Shutdown(context.Background())
+	}, nil
+}
+
+// CloseConnection shuts down the client and the SQL driver, the next call to ConnectToCluster or SQLDriver creates new ones.
+func CloseConnection(ctx context.Context) error {
+	conn.mu.Lock()
+	cli, db := conn.client, conn.sqlDriver
+	conn.client, conn.sqlDriver = nil, nil
+	conn.mu.Unlock()
+	var err error
+	if cli != nil {
+		err = cli.Shutdown(ctx)
+	}
+	if db != nil {
+		if closeErr := db.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	resetConnectionStatus()
+	return err
+}
+
 func SQLDriver(ctx context.Context, config *hazelcast.Config) (*sql.DB, error) {
-	if sqlDriver != nil {
-		return sqlDriver, nil
+	conn.mu.Lock()
+	db := conn.sqlDriver
+	conn.mu.Unlock()
+	if db != nil {
+		return db, checkConnectionStatus()
+	}
+	configCopy := config.Clone()
+	if protocolTrace != nil && configCopy.Logger.CustomLogger == nil {
+		// the SQL driver starts its own client, so it is traced separately
+		lg, err := newClientLogger(configCopy.Logger.Level)
+		if err != nil {
+			return nil, err
+		}
+		configCopy.Logger = logger.Config{CustomLogger: lg}
+	}
+	db = driver.Open(configCopy)
+	conn.mu.Lock()
+	conn.sqlDriver = db
+	conn.mu.Unlock()
+	ctx, stop := startConnecting(ctx, config)
+	defer stop()
+	err := db.PingContext(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = hzcerrors.NewLoggableError(err, "Cannot connect to the cluster in %s", timeouts.Connect)
+	} else if errors.Is(err, context.Canceled) {
+		err = hzcerrors.NewLoggableError(err, "Connecting to the cluster is cancelled")
+	}
+	return db, err
+}
+
+// startConnecting applies the connect timeout to the context and shows a spinner with the target of the connection.
+// The returned stop func must be called after the connection attempt.
+func startConnecting(ctx context.Context, conf *hazelcast.Config) (context.Context, func()) {
+	ctx, cancel := connectContext(ctx)
+	spinner := progress.NewSpinner(os.Stderr, fmt.Sprintf("Connecting to %s, press Ctrl+C to cancel", connectionTarget(conf)))
+	return ctx, func() {
+		spinner.Stop()
+		cancel()
+	}
+}
+
+// connectionTarget describes the cluster and the addresses to connect to.
+func connectionTarget(conf *hazelcast.Config) string {
+	c := &conf.Cluster
+	if c.Cloud.Enabled {
+		return fmt.Sprintf("Hazelcast Cloud cluster %s", c.Name)
+	}
+	addrs := c.Network.Addresses
+	if len(addrs) == 0 {
+		addrs = []string{config.DefaultClusterAddress}
 	}
-	sqlDriver = driver.Open(*config)
-	err := sqlDriver.PingContext(ctx)
-	return sqlDriver, err
+	return fmt.Sprintf("cluster %s at %s", c.Name, strings.Join(addrs, ", "))
 }
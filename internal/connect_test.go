@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/logger"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestDescribeClusterOperation(t *testing.T) {
+	conf := hazelcast.NewConfig()
+	conf.Cluster.Name = "dev"
+	conf.Cluster.Network.SetAddresses("10.0.0.1:5701")
+	for _, tc := range []struct {
+		operation string
+		state     string
+		want      string
+	}{
+		{operation: "get-state", want: "POST http://10.0.0.1:5701/hazelcast/rest/management/cluster/state"},
+		{operation: "version", want: "GET http://10.0.0.1:5701/hazelcast/rest/management/cluster/version"},
+		{operation: "change-version", state: "5.2", want: "POST http://10.0.0.1:5701/hazelcast/rest/management/cluster/version"},
+		{operation: "backup", want: "POST http://10.0.0.1:5701/hazelcast/rest/management/cluster/hotBackup"},
+		{operation: "backup-interrupt", want: "POST http://10.0.0.1:5701/hazelcast/rest/management/cluster/hotBackupInterrupt"},
+		{operation: "force-start", want: "POST http://10.0.0.1:5701/hazelcast/rest/management/cluster/forceStart"},
+		{operation: "partial-start", want: "POST http://10.0.0.1:5701/hazelcast/rest/management/cluster/partialStart"},
+	} {
+		t.Run(tc.operation, func(t *testing.T) {
+			got, err := DescribeClusterOperation(&conf, tc.operation, tc.state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("want %s got %s", tc.want, got)
+			}
+		})
+	}
+	if params := NewParams(&conf, "force-start", ""); params != "dev&" {
+		t.Errorf("want the cluster name and the password got %s", params)
+	}
+	for _, v := range []string{"5", "5.2.1", "v5.2", ""} {
+		if _, err := DescribeClusterOperation(&conf, "change-version", v); err == nil {
+			t.Errorf("version %q must be an error", v)
+		}
+	}
+}
+
+func TestSwitchConnectionKeepsStatusOnError(t *testing.T) {
+	defer resetConnectionStatus()
+	conf := hazelcast.NewConfig()
+	trackConnectionStatus(&conf)
+	atomic.StoreInt32(&connStatus.state, int32(StateConnected))
+	atomic.StoreInt32(&connStatus.members, 1)
+	setClusterMember(cluster.MemberInfo{UUID: types.NewUUID(), Address: "10.0.0.1:5701"}, true)
+	want := saveConnectionStatus()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	conf.Cluster.Network.SetAddresses("10.0.0.2:5701")
+	conf.Logger.Level = logger.ErrorLevel
+	if _, err := SwitchConnection(ctx, &conf); err == nil {
+		t.Fatal("expected an error for the cancelled context")
+	}
+	if got := saveConnectionStatus(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v got %+v", want, got)
+	}
+	if gen := atomic.LoadInt32(&connStatus.lastGeneration); gen <= want.generation {
+		t.Errorf("the generation of the failed client must not be given again, last %d", gen)
+	}
+}
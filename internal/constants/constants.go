@@ -25,6 +25,23 @@ const (
 	ClusterChangeStateEndpoint = "/hazelcast/rest/management/cluster/changeState"
 	ClusterShutdownEndpoint    = "/hazelcast/rest/management/cluster/clusterShutdown"
 	ClusterVersionEndpoint     = "/hazelcast/rest/management/cluster/version"
+	ClusterMembersEndpoint     = "/hazelcast/rest/cluster"
+	ClusterLicenseEndpoint     = "/hazelcast/rest/license"
+	// persistence endpoints, the hot restart names are kept by the members
+	ClusterBackupEndpoint          = "/hazelcast/rest/management/cluster/hotBackup"
+	ClusterBackupInterruptEndpoint = "/hazelcast/rest/management/cluster/hotBackupInterrupt"
+	ClusterForceStartEndpoint      = "/hazelcast/rest/management/cluster/forceStart"
+	ClusterPartialStartEndpoint    = "/hazelcast/rest/management/cluster/partialStart"
+)
+
+// Management Center REST API endpoints, formatted with the cluster name
+const (
+	MCClusterStateEndpointFmt   = "/rest/clusters/%s/state"
+	MCClusterMembersEndpointFmt = "/rest/clusters/%s/members"
+	// formatted with the cluster and the map names
+	MCMapConfigEndpointFmt = "/rest/clusters/%s/maps/%s/config"
+	// formatted with the cluster name, the member address and the map name
+	MCMemberMapStatsEndpointFmt = "/rest/clusters/%s/members/%s/maps/%s"
 )
 
 const (
@@ -32,8 +49,26 @@ const (
 	ClusterChangeState = "change-state"
 	ClusterShutdown    = "shutdown"
 	ClusterVersion     = "version"
+	ClusterMembers     = "members"
+	// ClusterChangeVersion is sent to the same endpoint as ClusterVersion, with the new version
+	ClusterChangeVersion = "change-version"
+	ClusterLicense       = "license"
+	// persistence operations
+	ClusterBackup          = "backup"
+	ClusterBackupInterrupt = "backup-interrupt"
+	ClusterForceStart      = "force-start"
+	ClusterPartialStart    = "partial-start"
 )
 
+// IsPersistenceOperation returns true if the operation manages the persistence of the cluster.
+func IsPersistenceOperation(operation string) bool {
+	switch operation {
+	case ClusterBackup, ClusterBackupInterrupt, ClusterForceStart, ClusterPartialStart:
+		return true
+	}
+	return false
+}
+
 const (
 	ClusterStateActive      = "active"
 	ClusterStateNoMigration = "no_migration"
@@ -1,13 +1,19 @@
 package internal
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
 )
 
 // supported types
@@ -21,8 +27,31 @@ const (
 	TypeNameInt64   = "int64"
 	TypeNameFloat32 = "float32"
 	TypeNameFloat64 = "float64"
+	TypeNameDecimal = "decimal"
+	// TypeNameBigInt is an integer of any size, which is a java.math.BigInteger in the cluster
+	TypeNameBigInt = "bigint"
+	// TypeNameDate is a date without time zone, such as 2022-06-27
+	TypeNameDate = "date"
+	// TypeNameTime is a time without date and time zone, such as 15:04:05
+	TypeNameTime = "time"
+	// TypeNameTimestamp is a date and time without time zone, such as 2022-06-27T15:04:05
+	TypeNameTimestamp = "timestamp"
+	// TypeNameTimestampTZ is a date and time with time zone, such as 2022-06-27T15:04:05+03:00
+	TypeNameTimestampTZ = "timestamptz"
+	TypeNameUUID        = "uuid"
+	// TypeNameBinary is a byte array, given in base64 such as AQID
+	TypeNameBinary = "binary"
 )
 
+// layouts to parse the temporal types, fractional seconds are optional
+const (
+	LayoutDate      = "2006-01-02"
+	LayoutTime      = "15:04:05.999999999"
+	LayoutTimestamp = "2006-01-02T15:04:05.999999999"
+)
+
+var decimalPattern = regexp.MustCompile(`^([+-]?)(\d+)(?:\.(\d+))?$`)
+
 var SupportedTypeNames = []string{
 	TypeNameString,
 	TypeNameBoolean,
@@ -33,6 +62,14 @@ var SupportedTypeNames = []string{
 	TypeNameInt64,
 	TypeNameFloat32,
 	TypeNameFloat64,
+	TypeNameDecimal,
+	TypeNameBigInt,
+	TypeNameDate,
+	TypeNameTime,
+	TypeNameTimestamp,
+	TypeNameTimestampTZ,
+	TypeNameUUID,
+	TypeNameBinary,
 }
 
 func ConvertString(value, valueType string) (interface{}, error) {
@@ -69,6 +106,24 @@ func ConvertString(value, valueType string) (interface{}, error) {
 		cv = float32(f)
 	case TypeNameFloat64:
 		cv, err = strconv.ParseFloat(value, 64)
+	case TypeNameDecimal:
+		cv, err = parseDecimal(value)
+	case TypeNameBigInt:
+		cv, err = parseBigInt(value)
+	case TypeNameDate:
+		cv, err = parseTime(LayoutDate, value, func(t time.Time) interface{} { return types.LocalDate(t) })
+	case TypeNameTime:
+		cv, err = parseTime(LayoutTime, value, func(t time.Time) interface{} { return types.LocalTime(t) })
+	case TypeNameTimestamp:
+		cv, err = parseTime(LayoutTimestamp, value, func(t time.Time) interface{} { return types.LocalDateTime(t) })
+	case TypeNameTimestampTZ:
+		cv, err = parseTime(time.RFC3339Nano, value, func(t time.Time) interface{} { return types.OffsetDateTime(t) })
+	case TypeNameUUID:
+		cv, err = parseUUID(value)
+	case TypeNameBinary:
+		if cv, err = base64.StdEncoding.DecodeString(strings.TrimSpace(value)); err != nil {
+			err = fmt.Errorf(`can not convert "%s" to %s, expected base64`, value, TypeNameBinary)
+		}
 	default:
 		err = fmt.Errorf("unknown type, provide one of %s", strings.Join(SupportedTypeNames, ","))
 	}
@@ -79,3 +134,45 @@ func ConvertString(value, valueType string) (interface{}, error) {
 	}
 	return cv, err
 }
+
+func parseDecimal(value string) (types.Decimal, error) {
+	m := decimalPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return types.Decimal{}, fmt.Errorf(`can not convert "%s" to %s, expected a number such as -12.345`, value, TypeNameDecimal)
+	}
+	unscaled, ok := new(big.Int).SetString(m[1]+m[2]+m[3], 10)
+	if !ok {
+		return types.Decimal{}, fmt.Errorf(`can not convert "%s" to %s`, value, TypeNameDecimal)
+	}
+	return types.NewDecimal(unscaled, len(m[3])), nil
+}
+
+func parseBigInt(value string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(strings.TrimSpace(value), 10)
+	if !ok {
+		return nil, fmt.Errorf(`can not convert "%s" to %s, expected an integer such as 123456789012345678901234567890`, value, TypeNameBigInt)
+	}
+	return n, nil
+}
+
+func parseTime(layout, value string, convert func(t time.Time) interface{}) (interface{}, error) {
+	t, err := time.Parse(layout, strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf(`can not convert "%s" to a value with the layout %s`, value, layout)
+	}
+	return convert(t), nil
+}
+
+func parseUUID(value string) (types.UUID, error) {
+	s := strings.ReplaceAll(strings.TrimSpace(value), "-", "")
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 16 {
+		return types.UUID{}, fmt.Errorf(`can not convert "%s" to %s, expected a value such as 123e4567-e89b-12d3-a456-426614174000`, value, TypeNameUUID)
+	}
+	var most, least uint64
+	for i := 0; i < 8; i++ {
+		most = most<<8 | uint64(b[i])
+		least = least<<8 | uint64(b[i+8])
+	}
+	return types.NewUUIDWith(most, least), nil
+}
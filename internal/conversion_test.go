@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestConvertString(t *testing.T) {
+	for _, tc := range []struct {
+		value     string
+		valueType string
+		want      interface{}
+		isErr     bool
+	}{
+		{value: "-12", valueType: TypeNameInt8, want: int8(-12)},
+		{value: "1.5", valueType: TypeNameFloat32, want: float32(1.5)},
+		{value: "true", valueType: TypeNameBoolean, want: true},
+		{value: "-12.345", valueType: TypeNameDecimal, want: types.NewDecimal(big.NewInt(-12345), 3)},
+		{value: "42", valueType: TypeNameDecimal, want: types.NewDecimal(big.NewInt(42), 0)},
+		{value: "1e3", valueType: TypeNameDecimal, isErr: true},
+		{value: "12345678901234567890.123456789", valueType: TypeNameDecimal, want: types.NewDecimal(bigInt("12345678901234567890123456789"), 9)},
+		{value: "-98765432109876543210987654321", valueType: TypeNameBigInt, want: bigInt("-98765432109876543210987654321")},
+		{value: "1.5", valueType: TypeNameBigInt, isErr: true},
+		{value: "2022-06-27", valueType: TypeNameDate, want: types.LocalDate(time.Date(2022, 6, 27, 0, 0, 0, 0, time.UTC))},
+		{value: "15:04:05.5", valueType: TypeNameTime, want: types.LocalTime(time.Date(0, 1, 1, 15, 4, 5, 500000000, time.UTC))},
+		{value: "2022-06-27T15:04:05", valueType: TypeNameTimestamp, want: types.LocalDateTime(time.Date(2022, 6, 27, 15, 4, 5, 0, time.UTC))},
+		{value: "27/06/2022", valueType: TypeNameDate, isErr: true},
+		{value: "123e4567-e89b-12d3-a456-426614174000", valueType: TypeNameUUID, want: types.NewUUIDWith(0x123e4567e89b12d3, 0xa456426614174000)},
+		{value: "123e4567", valueType: TypeNameUUID, isErr: true},
+		{value: "AQID", valueType: TypeNameBinary, want: []byte{1, 2, 3}},
+		{value: "AQ!D", valueType: TypeNameBinary, isErr: true},
+	} {
+		t.Run(tc.valueType+" "+tc.value, func(t *testing.T) {
+			got, err := ConvertString(tc.value, tc.valueType)
+			if (err != nil) != tc.isErr {
+				t.Fatalf("want error %t got %v", tc.isErr, err)
+			}
+			if tc.isErr {
+				return
+			}
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %v got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestConvertString_TimestampTZ(t *testing.T) {
+	got, err := ConvertString("2022-06-27T15:04:05+03:00", TypeNameTimestampTZ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2022, 6, 27, 12, 4, 5, 0, time.UTC)
+	if !time.Time(got.(types.OffsetDateTime)).Equal(want) {
+		t.Errorf("want %v got %v", want, got)
+	}
+}
+
+func bigInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid integer " + s)
+	}
+	return n
+}
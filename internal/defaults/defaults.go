@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package defaults
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+)
+
+const (
+	// ConfirmFlag is the name of the flag of the commands which ask before the irreversible changes
+	ConfirmFlag = "confirm"
+	// OutputTypeFlag is the name of the flag of the commands which can print their results in several formats
+	OutputTypeFlag = "output-type"
+	// ValuesAnnotation is the flag annotation which lists the values of the flag, the defaults which are not among them are not used
+	ValuesAnnotation = "defaultvalues"
+)
+
+// SetValues sets the values the flag accepts, such as the output types of the command.
+func SetValues(cmd *cobra.Command, flag string, values []string) {
+	if err := cmd.Flags().SetAnnotation(flag, ValuesAnnotation, values); err != nil {
+		panic(err)
+	}
+}
+
+// EnableDefaults sets the defaults of the flags in the tree to the ones in the configuration.
+// The default is only changed, so the flags given on the command line still override it.
+func EnableDefaults(root *cobra.Command, conf *config.Config) {
+	defaults := map[string]string{ConfirmFlag: strconv.FormatBool(conf.Defaults.Confirm)}
+	if conf.Defaults.OutputType != "" {
+		defaults[OutputTypeFlag] = conf.Defaults.OutputType
+	}
+	setDefaults(root, defaults)
+}
+
+func setDefaults(cmd *cobra.Command, defaults map[string]string) {
+	for _, c := range cmd.Commands() {
+		setDefaults(c, defaults)
+	}
+	for name, value := range defaults {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || !isAccepted(f, value) {
+			continue
+		}
+		if err := f.Value.Set(value); err != nil {
+			continue
+		}
+		f.DefValue = value
+	}
+}
+
+func isAccepted(f *pflag.Flag, value string) bool {
+	values, ok := f.Annotations[ValuesAnnotation]
+	if !ok {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package defaults
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+)
+
+func TestEnableDefaults(t *testing.T) {
+	for _, tc := range []struct {
+		info       string
+		defaults   config.DefaultsConfig
+		args       []string
+		outputType string
+		confirm    bool
+	}{
+		{info: "command defaults", args: []string{"sub"}, outputType: "pretty"},
+		{info: "configured defaults", defaults: config.DefaultsConfig{OutputType: "json", Confirm: true}, args: []string{"sub"}, outputType: "json", confirm: true},
+		{info: "flags override", defaults: config.DefaultsConfig{OutputType: "json", Confirm: true}, args: []string{"sub", "-o", "csv", "--confirm=false"}, outputType: "csv"},
+		{info: "unsupported output type", defaults: config.DefaultsConfig{OutputType: "vertical"}, args: []string{"sub"}, outputType: "pretty"},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			var outputType string
+			var confirm bool
+			root := &cobra.Command{Use: "root", SilenceErrors: true, SilenceUsage: true}
+			sub := &cobra.Command{Use: "sub", RunE: func(cmd *cobra.Command, args []string) error {
+				return nil
+			}}
+			sub.Flags().StringVarP(&outputType, OutputTypeFlag, "o", "pretty", "")
+			SetValues(sub, OutputTypeFlag, []string{"pretty", "csv", "json"})
+			sub.Flags().BoolVar(&confirm, ConfirmFlag, true, "")
+			root.AddCommand(sub)
+			EnableDefaults(root, &config.Config{Defaults: tc.defaults})
+			root.SetArgs(tc.args)
+			assert.NoError(t, root.Execute())
+			assert.Equal(t, tc.outputType, outputType)
+			assert.Equal(t, tc.confirm, confirm)
+		})
+	}
+}
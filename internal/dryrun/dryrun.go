@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dryrun
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+const (
+	// Flag is the name of the global flag which enables the dry run mode
+	Flag = "dry-run"
+	// Annotation marks the commands which support the dry run mode
+	Annotation = "dryrun"
+)
+
+// Supported marks the command as supporting the dry run mode.
+// Such commands must check Enabled and print what they would execute instead of executing it.
+func Supported(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[Annotation] = "true"
+	return cmd
+}
+
+// Enabled returns true if the dry run flag is given for the command.
+func Enabled(cmd *cobra.Command) bool {
+	f := cmd.Flag(Flag)
+	return f != nil && f.Value.String() == "true"
+}
+
+// Print writes the description of the operation which would be executed to the output of the command.
+func Print(cmd *cobra.Command, format string, args ...interface{}) {
+	fmt.Fprintf(cmd.OutOrStdout(), "Dry run, would %s\n", fmt.Sprintf(format, args...))
+}
+
+// EnableDryRun wraps the commands in the tree so that the commands which do not support the dry run mode
+// return an error if the flag is given, instead of executing the operation.
+func EnableDryRun(root *cobra.Command) {
+	for _, c := range root.Commands() {
+		EnableDryRun(c)
+	}
+	if root.Run != nil && root.RunE == nil {
+		run := root.Run
+		root.RunE = func(cmd *cobra.Command, args []string) error {
+			run(cmd, args)
+			return nil
+		}
+		root.Run = nil
+	}
+	if root.RunE == nil {
+		return
+	}
+	runE := root.RunE
+	root.RunE = func(cmd *cobra.Command, args []string) error {
+		if Enabled(cmd) && cmd.Annotations[Annotation] != "true" {
+			return hzcerrors.NewLoggableError(nil, "%s does not support --%s", cmd.CommandPath(), Flag)
+		}
+		return runE(cmd, args)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dryrun
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestEnableDryRun(t *testing.T) {
+	for _, tc := range []struct {
+		info      string
+		supported bool
+		args      []string
+		ran       bool
+		isErr     bool
+	}{
+		{info: "without the flag", args: []string{"sub"}, ran: true},
+		{info: "supported", supported: true, args: []string{"sub", "--dry-run"}, ran: true},
+		{info: "not supported", args: []string{"sub", "--dry-run"}, isErr: true},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			var ran bool
+			root := &cobra.Command{Use: "root", SilenceErrors: true, SilenceUsage: true}
+			root.PersistentFlags().Bool(Flag, false, "")
+			sub := &cobra.Command{Use: "sub", Run: func(cmd *cobra.Command, args []string) {
+				ran = true
+			}}
+			if tc.supported {
+				Supported(sub)
+			}
+			root.AddCommand(sub)
+			EnableDryRun(root)
+			root.SetArgs(tc.args)
+			err := root.Execute()
+			if tc.isErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ran != tc.ran {
+				t.Errorf("want ran %t got %t", tc.ran, ran)
+			}
+		})
+	}
+}
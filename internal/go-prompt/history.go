@@ -1,5 +1,7 @@
 package prompt
 
+import "strings"
+
 // History stores the texts that are entered.
 type History struct {
 	Commands         []string
@@ -51,6 +53,19 @@ func (h *History) Newer(buf *Buffer) (new *Buffer, changed bool) {
 	return new, true
 }
 
+// Suggest returns the rest of the most recent text in History which starts with the prefix, or an empty string if there is none.
+func (h *History) Suggest(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	for i := len(h.Commands) - 1; i >= 0; i-- {
+		if c := h.Commands[i]; len(c) > len(prefix) && strings.HasPrefix(c, prefix) {
+			return c[len(prefix):]
+		}
+	}
+	return ""
+}
+
 // NewHistory returns new History object.
 func NewHistory() *History {
 	return &History{
@@ -0,0 +1,59 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory_Suggest(t *testing.T) {
+	h := NewHistory()
+	for _, c := range []string{"map get --key a", "map put --key a --value b", "map get --key b", "sql"} {
+		h.Add(c)
+	}
+	testCases := []struct {
+		prefix     string
+		suggestion string
+	}{
+		{prefix: "map", suggestion: " get --key b"},
+		{prefix: "map p", suggestion: "ut --key a --value b"},
+		{prefix: "map get --key a", suggestion: ""},
+		{prefix: "sql", suggestion: ""},
+		{prefix: "cluster", suggestion: ""},
+		{prefix: "", suggestion: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.prefix, func(t *testing.T) {
+			assert.Equal(t, tc.suggestion, h.Suggest(tc.prefix))
+		})
+	}
+}
+
+func TestPrompt_FeedAcceptsHistorySuggestion(t *testing.T) {
+	newPrompt := func() *Prompt {
+		p := &Prompt{
+			buf:        NewBuffer(),
+			renderer:   &Render{},
+			History:    NewHistory(),
+			completion: NewCompletionManager(func(Document) []Suggest { return nil }, 6),
+		}
+		assert.NoError(t, OptionHistorySuggestion()(p))
+		p.History.Add("map get --key a")
+		return p
+	}
+	right := []byte{0x1b, 0x5b, 0x43}
+	end := []byte{0x1b, 0x5b, 0x46}
+	for _, key := range [][]byte{right, end} {
+		p := newPrompt()
+		p.Feed([]byte("map g"))
+		p.Feed(key)
+		assert.Equal(t, "map get --key a", p.buf.Text())
+	}
+	// only accepted at the end of the input, otherwise the cursor moves
+	p := newPrompt()
+	p.Feed([]byte("map g"))
+	p.buf.CursorLeft(1)
+	p.Feed(right)
+	assert.Equal(t, "map g", p.buf.Text())
+	assert.Equal(t, "", p.buf.Document().TextAfterCursor())
+}
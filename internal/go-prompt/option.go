@@ -61,6 +61,46 @@ func OptionLivePrefix(f func() (prefix string, useLivePrefix bool)) Option {
 	}
 }
 
+// OptionStatusBar to show a status line below the input, it is hidden while the suggestions are shown
+func OptionStatusBar(f func() string) Option {
+	return func(p *Prompt) error {
+		p.renderer.statusBarCallback = f
+		return nil
+	}
+}
+
+// OptionHistorySuggestion to show the rest of the most recent matching History entry after the input, it is accepted with Right or End
+func OptionHistorySuggestion() Option {
+	return func(p *Prompt) error {
+		p.renderer.historySuggestionCallback = p.historySuggestion
+		return nil
+	}
+}
+
+// OptionHistorySuggestionTextColor to change a text color of the History suggestion
+func OptionHistorySuggestionTextColor(x Color) Option {
+	return func(p *Prompt) error {
+		p.renderer.historySuggestionTextColor = x
+		return nil
+	}
+}
+
+// OptionStatusBarTextColor to change a text color of status bar
+func OptionStatusBarTextColor(x Color) Option {
+	return func(p *Prompt) error {
+		p.renderer.statusBarTextColor = x
+		return nil
+	}
+}
+
+// OptionStatusBarBGColor to change a background color of status bar
+func OptionStatusBarBGColor(x Color) Option {
+	return func(p *Prompt) error {
+		p.renderer.statusBarBGColor = x
+		return nil
+	}
+}
+
 // OptionPrefixTextColor change a text color of prefix string
 func OptionPrefixTextColor(x Color) Option {
 	return func(p *Prompt) error {
@@ -266,6 +306,17 @@ func OptionSetExitCheckerOnInput(fn ExitChecker) Option {
 	}
 }
 
+// OptionPalette to open a command palette with the key.
+// The items are fuzzy filtered with the input and the selected one, or the first match, is executed with Enter.
+func OptionPalette(key Key, prefix string, items func() []Suggest) Option {
+	return func(p *Prompt) error {
+		p.paletteKey = key
+		p.palettePrefix = prefix
+		p.paletteItems = items
+		return nil
+	}
+}
+
 // New returns a Prompt with powerful auto-completion.
 func New(executor Executor, completer Completer, opts ...Option) *Prompt {
 	defaultWriter := NewStdoutWriter()
@@ -293,6 +344,9 @@ func New(executor Executor, completer Completer, opts ...Option) *Prompt {
 			selectedDescriptionBGColor:   Cyan,
 			scrollbarThumbColor:          DarkGray,
 			scrollbarBGColor:             Cyan,
+			statusBarTextColor:           White,
+			statusBarBGColor:             DarkGray,
+			historySuggestionTextColor:   DarkGray,
 		},
 		buf:         NewBuffer(),
 		executor:    executor,
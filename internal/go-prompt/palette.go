@@ -0,0 +1,104 @@
+package prompt
+
+import (
+	"sort"
+	"strings"
+)
+
+// palette keeps the state of the prompt which is restored when the command palette is closed.
+type palette struct {
+	buf       *Buffer
+	completer Completer
+}
+
+func (p *Prompt) openPalette() {
+	items := p.paletteItems()
+	p.palette = &palette{buf: p.buf, completer: p.completion.completer}
+	p.buf = NewBuffer()
+	p.completion.completer = func(d Document) []Suggest {
+		return filterPalette(items, d.Text)
+	}
+	p.completion.Reset()
+	p.renderer.palettePrefix = p.palettePrefix
+}
+
+func (p *Prompt) closePalette() {
+	p.buf = p.palette.buf
+	p.completion.completer = p.palette.completer
+	p.completion.Reset()
+	p.renderer.palettePrefix = ""
+	p.palette = nil
+}
+
+func (p *Prompt) feedPalette(key Key, b []byte) (shouldExit bool, exec *Exec) {
+	switch key {
+	case Enter, ControlJ, ControlM:
+		s, ok := p.completion.GetSelectedSuggestion()
+		if all := p.completion.GetSuggestions(); !ok && len(all) > 0 {
+			s, ok = all[0], true
+		}
+		p.closePalette()
+		if !ok {
+			return
+		}
+		p.buf = NewBuffer()
+		p.buf.InsertText(s.Text, false, true)
+		p.renderer.BreakLine(p.buf)
+		exec = &Exec{input: s.Text}
+		p.buf = NewBuffer()
+		p.History.Add(s.Text)
+		return
+	case Escape, ControlC, p.paletteKey:
+		p.closePalette()
+		return
+	case Down, Tab, ControlI, ControlN:
+		p.completion.Next()
+		return
+	case Up, BackTab:
+		p.completion.Previous()
+		return
+	case NotDefined:
+		p.buf.InsertText(string(b), false, true)
+	default:
+		// only the editing key bindings, the custom ones act on the prompt
+		for _, kb := range commonKeyBindings {
+			if kb.Key == key {
+				kb.Fn(p.buf)
+			}
+		}
+		if p.keyBindMode == EmacsKeyBind {
+			for _, kb := range emacsKeyBindings {
+				if kb.Key == key {
+					kb.Fn(p.buf)
+				}
+			}
+		}
+	}
+	// the filter changed, start from the first match
+	p.completion.selected = -1
+	p.completion.verticalScroll = 0
+	return
+}
+
+// filterPalette fuzzy filters the items, the ones which start with the input come first and then the ones which contain it.
+func filterPalette(items []Suggest, sub string) []Suggest {
+	if sub == "" {
+		return items
+	}
+	matches := FilterFuzzy(items, sub, true)
+	sub = strings.ToUpper(sub)
+	rank := func(s Suggest) int {
+		text := strings.ToUpper(s.Text)
+		switch {
+		case strings.HasPrefix(text, sub):
+			return 0
+		case strings.Contains(text, sub):
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return rank(matches[i]) < rank(matches[j])
+	})
+	return matches
+}
@@ -35,6 +35,10 @@ type Prompt struct {
 	completionOnDown  bool
 	exitChecker       ExitChecker
 	skipTearDown      bool
+	paletteKey        Key
+	palettePrefix     string
+	paletteItems      func() []Suggest
+	palette           *palette
 }
 
 // Exec is the struct contains user input context.
@@ -115,6 +119,13 @@ func (p *Prompt) Run() {
 func (p *Prompt) Feed(b []byte) (shouldExit bool, exec *Exec) {
 	key := GetKey(b)
 	p.buf.lastKeyStroke = key
+	if p.palette != nil {
+		return p.feedPalette(key, b)
+	}
+	if p.paletteItems != nil && key == p.paletteKey {
+		p.openPalette()
+		return
+	}
 	// completion
 	completing := p.completion.Completing()
 	p.handleCompletionKeyBinding(key, completing)
@@ -151,6 +162,11 @@ func (p *Prompt) Feed(b []byte) (shouldExit bool, exec *Exec) {
 			}
 			return
 		}
+	case Right, End:
+		if s := p.historySuggestion(p.buf); s != "" && !completing {
+			p.buf.InsertText(s, false, true)
+			return
+		}
 	case ControlD:
 		if p.buf.Text() == "" {
 			shouldExit = true
@@ -167,6 +183,14 @@ func (p *Prompt) Feed(b []byte) (shouldExit bool, exec *Exec) {
 	return
 }
 
+// historySuggestion returns the rest of the most recent History entry which starts with the input, if the cursor is at the end of it.
+func (p *Prompt) historySuggestion(buf *Buffer) string {
+	if p.renderer.historySuggestionCallback == nil || p.palette != nil || buf.Document().TextAfterCursor() != "" {
+		return ""
+	}
+	return p.History.Suggest(buf.Text())
+}
+
 func (p *Prompt) handleCompletionKeyBinding(key Key, completing bool) {
 	switch key {
 	case Down:
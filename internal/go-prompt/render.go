@@ -14,9 +14,14 @@ type Render struct {
 	prefix             string
 	livePrefixCallback func() (prefix string, useLivePrefix bool)
 	breakLineCallback  func(*Document)
-	title              string
-	row                uint16
-	col                uint16
+	statusBarCallback  func() string
+	// historySuggestionCallback returns the text shown dimmed after the input, it is nil if History is not suggested
+	historySuggestionCallback func(*Buffer) string
+	// palettePrefix is shown instead of the prefix while the command palette is open
+	palettePrefix string
+	title         string
+	row           uint16
+	col           uint16
 
 	previousCursor int
 
@@ -37,6 +42,9 @@ type Render struct {
 	selectedDescriptionBGColor   Color
 	scrollbarThumbColor          Color
 	scrollbarBGColor             Color
+	statusBarTextColor           Color
+	statusBarBGColor             Color
+	historySuggestionTextColor   Color
 }
 
 // Setup to initialize console output.
@@ -50,6 +58,9 @@ func (r *Render) Setup() {
 // getCurrentPrefix to get current prefix.
 // If live-prefix is enabled, return live-prefix.
 func (r *Render) getCurrentPrefix() string {
+	if r.palettePrefix != "" {
+		return r.palettePrefix
+	}
 	if prefix, ok := r.livePrefixCallback(); ok {
 		return prefix
 	}
@@ -167,6 +178,31 @@ func (r *Render) renderCompletion(buf *Buffer, completions *CompletionManager) {
 	r.out.SetColor(DefaultColor, DefaultColor, false)
 }
 
+// renderStatusBar renders the status line below the last line of the input and moves the cursor back.
+func (r *Render) renderStatusBar(cursor, end int) {
+	if r.statusBarCallback == nil {
+		return
+	}
+	status := r.statusBarCallback()
+	if status == "" {
+		return
+	}
+	status = runewidth.Truncate(status, int(r.col)-1, "")
+	x, y := r.toPos(cursor)
+	_, endY := r.toPos(end)
+	down := endY - y + 1
+	r.out.CursorDown(endY - y)
+	r.prepareArea(1)
+	r.out.CursorDown(1)
+	r.out.CursorBackward(x)
+	r.out.SetColor(r.statusBarTextColor, r.statusBarBGColor, false)
+	r.out.WriteStr(status)
+	r.out.SetColor(DefaultColor, DefaultColor, false)
+	r.out.CursorUp(down)
+	r.out.CursorBackward(runewidth.StringWidth(status))
+	r.out.CursorForward(x)
+}
+
 // Render renders to the console.
 func (r *Render) Render(buffer *Buffer, completion *CompletionManager) {
 	// In situations where a pseudo tty is allocated (e.g. within a docker container),
@@ -198,14 +234,30 @@ func (r *Render) Render(buffer *Buffer, completion *CompletionManager) {
 	r.out.SetColor(r.inputTextColor, r.inputBGColor, false)
 	r.out.WriteStr(line)
 	r.out.SetColor(DefaultColor, DefaultColor, false)
+	// the selected completion is previewed instead of the History suggestion
+	var suggestion string
+	if _, ok := completion.GetSelectedSuggestion(); !ok && r.historySuggestionCallback != nil {
+		suggestion = r.historySuggestionCallback(buffer)
+	}
+	if suggestion != "" {
+		r.out.SetColor(r.historySuggestionTextColor, DefaultColor, false)
+		r.out.WriteStr(suggestion)
+		r.out.SetColor(DefaultColor, DefaultColor, false)
+		cursor += runewidth.StringWidth(suggestion)
+	}
 	r.lineWrap(cursor)
 
 	r.out.EraseDown()
 
-	cursor = r.backward(cursor, runewidth.StringWidth(line)-buffer.DisplayCursorPosition())
+	end := cursor
+	cursor = r.backward(cursor, runewidth.StringWidth(line)+runewidth.StringWidth(suggestion)-buffer.DisplayCursorPosition())
 
+	if len(completion.GetSuggestions()) == 0 {
+		r.renderStatusBar(cursor, end)
+	}
 	r.renderCompletion(buffer, completion)
-	if suggest, ok := completion.GetSelectedSuggestion(); ok {
+	// the palette input is a filter, the selected item is not previewed in place of it
+	if suggest, ok := completion.GetSelectedSuggestion(); ok && r.palettePrefix == "" {
 		cursor = r.backward(cursor, runewidth.StringWidth(buffer.Document().GetWordBeforeCursorUntilSeparator(completion.wordSeparator)))
 
 		r.out.SetColor(r.previewSuggestionTextColor, r.previewSuggestionBGColor, false)
@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+// the enterprise features which the commands of the CLI require
+const (
+	FeaturePersistence    = "Persistence"
+	FeatureRollingUpgrade = "Rolling Upgrade"
+)
+
+// LicenseInfo is the Hazelcast Enterprise license of the cluster, as reported by the license endpoint of the members.
+type LicenseInfo struct {
+	Type         string
+	CompanyName  string
+	OwnerEmail   string
+	MaxNodeCount int
+	// ExpiryDate is zero if the members do not report it
+	ExpiryDate time.Time
+	// Features are the enterprise features of the license, it is nil if the members do not report them
+	Features []string
+}
+
+// Expired returns true if the license has expired at the given time.
+func (l *LicenseInfo) Expired(now time.Time) bool {
+	return !l.ExpiryDate.IsZero() && now.After(l.ExpiryDate)
+}
+
+// HasFeature returns false only if the features are reported and the feature is not one of them.
+func (l *LicenseInfo) HasFeature(feature string) bool {
+	if l.Features == nil {
+		return true
+	}
+	for _, f := range l.Features {
+		if strings.EqualFold(f, feature) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLicenseInfo returns the license of the cluster with the REST API of the members.
+// It returns nil without an error if the cluster does not have a license, which is the case for the open source members.
+func GetLicenseInfo(conf *hazelcast.Config) (*LicenseInfo, error) {
+	obj, err := NewRESTCall(conf, constants.ClusterLicense, "")
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: conf.Cluster.Network.SSL.TLSConfig()}}
+	resp, err := client.Get(obj.url)
+	if err != nil {
+		if msg, handled := hzcerrors.TranslateError(err, conf.Cluster.Cloud.Enabled, constants.ClusterLicense); handled {
+			return nil, hzcerrors.NewLoggableError(err, msg)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, hzcerrors.NewLoggableError(err, "Could not read the response from the cluster")
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err = fmt.Errorf("unexpected status: %s", resp.Status)
+		return nil, hzcerrors.NewLoggableError(err, "Cluster rejected the license request (%s): %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return parseLicenseInfo(body)
+}
+
+// parseLicenseInfo parses the response of the license endpoint, which has the license in the licenseInfo object.
+// The expiry date is in milliseconds since the epoch, and the type is a number for some member versions.
+func parseLicenseInfo(body []byte) (*LicenseInfo, error) {
+	var resp struct {
+		LicenseInfo *struct {
+			ExpiryDate   int64           `json:"expiryDate"`
+			MaxNodeCount int             `json:"maxNodeCount"`
+			Type         json.RawMessage `json:"type"`
+			CompanyName  string          `json:"companyName"`
+			OwnerEmail   string          `json:"ownerEmail"`
+			Features     []string        `json:"features"`
+		} `json:"licenseInfo"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, hzcerrors.NewLoggableError(err, "Could not parse the license from the cluster")
+	}
+	li := resp.LicenseInfo
+	if li == nil {
+		return nil, nil
+	}
+	info := &LicenseInfo{
+		Type:         strings.Trim(string(li.Type), `"`),
+		CompanyName:  li.CompanyName,
+		OwnerEmail:   li.OwnerEmail,
+		MaxNodeCount: li.MaxNodeCount,
+		Features:     li.Features,
+	}
+	if li.ExpiryDate > 0 {
+		info.ExpiryDate = time.Unix(0, li.ExpiryDate*int64(time.Millisecond))
+	}
+	return info, nil
+}
+
+// LicenseWarning returns the warning for a command which requires the enterprise feature, it is empty if the feature is available
+// or the license cannot be read. The license is only checked to warn early, the command is still run if the feature is not available.
+func LicenseWarning(conf *hazelcast.Config, feature string) string {
+	info, err := GetLicenseInfo(conf)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case info == nil:
+		return fmt.Sprintf("%s requires Hazelcast Enterprise, but the cluster does not have an enterprise license", feature)
+	case info.Expired(time.Now()):
+		return fmt.Sprintf("%s requires Hazelcast Enterprise, but the license of the cluster expired on %s", feature, info.ExpiryDate.Format("2006-01-02"))
+	case !info.HasFeature(feature):
+		return fmt.Sprintf("%s is not one of the features of the license of the cluster", feature)
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+)
+
+func TestGetLicenseInfo(t *testing.T) {
+	license := `{"licenseInfo":{"expiryDate":1560380399161,"maxNodeCount":10,"type":-1,"companyName":"Company","ownerEmail":"info@example.com"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if license == "" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Path != "/hazelcast/rest/license" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(license))
+	}))
+	defer srv.Close()
+	conf := hazelcast.NewConfig()
+	conf.Cluster.Network.SetAddresses(strings.TrimPrefix(srv.URL, "http://"))
+	info, err := GetLicenseInfo(&conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Type != "-1" || info.CompanyName != "Company" || info.MaxNodeCount != 10 || info.Features != nil {
+		t.Errorf("unexpected license %+v", info)
+	}
+	if want := time.Unix(1560380399, 161000000); !info.ExpiryDate.Equal(want) {
+		t.Errorf("want expiry date %v got %v", want, info.ExpiryDate)
+	}
+	if w := LicenseWarning(&conf, FeaturePersistence); !strings.Contains(w, "expired on 2019-06-1") {
+		t.Errorf("want the expiry warning got %q", w)
+	}
+	// the open source members do not have the license endpoint
+	license = ""
+	if info, err = GetLicenseInfo(&conf); err != nil || info != nil {
+		t.Fatalf("want no license got %+v %v", info, err)
+	}
+	if w := LicenseWarning(&conf, FeaturePersistence); !strings.Contains(w, "does not have an enterprise license") {
+		t.Errorf("want the enterprise warning got %q", w)
+	}
+}
+
+func TestLicenseInfo_HasFeature(t *testing.T) {
+	info := &LicenseInfo{}
+	if !info.HasFeature(FeaturePersistence) {
+		t.Error("the features which are not reported must be assumed to be available")
+	}
+	info.Features = []string{"WAN Replication", "persistence"}
+	if !info.HasFeature(FeaturePersistence) {
+		t.Error("persistence must be available")
+	}
+	if info.HasFeature(FeatureRollingUpgrade) {
+		t.Error("rolling upgrade must not be available")
+	}
+}
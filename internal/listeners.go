@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+// ListenerInfo describes a listener registered by the commands of this session.
+type ListenerInfo struct {
+	ID string
+	// Kind is the kind of the events, such as topic or membership
+	Kind string
+	// Object is the name of the data structure the listener is registered on, or client for the client events
+	Object       string
+	RegisteredAt time.Time
+	// Removable is false for the listeners which are used by hzc itself, such as the ones of the status bar
+	Removable bool
+}
+
+type registeredListener struct {
+	info   ListenerInfo
+	remove func(ctx context.Context) error
+	// seq orders the listeners by their registration
+	seq int
+}
+
+var listeners = struct {
+	mu  sync.Mutex
+	m   map[string]registeredListener
+	seq int
+}{m: map[string]registeredListener{}}
+
+// RegisterListener records the listener, remove removes its registration from the cluster.
+// The listeners with a nil remove function are listed, but they cannot be removed.
+func RegisterListener(kind, object string, id types.UUID, remove func(ctx context.Context) error) {
+	listeners.mu.Lock()
+	defer listeners.mu.Unlock()
+	listeners.seq++
+	listeners.m[id.String()] = registeredListener{
+		info: ListenerInfo{
+			ID:           id.String(),
+			Kind:         kind,
+			Object:       object,
+			RegisteredAt: time.Now(),
+			Removable:    remove != nil,
+		},
+		remove: remove,
+		seq:    listeners.seq,
+	}
+}
+
+// UnregisterListener forgets the listener, it must be called after the command removes the listener itself.
+func UnregisterListener(id types.UUID) {
+	listeners.mu.Lock()
+	defer listeners.mu.Unlock()
+	delete(listeners.m, id.String())
+}
+
+// RemoveListener removes the registration of the listener with the ID from the cluster and forgets it.
+func RemoveListener(ctx context.Context, id string) error {
+	listeners.mu.Lock()
+	l, ok := listeners.m[id]
+	listeners.mu.Unlock()
+	if !ok {
+		return hzcerrors.NewLoggableError(nil, "There is no listener with ID %s", id)
+	}
+	if l.remove == nil {
+		return hzcerrors.NewLoggableError(nil, "The %s listener %s is used by hzc itself, it cannot be removed", l.info.Kind, id)
+	}
+	if err := l.remove(ctx); err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot remove the %s listener %s of %s", l.info.Kind, id, l.info.Object)
+	}
+	listeners.mu.Lock()
+	delete(listeners.m, id)
+	listeners.mu.Unlock()
+	return nil
+}
+
+// Listeners returns the registered listeners in the order of their registration.
+func Listeners() []ListenerInfo {
+	listeners.mu.Lock()
+	defer listeners.mu.Unlock()
+	ls := make([]registeredListener, 0, len(listeners.m))
+	for _, l := range listeners.m {
+		ls = append(ls, l)
+	}
+	sort.Slice(ls, func(i, j int) bool {
+		return ls[i].seq < ls[j].seq
+	})
+	infos := make([]ListenerInfo, len(ls))
+	for i, l := range ls {
+		infos[i] = l.info
+	}
+	return infos
+}
+
+// clearListeners forgets all the listeners, since they belong to a client which is closed.
+func copyListeners() map[string]registeredListener {
+	listeners.mu.Lock()
+	defer listeners.mu.Unlock()
+	m := make(map[string]registeredListener, len(listeners.m))
+	for id, l := range listeners.m {
+		m[id] = l
+	}
+	return m
+}
+
+func setListeners(m map[string]registeredListener) {
+	listeners.mu.Lock()
+	defer listeners.mu.Unlock()
+	listeners.m = m
+}
+
+func clearListeners() {
+	listeners.mu.Lock()
+	defer listeners.mu.Unlock()
+	listeners.m = map[string]registeredListener{}
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveListener(t *testing.T) {
+	defer clearListeners()
+	status := types.NewUUID()
+	RegisterListener("membership", "client", status, nil)
+	removed := 0
+	topic := types.NewUUID()
+	RegisterListener("topic", "orders", topic, func(ctx context.Context) error {
+		removed++
+		return nil
+	})
+	failing := types.NewUUID()
+	RegisterListener("topic", "payments", failing, func(ctx context.Context) error {
+		return errors.New("not connected")
+	})
+	require.Len(t, Listeners(), 3)
+	assert.Equal(t, status.String(), Listeners()[0].ID)
+	assert.False(t, Listeners()[0].Removable)
+
+	assert.Error(t, RemoveListener(context.Background(), status.String()))
+	assert.Error(t, RemoveListener(context.Background(), "unknown"))
+	assert.Error(t, RemoveListener(context.Background(), failing.String()))
+	require.NoError(t, RemoveListener(context.Background(), topic.String()))
+	assert.Equal(t, 1, removed)
+	// removed ones are forgotten, the failed ones stay listed
+	var ids []string
+	for _, l := range Listeners() {
+		ids = append(ids, l.ID)
+	}
+	assert.Equal(t, []string{status.String(), failing.String()}, ids)
+	UnregisterListener(failing)
+	assert.Len(t, Listeners(), 1)
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hazelcast/hazelcast-go-client/logger"
+)
+
+const (
+	connectedToClusterLogPrefix = "connected to cluster: "
+	// the client logs the failed invocations at trace level, they are retried if the error is retryable
+	invocationErrorLogPrefix = "error invoking "
+)
+
+var activeClusterName atomic.Value
+
+// protocolTrace receives all the client logs including the trace level ones, if protocol tracing is enabled
+var protocolTrace *log.Logger
+
+// EnableProtocolTrace writes all the logs of the clients created afterwards to the file at path, regardless of the log level.
+// The trace level logs of the client include the invocations with their correlation IDs, invocation errors and retries,
+// connection and heartbeat events, so they help debugging the connectivity and latency issues.
+func EnableProtocolTrace(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	protocolTrace = log.New(f, "", log.LstdFlags|log.Lmicroseconds)
+	return nil
+}
+
+// ActiveClusterName returns the name of the cluster the client is connected to.
+// It differs from the configured cluster name if the client switched to a failover cluster.
+func ActiveClusterName() string {
+	name, _ := activeClusterName.Load().(string)
+	return name
+}
+
+// clientLogger behaves the same as the default logger of the client, but also keeps track of the connected cluster.
+// The client does not expose the cluster it is connected to, so it is inferred from the connection logs.
+type clientLogger struct {
+	out    *log.Logger
+	trace  *log.Logger
+	weight logger.Weight
+}
+
+func newClientLogger(level logger.Level) (*clientLogger, error) {
+	weight, err := logger.WeightForLogLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	return &clientLogger{
+		out:    log.New(os.Stderr, "", log.LstdFlags),
+		trace:  protocolTrace,
+		weight: weight,
+	}, nil
+}
+
+func (l *clientLogger) Log(weight logger.Weight, f func() string) {
+	if weight == logger.WeightInfo || l.weight >= weight || l.trace != nil ||
+		weight == logger.WeightTrace && l.weight >= logger.WeightDebug {
+		l.log(weight, f())
+	}
+}
+
+func (l *clientLogger) log(weight logger.Weight, msg string) {
+	if weight == logger.WeightTrace && strings.HasPrefix(msg, invocationErrorLogPrefix) {
+		// surface the retries in the verbose output
+		weight = logger.WeightDebug
+	}
+	if strings.HasPrefix(msg, connectedToClusterLogPrefix) {
+		activeClusterName.Store(strings.TrimPrefix(msg, connectedToClusterLogPrefix))
+	}
+	if l.trace == nil && l.weight < weight {
+		return
+	}
+	var level logger.Level
+	switch weight {
+	case logger.WeightTrace:
+		level = logger.TraceLevel
+	case logger.WeightDebug:
+		level = logger.DebugLevel
+	case logger.WeightInfo:
+		level = logger.InfoLevel
+	case logger.WeightWarn:
+		level = logger.WarnLevel
+	case logger.WeightError:
+		level = logger.ErrorLevel
+	case logger.WeightFatal:
+		level = logger.FatalLevel
+	default:
+		return
+	}
+	line := fmt.Sprintf("%-5s: %s", strings.ToUpper(level.String()), msg)
+	if l.trace != nil {
+		_ = l.trace.Output(0, line)
+	}
+	if l.weight >= weight {
+		_ = l.out.Output(0, line)
+	}
+}
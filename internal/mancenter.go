@@ -0,0 +1,311 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+// IsManagementCenterOperation returns true if the cluster operation can be run through the Management Center.
+// The WAN replication operations are not covered, since there are no commands for them.
+func IsManagementCenterOperation(operation string) bool {
+	switch operation {
+	case constants.ClusterGetState, constants.ClusterChangeState, constants.ClusterMembers:
+		return true
+	}
+	return false
+}
+
+// CallManagementCenterOperation runs the cluster operation through the Management Center REST API instead of the REST endpoints of the members.
+func CallManagementCenterOperation(mc *config.ManagementCenterConfig, conf *hazelcast.Config, operation string, state string) (*string, error) {
+	req, err := newManagementCenterRequest(mc.URL, conf.Cluster.Name, operation, state)
+	if err != nil {
+		return nil, err
+	}
+	body, err := sendManagementCenterRequest(mc, conf, req, fmt.Sprintf("the %s operation", operation))
+	if err != nil {
+		return nil, err
+	}
+	sb := string(body)
+	return &sb, nil
+}
+
+// sendManagementCenterRequest sends the request and returns the body of the response, what describes the request in the error messages.
+func sendManagementCenterRequest(mc *config.ManagementCenterConfig, conf *hazelcast.Config, req *http.Request, what string) ([]byte, error) {
+	tr := &http.Transport{
+		TLSClientConfig: conf.Cluster.Network.SSL.TLSConfig(),
+	}
+	client := &http.Client{Transport: tr}
+	resp, err := client.Do(req)
+	if err != nil {
+		if msg, handled := hzcerrors.TranslateNetworkError(err, false); handled {
+			return nil, hzcerrors.NewLoggableError(err, "Can not connect to the Management Center at %s: %s", mc.URL, msg)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, hzcerrors.NewLoggableError(err, "Could not read the response from the Management Center")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err = fmt.Errorf("unexpected status: %s", resp.Status)
+		return nil, hzcerrors.NewLoggableError(err, "Management Center rejected %s (%s): %s", what, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// DescribeManagementCenterOperation returns the HTTP method and the URL of the Management Center request which runs the operation, without sending it.
+func DescribeManagementCenterOperation(mc *config.ManagementCenterConfig, conf *hazelcast.Config, operation string, state string) (string, error) {
+	req, err := newManagementCenterRequest(mc.URL, conf.Cluster.Name, operation, state)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s", req.Method, req.URL), nil
+}
+
+func newManagementCenterRequest(mcURL, clusterName, operation, state string) (*http.Request, error) {
+	base := strings.TrimRight(mcURL, "/")
+	name := url.PathEscape(clusterName)
+	switch operation {
+	case constants.ClusterGetState:
+		return http.NewRequest(http.MethodGet, base+fmt.Sprintf(constants.MCClusterStateEndpointFmt, name), nil)
+	case constants.ClusterChangeState:
+		if !EnsureState(state) {
+			return nil, hzcerrors.NewLoggableError(InvalidStateErr, "Invalid new state. It should be one the following: %s, %s, %s, %s\n", constants.ClusterStateActive, constants.ClusterStateFrozen, constants.ClusterStateNoMigration, constants.ClusterStatePassive)
+		}
+		body, err := json.Marshal(map[string]string{"state": strings.ToUpper(state)})
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(http.MethodPost, base+fmt.Sprintf(constants.MCClusterStateEndpointFmt, name), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	case constants.ClusterMembers:
+		return http.NewRequest(http.MethodGet, base+fmt.Sprintf(constants.MCClusterMembersEndpointFmt, name), nil)
+	}
+	panic("Invalid operation for the Management Center.")
+}
+
+// MapEvictionConfig is the eviction configuration of a map, as reported by the Management Center.
+type MapEvictionConfig struct {
+	// EvictionPolicy is one of NONE, LRU, LFU and RANDOM
+	EvictionPolicy string `json:"evictionPolicy" yaml:"eviction-policy"`
+	// MaxSizePolicy is the unit of Size, such as PER_NODE or USED_HEAP_SIZE
+	MaxSizePolicy string `json:"maxSizePolicy" yaml:"max-size-policy"`
+	Size          int64  `json:"size" yaml:"size"`
+}
+
+// MapConfig is the effective configuration of a map on the members, as reported by the Management Center.
+// The YAML names are the ones of the member configuration.
+type MapConfig struct {
+	Name              string            `json:"name" yaml:"name"`
+	BackupCount       int               `json:"backupCount" yaml:"backup-count"`
+	AsyncBackupCount  int               `json:"asyncBackupCount" yaml:"async-backup-count"`
+	TimeToLiveSeconds int               `json:"timeToLiveSeconds" yaml:"time-to-live-seconds"`
+	MaxIdleSeconds    int               `json:"maxIdleSeconds" yaml:"max-idle-seconds"`
+	InMemoryFormat    string            `json:"inMemoryFormat" yaml:"in-memory-format"`
+	ReadBackupData    bool              `json:"readBackupData" yaml:"read-backup-data"`
+	Eviction          MapEvictionConfig `json:"eviction" yaml:"eviction"`
+	Indexes           []MapIndexConfig  `json:"indexes" yaml:"indexes"`
+}
+
+// MapIndexConfig is an index in the configuration of a map.
+type MapIndexConfig struct {
+	Name       string   `json:"name" yaml:"name"`
+	Type       string   `json:"type" yaml:"type"`
+	Attributes []string `json:"attributes" yaml:"attributes"`
+}
+
+// MemberMapStats are the statistics of the map entries owned by a member, as reported by the Management Center.
+type MemberMapStats struct {
+	Member                string `json:"-"`
+	OwnedEntryCount       int64  `json:"ownedEntryCount"`
+	BackupEntryCount      int64  `json:"backupEntryCount"`
+	OwnedEntryMemoryCost  int64  `json:"ownedEntryMemoryCost"`
+	BackupEntryMemoryCost int64  `json:"backupEntryMemoryCost"`
+}
+
+// GetManagementCenterMapConfig returns the configuration of the map through the Management Center REST API.
+func GetManagementCenterMapConfig(mc *config.ManagementCenterConfig, conf *hazelcast.Config, mapName string) (MapConfig, error) {
+	path := fmt.Sprintf(constants.MCMapConfigEndpointFmt, url.PathEscape(conf.Cluster.Name), url.PathEscape(mapName))
+	body, err := getManagementCenter(mc, conf, path, fmt.Sprintf("getting the configuration of map %s", mapName))
+	if err != nil {
+		return MapConfig{}, err
+	}
+	return parseMapConfig(body)
+}
+
+// GetManagementCenterMapEvictionConfig returns the eviction configuration of the map through the Management Center REST API.
+func GetManagementCenterMapEvictionConfig(mc *config.ManagementCenterConfig, conf *hazelcast.Config, mapName string) (MapEvictionConfig, error) {
+	c, err := GetManagementCenterMapConfig(mc, conf, mapName)
+	return c.Eviction, err
+}
+
+// parseMapConfig parses the map configuration, the indexes are either in indexConfigs or in indexes with their attributes in attributes or attributeNames.
+func parseMapConfig(body []byte) (MapConfig, error) {
+	var raw struct {
+		Name              string           `json:"name"`
+		BackupCount       int              `json:"backupCount"`
+		AsyncBackupCount  int              `json:"asyncBackupCount"`
+		TimeToLiveSeconds int              `json:"timeToLiveSeconds"`
+		MaxIdleSeconds    int              `json:"maxIdleSeconds"`
+		InMemoryFormat    string           `json:"inMemoryFormat"`
+		ReadBackupData    bool             `json:"readBackupData"`
+		IndexConfigs      []rawIndexConfig `json:"indexConfigs"`
+		Indexes           []rawIndexConfig `json:"indexes"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return MapConfig{}, hzcerrors.NewLoggableError(err, "Could not parse the map configuration from the Management Center")
+	}
+	ec, err := parseMapEvictionConfig(body)
+	if err != nil {
+		return MapConfig{}, err
+	}
+	c := MapConfig{
+		Name:              raw.Name,
+		BackupCount:       raw.BackupCount,
+		AsyncBackupCount:  raw.AsyncBackupCount,
+		TimeToLiveSeconds: raw.TimeToLiveSeconds,
+		MaxIdleSeconds:    raw.MaxIdleSeconds,
+		InMemoryFormat:    raw.InMemoryFormat,
+		ReadBackupData:    raw.ReadBackupData,
+		Eviction:          ec,
+		Indexes:           []MapIndexConfig{},
+	}
+	for _, ic := range append(raw.IndexConfigs, raw.Indexes...) {
+		attributes := ic.Attributes
+		if len(attributes) == 0 {
+			attributes = ic.AttributeNames
+		}
+		c.Indexes = append(c.Indexes, MapIndexConfig{Name: ic.Name, Type: ic.Type, Attributes: attributes})
+	}
+	return c, nil
+}
+
+type rawIndexConfig struct {
+	Name           string   `json:"name"`
+	Type           string   `json:"type"`
+	Attributes     []string `json:"attributes"`
+	AttributeNames []string `json:"attributeNames"`
+}
+
+// parseMapEvictionConfig parses the map configuration, the eviction settings are either in the evictionConfig object or on the map configuration itself.
+func parseMapEvictionConfig(body []byte) (MapEvictionConfig, error) {
+	var mc struct {
+		EvictionConfig *struct {
+			EvictionPolicy string `json:"evictionPolicy"`
+			MaxSizePolicy  string `json:"maxSizePolicy"`
+			Size           int64  `json:"size"`
+		} `json:"evictionConfig"`
+		EvictionPolicy string `json:"evictionPolicy"`
+		MaxSizePolicy  string `json:"maxSizePolicy"`
+		MaxSize        int64  `json:"maxSize"`
+	}
+	if err := json.Unmarshal(body, &mc); err != nil {
+		return MapEvictionConfig{}, hzcerrors.NewLoggableError(err, "Could not parse the map configuration from the Management Center")
+	}
+	if ec := mc.EvictionConfig; ec != nil {
+		return MapEvictionConfig{EvictionPolicy: ec.EvictionPolicy, MaxSizePolicy: ec.MaxSizePolicy, Size: ec.Size}, nil
+	}
+	return MapEvictionConfig{EvictionPolicy: mc.EvictionPolicy, MaxSizePolicy: mc.MaxSizePolicy, Size: mc.MaxSize}, nil
+}
+
+// GetManagementCenterMemberMapStats returns the statistics of the map on each member through the Management Center REST API.
+func GetManagementCenterMemberMapStats(mc *config.ManagementCenterConfig, conf *hazelcast.Config, mapName string) ([]MemberMapStats, error) {
+	stats, err := GetManagementCenterMapsStats(mc, conf, []string{mapName})
+	if err != nil {
+		return nil, err
+	}
+	return stats[mapName], nil
+}
+
+// GetManagementCenterMapsStats returns the statistics of the maps on each member through the Management Center REST API, by the map names.
+func GetManagementCenterMapsStats(mc *config.ManagementCenterConfig, conf *hazelcast.Config, mapNames []string) (map[string][]MemberMapStats, error) {
+	cluster := url.PathEscape(conf.Cluster.Name)
+	body, err := getManagementCenter(mc, conf, fmt.Sprintf(constants.MCClusterMembersEndpointFmt, cluster), "listing the members")
+	if err != nil {
+		return nil, err
+	}
+	members, err := parseManagementCenterMembers(body)
+	if err != nil {
+		return nil, err
+	}
+	all := make(map[string][]MemberMapStats, len(mapNames))
+	for _, mapName := range mapNames {
+		stats := make([]MemberMapStats, 0, len(members))
+		for _, m := range members {
+			path := fmt.Sprintf(constants.MCMemberMapStatsEndpointFmt, cluster, url.PathEscape(m), url.PathEscape(mapName))
+			body, err := getManagementCenter(mc, conf, path, fmt.Sprintf("getting the statistics of map %s on member %s", mapName, m))
+			if err != nil {
+				return nil, err
+			}
+			s := MemberMapStats{Member: m}
+			if err := json.Unmarshal(body, &s); err != nil {
+				return nil, hzcerrors.NewLoggableError(err, "Could not parse the statistics of map %s on member %s from the Management Center", mapName, m)
+			}
+			stats = append(stats, s)
+		}
+		all[mapName] = stats
+	}
+	return all, nil
+}
+
+// parseManagementCenterMembers returns the addresses of the members, which are listed either as strings or as objects with an address.
+func parseManagementCenterMembers(body []byte) ([]string, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, hzcerrors.NewLoggableError(err, "Could not parse the member list from the Management Center")
+	}
+	members := make([]string, 0, len(items))
+	for _, item := range items {
+		var address string
+		if err := json.Unmarshal(item, &address); err != nil {
+			var m struct {
+				Address string `json:"address"`
+			}
+			if err := json.Unmarshal(item, &m); err != nil || m.Address == "" {
+				return nil, hzcerrors.NewLoggableError(err, "Could not parse the member %s from the Management Center", string(item))
+			}
+			address = m.Address
+		}
+		members = append(members, address)
+	}
+	return members, nil
+}
+
+func getManagementCenter(mc *config.ManagementCenterConfig, conf *hazelcast.Config, path, what string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(mc.URL, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sendManagementCenterRequest(mc, conf, req, what)
+}
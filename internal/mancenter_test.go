@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+)
+
+func TestNewManagementCenterRequest(t *testing.T) {
+	for _, tc := range []struct {
+		info      string
+		operation string
+		state     string
+		method    string
+		url       string
+		body      string
+		isErr     bool
+	}{
+		{info: "get state", operation: "get-state", method: http.MethodGet, url: "http://mc:8080/rest/clusters/dev/state"},
+		{info: "change state", operation: "change-state", state: "frozen", method: http.MethodPost, url: "http://mc:8080/rest/clusters/dev/state", body: `{"state":"FROZEN"}`},
+		{info: "invalid state", operation: "change-state", state: "unknown", isErr: true},
+		{info: "members", operation: "members", method: http.MethodGet, url: "http://mc:8080/rest/clusters/dev/members"},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			req, err := newManagementCenterRequest("http://mc:8080/", "dev", tc.operation, tc.state)
+			if tc.isErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if req.Method != tc.method || req.URL.String() != tc.url {
+				t.Errorf("want %s %s got %s %s", tc.method, tc.url, req.Method, req.URL)
+			}
+			var body string
+			if req.Body != nil {
+				b, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = string(b)
+			}
+			if body != tc.body {
+				t.Errorf("want body %s got %s", tc.body, body)
+			}
+		})
+	}
+}
+
+func TestParseMapEvictionConfig(t *testing.T) {
+	want := MapEvictionConfig{EvictionPolicy: "LRU", MaxSizePolicy: "PER_NODE", Size: 1000}
+	for _, body := range []string{
+		`{"name":"m","evictionConfig":{"evictionPolicy":"LRU","maxSizePolicy":"PER_NODE","size":1000}}`,
+		`{"name":"m","evictionPolicy":"LRU","maxSizePolicy":"PER_NODE","maxSize":1000}`,
+	} {
+		ec, err := parseMapEvictionConfig([]byte(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ec != want {
+			t.Errorf("%s: want %+v got %+v", body, want, ec)
+		}
+	}
+	if _, err := parseMapEvictionConfig([]byte("<html>")); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestParseMapConfig(t *testing.T) {
+	want := MapConfig{
+		Name:              "m",
+		BackupCount:       1,
+		AsyncBackupCount:  2,
+		TimeToLiveSeconds: 60,
+		InMemoryFormat:    "BINARY",
+		ReadBackupData:    true,
+		Eviction:          MapEvictionConfig{EvictionPolicy: "LRU", MaxSizePolicy: "PER_NODE", Size: 1000},
+		Indexes:           []MapIndexConfig{{Name: "byAge", Type: "SORTED", Attributes: []string{"age"}}},
+	}
+	for _, body := range []string{
+		`{"name":"m","backupCount":1,"asyncBackupCount":2,"timeToLiveSeconds":60,"inMemoryFormat":"BINARY","readBackupData":true,
+			"evictionConfig":{"evictionPolicy":"LRU","maxSizePolicy":"PER_NODE","size":1000},
+			"indexConfigs":[{"name":"byAge","type":"SORTED","attributes":["age"]}]}`,
+		`{"name":"m","backupCount":1,"asyncBackupCount":2,"timeToLiveSeconds":60,"inMemoryFormat":"BINARY","readBackupData":true,
+			"evictionPolicy":"LRU","maxSizePolicy":"PER_NODE","maxSize":1000,
+			"indexes":[{"name":"byAge","type":"SORTED","attributeNames":["age"]}]}`,
+	} {
+		c, err := parseMapConfig([]byte(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(want, c) {
+			t.Errorf("%s: want %+v got %+v", body, want, c)
+		}
+	}
+	c, err := parseMapConfig([]byte(`{"name":"m"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Indexes == nil {
+		t.Error("want an empty list of indexes")
+	}
+}
+
+func TestGetManagementCenterMemberMapStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/clusters/dev/members":
+			w.Write([]byte(`["10.0.0.1:5701",{"address":"10.0.0.2:5701"}]`))
+		case "/rest/clusters/dev/members/10.0.0.1:5701/maps/m":
+			w.Write([]byte(`{"ownedEntryCount":10,"backupEntryCount":12,"ownedEntryMemoryCost":1000,"backupEntryMemoryCost":1200}`))
+		case "/rest/clusters/dev/members/10.0.0.2:5701/maps/m":
+			w.Write([]byte(`{"ownedEntryCount":12,"backupEntryCount":10}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	mc := &config.ManagementCenterConfig{URL: srv.URL}
+	conf := hazelcast.NewConfig()
+	conf.Cluster.Name = "dev"
+	stats, err := GetManagementCenterMemberMapStats(mc, &conf, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []MemberMapStats{
+		{Member: "10.0.0.1:5701", OwnedEntryCount: 10, BackupEntryCount: 12, OwnedEntryMemoryCost: 1000, BackupEntryMemoryCost: 1200},
+		{Member: "10.0.0.2:5701", OwnedEntryCount: 12, BackupEntryCount: 10},
+	}
+	if !reflect.DeepEqual(want, stats) {
+		t.Errorf("want %+v got %+v", want, stats)
+	}
+	if _, err := GetManagementCenterMemberMapStats(mc, &conf, "unknown"); err == nil {
+		t.Fatal("expected an error for the missing map")
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package member
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+)
+
+const (
+	// Flag is the name of the global flag which selects the member the command runs on
+	Flag = "member"
+	// Annotation marks the commands which can target a member
+	Annotation = "member"
+)
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Targetable marks the command as able to run on the member given with the member flag.
+// Such commands must get the address of the member with Address.
+func Targetable(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[Annotation] = "true"
+	return cmd
+}
+
+// Value returns the UUID or the address given with the member flag, it is empty if the flag is not given.
+func Value(cmd *cobra.Command) string {
+	f := cmd.Flag(Flag)
+	if f == nil {
+		return ""
+	}
+	return strings.TrimSpace(f.Value.String())
+}
+
+// Address returns the address of the member given with the member flag, it is empty if the flag is not given.
+// The UUIDs are looked up in the membership list, connecting to the cluster if the client is not connected yet.
+// The addresses are checked against the membership list only if the client is connected.
+func Address(ctx context.Context, conf *hazelcast.Config, cmd *cobra.Command) (string, error) {
+	m := Value(cmd)
+	if m == "" {
+		return "", nil
+	}
+	if uuidRe.MatchString(m) {
+		if _, err := internal.ConnectToCluster(ctx, conf); err != nil {
+			return "", err
+		}
+	}
+	return resolve(m, internal.ClusterMembers())
+}
+
+func resolve(member string, members []cluster.MemberInfo) (string, error) {
+	isUUID := uuidRe.MatchString(member)
+	if !isUUID && len(members) == 0 {
+		return member, nil
+	}
+	for _, m := range members {
+		if (isUUID && strings.EqualFold(m.UUID.String(), member)) || (!isUUID && string(m.Address) == member) {
+			return string(m.Address), nil
+		}
+	}
+	return "", hzcerrors.NewLoggableError(nil, "There is no member %s in the cluster, see the members with \"hzc cluster members\"", member)
+}
+
+// Complete completes the member flag with the addresses of the members, from the membership list of the connected client.
+func Complete(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var values []string
+	for _, m := range internal.ClusterMembers() {
+		values = append(values, string(m.Address)+"\t"+m.UUID.String())
+	}
+	return values, cobra.ShellCompDirectiveNoFileComp
+}
+
+// EnableMember wraps the commands in the tree so that the commands which cannot target a member
+// return an error if the member flag is given, instead of running on all the members.
+func EnableMember(root *cobra.Command) {
+	for _, c := range root.Commands() {
+		EnableMember(c)
+	}
+	if root.Run != nil && root.RunE == nil {
+		run := root.Run
+		root.RunE = func(cmd *cobra.Command, args []string) error {
+			run(cmd, args)
+			return nil
+		}
+		root.Run = nil
+	}
+	if root.RunE == nil {
+		return
+	}
+	runE := root.RunE
+	root.RunE = func(cmd *cobra.Command, args []string) error {
+		if Value(cmd) != "" && cmd.Annotations[Annotation] != "true" {
+			return hzcerrors.NewLoggableError(nil, "%s cannot target a member, it does not support --%s", cmd.CommandPath(), Flag)
+		}
+		return runE(cmd, args)
+	}
+}
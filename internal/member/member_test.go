@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package member
+
+import (
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+)
+
+func TestEnableMember(t *testing.T) {
+	for _, tc := range []struct {
+		info       string
+		targetable bool
+		args       []string
+		ran        bool
+		isErr      bool
+	}{
+		{info: "without the flag", args: []string{"sub"}, ran: true},
+		{info: "targetable", targetable: true, args: []string{"sub", "--member", "10.0.0.1:5701"}, ran: true},
+		{info: "not targetable", args: []string{"sub", "--member", "10.0.0.1:5701"}, isErr: true},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			var ran bool
+			root := &cobra.Command{Use: "root", SilenceErrors: true, SilenceUsage: true}
+			root.PersistentFlags().String(Flag, "", "")
+			sub := &cobra.Command{Use: "sub", Run: func(cmd *cobra.Command, args []string) {
+				ran = true
+			}}
+			if tc.targetable {
+				Targetable(sub)
+			}
+			root.AddCommand(sub)
+			EnableMember(root)
+			root.SetArgs(tc.args)
+			err := root.Execute()
+			if tc.isErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ran != tc.ran {
+				t.Errorf("want ran %t got %t", tc.ran, ran)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	uuid := types.NewUUIDWith(1, 2)
+	members := []cluster.MemberInfo{
+		{Address: "10.0.0.1:5701", UUID: types.NewUUIDWith(3, 4)},
+		{Address: "10.0.0.2:5701", UUID: uuid},
+	}
+	for _, tc := range []struct {
+		info    string
+		member  string
+		members []cluster.MemberInfo
+		want    string
+		isErr   bool
+	}{
+		{info: "address", member: "10.0.0.1:5701", members: members, want: "10.0.0.1:5701"},
+		{info: "uuid", member: uuid.String(), members: members, want: "10.0.0.2:5701"},
+		{info: "unknown address", member: "10.0.0.3:5701", members: members, isErr: true},
+		{info: "unknown uuid", member: types.NewUUIDWith(5, 6).String(), members: members, isErr: true},
+		{info: "address without the members", member: "10.0.0.3:5701", want: "10.0.0.3:5701"},
+		{info: "uuid without the members", member: uuid.String(), isErr: true},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			got, err := resolve(tc.member, tc.members)
+			if tc.isErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("want %s got %s", tc.want, got)
+			}
+		})
+	}
+}
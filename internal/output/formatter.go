@@ -0,0 +1,258 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	runewidth "github.com/mattn/go-runewidth"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/table"
+)
+
+// output types of the formatters
+const (
+	TypePretty   = "pretty"
+	TypeCSV      = "csv"
+	TypeJSON     = "json"
+	TypeVertical = "vertical"
+	TypeTemplate = "template"
+)
+
+// Types is the list of supported output types.
+var Types = []string{TypePretty, TypeCSV, TypeJSON, TypeVertical, TypeTemplate}
+
+// Formatter writes the rows of a result as they arrive, so that the whole result is never kept in memory.
+type Formatter interface {
+	// WriteHeader is called once with the column names, before the rows.
+	WriteHeader(names []string) error
+	WriteRow(values []interface{}) error
+	// Close writes the buffered output, if any.
+	Close() error
+}
+
+// NewFormatter returns the formatter of the output type, which writes to out.
+func NewFormatter(outputType string, out io.Writer, opts Options) (Formatter, error) {
+	switch outputType {
+	case TypePretty:
+		return &tableFormatter{w: table.NewTableWriter(out), opts: opts}, nil
+	case TypeCSV:
+		return &csvFormatter{w: csv.NewWriter(out), opts: opts}, nil
+	case TypeJSON:
+		return &jsonFormatter{out: out, opts: opts}, nil
+	case TypeVertical:
+		return &verticalFormatter{out: out, opts: opts}, nil
+	case TypeTemplate:
+		return newTemplateFormatter(out, opts)
+	}
+	return nil, fmt.Errorf("unknown output type %s, the types are %s", outputType, strings.Join(Types, ", "))
+}
+
+// typedHeaderWriter is implemented by the formatters which do not show the column types in the column names.
+type typedHeaderWriter interface {
+	writeTypedHeader(names, types []string) error
+}
+
+// WriteTypedHeader writes the header with the SQL type of each column next to its name, such as "age (INTEGER)".
+// The JSON formatter writes the types in a metadata line before the rows instead, and the template formatter leaves them out.
+func WriteTypedHeader(f Formatter, names, types []string) error {
+	if w, ok := f.(typedHeaderWriter); ok {
+		return w.writeTypedHeader(names, types)
+	}
+	typed := make([]string, len(names))
+	for i, n := range names {
+		typed[i] = fmt.Sprintf("%s (%s)", n, types[i])
+	}
+	return f.WriteHeader(typed)
+}
+
+type tableFormatter struct {
+	w    *table.TabularWriter
+	opts Options
+}
+
+func (f *tableFormatter) WriteHeader(names []string) error {
+	if f.opts.NoHeader {
+		return nil
+	}
+	cells := make([]interface{}, len(names))
+	for i, n := range names {
+		cells[i] = n
+	}
+	return f.w.WriteHeader(cells...)
+}
+
+func (f *tableFormatter) WriteRow(values []interface{}) error {
+	cells := make([]interface{}, len(values))
+	for i, v := range values {
+		cells[i] = f.opts.Format(v)
+	}
+	return f.w.Write(cells...)
+}
+
+func (f *tableFormatter) Close() error {
+	return nil
+}
+
+// csvFormatter flushes each row, so that the rows are seen as they arrive.
+type csvFormatter struct {
+	w    *csv.Writer
+	opts Options
+}
+
+func (f *csvFormatter) WriteHeader(names []string) error {
+	if f.opts.NoHeader {
+		return nil
+	}
+	if err := f.w.Write(names); err != nil {
+		return err
+	}
+	f.w.Flush()
+	return f.w.Error()
+}
+
+func (f *csvFormatter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = f.opts.Format(v)
+	}
+	if err := f.w.Write(record); err != nil {
+		return err
+	}
+	f.w.Flush()
+	return f.w.Error()
+}
+
+func (f *csvFormatter) Close() error {
+	f.w.Flush()
+	return f.w.Error()
+}
+
+// jsonFormatter writes each row as a JSON object on its own line.
+type jsonFormatter struct {
+	out   io.Writer
+	opts  Options
+	names []string
+}
+
+func (f *jsonFormatter) WriteHeader(names []string) error {
+	f.names = names
+	return nil
+}
+
+// jsonColumn is the metadata of a column in the JSON output.
+type jsonColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// writeTypedHeader writes a line with the columns before the rows, such as {"columns":[{"name":"age","type":"INTEGER"}]}.
+func (f *jsonFormatter) writeTypedHeader(names, types []string) error {
+	f.names = names
+	if f.opts.NoHeader {
+		return nil
+	}
+	cols := make([]jsonColumn, len(names))
+	for i, n := range names {
+		cols[i] = jsonColumn{Name: n, Type: types[i]}
+	}
+	b, err := json.Marshal(struct {
+		Columns []jsonColumn `json:"columns"`
+	}{cols})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f.out, "%s\n", b)
+	return err
+}
+
+func (f *jsonFormatter) WriteRow(values []interface{}) error {
+	b, err := f.opts.MarshalJSONObject(f.names, values)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f.out, "%s\n", b)
+	return err
+}
+
+func (f *jsonFormatter) Close() error {
+	return nil
+}
+
+// verticalFormatter writes each row as a block of "column | value" lines.
+type verticalFormatter struct {
+	out   io.Writer
+	opts  Options
+	names []string
+	width int
+	n     int
+}
+
+func (f *verticalFormatter) WriteHeader(names []string) error {
+	f.names = names
+	for _, n := range names {
+		if w := runewidth.StringWidth(n); w > f.width {
+			f.width = w
+		}
+	}
+	return nil
+}
+
+func (f *verticalFormatter) WriteRow(values []interface{}) error {
+	f.n++
+	return writeVerticalRecord(f.out, f.n, f.names, f.width, values, f.opts)
+}
+
+func (f *verticalFormatter) Close() error {
+	return nil
+}
+
+/*
+writeVerticalRecord outputs the row with the form:
+-[ RECORD 1 ]------
+__key | 12
+name  | Jane Brown
+*/
+func writeVerticalRecord(out io.Writer, n int, names []string, width int, values []interface{}, opts Options) error {
+	cells := make([]string, len(values))
+	var valueWidth int
+	for i, v := range values {
+		cells[i] = opts.Format(v)
+		if w := runewidth.StringWidth(cells[i]); w > valueWidth {
+			valueWidth = w
+		}
+	}
+	// the title line spans the record, like the separator of the table header
+	title := fmt.Sprintf("-[ RECORD %d ]", n)
+	dashes := width + 3 + valueWidth - len(title)
+	if dashes < 1 {
+		dashes = 1
+	}
+	if _, err := fmt.Fprintf(out, "%s%s\n", title, strings.Repeat("-", dashes)); err != nil {
+		return err
+	}
+	for i, name := range names {
+		pad := strings.Repeat(" ", width-runewidth.StringWidth(name))
+		if _, err := fmt.Fprintf(out, "%s%s | %s\n", name, pad, cells[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteVerticalRecord(t *testing.T) {
+	var b bytes.Buffer
+	names := []string{"__key", "name", "age"}
+	if err := writeVerticalRecord(&b, 2, names, 5, []interface{}{int32(12), "Jane Brown", nil}, DefaultOptions()); err != nil {
+		t.Fatal(err)
+	}
+	want := "-[ RECORD 2 ]-----\n" +
+		"__key | 12\n" +
+		"name  | Jane Brown\n" +
+		"age   | NULL\n"
+	if b.String() != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, b.String())
+	}
+}
+
+func TestFormatters(t *testing.T) {
+	noHeader := DefaultOptions()
+	noHeader.NoHeader = true
+	withTemplate := DefaultOptions()
+	withTemplate.Template = "{{.id}}: {{with .name}}{{.}}{{else}}unknown{{end}}"
+	for _, tc := range []struct {
+		outputType string
+		opts       Options
+		want       string
+	}{
+		{outputType: TypeCSV, opts: DefaultOptions(), want: "id,name\n1,\"Jane, Doe\"\n2,NULL\n"},
+		{outputType: TypeCSV, opts: noHeader, want: "1,\"Jane, Doe\"\n2,NULL\n"},
+		{outputType: TypeJSON, opts: noHeader, want: `{"id":1,"name":"Jane, Doe"}` + "\n" + `{"id":2,"name":null}` + "\n"},
+		{outputType: TypeVertical, opts: DefaultOptions(), want: "-[ RECORD 1 ]---\nid   | 1\nname | Jane, Doe\n-[ RECORD 2 ]-\nid   | 2\nname | NULL\n"},
+		{outputType: TypeTemplate, opts: withTemplate, want: "1: Jane, Doe\n2: unknown\n"},
+	} {
+		t.Run(tc.outputType, func(t *testing.T) {
+			var b bytes.Buffer
+			f, err := NewFormatter(tc.outputType, &b, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := f.WriteHeader([]string{"id", "name"}); err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range [][]interface{}{{int32(1), "Jane, Doe"}, {int64(2), nil}} {
+				if err := f.WriteRow(row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := f.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tc.want {
+				t.Errorf("want:\n%q\ngot:\n%q", tc.want, b.String())
+			}
+		})
+	}
+	if _, err := NewFormatter("xml", &bytes.Buffer{}, DefaultOptions()); err == nil {
+		t.Fatal("unknown output types must be an error")
+	}
+	if _, err := NewFormatter(TypeTemplate, &bytes.Buffer{}, DefaultOptions()); err == nil {
+		t.Fatal("template output without a template must be an error")
+	}
+}
+
+func TestWriteTypedHeader(t *testing.T) {
+	noHeader := DefaultOptions()
+	noHeader.NoHeader = true
+	withTemplate := DefaultOptions()
+	withTemplate.Template = "{{.id}}"
+	for _, tc := range []struct {
+		outputType string
+		opts       Options
+		want       string
+	}{
+		{outputType: TypeCSV, opts: DefaultOptions(), want: "id (INTEGER),name (VARCHAR)\n1,Jane\n"},
+		{outputType: TypeCSV, opts: noHeader, want: "1,Jane\n"},
+		{outputType: TypeJSON, opts: DefaultOptions(), want: `{"columns":[{"name":"id","type":"INTEGER"},{"name":"name","type":"VARCHAR"}]}` + "\n" + `{"id":1,"name":"Jane"}` + "\n"},
+		{outputType: TypeJSON, opts: noHeader, want: `{"id":1,"name":"Jane"}` + "\n"},
+		{outputType: TypeVertical, opts: DefaultOptions(), want: "-[ RECORD 1 ]--------\nid (INTEGER)   | 1\nname (VARCHAR) | Jane\n"},
+		{outputType: TypeTemplate, opts: withTemplate, want: "1\n"},
+	} {
+		t.Run(tc.outputType, func(t *testing.T) {
+			var b bytes.Buffer
+			f, err := NewFormatter(tc.outputType, &b, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := WriteTypedHeader(f, []string{"id", "name"}, []string{"INTEGER", "VARCHAR"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := f.WriteRow([]interface{}{int32(1), "Jane"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := f.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tc.want {
+				t.Errorf("want:\n%q\ngot:\n%q", tc.want, b.String())
+			}
+		})
+	}
+}
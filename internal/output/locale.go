@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package output
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+// renderings of the temporal values, any other time format is used as a Go time layout
+const (
+	TimeISO8601     = "iso8601"
+	TimeEpoch       = "epoch"
+	TimeEpochMillis = "epoch-millis"
+)
+
+// TimeFormats is the list of the named time formats.
+var TimeFormats = []string{TimeISO8601, TimeEpoch, TimeEpochMillis}
+
+const defaultDecimalSeparator = "."
+
+// timeValue renders the temporal value in the time format, ok is false if v is not a temporal value.
+// The epoch formats return the value as an int64, the others as a string.
+// The dates and times without a time zone are taken to be in UTC for the epoch formats, and the times without a date are always in ISO 8601.
+func (o Options) timeValue(v interface{}) (value interface{}, ok bool) {
+	var t time.Time
+	switch vv := v.(type) {
+	case types.LocalDate:
+		t = wallClockUTC(time.Time(vv))
+	case types.LocalTime:
+		if o.TimeFormat == TimeEpoch || o.TimeFormat == TimeEpochMillis {
+			return String(vv), true
+		}
+		t = wallClockUTC(time.Time(vv))
+	case types.LocalDateTime:
+		t = wallClockUTC(time.Time(vv))
+	case types.OffsetDateTime:
+		t = time.Time(vv)
+		if o.TimeZone != nil {
+			t = t.In(o.TimeZone)
+		}
+		if o.TimeFormat == "" || o.TimeFormat == TimeISO8601 {
+			return t.Format(time.RFC3339Nano), true
+		}
+	default:
+		return nil, false
+	}
+	switch o.TimeFormat {
+	case "", TimeISO8601:
+		return String(v), true
+	case TimeEpoch:
+		return t.Unix(), true
+	case TimeEpochMillis:
+		return t.UnixNano() / int64(time.Millisecond), true
+	}
+	return t.Format(o.TimeFormat), true
+}
+
+// wallClockUTC returns the time with the same date and clock in UTC.
+func wallClockUTC(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// formatNumber renders the number with the separators of the options, ok is false if v is not a number.
+func (o Options) formatNumber(v interface{}) (string, bool) {
+	switch v.(type) {
+	case int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint, float32, float64, types.Decimal, *types.Decimal, *big.Int:
+	default:
+		return "", false
+	}
+	s := String(v)
+	if (o.DecimalSeparator == "" || o.DecimalSeparator == defaultDecimalSeparator) && o.ThousandsSeparator == "" {
+		return s, true
+	}
+	return groupDigits(s, o.DecimalSeparator, o.ThousandsSeparator), true
+}
+
+// groupDigits replaces the decimal point of the number and groups the digits of its integer part by three.
+// The numbers which are not written with digits, such as NaN, are returned as they are.
+func groupDigits(s, decimalSep, thousandsSep string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	end := strings.IndexAny(s, ".eE")
+	if end < 0 {
+		end = len(s)
+	}
+	intPart, rest := s[:end], s[end:]
+	if intPart == "" || strings.Trim(intPart, "0123456789") != "" {
+		return sign + s
+	}
+	if thousandsSep != "" {
+		var sb strings.Builder
+		for i, d := range intPart {
+			if i > 0 && (len(intPart)-i)%3 == 0 {
+				sb.WriteString(thousandsSep)
+			}
+			sb.WriteRune(d)
+		}
+		intPart = sb.String()
+	}
+	if decimalSep != "" && strings.HasPrefix(rest, ".") {
+		rest = decimalSep + rest[1:]
+	}
+	return sign + intPart + rest
+}
+
+// validateTimeFormat returns an error if the custom time layout does not render any part of the time.
+func validateTimeFormat(format string) error {
+	switch format {
+	case "", TimeISO8601, TimeEpoch, TimeEpochMillis:
+		return nil
+	}
+	// a layout without any of the elements of the reference time renders as itself
+	ref := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	if ref.Format(format) == format {
+		return fmt.Errorf("unknown time format %s, provide one of %s or a Go time layout such as 2006-01-02 15:04", format, strings.Join(TimeFormats, ", "))
+	}
+	return nil
+}
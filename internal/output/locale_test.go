@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package output
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestOptions_FormatTimeAndNumbers(t *testing.T) {
+	istanbul := time.FixedZone("+03", 3*60*60)
+	ts := types.OffsetDateTime(time.Date(2022, 6, 27, 10, 30, 0, 0, time.UTC))
+	local := types.LocalDateTime(time.Date(2022, 6, 27, 10, 30, 0, 0, time.Local))
+	withOpts := func(f func(o *Options)) Options {
+		o := DefaultOptions()
+		f(&o)
+		return o
+	}
+	epoch := withOpts(func(o *Options) { o.TimeFormat = TimeEpoch })
+	epochMillis := withOpts(func(o *Options) { o.TimeFormat = TimeEpochMillis })
+	layout := withOpts(func(o *Options) { o.TimeFormat = "02.01.2006 15:04" })
+	zone := withOpts(func(o *Options) { o.TimeZone = istanbul })
+	german := withOpts(func(o *Options) { o.DecimalSeparator = ","; o.ThousandsSeparator = "." })
+	for _, tc := range []struct {
+		info  string
+		opts  Options
+		value interface{}
+		want  string
+	}{
+		{"iso8601", DefaultOptions(), ts, "2022-06-27T10:30:00Z"},
+		{"epoch", epoch, ts, "1656325800"},
+		{"epoch millis", epochMillis, ts, "1656325800000"},
+		{"epoch local", epoch, local, "1656325800"},
+		{"epoch time", epoch, types.LocalTime(time.Date(0, 1, 1, 10, 30, 0, 0, time.Local)), "10:30:00"},
+		{"layout", layout, local, "27.06.2022 10:30"},
+		{"zone", zone, ts, "2022-06-27T13:30:00+03:00"},
+		{"zone leaves local times", zone, local, "2022-06-27T10:30:00"},
+		{"grouped", german, int64(-1234567), "-1.234.567"},
+		{"grouped short", german, int32(123), "123"},
+		{"grouped float", german, 1234.5, "1.234,5"},
+		{"grouped decimal", german, types.NewDecimal(big.NewInt(123456789), 2), "1.234.567,89"},
+		{"exponent", german, 1.5e21, "1,5e+21"},
+		{"not a number", german, "1234.5", "1234.5"},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if got := tc.opts.Format(tc.value); got != tc.want {
+				t.Errorf("want %q got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestOptions_JSONValueTime(t *testing.T) {
+	o := DefaultOptions()
+	o.TimeFormat = TimeEpoch
+	o.ThousandsSeparator = ","
+	b, err := o.MarshalJSONObject([]string{"at", "n"}, []interface{}{types.LocalDate(time.Date(2022, 6, 27, 0, 0, 0, 0, time.Local)), int32(1234)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"at":1656288000,"n":1234}`; string(b) != want {
+		t.Errorf("want %s got %s", want, b)
+	}
+}
+
+func TestValidateTimeFormat(t *testing.T) {
+	for _, f := range []string{"", TimeISO8601, TimeEpoch, "2006-01-02"} {
+		if err := validateTimeFormat(f); err != nil {
+			t.Errorf("%q: %s", f, err)
+		}
+	}
+	if err := validateTimeFormat("epoc"); err == nil {
+		t.Error("a layout without time elements must be an error")
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// templateFormatter executes the template for each row, with the row as a map of the column names to the values.
+type templateFormatter struct {
+	out   io.Writer
+	opts  Options
+	tmpl  *template.Template
+	names []string
+	buf   bytes.Buffer
+}
+
+func newTemplateFormatter(out io.Writer, opts Options) (*templateFormatter, error) {
+	if opts.Template == "" {
+		return nil, errors.New("template output requires a template, set it with --template")
+	}
+	tmpl, err := parseTemplate(opts.Template)
+	if err != nil {
+		return nil, err
+	}
+	return &templateFormatter{out: out, opts: opts, tmpl: tmpl}, nil
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("row").Option("missingkey=error").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func (f *templateFormatter) WriteHeader(names []string) error {
+	f.names = names
+	return nil
+}
+
+// writeTypedHeader leaves out the types, since the column names are the keys of the template.
+func (f *templateFormatter) writeTypedHeader(names, _ []string) error {
+	return f.WriteHeader(names)
+}
+
+func (f *templateFormatter) WriteRow(values []interface{}) error {
+	row := make(map[string]interface{}, len(f.names))
+	for i, name := range f.names {
+		v, err := f.opts.templateValue(values[i])
+		if err != nil {
+			return fmt.Errorf("decoding %s: %w", name, err)
+		}
+		row[name] = v
+	}
+	f.buf.Reset()
+	if err := f.tmpl.Execute(&f.buf, row); err != nil {
+		return err
+	}
+	// each row is on its own line, unless the template ends with a new line already
+	if b := f.buf.Bytes(); len(b) == 0 || b[len(b)-1] != '\n' {
+		f.buf.WriteByte('\n')
+	}
+	_, err := f.out.Write(f.buf.Bytes())
+	return err
+}
+
+func (f *templateFormatter) Close() error {
+	return nil
+}
+
+// templateValue returns the value as seen by the template, JSON values are decoded so that their fields can be accessed.
+func (o Options) templateValue(v interface{}) (interface{}, error) {
+	v, ok := o.JSONValue(v)
+	if !ok {
+		return nil, nil
+	}
+	m, ok := v.(json.Marshaler)
+	if !ok {
+		return v, nil
+	}
+	b, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+)
+
+func TestTemplateFormatter(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Template = `{{.key}}={{.value.name}} ({{.value.age}}){{if gt .value.age 40.0}} senior{{end}}` + "\n"
+	var b bytes.Buffer
+	f, err := NewFormatter(TypeTemplate, &b, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.WriteHeader([]string{"key", "value"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range [][]interface{}{
+		{"k1", serialization.JSON(`{"name":"Jane","age":41}`)},
+		{"k2", serialization.JSON(`{"name":"Joe","age":25}`)},
+	} {
+		if err := f.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := "k1=Jane (41) senior\nk2=Joe (25)\n"
+	if b.String() != want {
+		t.Errorf("want %q got %q", want, b.String())
+	}
+	// the unknown columns are errors instead of empty values
+	opts.Template = "{{.missing}}"
+	f, err = NewFormatter(TypeTemplate, &bytes.Buffer{}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.WriteHeader([]string{"key"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.WriteRow([]interface{}{"k1"}); err == nil {
+		t.Fatal("unknown columns must be an error")
+	}
+	opts.Template = "{{.key"
+	if err := opts.Validate(); err == nil {
+		t.Fatal("invalid templates must be an error")
+	}
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package output
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+const (
+	BinaryHex    = "hex"
+	BinaryBase64 = "base64"
+	BinaryOmit   = "omit"
+)
+
+const (
+	DefaultNullString = "NULL"
+	truncationTail    = "..."
+)
+
+// BinaryFormats is the list of supported binary value renderings.
+var BinaryFormats = []string{BinaryHex, BinaryBase64, BinaryOmit}
+
+// Options controls how the values are rendered by the formatters.
+type Options struct {
+	// Null is the string shown for NULL values in text outputs, JSON output uses null
+	Null string
+	// MaxWidth is the maximum number of characters shown for a value, longer values are truncated with an ellipsis.
+	// Zero means no limit.
+	MaxWidth int
+	// Binary is the rendering of byte array values, one of BinaryFormats
+	Binary string
+	// NoHeader leaves out the column names in the table and CSV outputs
+	NoHeader bool
+	// Template is the Go template applied to each row in the template output
+	Template string
+	// ShowTypes adds the SQL types of the columns to the header, see WriteTypedHeader
+	ShowTypes bool
+	// TimeFormat is the rendering of the temporal values, one of TimeFormats or a Go time layout
+	TimeFormat string
+	// TimeZone is the time zone the TIMESTAMP WITH TIME ZONE values are converted to, they keep their own offset if it is nil
+	TimeZone *time.Location
+	// DecimalSeparator replaces the decimal point of the numbers in text outputs
+	DecimalSeparator string
+	// ThousandsSeparator groups the digits of the numbers in text outputs, the digits are not grouped if it is empty
+	ThousandsSeparator string
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Null:             DefaultNullString,
+		Binary:           BinaryHex,
+		TimeFormat:       TimeISO8601,
+		DecimalSeparator: defaultDecimalSeparator,
+	}
+}
+
+// Validate returns an error if the options are not valid.
+func (o Options) Validate() error {
+	if o.MaxWidth < 0 {
+		return fmt.Errorf("maximum column width cannot be negative: %d", o.MaxWidth)
+	}
+	if o.Template != "" {
+		if _, err := parseTemplate(o.Template); err != nil {
+			return err
+		}
+	}
+	if err := validateTimeFormat(o.TimeFormat); err != nil {
+		return err
+	}
+	switch o.Binary {
+	case BinaryHex, BinaryBase64, BinaryOmit:
+		return nil
+	}
+	return fmt.Errorf("unknown binary format %s, provide one of %v", o.Binary, BinaryFormats)
+}
+
+// Format renders the value as text for table and CSV outputs.
+func (o Options) Format(v interface{}) string {
+	var s string
+	switch vv := v.(type) {
+	case nil:
+		return o.Null
+	case []byte:
+		s = o.formatBinary(vv)
+	default:
+		if t, ok := o.timeValue(v); ok {
+			s = String(t)
+		} else if n, ok := o.formatNumber(v); ok {
+			s = n
+		} else {
+			s = String(v)
+		}
+	}
+	return o.truncate(s)
+}
+
+// String renders the value as text, the temporal types and decimals of the client are rendered in their SQL literal format.
+func String(v interface{}) string {
+	switch vv := v.(type) {
+	case types.LocalDate:
+		return time.Time(vv).Format("2006-01-02")
+	case types.LocalTime:
+		return time.Time(vv).Format("15:04:05.999999999")
+	case types.LocalDateTime:
+		return time.Time(vv).Format("2006-01-02T15:04:05.999999999")
+	case types.OffsetDateTime:
+		return time.Time(vv).Format(time.RFC3339Nano)
+	case types.Decimal:
+		return decimalString(vv)
+	case *types.Decimal:
+		if vv != nil {
+			return decimalString(*vv)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+// TypedString renders the value as text prefixed with its type, so that the values of different types
+// which are rendered the same, such as the string "1" and the integer 1, can be told apart.
+func TypedString(v interface{}) string {
+	return fmt.Sprintf("%T:%s", v, String(v))
+}
+
+// QuoteIdentifier quotes the name so that it is used as is in an SQL statement, such as the name of a mapping or a column.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// QuoteLiteral quotes the string as an SQL string literal, such as an option of a mapping.
+func QuoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func decimalString(d types.Decimal) string {
+	digits := d.UnscaledValue().String()
+	scale := d.Scale()
+	if scale == 0 {
+		return digits
+	}
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+// JSONValue returns the value to be encoded in JSON output.
+// ok is false if the value should be left out.
+func (o Options) JSONValue(v interface{}) (value interface{}, ok bool) {
+	switch vv := v.(type) {
+	case nil:
+		return nil, true
+	case []byte:
+		if o.Binary == BinaryOmit {
+			return nil, false
+		}
+		return o.truncate(o.formatBinary(vv)), true
+	case string:
+		return o.truncate(vv), true
+	case types.LocalDate, types.LocalTime, types.LocalDateTime, types.OffsetDateTime:
+		return o.timeValue(vv)
+	case types.Decimal, *types.Decimal, *big.Int:
+		// encoded as a string to keep the precision
+		return String(vv), true
+	case bool, int8, int16, int32, int64, int, float32, float64:
+		return vv, true
+	case json.Marshaler:
+		return vv, true
+	case fmt.Stringer:
+		return o.truncate(vv.String()), true
+	}
+	return v, true
+}
+
+// MarshalJSONObject encodes the given fields as a JSON object, preserving their order.
+func (o Options) MarshalJSONObject(names []string, values []interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	first := true
+	for i, name := range names {
+		v, ok := o.JSONValue(values[i])
+		if !ok {
+			continue
+		}
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		kb, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		vb, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", name, err)
+		}
+		b.Write(kb)
+		b.WriteByte(':')
+		b.Write(vb)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func (o Options) formatBinary(b []byte) string {
+	switch o.Binary {
+	case BinaryBase64:
+		return base64.StdEncoding.EncodeToString(b)
+	case BinaryOmit:
+		return fmt.Sprintf("<%d bytes>", len(b))
+	}
+	return hex.EncodeToString(b)
+}
+
+func (o Options) truncate(s string) string {
+	if o.MaxWidth <= 0 {
+		return s
+	}
+	return runewidth.Truncate(s, o.MaxWidth, truncationTail)
+}
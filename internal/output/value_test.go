@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package output
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestOptions_Format(t *testing.T) {
+	for _, tc := range []struct {
+		info  string
+		opts  Options
+		value interface{}
+		want  string
+	}{
+		{"null", DefaultOptions(), nil, "NULL"},
+		{"custom null", Options{Null: "-", Binary: BinaryHex}, nil, "-"},
+		{"hex", DefaultOptions(), []byte{0xca, 0xfe}, "cafe"},
+		{"base64", Options{Binary: BinaryBase64}, []byte("hi"), "aGk="},
+		{"omit", Options{Binary: BinaryOmit}, []byte("hello"), "<5 bytes>"},
+		{"truncated", Options{MaxWidth: 8}, "hello world", "hello..."},
+		{"fits", Options{MaxWidth: 8}, "hello", "hello"},
+		{"number", DefaultOptions(), int32(42), "42"},
+		{"decimal", DefaultOptions(), types.NewDecimal(big.NewInt(-12345), 3), "-12.345"},
+		{"small decimal", DefaultOptions(), types.NewDecimal(big.NewInt(5), 3), "0.005"},
+		{"date", DefaultOptions(), types.LocalDate(time.Date(2022, 6, 27, 0, 0, 0, 0, time.UTC)), "2022-06-27"},
+		{"big integer", DefaultOptions(), new(big.Int).Lsh(big.NewInt(1), 100), "1267650600228229401496703205376"},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if got := tc.opts.Format(tc.value); got != tc.want {
+				t.Errorf("want %q got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestOptions_MarshalJSONObject(t *testing.T) {
+	opts := Options{Binary: BinaryOmit, MaxWidth: 5}
+	names := []string{"name", "age", "photo", "extra", "data"}
+	values := []interface{}{"Jane Brown", int32(41), []byte{1, 2}, nil, serialization.JSON(`{"a":1}`)}
+	b, err := opts.MarshalJSONObject(names, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"Ja...","age":41,"extra":null,"data":{"a":1}}`
+	if string(b) != want {
+		t.Errorf("want %s got %s", want, b)
+	}
+}
+
+func TestOptions_JSONValuePrecision(t *testing.T) {
+	opts := DefaultOptions()
+	names := []string{"big", "decimal"}
+	values := []interface{}{new(big.Int).Lsh(big.NewInt(1), 100), types.NewDecimal(new(big.Int).Lsh(big.NewInt(1), 100), 10)}
+	b, err := opts.MarshalJSONObject(names, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"big":"1267650600228229401496703205376","decimal":"126765060022822940149.6703205376"}`; string(b) != want {
+		t.Errorf("want %s got %s", want, b)
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{in: "job", want: `"job"`},
+		{in: `my "job"`, want: `"my ""job"""`},
+		{in: "it's", want: `"it's"`},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := QuoteIdentifier(tc.in); got != tc.want {
+				t.Errorf("want %s got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestQuoteLiteral(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{in: "job", want: `'job'`},
+		{in: `my "job"`, want: `'my "job"'`},
+		{in: "it's", want: `'it''s'`},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := QuoteLiteral(tc.in); got != tc.want {
+				t.Errorf("want %s got %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package partition finds the partitions of the keys on the client, the way the members find them.
+// The Go client does not expose its partition service, so the keys are serialized and hashed here.
+package partition
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// DefaultCount is the default partition count of the members.
+const DefaultCount = 271
+
+// seed is the seed of the MurmurHash3 hash of the serialized keys
+const seed uint32 = 0x01000193
+
+// ID returns the partition of the key, for the keys of the types which are serialized the same by all the clients: strings and integers.
+// The keys are serialized in big endian, which is the default byte order of the serialization.
+func ID(key interface{}, count int32) (int32, error) {
+	b, err := payload(key)
+	if err != nil {
+		return 0, err
+	}
+	return index(murmur3(b), count), nil
+}
+
+// payload returns the serialized key without the header of the serialized data, only the rest is hashed.
+func payload(key interface{}) ([]byte, error) {
+	var b []byte
+	switch k := key.(type) {
+	case string:
+		b = make([]byte, 4+len(k))
+		binary.BigEndian.PutUint32(b, uint32(len(k)))
+		copy(b[4:], k)
+	case int:
+		// int is serialized as int64
+		b = make([]byte, 8)
+		binary.BigEndian.PutUint64(b, uint64(k))
+	case int64:
+		b = make([]byte, 8)
+		binary.BigEndian.PutUint64(b, uint64(k))
+	case int32:
+		b = make([]byte, 4)
+		binary.BigEndian.PutUint32(b, uint32(k))
+	case int16:
+		b = make([]byte, 2)
+		binary.BigEndian.PutUint16(b, uint16(k))
+	case int8:
+		b = []byte{byte(k)}
+	case uint8:
+		b = []byte{k}
+	default:
+		return nil, fmt.Errorf("the partition of keys of type %T cannot be found", key)
+	}
+	return b, nil
+}
+
+// murmur3 returns the MurmurHash3 x86 32-bit hash of b.
+func murmur3(b []byte) int32 {
+	const (
+		c1 uint32 = 0xcc9e2d51
+		c2 uint32 = 0x1b873593
+	)
+	h := seed
+	n := len(b) &^ 3
+	for i := 0; i < n; i += 4 {
+		k := binary.LittleEndian.Uint32(b[i:])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+	var k uint32
+	switch len(b) & 3 {
+	case 3:
+		k ^= uint32(b[n+2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(b[n+1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(b[n])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+	h ^= uint32(len(b))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return int32(h)
+}
+
+// index returns the partition of the hash.
+func index(hash, count int32) int32 {
+	if hash == math.MinInt32 {
+		return 0
+	}
+	if hash < 0 {
+		hash = -hash
+	}
+	return hash % count
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package partition
+
+import (
+	"testing"
+)
+
+func TestID(t *testing.T) {
+	for _, tc := range []struct {
+		key  interface{}
+		want int32
+	}{
+		{key: "a", want: 73},
+		{key: "hello", want: 270},
+		{key: "", want: 11},
+		{key: int64(1), want: 110},
+		// int is serialized as int64
+		{key: 1, want: 110},
+		{key: int32(1), want: 31},
+		{key: int8(-1), want: 189},
+	} {
+		got, err := ID(tc.key, DefaultCount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("%T %v: want %d got %d", tc.key, tc.key, tc.want, got)
+		}
+	}
+	if _, err := ID(1.5, DefaultCount); err == nil {
+		t.Fatal("the partition of a float key must not be found")
+	}
+}
+
+func TestIndex(t *testing.T) {
+	for _, tc := range []struct {
+		hash, want int32
+	}{
+		{hash: 272, want: 1},
+		{hash: -272, want: 1},
+		{hash: -2147483648, want: 0},
+	} {
+		if got := index(tc.hash, DefaultCount); got != tc.want {
+			t.Errorf("%d: want %d got %d", tc.hash, tc.want, got)
+		}
+	}
+}
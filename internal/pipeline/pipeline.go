@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Pipeline runs invocations concurrently, with at most depth of them in flight, instead of waiting for each round trip.
+// It measures the latency of each invocation. The first failed invocation cancels the others.
+type Pipeline struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	slots     chan struct{}
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+	err       error
+	latencies []time.Duration
+	start     time.Time
+}
+
+// New returns a pipeline which keeps at most depth invocations in flight, depth is at least 1.
+func New(ctx context.Context, depth int) *Pipeline {
+	if depth < 1 {
+		depth = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Pipeline{ctx: ctx, cancel: cancel, slots: make(chan struct{}, depth), start: time.Now()}
+}
+
+// Go starts the invocation when there is room in the pipeline, blocking until then.
+// The invocation is skipped if the pipeline is canceled, because of a failed invocation or the parent context.
+func (p *Pipeline) Go(invoke func(ctx context.Context) error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-p.ctx.Done():
+		return
+	}
+	if p.ctx.Err() != nil {
+		<-p.slots
+		return
+	}
+	p.wg.Add(1)
+	go func() {
+		defer func() {
+			<-p.slots
+			p.wg.Done()
+		}()
+		start := time.Now()
+		err := invoke(p.ctx)
+		latency := time.Since(start)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if err != nil {
+			if p.err == nil {
+				p.err = err
+				p.cancel()
+			}
+			return
+		}
+		p.latencies = append(p.latencies, latency)
+	}()
+}
+
+// Wait waits for the invocations in flight, and returns the statistics of the successful ones and the first error.
+// The error of the parent context is returned if it is done before all the invocations are started.
+func (p *Pipeline) Wait() (Stats, error) {
+	p.wg.Wait()
+	defer p.cancel()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err := p.err
+	if err == nil && p.ctx.Err() != nil {
+		err = p.ctx.Err()
+	}
+	return newStats(p.latencies, time.Since(p.start)), err
+}
+
+// Stats are the aggregate latencies of the invocations.
+type Stats struct {
+	Count   int
+	Elapsed time.Duration
+	Min     time.Duration
+	Mean    time.Duration
+	P50     time.Duration
+	P99     time.Duration
+	Max     time.Duration
+}
+
+func newStats(latencies []time.Duration, elapsed time.Duration) Stats {
+	s := Stats{Count: len(latencies), Elapsed: elapsed}
+	if s.Count == 0 {
+		return s
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+	s.Min = sorted[0]
+	s.Max = sorted[s.Count-1]
+	s.Mean = total / time.Duration(s.Count)
+	s.P50 = percentile(sorted, 50)
+	s.P99 = percentile(sorted, 99)
+	return s
+}
+
+// percentile returns the nearest rank percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func (s Stats) String() string {
+	r := func(d time.Duration) time.Duration {
+		return d.Round(time.Microsecond)
+	}
+	return fmt.Sprintf("%d invocations in %s, latency min %s, mean %s, p50 %s, p99 %s, max %s",
+		s.Count, r(s.Elapsed), r(s.Min), r(s.Mean), r(s.P50), r(s.P99), r(s.Max))
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPipelineDepth(t *testing.T) {
+	p := New(context.Background(), 3)
+	var inFlight, maxInFlight int32
+	for i := 0; i < 20; i++ {
+		p.Go(func(ctx context.Context) error {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return nil
+		})
+	}
+	stats, err := p.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Count != 20 {
+		t.Fatalf("got %d invocations, want 20", stats.Count)
+	}
+	if maxInFlight > 3 {
+		t.Fatalf("%d invocations were in flight, the depth is 3", maxInFlight)
+	}
+}
+
+func TestPipelineError(t *testing.T) {
+	p := New(context.Background(), 2)
+	failure := errors.New("failure")
+	var started int32
+	for i := 0; i < 100; i++ {
+		i := i
+		p.Go(func(ctx context.Context) error {
+			atomic.AddInt32(&started, 1)
+			if i == 0 {
+				return failure
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}
+	if _, err := p.Wait(); !errors.Is(err, failure) {
+		t.Fatalf("got %v, want the first error", err)
+	}
+	if started > 3 {
+		t.Fatalf("%d invocations were started after the failure", started)
+	}
+}
+
+func TestStats(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	s := newStats(latencies, time.Second)
+	want := Stats{
+		Count:   100,
+		Elapsed: time.Second,
+		Min:     time.Millisecond,
+		Mean:    50500 * time.Microsecond,
+		P50:     50 * time.Millisecond,
+		P99:     99 * time.Millisecond,
+		Max:     100 * time.Millisecond,
+	}
+	if s != want {
+		t.Fatalf("got %+v, want %+v", s, want)
+	}
+	if s := newStats(nil, 0); s.Count != 0 || s.Max != 0 {
+		t.Fatalf("unexpected stats of no invocations: %+v", s)
+	}
+}
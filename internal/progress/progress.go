@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package progress
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"golang.org/x/term"
+)
+
+const (
+	barWidth = 30
+	// terminalRefreshInterval is the interval to redraw the bar in place
+	terminalRefreshInterval = 100 * time.Millisecond
+	// plainRefreshInterval is the interval to print a line when the output is not a terminal
+	plainRefreshInterval = 2 * time.Second
+)
+
+// hidden is 1 while the progress must not be shown, such as in the quiet mode
+var hidden int32
+
+// SetHidden hides the trackers and spinners created afterwards, if h is true.
+func SetHidden(h bool) {
+	var v int32
+	if h {
+		v = 1
+	}
+	atomic.StoreInt32(&hidden, v)
+}
+
+func isHidden() bool {
+	return atomic.LoadInt32(&hidden) == 1
+}
+
+// Tracker shows the progress of a long-running operation together with its throughput and estimated remaining time.
+// A progress bar is drawn in place if the output is a terminal, otherwise a line is printed periodically.
+type Tracker struct {
+	out      io.Writer
+	title    string
+	total    int64
+	current  int64
+	start    time.Time
+	bar      *progress.Model
+	done     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// New creates and starts a tracker, total is the number of items to process, or zero if it is unknown.
+func New(out io.Writer, title string, total int64) *Tracker {
+	if isHidden() {
+		out = ioutil.Discard
+	}
+	t := &Tracker{
+		out:   out,
+		title: title,
+		total: total,
+		start: time.Now(),
+		done:  make(chan struct{}),
+	}
+	interval := plainRefreshInterval
+	if isTerminal(out) {
+		bar := progress.New(progress.WithDefaultGradient(), progress.WithWidth(barWidth), progress.WithoutPercentage())
+		t.bar = &bar
+		interval = terminalRefreshInterval
+	}
+	t.wg.Add(1)
+	go t.refresh(interval)
+	return t
+}
+
+// Add increases the number of processed items by n.
+func (t *Tracker) Add(n int64) {
+	atomic.AddInt64(&t.current, n)
+}
+
+// Done stops the tracker and prints the final state.
+func (t *Tracker) Done() {
+	t.stopOnce.Do(func() {
+		close(t.done)
+		t.wg.Wait()
+		t.render(true)
+	})
+}
+
+func (t *Tracker) refresh(interval time.Duration) {
+	defer t.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+			t.render(false)
+		}
+	}
+}
+
+func (t *Tracker) render(final bool) {
+	line := t.status(atomic.LoadInt64(&t.current), time.Since(t.start))
+	if t.bar == nil {
+		fmt.Fprintln(t.out, line)
+		return
+	}
+	// erase the rest of the line, since the previous status might have been longer
+	fmt.Fprintf(t.out, "\r%s\x1b[K", line)
+	if final {
+		fmt.Fprintln(t.out)
+	}
+}
+
+func (t *Tracker) status(current int64, elapsed time.Duration) string {
+	var b strings.Builder
+	b.WriteString(t.title)
+	rate := float64(current) / elapsed.Seconds()
+	if t.total <= 0 {
+		fmt.Fprintf(&b, " %d", current)
+	} else {
+		percent := float64(current) / float64(t.total)
+		if percent > 1 {
+			percent = 1
+		}
+		if t.bar != nil {
+			b.WriteString(" ")
+			b.WriteString(t.bar.ViewAs(percent))
+		}
+		fmt.Fprintf(&b, " %3.0f%% %d/%d", percent*100, current, t.total)
+	}
+	fmt.Fprintf(&b, " %.1f/s", rate)
+	if t.total > 0 && current < t.total && rate > 0 {
+		eta := time.Duration(float64(t.total-current) / rate * float64(time.Second))
+		fmt.Fprintf(&b, " ETA %s", eta.Round(time.Second))
+	} else {
+		fmt.Fprintf(&b, " in %s", elapsed.Round(time.Millisecond))
+	}
+	return b.String()
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package progress
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestTrackerStatus(t *testing.T) {
+	for _, tc := range []struct {
+		info    string
+		total   int64
+		current int64
+		elapsed time.Duration
+		want    string
+	}{
+		{"in progress", 1000, 500, 10 * time.Second, "Putting  50% 500/1000 50.0/s ETA 10s"},
+		{"completed", 1000, 1000, 4 * time.Second, "Putting 100% 1000/1000 250.0/s in 4s"},
+		{"unknown total", 0, 300, 2 * time.Second, "Putting 300 150.0/s in 2s"},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			tr := &Tracker{title: "Putting", total: tc.total}
+			if got := tr.status(tc.current, tc.elapsed); got != tc.want {
+				t.Errorf("want %q got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSpinnerNotTerminal(t *testing.T) {
+	var b bytes.Buffer
+	s := NewSpinner(&b, "Connecting")
+	s.Stop()
+	// the spinner is only drawn on a terminal
+	if b.Len() != 0 {
+		t.Errorf("want no output got %q", b.String())
+	}
+}
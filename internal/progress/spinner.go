@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package progress
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const spinnerInterval = 100 * time.Millisecond
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Spinner shows that an operation of unknown length is in progress.
+// It is drawn in place only if the output is a terminal, nothing is printed otherwise.
+type Spinner struct {
+	out   io.Writer
+	title string
+	// active is false if the output is not a terminal or the progress is hidden
+	active   bool
+	done     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewSpinner creates and starts a spinner with the given title.
+func NewSpinner(out io.Writer, title string) *Spinner {
+	s := &Spinner{out: out, title: title, active: isTerminal(out) && !isHidden(), done: make(chan struct{})}
+	if !s.active {
+		return s
+	}
+	s.wg.Add(1)
+	go s.spin()
+	return s
+}
+
+// Stop stops the spinner and erases it.
+func (s *Spinner) Stop() {
+	if !s.active {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.done)
+		s.wg.Wait()
+		fmt.Fprint(s.out, "\r\x1b[K")
+	})
+}
+
+func (s *Spinner) spin() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		fmt.Fprintf(s.out, "\r%s %s\x1b[K", spinnerFrames[i%len(spinnerFrames)], s.title)
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
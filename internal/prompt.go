@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"regexp"
+)
+
+// DefaultPrompt is the prompt template of the interactive mode if none is configured.
+const DefaultPrompt = "hzc {address}@{cluster}{names}> "
+
+var promptVariable = regexp.MustCompile(`\{[a-z]+\}`)
+
+// RenderPrompt replaces the {name} variables in the template with their values.
+// Unknown variables are kept as they are, so that typos are visible in the prompt.
+func RenderPrompt(template string, vars map[string]string) string {
+	return promptVariable.ReplaceAllStringFunc(template, func(v string) string {
+		if value, ok := vars[v[1:len(v)-1]]; ok {
+			return value
+		}
+		return v
+	})
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import "testing"
+
+func TestRenderPrompt(t *testing.T) {
+	vars := map[string]string{"config": "prod", "cluster": "dev", "state": "connected", "map": ""}
+	for _, tc := range []struct {
+		info     string
+		template string
+		want     string
+	}{
+		{info: "variables", template: "{config}@{cluster}[{state}]> ", want: "prod@dev[connected]> "},
+		{info: "empty value", template: "{cluster}{map}> ", want: "dev> "},
+		{info: "unknown variable", template: "{cluster}{unknown}> ", want: "dev{unknown}> "},
+		{info: "no variables", template: "> ", want: "> "},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if got := RenderPrompt(tc.template, vars); got != tc.want {
+				t.Errorf("want %q got %q", tc.want, got)
+			}
+		})
+	}
+}
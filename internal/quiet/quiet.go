@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package quiet
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/progress"
+)
+
+// Flag is the name of the global flag which suppresses the informational output
+const Flag = "quiet"
+
+// Enabled returns true if the quiet flag is given for the command.
+// Commands must print only the data in that case, without headers, footers and status messages.
+func Enabled(cmd *cobra.Command) bool {
+	f := cmd.Flag(Flag)
+	return f != nil && f.Value.String() == "true"
+}
+
+// Printf writes the informational message with cmd.Printf, unless the quiet flag is given.
+func Printf(cmd *cobra.Command, format string, args ...interface{}) {
+	if !Enabled(cmd) {
+		cmd.Printf(format, args...)
+	}
+}
+
+// Println is the same as Printf, but with the default formats and a new line.
+func Println(cmd *cobra.Command, args ...interface{}) {
+	if !Enabled(cmd) {
+		cmd.Println(args...)
+	}
+}
+
+// EnableQuiet wraps the commands in the tree so that the progress indicators, such as the connection spinner,
+// are hidden while the commands run with the quiet flag.
+func EnableQuiet(root *cobra.Command) {
+	for _, c := range root.Commands() {
+		EnableQuiet(c)
+	}
+	if root.RunE == nil {
+		return
+	}
+	runE := root.RunE
+	root.RunE = func(cmd *cobra.Command, args []string) error {
+		progress.SetHidden(Enabled(cmd))
+		defer progress.SetHidden(false)
+		return runE(cmd, args)
+	}
+}
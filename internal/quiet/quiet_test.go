@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package quiet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestPrintf(t *testing.T) {
+	for _, tc := range []struct {
+		info string
+		args []string
+		want string
+	}{
+		{info: "without the flag", args: []string{"sub"}, want: "done\n"},
+		{info: "quiet", args: []string{"sub", "--quiet"}, want: ""},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			var out bytes.Buffer
+			root := &cobra.Command{Use: "root", SilenceErrors: true, SilenceUsage: true}
+			root.PersistentFlags().Bool(Flag, false, "")
+			root.SetOut(&out)
+			root.AddCommand(&cobra.Command{Use: "sub", RunE: func(cmd *cobra.Command, args []string) error {
+				Printf(cmd, "%s\n", "done")
+				return nil
+			}})
+			EnableQuiet(root)
+			root.SetArgs(tc.args)
+			if err := root.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tc.want {
+				t.Errorf("want %q got %q", tc.want, out.String())
+			}
+		})
+	}
+}
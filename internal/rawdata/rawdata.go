@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rawdata
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+)
+
+const (
+	// JavaSerializableTypeID is the type ID of the values written with the Java serialization, which the Go client cannot read
+	JavaSerializableTypeID int32 = -100
+	// headerSize is the size of the partition hash and the type ID before the payload of a serialized value
+	headerSize = 8
+	// bytesPerLine is the number of the bytes in each line of the dump
+	bytesPerLine = 16
+)
+
+// Data is a value which the client cannot deserialize, kept as its serialized bytes.
+type Data struct {
+	// Header is false if the value is a field of another value, so there is no partition hash
+	Header        bool
+	PartitionHash int32
+	TypeID        int32
+	Payload       []byte
+}
+
+func (d Data) String() string {
+	return fmt.Sprintf("<type %d, %d bytes>", d.TypeID, len(d.Payload))
+}
+
+// Register sets the serializers which read the values of the type IDs as Data, instead of failing to deserialize them.
+// The custom serializers of the Go client must have positive type IDs, so the values written with the Java serialization
+// are read with the global serializer, if no other global serializer is set.
+func Register(config *serialization.Config, typeIDs []int32) error {
+	for _, id := range typeIDs {
+		if id <= 0 {
+			return fmt.Errorf("raw type IDs must be positive: %d", id)
+		}
+		// the serializers are registered by the type they write, which is never written, so each gets a distinct zero sized type
+		t := reflect.ArrayOf(int(id), reflect.TypeOf(struct{}{}))
+		if err := config.SetCustomSerializer(t, serializer{id: id}); err != nil {
+			return err
+		}
+	}
+	if g := config.GlobalSerializer(); g == nil || g.ID() == JavaSerializableTypeID {
+		config.SetGlobalSerializer(serializer{id: JavaSerializableTypeID})
+	}
+	return nil
+}
+
+type serializer struct {
+	id int32
+}
+
+func (s serializer) ID() int32 {
+	return s.id
+}
+
+func (s serializer) Read(input serialization.DataInput) interface{} {
+	d := Data{TypeID: s.id}
+	// the values in the maps and the collections start after the header, the fields of other values do not have one
+	if pos := input.Position(); pos == headerSize {
+		input.SetPosition(0)
+		d.PartitionHash = input.ReadInt32()
+		d.TypeID = input.ReadInt32()
+		d.Header = true
+	}
+	// the payload is the rest of the input
+	if in, ok := input.(interface{ Available() int32 }); ok {
+		d.Payload = make([]byte, in.Available())
+		for i := range d.Payload {
+			d.Payload[i] = input.ReadByte()
+		}
+	}
+	return d
+}
+
+// Write writes the payload of the raw data back as it is, so the values read as raw data can be copied, such as to another cluster.
+// Since the global serializer is used for all the types the client cannot serialize otherwise, the other values are errors.
+func (s serializer) Write(output serialization.DataOutput, object interface{}) {
+	d, ok := object.(Data)
+	if !ok || d.TypeID != s.id {
+		panic(fmt.Errorf("values of type %T cannot be serialized", object))
+	}
+	for _, b := range d.Payload {
+		output.WriteByte(b)
+	}
+}
+
+// Dump returns the lines of the hex and ASCII dump of the data, the header bytes are annotated.
+func Dump(d Data) []string {
+	var lines []string
+	if d.Header {
+		header := make([]byte, headerSize)
+		binary.BigEndian.PutUint32(header[:4], uint32(d.PartitionHash))
+		binary.BigEndian.PutUint32(header[4:], uint32(d.TypeID))
+		lines = append(lines,
+			fmt.Sprintf("partition hash  % x  (%d)", header[:4], d.PartitionHash),
+			fmt.Sprintf("type id         % x  (%d)", header[4:], d.TypeID))
+	} else {
+		lines = append(lines, fmt.Sprintf("type id         %d", d.TypeID))
+	}
+	lines = append(lines, fmt.Sprintf("payload         %d bytes", len(d.Payload)))
+	return append(lines, HexDump(d.Payload)...)
+}
+
+// HexDump returns the lines of the hex and ASCII dump of b, with the offsets of the lines.
+func HexDump(b []byte) []string {
+	var lines []string
+	for off := 0; off < len(b); off += bytesPerLine {
+		end := off + bytesPerLine
+		if end > len(b) {
+			end = len(b)
+		}
+		var hex, ascii strings.Builder
+		for i := off; i < off+bytesPerLine; i++ {
+			if i == off+bytesPerLine/2 {
+				hex.WriteByte(' ')
+			}
+			if i >= end {
+				hex.WriteString("   ")
+				continue
+			}
+			fmt.Fprintf(&hex, "%02x ", b[i])
+			if c := b[i]; c >= 0x20 && c < 0x7f {
+				ascii.WriteByte(c)
+			} else {
+				ascii.WriteByte('.')
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%08x  %s |%s|", off, hex.String(), ascii.String()))
+	}
+	return lines
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rawdata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+)
+
+func TestDump(t *testing.T) {
+	d := Data{Header: true, PartitionHash: 0x1234, TypeID: 1001, Payload: []byte("Hello, serialized world\x00\x01")}
+	want := []string{
+		"partition hash  00 00 12 34  (4660)",
+		"type id         00 00 03 e9  (1001)",
+		"payload         25 bytes",
+		"00000000  48 65 6c 6c 6f 2c 20 73  65 72 69 61 6c 69 7a 65  |Hello, serialize|",
+		"00000010  64 20 77 6f 72 6c 64 00  01                       |d world..|",
+	}
+	if got := Dump(d); !reflect.DeepEqual(want, got) {
+		t.Fatalf("want\n%q\ngot\n%q", want, got)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	var c serialization.Config
+	if err := Register(&c, []int32{1001, 1002}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(c.CustomSerializers()); n != 2 {
+		t.Fatalf("want 2 custom serializers got %d", n)
+	}
+	if g := c.GlobalSerializer(); g == nil || g.ID() != JavaSerializableTypeID {
+		t.Fatalf("want the Java serializable type as the global serializer, got %v", g)
+	}
+	// registering again is harmless, since the configuration is updated each time it is loaded
+	if err := Register(&c, []int32{1001}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Register(&c, []int32{-5}); err == nil {
+		t.Fatal("non-positive type IDs must be an error")
+	}
+}
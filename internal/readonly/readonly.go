@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package readonly
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+)
+
+const (
+	// Flag is the name of the global flag which enables the read-only mode
+	Flag = "read-only"
+	// Annotation marks the commands which change data only for some of their arguments, such as sql.
+	// Such commands must check Enabled themselves.
+	Annotation = "readonlycheck"
+)
+
+// ChecksItself marks the command as checking the read-only mode itself.
+func ChecksItself(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[Annotation] = "true"
+	return cmd
+}
+
+// Enabled returns true if the read-only mode is enabled with the flag or in the configuration.
+func Enabled(cmd *cobra.Command, conf *config.Config) bool {
+	if conf.ReadOnly {
+		return true
+	}
+	f := cmd.Flag(Flag)
+	return f != nil && f.Value.String() == "true"
+}
+
+// Error returns the error for the operation which is not allowed in the read-only mode.
+func Error(operation string) error {
+	return hzcerrors.NewLoggableError(nil, "%s changes data, it is not allowed in the read-only mode", operation)
+}
+
+// EnableReadOnly wraps the commands in the tree so that the commands which change data return an error in the read-only mode.
+// The commands which support the dry run mode are the ones which change data, they are still allowed with the dry run flag.
+func EnableReadOnly(root *cobra.Command, conf *config.Config) {
+	for _, c := range root.Commands() {
+		EnableReadOnly(c, conf)
+	}
+	if root.RunE == nil {
+		return
+	}
+	runE := root.RunE
+	root.RunE = func(cmd *cobra.Command, args []string) error {
+		if cmd.Annotations[dryrun.Annotation] == "true" && cmd.Annotations[Annotation] != "true" &&
+			!dryrun.Enabled(cmd) && Enabled(cmd, conf) {
+			return Error(cmd.CommandPath())
+		}
+		return runE(cmd, args)
+	}
+}
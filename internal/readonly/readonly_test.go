@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package readonly
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+)
+
+func TestEnableReadOnly(t *testing.T) {
+	for _, tc := range []struct {
+		info     string
+		mutating bool
+		readOnly bool
+		args     []string
+		ran      bool
+		isErr    bool
+	}{
+		{info: "not read-only", mutating: true, args: []string{"sub"}, ran: true},
+		{info: "read-only flag", mutating: true, args: []string{"sub", "--read-only"}, isErr: true},
+		{info: "read-only configuration", mutating: true, readOnly: true, args: []string{"sub"}, isErr: true},
+		{info: "dry run", mutating: true, args: []string{"sub", "--read-only", "--dry-run"}, ran: true},
+		{info: "not mutating", args: []string{"sub", "--read-only"}, ran: true},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			var ran bool
+			root := &cobra.Command{Use: "root", SilenceErrors: true, SilenceUsage: true}
+			root.PersistentFlags().Bool(Flag, false, "")
+			root.PersistentFlags().Bool(dryrun.Flag, false, "")
+			sub := &cobra.Command{Use: "sub", RunE: func(cmd *cobra.Command, args []string) error {
+				ran = true
+				return nil
+			}}
+			if tc.mutating {
+				dryrun.Supported(sub)
+			}
+			root.AddCommand(sub)
+			EnableReadOnly(root, &config.Config{ReadOnly: tc.readOnly})
+			root.SetArgs(tc.args)
+			err := root.Execute()
+			if tc.isErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ran != tc.ran {
+				t.Errorf("want ran %t got %t", tc.ran, ran)
+			}
+		})
+	}
+}
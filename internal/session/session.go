@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/shlex"
+)
+
+// secretFlags are left out of the recorded commands together with their values.
+var secretFlags = []string{"--password", "--cloud-token"}
+
+// Command is a recorded command with the metadata of its result.
+type Command struct {
+	StartedAt time.Time `json:"startedAt"`
+	Args      []string  `json:"args"`
+	Duration  string    `json:"duration"`
+	// Error is the error message if the command failed
+	Error string `json:"error,omitempty"`
+}
+
+// Session is the content of a recording file.
+type Session struct {
+	Commands []Command `json:"commands"`
+}
+
+var (
+	mu        sync.Mutex
+	recording *Session
+	path      string
+	// executed are the commands run in this process, they can be saved as a script
+	executed []Command
+)
+
+// StartRecording records the commands executed afterwards to the file at p.
+// The commands are appended to the session in the file if it exists.
+func StartRecording(p string) error {
+	s, err := Load(p)
+	if os.IsNotExist(err) {
+		s, err = &Session{}, nil
+	}
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	recording, path = s, p
+	return write()
+}
+
+// Record keeps the command to save it with SaveScript, and adds it to the session file if recording is started.
+// The password and the cloud token are not recorded.
+func Record(args []string, start time.Time, cmdErr error) error {
+	mu.Lock()
+	defer mu.Unlock()
+	c := Command{
+		StartedAt: start,
+		Args:      RemoveFlags(args, "--record"),
+		Duration:  time.Since(start).Round(time.Millisecond).String(),
+	}
+	c.Args = RemoveFlags(c.Args, secretFlags...)
+	if cmdErr != nil {
+		c.Error = cmdErr.Error()
+	}
+	executed = append(executed, c)
+	if recording == nil {
+		return nil
+	}
+	recording.Commands = append(recording.Commands, c)
+	return write()
+}
+
+// SaveScript writes the commands which succeeded in this process to the file at p, one command per line.
+func SaveScript(p string) (int, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	var b strings.Builder
+	b.WriteString("# saved by hzc, run with: hzc replay " + quote(p) + "\n")
+	var n int
+	for _, c := range executed {
+		if c.Error != "" {
+			continue
+		}
+		b.WriteString(CommandLine(c.Args) + "\n")
+		n++
+	}
+	return n, ioutil.WriteFile(p, []byte(b.String()), 0600)
+}
+
+// Load reads the session in the file at p.
+// The file is either a recording in JSON or a script with a command on each line, as written by SaveScript.
+func Load(p string) (*Session, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(strings.TrimSpace(string(b)), "{") {
+		var s Session
+		if err := json.Unmarshal(b, &s); err != nil {
+			return nil, err
+		}
+		return &s, nil
+	}
+	return parseScript(string(b))
+}
+
+// parseScript reads the commands in the script, skipping the empty lines and the comments starting with #.
+func parseScript(text string) (*Session, error) {
+	var s Session
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		args, err := shlex.Split(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		s.Commands = append(s.Commands, Command{Args: args})
+	}
+	return &s, nil
+}
+
+// CommandLine joins the arguments so that they can be split again in the same way by the shell.
+func CommandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = quote(a)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func quote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\#$&;|<>()*?`") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// write replaces the file with the current session, so that it is complete even if the process is killed.
+func write() error {
+	b, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(b, '\n'), 0600)
+}
+
+// RemoveFlags returns args without the given flags and their values.
+// The flags can be given as "--flag value" or "--flag=value".
+func RemoveFlags(args []string, flags ...string) []string {
+	var out []string
+	for i := 0; i < len(args); i++ {
+		if matchesFlag(args[i], flags) {
+			if !strings.Contains(args[i], "=") {
+				// skip the value as well
+				i++
+			}
+			continue
+		}
+		out = append(out, args[i])
+	}
+	return out
+}
+
+func matchesFlag(arg string, flags []string) bool {
+	for _, f := range flags {
+		if arg == f || strings.HasPrefix(arg, f+"=") {
+			return true
+		}
+	}
+	return false
+}
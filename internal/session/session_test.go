@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveFlags(t *testing.T) {
+	for _, tc := range []struct {
+		info string
+		args []string
+		want []string
+	}{
+		{info: "no flags", args: []string{"map", "get", "--key", "k1"}, want: []string{"map", "get", "--key", "k1"}},
+		{info: "separate value", args: []string{"--password", "secret", "map", "get"}, want: []string{"map", "get"}},
+		{info: "value with equals sign", args: []string{"map", "get", "--password=secret"}, want: []string{"map", "get"}},
+		{info: "flag with the same prefix", args: []string{"--passwords", "map"}, want: []string{"--passwords", "map"}},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			assert.Equal(t, tc.want, RemoveFlags(tc.args, "--password"))
+		})
+	}
+}
+
+func TestCommandLine(t *testing.T) {
+	args := []string{"map", "put", "--key", "it's a key", "--value", "", "--value-type", "json"}
+	s, err := parseScript("# comment\n\n" + CommandLine(args) + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []Command{{Args: args}}, s.Commands)
+}
@@ -0,0 +1,236 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+type ConnectionState int32
+
+const (
+	StateNotConnected ConnectionState = iota
+	StateConnected
+	StateReconnecting
+	StateShutdown
+)
+
+const latencyCheckTimeout = 3 * time.Second
+
+var ErrReconnecting = errors.New("connection to the cluster is lost")
+
+// connMembers are the members of the cluster the client is connected to, by their UUIDs
+var connMembers struct {
+	mu      sync.Mutex
+	members map[types.UUID]cluster.MemberInfo
+}
+
+var connStatus struct {
+	// generation identifies the client being tracked, so that late events of a closed client are ignored
+	generation int32
+	// lastGeneration is the last generation given to a client, it keeps increasing even if the status of a previous client is restored
+	lastGeneration int32
+	state          int32
+	members        int32
+	latency        int64
+}
+
+// ConnectionStatus returns the state of the connection to the cluster, the number of members and the last measured latency.
+func ConnectionStatus() (state ConnectionState, members int, latency time.Duration) {
+	return ConnectionState(atomic.LoadInt32(&connStatus.state)),
+		int(atomic.LoadInt32(&connStatus.members)),
+		time.Duration(atomic.LoadInt64(&connStatus.latency))
+}
+
+// ClusterMembers returns the members of the cluster the client is connected to, sorted by their addresses.
+func ClusterMembers() []cluster.MemberInfo {
+	connMembers.mu.Lock()
+	defer connMembers.mu.Unlock()
+	members := make([]cluster.MemberInfo, 0, len(connMembers.members))
+	for _, m := range connMembers.members {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Address < members[j].Address
+	})
+	return members
+}
+
+func setClusterMember(m cluster.MemberInfo, added bool) {
+	connMembers.mu.Lock()
+	defer connMembers.mu.Unlock()
+	if !added {
+		delete(connMembers.members, m.UUID)
+		return
+	}
+	if connMembers.members == nil {
+		connMembers.members = map[types.UUID]cluster.MemberInfo{}
+	}
+	connMembers.members[m.UUID] = m
+}
+
+func clearClusterMembers() {
+	connMembers.mu.Lock()
+	connMembers.members = nil
+	connMembers.mu.Unlock()
+}
+
+// trackConnectionStatus registers the listeners which keep the connection status up to date.
+func trackConnectionStatus(config *hazelcast.Config) {
+	gen := nextGeneration()
+	atomic.StoreInt32(&connStatus.members, 0)
+	clearClusterMembers()
+	// the listeners of the previous client are gone with it
+	clearListeners()
+	id := config.AddLifecycleListener(func(event hazelcast.LifecycleStateChanged) {
+		if atomic.LoadInt32(&connStatus.generation) != gen {
+			return
+		}
+		switch event.State {
+		case hazelcast.LifecycleStateConnected, hazelcast.LifecycleStateChangedCluster:
+			atomic.StoreInt32(&connStatus.state, int32(StateConnected))
+		case hazelcast.LifecycleStateDisconnected:
+			atomic.StoreInt32(&connStatus.state, int32(StateReconnecting))
+			atomic.StoreInt64(&connStatus.latency, 0)
+		case hazelcast.LifecycleStateShutDown:
+			atomic.StoreInt32(&connStatus.state, int32(StateShutdown))
+			atomic.StoreInt32(&connStatus.members, 0)
+		}
+	})
+	RegisterListener("lifecycle", "client", id, nil)
+	id = config.AddMembershipListener(func(event cluster.MembershipStateChanged) {
+		if atomic.LoadInt32(&connStatus.generation) != gen {
+			return
+		}
+		switch event.State {
+		case cluster.MembershipStateAdded:
+			atomic.AddInt32(&connStatus.members, 1)
+			setClusterMember(event.Member, true)
+		case cluster.MembershipStateRemoved:
+			atomic.AddInt32(&connStatus.members, -1)
+			setClusterMember(event.Member, false)
+		}
+	})
+	RegisterListener("membership", "client", id, nil)
+}
+
+func resetConnectionStatus() {
+	nextGeneration()
+	atomic.StoreInt32(&connStatus.state, int32(StateNotConnected))
+	atomic.StoreInt32(&connStatus.members, 0)
+	atomic.StoreInt64(&connStatus.latency, 0)
+	clearClusterMembers()
+	activeClusterName.Store("")
+	clearListeners()
+}
+
+func nextGeneration() int32 {
+	gen := atomic.AddInt32(&connStatus.lastGeneration, 1)
+	atomic.StoreInt32(&connStatus.generation, gen)
+	return gen
+}
+
+// connectionStatus is a copy of the tracked status of a client, so that it can be restored if connecting to another cluster fails.
+type connectionStatus struct {
+	generation, state, members int32
+	latency                    int64
+	memberInfos                map[types.UUID]cluster.MemberInfo
+	listeners                  map[string]registeredListener
+	clusterName                string
+}
+
+func saveConnectionStatus() connectionStatus {
+	s := connectionStatus{
+		generation:  atomic.LoadInt32(&connStatus.generation),
+		state:       atomic.LoadInt32(&connStatus.state),
+		members:     atomic.LoadInt32(&connStatus.members),
+		latency:     atomic.LoadInt64(&connStatus.latency),
+		memberInfos: map[types.UUID]cluster.MemberInfo{},
+		listeners:   copyListeners(),
+		clusterName: ActiveClusterName(),
+	}
+	connMembers.mu.Lock()
+	for id, m := range connMembers.members {
+		s.memberInfos[id] = m
+	}
+	connMembers.mu.Unlock()
+	return s
+}
+
+// restoreConnectionStatus restores the status, the events of the previous client are tracked again since its generation is the current one.
+func restoreConnectionStatus(s connectionStatus) {
+	atomic.StoreInt32(&connStatus.generation, s.generation)
+	atomic.StoreInt32(&connStatus.state, s.state)
+	atomic.StoreInt32(&connStatus.members, s.members)
+	atomic.StoreInt64(&connStatus.latency, s.latency)
+	connMembers.mu.Lock()
+	connMembers.members = s.memberInfos
+	connMembers.mu.Unlock()
+	setListeners(s.listeners)
+	activeClusterName.Store(s.clusterName)
+}
+
+// checkConnectionStatus returns an error if the client is trying to reconnect to the cluster,
+// so that commands fail fast with a clear message instead of blocking on an unavailable cluster.
+func checkConnectionStatus() error {
+	if state, _, _ := ConnectionStatus(); state == StateReconnecting {
+		return hzcerrors.NewLoggableError(ErrReconnecting, "Connection to the cluster is lost, reconnecting. Try again after the connection is restored")
+	}
+	return nil
+}
+
+// MonitorLatency periodically measures the round trip time to the cluster until the context is done.
+func MonitorLatency(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		measureLatency(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func measureLatency(ctx context.Context) {
+	cli := sharedClient()
+	if cli == nil {
+		return
+	}
+	if state, _, _ := ConnectionStatus(); state != StateConnected {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, latencyCheckTimeout)
+	defer cancel()
+	start := time.Now()
+	// fetching the distributed object names is the cheapest call which does not have any side effects on the cluster
+	if _, err := cli.GetDistributedObjectsInfo(ctx); err != nil {
+		return
+	}
+	atomic.StoreInt64(&connStatus.latency, int64(time.Since(start)))
+}
@@ -0,0 +1,448 @@
+package layout
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+	"github.com/muesli/reflow/truncate"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+// Direction is the way the children of a split are laid out.
+type Direction int
+
+const (
+	// Horizontal lays out the children side by side
+	Horizontal Direction = iota
+	// Vertical lays out the children one below another
+	Vertical
+)
+
+// FocusMsg is sent to a focusable pane when it gets the keyboard focus.
+type FocusMsg struct{}
+
+// BlurMsg is sent to a focusable pane when it loses the keyboard focus.
+type BlurMsg struct{}
+
+// Pane shows a model in a region of the screen.
+type Pane struct {
+	Title string
+	Model tea.Model
+	// Focusable panes get the keys while they are focused, Tab moves the focus to the next one
+	Focusable bool
+	// Border draws a border with the title around the pane, the border of the focused pane is highlighted.
+	// The borders are dragged with the mouse to resize the panes, and the title is clicked to collapse the pane.
+	Border bool
+	// Selectable panes let the text be selected by dragging the mouse, the selected text is copied to the clipboard
+	Selectable bool
+}
+
+// Rect is a region of the screen.
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// Contains returns true if the cell is in the region.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Node is either a pane or a split of other nodes.
+type Node struct {
+	pane      *Pane
+	direction Direction
+	children  []*Node
+	// size is the fixed size of the node along the direction of its parent,
+	// the nodes without a fixed size share the rest of the space by their weights
+	size   int
+	weight int
+	// locked nodes keep their sizes when the borders next to them are dragged
+	locked bool
+	// collapsed panes show only their titles
+	collapsed bool
+	parent    *Node
+	rect      Rect
+	// lines are the last rendered lines of the content of the pane, the selected text is taken from them
+	lines []string
+}
+
+// NewPane returns the node of the pane.
+func NewPane(p *Pane) *Node {
+	return &Node{pane: p, weight: 1}
+}
+
+// Split returns the node which lays out the children in the direction.
+func Split(d Direction, children ...*Node) *Node {
+	return &Node{direction: d, children: children, weight: 1}
+}
+
+// Fixed sets the size of the node to the number of the cells, it is the width if the parent is horizontal and the height otherwise.
+func (n *Node) Fixed(size int) *Node {
+	n.size = size
+	return n
+}
+
+// Locked keeps the size of the node when the borders next to it are dragged, such as for a line of help.
+func (n *Node) Locked() *Node {
+	n.locked = true
+	return n
+}
+
+// Weight sets the share of the node in the space left to the nodes without a fixed size.
+func (n *Node) Weight(weight int) *Node {
+	n.weight = weight
+	return n
+}
+
+// Model lays out the panes on the screen and moves the keyboard focus between them.
+type Model struct {
+	root *Node
+	// panes are the leaves of the layout, in the order the focus moves
+	panes  []*Node
+	focus  int
+	width  int
+	height int
+	mouse  mouseState
+}
+
+// New returns the layout of the nodes, the first focusable pane has the focus.
+func New(root *Node) *Model {
+	m := &Model{root: root, focus: -1}
+	m.collect(root)
+	for i, n := range m.panes {
+		if n.pane.Focusable {
+			m.focus = i
+			break
+		}
+	}
+	return m
+}
+
+func (m *Model) collect(n *Node) {
+	if n.pane != nil {
+		m.panes = append(m.panes, n)
+		return
+	}
+	for _, c := range n.children {
+		c.parent = n
+		m.collect(c)
+	}
+}
+
+// Focused returns the focused pane, it is nil if no pane is focusable.
+func (m *Model) Focused() *Pane {
+	if m.focus < 0 {
+		return nil
+	}
+	return m.panes[m.focus].pane
+}
+
+// Focus moves the focus to the pane, if it is focusable.
+func (m *Model) Focus(p *Pane) tea.Cmd {
+	for i, n := range m.panes {
+		if n.pane == p && p.Focusable {
+			return m.moveFocus(i)
+		}
+	}
+	return nil
+}
+
+// Rect returns the region of the pane on the screen, it is empty until the size of the screen is known.
+func (m *Model) Rect(p *Pane) Rect {
+	for _, n := range m.panes {
+		if n.pane == p {
+			return n.rect
+		}
+	}
+	return Rect{}
+}
+
+func (m *Model) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for i, n := range m.panes {
+		cmds = append(cmds, n.pane.Model.Init())
+		if !n.pane.Focusable {
+			continue
+		}
+		// the panes start focused or blurred as the layout sees them
+		var msg tea.Msg = BlurMsg{}
+		if i == m.focus {
+			msg = FocusMsg{}
+		}
+		cmds = append(cmds, m.updatePane(n, msg))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, m.resize()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+q":
+			return m, tea.Quit
+		case "tab":
+			return m, m.cycleFocus(1)
+		case "shift+tab":
+			return m, m.cycleFocus(-1)
+		case "alt+h":
+			return m, m.resizeFocused(Horizontal, -resizeStep)
+		case "alt+l":
+			return m, m.resizeFocused(Horizontal, resizeStep)
+		case "alt+k":
+			return m, m.resizeFocused(Vertical, -resizeStep)
+		case "alt+j":
+			return m, m.resizeFocused(Vertical, resizeStep)
+		case "alt+c":
+			if m.focus >= 0 {
+				return m, m.toggleCollapsed(m.panes[m.focus])
+			}
+			return m, nil
+		}
+		// a key clears the selected text, the same as in the terminals
+		m.mouse.selection = nil
+		// the keys go only to the focused pane, unless it is collapsed
+		if m.focus < 0 || m.panes[m.focus].collapsed {
+			return m, nil
+		}
+		return m, m.updatePane(m.panes[m.focus], msg)
+	case tea.MouseMsg:
+		return m, m.handleMouse(msg)
+	}
+	// the other messages, such as the results of the commands, go to all the panes
+	cmds := make([]tea.Cmd, len(m.panes))
+	for i, n := range m.panes {
+		cmds[i] = m.updatePane(n, msg)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) updatePane(n *Node, msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	n.pane.Model, cmd = n.pane.Model.Update(msg)
+	return cmd
+}
+
+// cycleFocus moves the focus by delta focusable panes, wrapping around at the ends.
+func (m *Model) cycleFocus(delta int) tea.Cmd {
+	if m.focus < 0 {
+		return nil
+	}
+	for i, count := m.focus, len(m.panes); ; {
+		i = (i + delta + count) % count
+		if m.panes[i].pane.Focusable {
+			return m.moveFocus(i)
+		}
+	}
+}
+
+func (m *Model) moveFocus(i int) tea.Cmd {
+	if i == m.focus {
+		return nil
+	}
+	blur := m.updatePane(m.panes[m.focus], BlurMsg{})
+	m.focus = i
+	return tea.Batch(blur, m.updatePane(m.panes[i], FocusMsg{}))
+}
+
+// resize lays out the nodes on the screen and sends the size of its content to each pane.
+func (m *Model) resize() tea.Cmd {
+	m.root.layout(Rect{Width: m.width, Height: m.height})
+	cmds := make([]tea.Cmd, len(m.panes))
+	for i, n := range m.panes {
+		w, h := n.contentSize()
+		cmds[i] = m.updatePane(n, tea.WindowSizeMsg{Width: w, Height: h})
+	}
+	return tea.Batch(cmds...)
+}
+
+// layout sets the regions of the node and its children.
+func (n *Node) layout(r Rect) {
+	n.rect = r
+	if n.pane != nil {
+		return
+	}
+	total := r.Width
+	if n.direction == Vertical {
+		total = r.Height
+	}
+	sizes := shareSpace(n.children, total)
+	offset := 0
+	for i, c := range n.children {
+		cr := Rect{X: r.X + offset, Y: r.Y, Width: sizes[i], Height: r.Height}
+		if n.direction == Vertical {
+			cr = Rect{X: r.X, Y: r.Y + offset, Width: r.Width, Height: sizes[i]}
+		}
+		c.layout(cr)
+		offset += sizes[i]
+	}
+}
+
+// shareSpace returns the sizes of the nodes, the fixed ones get their sizes first
+// and the rest of the space is shared by the weights, the last weighted node gets the remainder.
+func shareSpace(nodes []*Node, total int) []int {
+	sizes := make([]int, len(nodes))
+	left, weights, last := total, 0, -1
+	for i, c := range nodes {
+		if size := c.fixedSize(); size > 0 {
+			sizes[i] = min(size, max(left, 0))
+			left -= sizes[i]
+			continue
+		}
+		weights += c.weight
+		last = i
+	}
+	if weights == 0 || left <= 0 {
+		return sizes
+	}
+	shared := 0
+	for i, c := range nodes {
+		if c.fixedSize() > 0 {
+			continue
+		}
+		sizes[i] = left * c.weight / weights
+		shared += sizes[i]
+	}
+	sizes[last] += left - shared
+	return sizes
+}
+
+// fixedSize returns the size the node takes before the space is shared by the weights, it is 0 if the node is weighted.
+func (n *Node) fixedSize() int {
+	if n.collapsed {
+		return collapsedSize
+	}
+	return n.size
+}
+
+// contentOrigin returns the top left cell of the content of the pane.
+func (n *Node) contentOrigin() (int, int) {
+	if n.pane.Border {
+		return n.rect.X + 1, n.rect.Y + 1
+	}
+	return n.rect.X, n.rect.Y
+}
+
+// contentSize returns the size of the content of the pane, inside its border.
+func (n *Node) contentSize() (int, int) {
+	w, h := n.rect.Width, n.rect.Height
+	if n.pane.Border {
+		w, h = w-2, h-2
+	}
+	return max(w, 0), max(h, 0)
+}
+
+func (m *Model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	return m.view(m.root)
+}
+
+func (m *Model) view(n *Node) string {
+	if n.pane != nil {
+		return m.viewPane(n)
+	}
+	views := make([]string, 0, len(n.children))
+	for _, c := range n.children {
+		if c.rect.Width > 0 && c.rect.Height > 0 {
+			views = append(views, m.view(c))
+		}
+	}
+	if n.direction == Vertical {
+		return lipgloss.JoinVertical(lipgloss.Left, views...)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, views...)
+}
+
+// viewPane renders the pane to fill its region exactly, the lines of the content are cut rather than wrapped.
+func (m *Model) viewPane(n *Node) string {
+	if n.collapsed {
+		return m.viewCollapsed(n)
+	}
+	w, h := n.contentSize()
+	lines := fitLines(n.pane.Model.View(), w, h)
+	n.lines = lines
+	if s := m.mouse.selection; s != nil && s.node == n {
+		lines = s.highlight(lines)
+	}
+	if !n.pane.Border || n.rect.Width < 2 || n.rect.Height < 2 {
+		return strings.Join(fitLines(strings.Join(lines, "\n"), n.rect.Width, n.rect.Height), "\n")
+	}
+	style := m.borderStyle(n)
+	border := lipgloss.RoundedBorder()
+	title := n.title(w)
+	top := style.Render(border.TopLeft + title + strings.Repeat(border.Top, w-ansi.PrintableRuneWidth(title)) + border.TopRight)
+	rows := []string{top}
+	for _, l := range lines {
+		rows = append(rows, style.Render(border.Left)+l+style.Render(border.Right))
+	}
+	rows = append(rows, style.Render(border.BottomLeft+strings.Repeat(border.Bottom, w)+border.BottomRight))
+	return strings.Join(rows, "\n")
+}
+
+// viewCollapsed renders the collapsed pane as its title on a line, or as a column of the border if its parent is horizontal.
+func (m *Model) viewCollapsed(n *Node) string {
+	style := m.borderStyle(n)
+	border := lipgloss.RoundedBorder()
+	if n.parent != nil && n.parent.direction == Horizontal {
+		rows := []string{style.Render("▸")}
+		for len(rows) < n.rect.Height {
+			rows = append(rows, style.Render(border.Left))
+		}
+		return strings.Join(rows, "\n")
+	}
+	title := truncate.String("▸"+n.title(n.rect.Width-1), uint(n.rect.Width))
+	return style.Render(title + strings.Repeat(border.Top, max(n.rect.Width-ansi.PrintableRuneWidth(title), 0)))
+}
+
+func (m *Model) borderStyle(n *Node) lipgloss.Style {
+	focused := m.focus >= 0 && m.panes[m.focus] == n
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(tuiutil.Highlight())).Faint(!focused)
+}
+
+// title returns the title of the pane as it is shown in its top border, cut to the width.
+func (n *Node) title(width int) string {
+	if n.pane.Title == "" || width <= 2 {
+		return ""
+	}
+	return truncate.StringWithTail(" "+n.pane.Title+" ", uint(width), "…")
+}
+
+// fitLines cuts or pads the text to exactly the height lines of the width.
+func fitLines(s string, width, height int) []string {
+	lines := strings.Split(s, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	for i, l := range lines {
+		l = truncate.String(l, uint(width))
+		if pad := width - ansi.PrintableRuneWidth(l); pad > 0 {
+			l += strings.Repeat(" ", pad)
+		}
+		lines[i] = l
+	}
+	return lines
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
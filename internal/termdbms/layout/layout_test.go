@@ -0,0 +1,125 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/reflow/ansi"
+)
+
+// recorder keeps the messages it gets and shows its name.
+type recorder struct {
+	name    string
+	focused bool
+	size    tea.WindowSizeMsg
+	keys    []string
+}
+
+func (r *recorder) Init() tea.Cmd {
+	return nil
+}
+
+func (r *recorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m := msg.(type) {
+	case FocusMsg:
+		r.focused = true
+	case BlurMsg:
+		r.focused = false
+	case tea.WindowSizeMsg:
+		r.size = m
+	case tea.KeyMsg:
+		r.keys = append(r.keys, m.String())
+	}
+	return r, nil
+}
+
+func (r *recorder) View() string {
+	return r.name
+}
+
+func newTestLayout() (*Model, []*recorder) {
+	side, results, editor, help := &recorder{name: "side"}, &recorder{name: "results"}, &recorder{name: "editor"}, &recorder{name: "help"}
+	root := Split(Vertical,
+		Split(Horizontal,
+			NewPane(&Pane{Title: "Side", Model: side, Focusable: true, Border: true}).Fixed(20),
+			Split(Vertical,
+				NewPane(&Pane{Title: "Results", Model: results, Focusable: true, Border: true}).Weight(3),
+				NewPane(&Pane{Title: "Editor", Model: editor, Focusable: true, Border: true}),
+			),
+		),
+		NewPane(&Pane{Model: help}).Fixed(1).Locked(),
+	)
+	m := New(root)
+	m.Init()
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 25})
+	return m, []*recorder{side, results, editor, help}
+}
+
+func TestLayoutSizes(t *testing.T) {
+	_, r := newTestLayout()
+	want := []tea.WindowSizeMsg{
+		{Width: 18, Height: 22},
+		{Width: 58, Height: 16},
+		{Width: 58, Height: 4},
+		{Width: 80, Height: 1},
+	}
+	for i, w := range want {
+		if r[i].size != w {
+			t.Errorf("%s: want %v got %v", r[i].name, w, r[i].size)
+		}
+	}
+}
+
+func TestLayoutFocus(t *testing.T) {
+	m, r := newTestLayout()
+	if !r[0].focused || r[1].focused {
+		t.Fatal("the first focusable pane must be focused")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if strings.Join(r[0].keys, "") != "a" || strings.Join(r[1].keys, "") != "b" {
+		t.Fatalf("the keys must go to the focused pane: %v %v", r[0].keys, r[1].keys)
+	}
+	// the help is not focusable, the focus wraps around to the first pane
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !r[0].focused || r[1].focused || r[2].focused {
+		t.Fatal("the focus must wrap around")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if m.Focused().Title != "Editor" || !r[2].focused || r[0].focused {
+		t.Fatal("shift+tab must move the focus back")
+	}
+}
+
+func TestLayoutView(t *testing.T) {
+	m, _ := newTestLayout()
+	lines := strings.Split(m.View(), "\n")
+	if len(lines) != 25 {
+		t.Fatalf("want 25 lines got %d", len(lines))
+	}
+	for i, l := range lines {
+		if w := ansi.PrintableRuneWidth(l); w != 80 {
+			t.Fatalf("line %d: want width 80 got %d: %q", i, w, l)
+		}
+	}
+	view := m.View()
+	for _, s := range []string{"Side", "Results", "Editor", "side", "results", "editor", "help"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("want %s in the view", s)
+		}
+	}
+}
+
+func TestShareSpace(t *testing.T) {
+	nodes := []*Node{NewPane(&Pane{}).Fixed(10), NewPane(&Pane{}).Weight(2), NewPane(&Pane{})}
+	if got := shareSpace(nodes, 41); got[0] != 10 || got[1] != 20 || got[2] != 11 {
+		t.Fatalf("unexpected sizes %v", got)
+	}
+	// the fixed nodes do not get more than the space
+	if got := shareSpace(nodes, 6); got[0] != 6 || got[1] != 0 || got[2] != 0 {
+		t.Fatalf("unexpected sizes %v", got)
+	}
+}
@@ -0,0 +1,230 @@
+package layout
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+// mouseState tracks the left button between pressing and releasing it.
+type mouseState struct {
+	pressed bool
+	// dragged is the boundary being dragged, offset is where it was grabbed relative to the start of the next node
+	dragged *boundary
+	offset  int
+	// selection is the text selected in a pane, it stays highlighted until the next click or key
+	selection *selection
+}
+
+// selection is the text selected by dragging the mouse, the positions are relative to the content of the pane.
+type selection struct {
+	node         *Node
+	fromX, fromY int
+	toX, toY     int
+	// moved is set once the mouse is dragged, a click without moving selects no text
+	moved bool
+}
+
+// handleMouse resizes, collapses and focuses the panes and selects the text,
+// the other mouse events go to the pane under the pointer, relative to its content.
+// Terminals without mouse reporting send no mouse events, and the panes are resized, collapsed and focused with the keys.
+func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.MouseLeft:
+		// the terminals report dragging as pressing the button again at the new position
+		if m.mouse.pressed {
+			return m.drag(msg.X, msg.Y)
+		}
+		m.mouse.pressed = true
+		return m.press(msg)
+	case tea.MouseRelease:
+		wasDragging := m.mouse.dragged != nil
+		m.mouse.pressed = false
+		m.mouse.dragged = nil
+		if wasDragging {
+			return nil
+		}
+		if s := m.mouse.selection; s != nil && s.moved {
+			return tuiutil.CopyCommand(s.text())
+		}
+		m.mouse.selection = nil
+	}
+	return m.sendMouse(msg)
+}
+
+// press handles pressing the left button, which grabs a border, collapses a pane or focuses it.
+func (m *Model) press(msg tea.MouseMsg) tea.Cmd {
+	m.mouse.selection = nil
+	n := m.paneAt(msg.X, msg.Y)
+	if n != nil && n.onTitle(msg.X, msg.Y) {
+		return m.toggleCollapsed(n)
+	}
+	if b := m.root.boundaryAt(msg.X, msg.Y); b != nil {
+		d := b.split.direction
+		pos := msg.X
+		if d == Vertical {
+			pos = msg.Y
+		}
+		m.mouse.dragged = b
+		m.mouse.offset = pos - b.split.children[b.i+1].start(d)
+		return nil
+	}
+	if n == nil {
+		return nil
+	}
+	var cmd tea.Cmd
+	if n.pane.Focusable {
+		cmd = m.moveFocus(indexOfNode(m.panes, n))
+	}
+	if n.pane.Selectable {
+		x, y := m.contentPosition(n, msg.X, msg.Y)
+		m.mouse.selection = &selection{node: n, fromX: x, fromY: y, toX: x, toY: y}
+	}
+	return tea.Batch(cmd, m.sendMouse(msg))
+}
+
+// drag moves the grabbed border or extends the selection to the position.
+func (m *Model) drag(x, y int) tea.Cmd {
+	if b := m.mouse.dragged; b != nil {
+		pos := x
+		if b.split.direction == Vertical {
+			pos = y
+		}
+		b.moveTo(pos - m.mouse.offset)
+		return m.resize()
+	}
+	if s := m.mouse.selection; s != nil {
+		s.toX, s.toY = m.contentPosition(s.node, x, y)
+		s.moved = s.moved || s.toX != s.fromX || s.toY != s.fromY
+	}
+	return nil
+}
+
+// sendMouse sends the event to the pane under the pointer, relative to its content.
+func (m *Model) sendMouse(msg tea.MouseMsg) tea.Cmd {
+	n := m.paneAt(msg.X, msg.Y)
+	if n == nil || n.collapsed {
+		return nil
+	}
+	msg.X, msg.Y = m.contentPosition(n, msg.X, msg.Y)
+	return m.updatePane(n, msg)
+}
+
+func (m *Model) paneAt(x, y int) *Node {
+	for _, n := range m.panes {
+		if n.rect.Contains(x, y) {
+			return n
+		}
+	}
+	return nil
+}
+
+// contentPosition returns the position relative to the content of the pane, kept in the content.
+func (m *Model) contentPosition(n *Node, x, y int) (int, int) {
+	ox, oy := n.contentOrigin()
+	w, h := n.contentSize()
+	return clamp(x-ox, 0, w-1), clamp(y-oy, 0, h-1)
+}
+
+// onTitle returns true if the cell is on the title of the pane, all of a collapsed pane is its title.
+func (n *Node) onTitle(x, y int) bool {
+	if !n.pane.Border {
+		return false
+	}
+	if n.collapsed {
+		return true
+	}
+	w, _ := n.contentSize()
+	return y == n.rect.Y && x > n.rect.X && x <= n.rect.X+ansi.PrintableRuneWidth(n.title(w))
+}
+
+// ordered returns the start and the end of the selection in the reading order.
+func (s *selection) ordered() (int, int, int, int) {
+	if s.fromY < s.toY || s.fromY == s.toY && s.fromX <= s.toX {
+		return s.fromX, s.fromY, s.toX, s.toY
+	}
+	return s.toX, s.toY, s.fromX, s.fromY
+}
+
+// span returns the columns of the line in the selection, the end is exclusive.
+func (s *selection) span(y, width int) (int, int, bool) {
+	x1, y1, x2, y2 := s.ordered()
+	if y < y1 || y > y2 {
+		return 0, 0, false
+	}
+	from, to := 0, width
+	if y == y1 {
+		from = x1
+	}
+	if y == y2 {
+		to = x2 + 1
+	}
+	return from, min(to, width), true
+}
+
+// text returns the selected text of the last rendered lines, without the styles and the spaces at the ends of the lines.
+func (s *selection) text() string {
+	var lines []string
+	for y, l := range s.node.lines {
+		r := []rune(stripStyles(l))
+		from, to, ok := s.span(y, len(r))
+		if !ok {
+			continue
+		}
+		if from > to {
+			from = to
+		}
+		lines = append(lines, strings.TrimRight(string(r[from:to]), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// highlight returns the lines with the selected text reversed, the selected lines lose their styles.
+func (s *selection) highlight(lines []string) []string {
+	if !s.moved {
+		return lines
+	}
+	reverse := lipgloss.NewStyle().Reverse(true)
+	out := make([]string, len(lines))
+	for y, l := range lines {
+		r := []rune(stripStyles(l))
+		from, to, ok := s.span(y, len(r))
+		if !ok || from >= to {
+			out[y] = l
+			continue
+		}
+		out[y] = string(r[:from]) + reverse.Render(string(r[from:to])) + string(r[to:])
+	}
+	return out
+}
+
+// stripStyles removes the escape sequences of the styles from the text.
+func stripStyles(s string) string {
+	var sb strings.Builder
+	escape := false
+	for _, r := range s {
+		switch {
+		case r == ansi.Marker:
+			escape = true
+		case escape:
+			escape = !ansi.IsTerminator(r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func clamp(v, low, high int) int {
+	if v > high {
+		v = high
+	}
+	if v < low {
+		v = low
+	}
+	return v
+}
@@ -0,0 +1,98 @@
+package layout
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func mouse(t tea.MouseEventType, x, y int) tea.MouseMsg {
+	return tea.MouseMsg{Type: t, X: x, Y: y}
+}
+
+func TestDragBorderResizesPanes(t *testing.T) {
+	m, r := newTestLayout()
+	// the right border of the side pane is grabbed and dragged by 10 cells
+	m.Update(mouse(tea.MouseLeft, 19, 5))
+	m.Update(mouse(tea.MouseLeft, 29, 5))
+	m.Update(mouse(tea.MouseRelease, 29, 5))
+	if r[0].size.Width != 28 || r[1].size.Width != 48 {
+		t.Fatalf("unexpected widths %d and %d", r[0].size.Width, r[1].size.Width)
+	}
+	// the top border of the editor is dragged up, the panes keep at least the minimum size
+	m.Update(mouse(tea.MouseLeft, 40, 18))
+	m.Update(mouse(tea.MouseLeft, 40, 0))
+	m.Update(mouse(tea.MouseRelease, 40, 0))
+	if r[1].size.Height != 1 || r[2].size.Height != 19 {
+		t.Fatalf("unexpected heights %d and %d", r[1].size.Height, r[2].size.Height)
+	}
+	// the help is locked
+	m.Update(mouse(tea.MouseLeft, 40, 24))
+	m.Update(mouse(tea.MouseLeft, 40, 20))
+	m.Update(mouse(tea.MouseRelease, 40, 20))
+	if r[3].size.Height != 1 {
+		t.Fatalf("want the help to keep its height, got %d", r[3].size.Height)
+	}
+}
+
+func TestKeysResizePanes(t *testing.T) {
+	m, r := newTestLayout()
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l"), Alt: true})
+	if r[0].size.Width != 20 {
+		t.Fatalf("want the side pane wider, got %d", r[0].size.Width)
+	}
+	// the editor is the last pane, so the border before it moves
+	m.Focus(m.panes[2].pane)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j"), Alt: true})
+	if r[2].size.Height != 6 || r[1].size.Height != 14 {
+		t.Fatalf("unexpected heights %d and %d", r[1].size.Height, r[2].size.Height)
+	}
+}
+
+func TestClickTitleCollapsesPane(t *testing.T) {
+	m, r := newTestLayout()
+	m.Update(mouse(tea.MouseLeft, 23, 0))
+	m.Update(mouse(tea.MouseRelease, 23, 0))
+	if !m.panes[1].collapsed || r[2].size.Height != 21 {
+		t.Fatalf("want the results collapsed, the editor height is %d", r[2].size.Height)
+	}
+	// clicking the collapsed pane expands it
+	m.Update(mouse(tea.MouseLeft, 40, 0))
+	m.Update(mouse(tea.MouseRelease, 40, 0))
+	if m.panes[1].collapsed || r[2].size.Height != 4 {
+		t.Fatalf("want the results expanded, the editor height is %d", r[2].size.Height)
+	}
+	// the editor is the only weighted pane left in the split, so it is not collapsed
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c"), Alt: true})
+	m.Focus(m.panes[1].pane)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c"), Alt: true})
+	m.Focus(m.panes[2].pane)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c"), Alt: true})
+	if !m.panes[1].collapsed || m.panes[2].collapsed {
+		t.Fatal("want only the results collapsed")
+	}
+}
+
+func TestSelectText(t *testing.T) {
+	m, r := newTestLayout()
+	m.panes[1].pane.Selectable = true
+	m.View()
+	m.Update(mouse(tea.MouseLeft, 21, 1))
+	if !r[1].focused {
+		t.Fatal("want the clicked pane focused")
+	}
+	m.Update(mouse(tea.MouseLeft, 27, 1))
+	m.View()
+	s := m.mouse.selection
+	if s == nil || s.text() != "results" {
+		t.Fatalf("unexpected selection %v", s)
+	}
+	if _, cmd := m.Update(mouse(tea.MouseRelease, 27, 1)); cmd == nil {
+		t.Fatal("want the text copied")
+	}
+	// a key clears the selection
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.mouse.selection != nil {
+		t.Fatal("want the selection cleared")
+	}
+}
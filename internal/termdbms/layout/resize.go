@@ -0,0 +1,157 @@
+package layout
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// minPaneSize is the smallest size the panes are resized to, which fits the border and a line of the content
+	minPaneSize = 3
+	// collapsedSize is the size of a collapsed pane along the direction of its parent
+	collapsedSize = 1
+	// resizeStep is the number of the cells the keys resize the focused pane by
+	resizeStep = 2
+)
+
+// boundary is the edge between the child i and the next child of a split.
+type boundary struct {
+	split *Node
+	i     int
+}
+
+// boundaryAt returns the boundary at the cell, the borders of the nodes next to each other are its handles.
+// The boundaries of the outer splits are found first, since the inner ones end at them.
+func (n *Node) boundaryAt(x, y int) *boundary {
+	if n.pane != nil || !n.rect.Contains(x, y) {
+		return nil
+	}
+	for i := 0; i < len(n.children)-1; i++ {
+		a, b := n.children[i].rect, n.children[i+1].rect
+		onEdge := x == a.X+a.Width-1 || x == b.X
+		if n.direction == Vertical {
+			onEdge = y == a.Y+a.Height-1 || y == b.Y
+		}
+		if onEdge && n.resizable(i) {
+			return &boundary{split: n, i: i}
+		}
+	}
+	for _, c := range n.children {
+		if b := c.boundaryAt(x, y); b != nil {
+			return b
+		}
+	}
+	return nil
+}
+
+// resizable returns true if the boundary after the child i can be moved.
+func (n *Node) resizable(i int) bool {
+	a, b := n.children[i], n.children[i+1]
+	return !a.locked && !b.locked && !a.collapsed && !b.collapsed
+}
+
+// start returns the position of the node along the direction.
+func (n *Node) start(d Direction) int {
+	if d == Vertical {
+		return n.rect.Y
+	}
+	return n.rect.X
+}
+
+// length returns the size of the node along the direction.
+func (n *Node) length(d Direction) int {
+	if d == Vertical {
+		return n.rect.Height
+	}
+	return n.rect.Width
+}
+
+// moveTo moves the boundary so that the next child starts at the position, both children keep at least the minimum size.
+func (b *boundary) moveTo(pos int) {
+	s := b.split
+	d := s.direction
+	first, second := s.children[b.i], s.children[b.i+1]
+	total := first.length(d) + second.length(d)
+	if total < 2*minPaneSize {
+		return
+	}
+	size := pos - first.start(d)
+	if size < minPaneSize {
+		size = minPaneSize
+	}
+	if size > total-minPaneSize {
+		size = total - minPaneSize
+	}
+	// the weights are set to the current sizes, so that only the two children change
+	for _, c := range s.children {
+		if c.fixedSize() == 0 {
+			c.weight = max(c.length(d), 1)
+		}
+	}
+	first.setSize(size)
+	second.setSize(total - size)
+}
+
+// setSize sets the fixed size or, for the weighted nodes, the weight.
+func (n *Node) setSize(size int) {
+	if n.size > 0 {
+		n.size = size
+		return
+	}
+	n.weight = size
+}
+
+// resizeFocused grows the focused pane by delta cells in the direction, or shrinks it if delta is negative.
+// The border after the pane is moved if it can be, otherwise the one before it.
+func (m *Model) resizeFocused(d Direction, delta int) tea.Cmd {
+	if m.focus < 0 || m.width == 0 {
+		return nil
+	}
+	for c := m.panes[m.focus]; c.parent != nil; c = c.parent {
+		s := c.parent
+		if s.direction != d {
+			continue
+		}
+		i := indexOfNode(s.children, c)
+		switch {
+		case i < len(s.children)-1 && s.resizable(i):
+			(&boundary{split: s, i: i}).moveTo(s.children[i+1].start(d) + delta)
+		case i > 0 && s.resizable(i-1):
+			(&boundary{split: s, i: i - 1}).moveTo(c.start(d) - delta)
+		default:
+			continue
+		}
+		return m.resize()
+	}
+	return nil
+}
+
+// toggleCollapsed collapses the pane to its title, or expands it.
+// The pane is not collapsed if none of the other nodes next to it would take its space.
+func (m *Model) toggleCollapsed(n *Node) tea.Cmd {
+	if n.parent == nil || !n.pane.Border {
+		return nil
+	}
+	if !n.collapsed && !hasWeightedSibling(n) {
+		return nil
+	}
+	n.collapsed = !n.collapsed
+	return m.resize()
+}
+
+func hasWeightedSibling(n *Node) bool {
+	for _, c := range n.parent.children {
+		if c != n && c.fixedSize() == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func indexOfNode(nodes []*Node, n *Node) int {
+	for i, c := range nodes {
+		if c == n {
+			return i
+		}
+	}
+	return -1
+}
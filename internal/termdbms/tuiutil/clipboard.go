@@ -0,0 +1,19 @@
+package tuiutil
+
+import (
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ClipboardMsg reports copying the text to the clipboard, Err is set if there is no clipboard, such as over SSH.
+type ClipboardMsg struct {
+	Text string
+	Err  error
+}
+
+// CopyCommand returns the command which copies the text to the clipboard.
+func CopyCommand(text string) tea.Cmd {
+	return func() tea.Msg {
+		return ClipboardMsg{Text: text, Err: clipboard.WriteAll(text)}
+	}
+}
@@ -46,11 +46,35 @@ var (
 var (
 	SelectedTheme = 0
 	ValidThemes   = []string{
-		"default",   // 0
+		"dark",      // 0
 		"nord",      // 1
 		"solarized", // not accurate but whatever
+		"light",     // 3
+		"mono",      // 4
 	}
 	ThemesMap = map[int]map[string]string{
+		4: {
+			HeaderBackgroundKey:         "#000000",
+			HeaderBorderBackgroundKey:   "#000000",
+			HeaderBottomColorKey:        "#FFFFFF",
+			BorderColorKey:              "#FFFFFF",
+			TextColorKey:                "#FFFFFF",
+			HeaderForegroundKey:         "#FFFFFF",
+			HighlightKey:                "#FFFFFF",
+			FooterForegroundColorKey:    "#FFFFFF",
+			HeaderTopForegroundColorKey: "#FFFFFF",
+		},
+		3: {
+			HeaderBackgroundKey:         "#E4E4E4",
+			HeaderBorderBackgroundKey:   "#C6C6C6",
+			HeaderBottomColorKey:        "#303030",
+			BorderColorKey:              "#303030",
+			TextColorKey:                "#1C1C1C",
+			HeaderForegroundKey:         "#1C1C1C",
+			HighlightKey:                "#005F87",
+			FooterForegroundColorKey:    "#5F5F5F",
+			HeaderTopForegroundColorKey: "#5F5F5F",
+		},
 		2: {
 			HeaderBackgroundKey:         "#268bd2",
 			HeaderBorderBackgroundKey:   "#268bd2",
@@ -18,6 +18,8 @@ type ScrollData struct {
 	PreScrollYOffset   int
 	PreScrollYPosition int
 	ScrollXOffset      int
+	// PinnedColumns is the number of leftmost columns which stay visible while scrolling horizontally
+	PinnedColumns int
 }
 
 // TableState holds everything needed to save/serialize state
@@ -36,6 +38,11 @@ type UIState struct {
 	ShowClipboard     bool
 	ExpandColumn      int
 	CurrentTable      int
+	// VisualSelection is set while the rows from VisualAnchor to the selected row are selected
+	VisualSelection bool
+	VisualAnchor    int
+	// Search is the text whose cells are highlighted, the case is ignored
+	Search string
 }
 
 type UIData struct {
@@ -35,6 +35,10 @@ func init() {
 		ToggleColumn(m)
 		return nil
 	}
+	GlobalCommands["p"] = func(m *TuiModel) tea.Cmd {
+		TogglePinnedColumns(m)
+		return nil
+	}
 	GlobalCommands["b"] = func(m *TuiModel) tea.Cmd {
 		m.UI.BorderToggle = !m.UI.BorderToggle
 		return nil
@@ -69,9 +73,7 @@ func init() {
 		return nil
 	}
 	GlobalCommands["right"] = func(m *TuiModel) tea.Cmd {
-		headers := m.GetHeaders()
-		headersLen := len(headers)
-		if headersLen > maxHeaders && m.Scroll.ScrollXOffset <= headersLen-maxHeaders {
+		if len(m.GetHeaders()) > maxHeaders && m.Scroll.ScrollXOffset < m.maxScrollXOffset() {
 			m.Scroll.ScrollXOffset++
 		}
 		return nil
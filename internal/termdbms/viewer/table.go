@@ -52,6 +52,9 @@ func init() {
 				len(m.Data().TableHeaders), // look at how headers get rendered to get accurate record number
 				len(m.GetColumnData()),
 				len(m.GetHeaders())) // this will need to be refactored when filters get added
+			if len(m.GetHeaders()) > maxHeaders && m.pinnedColumns() > 0 {
+				headerTop += fmt.Sprintf(" + %d pinned", m.pinnedColumns())
+			}
 			navigationArrowL := lipgloss.Width("  <<<")
 			titleWidth := m.Viewport.Width - navigationArrowL*2
 			headerTop = "  <<<" + fmt.Sprintf("%*s", -titleWidth, fmt.Sprintf("%*s", (titleWidth+len(headerTop))/2, headerTop)) + ">>>  "
@@ -76,7 +79,7 @@ func init() {
 		)
 		if !m.UI.FormatModeEnabled { // reason we flip is because it makes more sense to store things by column for data
 			row = m.GetRow() + m.Viewport.YOffset
-			col = m.GetColumn() + m.Scroll.ScrollXOffset
+			col = m.headerIndex(m.GetColumn())
 		} else { // but for format mode thats just a regular row/col situation
 			row = m.Format.CursorX
 			col = m.Format.CursorY + m.Viewport.YOffset
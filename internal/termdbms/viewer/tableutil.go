@@ -8,7 +8,8 @@ import (
 	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
 )
 
-var maxHeaders int
+// maxHeaders is the number of columns above which the table is scrolled horizontally
+const maxHeaders = 7
 
 // AssembleTable shows either the selection text or the table
 func AssembleTable(m *TuiModel) string {
@@ -33,23 +34,61 @@ func (m *TuiModel) NumHeaders() int {
 		return 1
 	}
 
-	maxHeaders = 7
-
-	if l > maxHeaders { // this just looked the best after some trial and error
-		if l%5 == 0 {
-			return 5
-		} else if l%4 == 0 {
-			return 4
-		} else if l%3 == 0 {
-			return 3
-		} else {
-			return 6 // primes and shiiiii
-		}
+	if l > maxHeaders {
+		// wide tables are scrolled horizontally, so the cells have the width of the visible columns
+		return visibleColumns()
 	}
 
 	return l
 }
 
+// visibleColumns is the number of columns shown at once for tables wider than maxHeaders
+func visibleColumns() int {
+	return maxHeaders - 1
+}
+
+// pinnedColumns returns the number of pinned columns, at least one column is left to scroll
+func (m *TuiModel) pinnedColumns() int {
+	return Max(Min(m.Scroll.PinnedColumns, visibleColumns()-1), 0)
+}
+
+// maxScrollXOffset is the offset that shows the last column
+func (m *TuiModel) maxScrollXOffset() int {
+	return Max(len(m.GetHeaders())-visibleColumns(), 0)
+}
+
+// visibleHeaders returns the pinned headers followed by the headers in the horizontal scroll window
+func (m *TuiModel) visibleHeaders() []string {
+	headers := m.GetHeaders()
+	if len(headers) <= maxHeaders {
+		return headers
+	}
+	pinned := m.pinnedColumns()
+	offset := Min(m.Scroll.ScrollXOffset, m.maxScrollXOffset())
+	scrollable := headers[pinned:]
+	end := Min(offset+visibleColumns()-pinned, len(scrollable))
+	visible := make([]string, 0, visibleColumns())
+	visible = append(visible, headers[:pinned]...)
+	return append(visible, scrollable[offset:end]...)
+}
+
+// headerIndex converts the index of a visible column to its index among all headers
+func (m *TuiModel) headerIndex(visible int) int {
+	if len(m.GetHeaders()) <= maxHeaders || visible < m.pinnedColumns() {
+		return visible
+	}
+	return visible + Min(m.Scroll.ScrollXOffset, m.maxScrollXOffset())
+}
+
+// TogglePinnedColumns pins one more column from the left, or unpins all once no more columns can be pinned
+func TogglePinnedColumns(m *TuiModel) {
+	if len(m.GetHeaders()) <= maxHeaders || m.pinnedColumns() == visibleColumns()-1 {
+		m.Scroll.PinnedColumns = 0
+	} else {
+		m.Scroll.PinnedColumns = m.pinnedColumns() + 1
+	}
+}
+
 // CellWidth gets the current cell width for schema
 func (m *TuiModel) CellWidth() int {
 	h := m.NumHeaders()
@@ -94,6 +133,21 @@ func (m *TuiModel) GetRow() int {
 	return baseVal
 }
 
+// VisualRows returns the first and the last row of the visual selection, which are the same if there is no selection
+func (m *TuiModel) VisualRows() (int, int) {
+	row := m.Viewport.YOffset + m.GetRow()
+	if !m.UI.VisualSelection {
+		return row, row
+	}
+	return Min(row, m.UI.VisualAnchor), Max(row, m.UI.VisualAnchor)
+}
+
+// InVisualSelection returns true if the row is in the visual selection
+func (m *TuiModel) InVisualSelection(row int) bool {
+	from, to := m.VisualRows()
+	return m.UI.VisualSelection && row >= from && row <= to
+}
+
 // GetSchemaName gets the current schema name
 func (m *TuiModel) GetSchemaName() string {
 	return m.Data().TableIndexMap[m.UI.CurrentTable]
@@ -128,12 +182,7 @@ func (m *TuiModel) SetViewSlices() {
 		}
 	} else {
 		// header slices
-		headers := d.TableHeaders[m.GetSchemaName()]
-		headersLen := len(headers)
-
-		if headersLen > maxHeaders {
-			headers = headers[m.Scroll.ScrollXOffset : maxHeaders+m.Scroll.ScrollXOffset-1]
-		}
+		headers := m.visibleHeaders()
 		// data slices
 		defer func() {
 			if recover() != nil {
@@ -169,10 +218,10 @@ func (m *TuiModel) GetSchemaData() map[string][]interface{} {
 func (m *TuiModel) GetSelectedColumnName() string {
 	col := m.GetColumn()
 	headers := m.GetHeaders()
-	index := Min(m.NumHeaders()-1, col)
 	if len(headers) == 0 {
 		return ""
 	}
+	index := Min(len(headers)-1, m.headerIndex(Min(m.NumHeaders()-1, col)))
 	return headers[index]
 }
 
@@ -117,6 +117,12 @@ func DisplayTable(m *TuiModel) string {
 					s = "|" + s
 				}
 			}
+			if r != m.GetRow() && m.InVisualSelection(m.Viewport.YOffset+r) {
+				tmpStyle = tmpStyle.Reverse(true)
+			}
+			if m.UI.Search != "" && ContainsFold(s, m.UI.Search) {
+				tmpStyle = tmpStyle.Underline(true).Bold(true)
+			}
 			// display text based on type
 			rowBuilder = append(rowBuilder, tmpStyle.Render(TruncateIfApplicable(m, s)))
 		}
@@ -62,6 +62,11 @@ func GetStringRepresentationOfInterface(val interface{}) string {
 	return ""
 }
 
+// ContainsFold returns true if s contains substr, the case is ignored
+func ContainsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
 func SplitLines(s string) []string {
 	var lines []string
 	if strings.Count(s, "\n") == 0 {
@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	goprompt "github.com/hazelcast/hazelcast-commandline-client/internal/go-prompt"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/tuiutil"
+)
+
+const (
+	Dark      = "dark"
+	Light     = "light"
+	Solarized = "solarized"
+	Mono      = "mono"
+	// Default is the theme used if none is configured
+	Default = Dark
+)
+
+// noColorEnv disables all colors if it is set to any value, see https://no-color.org
+const noColorEnv = "NO_COLOR"
+
+// Theme is the set of colors used by the interactive shell, the SQL browser and the syntax highlighting.
+type Theme struct {
+	Name string
+	// Viewer is the theme of the SQL browser, one of tuiutil.ValidThemes
+	Viewer                  string
+	PromptPrefix            goprompt.Color
+	StatusBarText           goprompt.Color
+	StatusBarBG             goprompt.Color
+	SuggestionText          goprompt.Color
+	SuggestionBG            goprompt.Color
+	SelectedSuggestionText  goprompt.Color
+	SelectedSuggestionBG    goprompt.Color
+	DescriptionText         goprompt.Color
+	DescriptionBG           goprompt.Color
+	SelectedDescriptionText goprompt.Color
+	SelectedDescriptionBG   goprompt.Color
+	PreviewSuggestionText   goprompt.Color
+	HistorySuggestionText   goprompt.Color
+	ScrollbarThumb          goprompt.Color
+	ScrollbarBG             goprompt.Color
+	// Syntax is the chroma style to highlight JSON values, empty disables highlighting
+	Syntax string
+	// Colorless disables colors in the SQL browser as well
+	Colorless bool
+}
+
+var themes = map[string]Theme{
+	Dark: {
+		Name:                    Dark,
+		Viewer:                  "dark",
+		PromptPrefix:            goprompt.Blue,
+		StatusBarText:           goprompt.White,
+		StatusBarBG:             goprompt.DarkGray,
+		SuggestionText:          goprompt.White,
+		SuggestionBG:            goprompt.DarkGray,
+		SelectedSuggestionText:  goprompt.White,
+		SelectedSuggestionBG:    goprompt.Blue,
+		DescriptionText:         goprompt.LightGray,
+		DescriptionBG:           goprompt.DarkGray,
+		SelectedDescriptionText: goprompt.LightGray,
+		SelectedDescriptionBG:   goprompt.Blue,
+		PreviewSuggestionText:   goprompt.Green,
+		HistorySuggestionText:   goprompt.DarkGray,
+		ScrollbarThumb:          goprompt.DarkGray,
+		ScrollbarBG:             goprompt.Cyan,
+		Syntax:                  "tango",
+	},
+	Light: {
+		Name:                    Light,
+		Viewer:                  "light",
+		PromptPrefix:            goprompt.DarkBlue,
+		StatusBarText:           goprompt.Black,
+		StatusBarBG:             goprompt.LightGray,
+		SuggestionText:          goprompt.Black,
+		SuggestionBG:            goprompt.LightGray,
+		SelectedSuggestionText:  goprompt.White,
+		SelectedSuggestionBG:    goprompt.DarkBlue,
+		DescriptionText:         goprompt.DarkGray,
+		DescriptionBG:           goprompt.LightGray,
+		SelectedDescriptionText: goprompt.White,
+		SelectedDescriptionBG:   goprompt.DarkBlue,
+		PreviewSuggestionText:   goprompt.DarkGreen,
+		HistorySuggestionText:   goprompt.LightGray,
+		ScrollbarThumb:          goprompt.DarkGray,
+		ScrollbarBG:             goprompt.LightGray,
+		Syntax:                  "github",
+	},
+	Solarized: {
+		Name:                    Solarized,
+		Viewer:                  "solarized",
+		PromptPrefix:            goprompt.Yellow,
+		StatusBarText:           goprompt.White,
+		StatusBarBG:             goprompt.DarkBlue,
+		SuggestionText:          goprompt.LightGray,
+		SuggestionBG:            goprompt.DarkBlue,
+		SelectedSuggestionText:  goprompt.Black,
+		SelectedSuggestionBG:    goprompt.Turquoise,
+		DescriptionText:         goprompt.LightGray,
+		DescriptionBG:           goprompt.DarkBlue,
+		SelectedDescriptionText: goprompt.Black,
+		SelectedDescriptionBG:   goprompt.Turquoise,
+		PreviewSuggestionText:   goprompt.Turquoise,
+		HistorySuggestionText:   goprompt.DarkGray,
+		ScrollbarThumb:          goprompt.Turquoise,
+		ScrollbarBG:             goprompt.DarkBlue,
+		Syntax:                  "solarized-dark",
+	},
+	Mono: {
+		Name:      Mono,
+		Viewer:    "mono",
+		Colorless: true,
+	},
+}
+
+var current = themes[Default]
+
+// Names returns the names of the built-in themes.
+func Names() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Current returns the theme in use.
+func Current() Theme {
+	return current
+}
+
+// Set changes the theme in use, the mono theme is used regardless of the name if NO_COLOR is set.
+// An empty name selects the default theme.
+func Set(name string) error {
+	if name == "" {
+		name = Default
+	}
+	t, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown theme %s, provide one of %s", name, strings.Join(Names(), ", "))
+	}
+	if _, ok := os.LookupEnv(noColorEnv); ok {
+		t = themes[Mono]
+	}
+	current = t
+	for i, v := range tuiutil.ValidThemes {
+		if v == t.Viewer {
+			tuiutil.SelectedTheme = i
+		}
+	}
+	if t.Colorless {
+		tuiutil.Ascii = true
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	return nil
+}
+
+// PromptOptions returns the options to apply the theme to the interactive shell.
+func (t Theme) PromptOptions() []goprompt.Option {
+	return []goprompt.Option{
+		goprompt.OptionPrefixTextColor(t.PromptPrefix),
+		goprompt.OptionInputTextColor(goprompt.DefaultColor),
+		goprompt.OptionStatusBarTextColor(t.StatusBarText), goprompt.OptionStatusBarBGColor(t.StatusBarBG),
+		goprompt.OptionSelectedSuggestionTextColor(t.SelectedSuggestionText), goprompt.OptionSuggestionTextColor(t.SuggestionText),
+		goprompt.OptionSelectedDescriptionTextColor(t.SelectedDescriptionText), goprompt.OptionDescriptionTextColor(t.DescriptionText),
+		goprompt.OptionSelectedSuggestionBGColor(t.SelectedSuggestionBG), goprompt.OptionSuggestionBGColor(t.SuggestionBG),
+		goprompt.OptionSelectedDescriptionBGColor(t.SelectedDescriptionBG), goprompt.OptionDescriptionBGColor(t.DescriptionBG),
+		goprompt.OptionPreviewSuggestionTextColor(t.PreviewSuggestionText),
+		goprompt.OptionHistorySuggestionTextColor(t.HistorySuggestionText),
+		goprompt.OptionScrollbarThumbColor(t.ScrollbarThumb), goprompt.OptionScrollbarBGColor(t.ScrollbarBG),
+	}
+}
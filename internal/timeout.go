@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	"context"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/sql/driver"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+)
+
+// timeouts is set once on start up, before running any command
+var timeouts config.TimeoutConfig
+
+// SetTimeouts sets the timeouts of connecting to the cluster and executing the SQL statements.
+// The invocation timeout is applied through the client configuration instead.
+func SetTimeouts(t config.TimeoutConfig) {
+	timeouts = t
+}
+
+// SQLContext returns the context to execute an SQL statement with, the statement is cancelled on the cluster as well if it times out.
+// The returned cancel func must be called after the results are read.
+func SQLContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeouts.SQL > 0 {
+		ctx = driver.WithQueryTimeout(ctx, timeouts.SQL)
+	}
+	return withTimeout(ctx, timeouts.SQL)
+}
+
+func connectContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, timeouts.Connect)
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package transaction
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+)
+
+// Annotation marks the commands which support transaction blocks
+const Annotation = "transaction"
+
+// Step is a change made in a transaction block, with the operation which reverts it.
+type Step struct {
+	Description string
+	Undo        func(ctx context.Context) error
+}
+
+// Undone is a step which is rolled back, Err is set if it cannot be reverted.
+type Undone struct {
+	Description string
+	Err         error
+}
+
+// Journal keeps the steps of a transaction block, so that they can be reverted in the reverse order.
+// The cluster has no transactions for these operations, so the changes are visible to the other clients before the block is committed.
+type Journal struct {
+	steps []Step
+	// savepoints are the number of steps when the savepoint is set
+	savepoints map[string]int
+}
+
+// NewJournal returns the journal of a new transaction block.
+func NewJournal() *Journal {
+	return &Journal{savepoints: map[string]int{}}
+}
+
+// Record adds a step to the journal.
+func (j *Journal) Record(description string, undo func(ctx context.Context) error) {
+	j.steps = append(j.steps, Step{Description: description, Undo: undo})
+}
+
+// Savepoint marks the current step, so that the later ones can be rolled back with RollbackTo.
+// A savepoint with the same name is replaced.
+func (j *Journal) Savepoint(name string) {
+	j.savepoints[name] = len(j.steps)
+}
+
+// Rollback reverts all the steps, the journal is empty afterwards.
+func (j *Journal) Rollback(ctx context.Context) []Undone {
+	undone := j.undo(ctx, 0)
+	j.savepoints = map[string]int{}
+	return undone
+}
+
+// RollbackTo reverts the steps after the savepoint, the savepoint is kept and the later ones are removed.
+func (j *Journal) RollbackTo(ctx context.Context, name string) ([]Undone, error) {
+	n, ok := j.savepoints[name]
+	if !ok {
+		return nil, hzcerrors.NewLoggableError(nil, "There is no savepoint named %s", name)
+	}
+	for s, m := range j.savepoints {
+		if m > n {
+			delete(j.savepoints, s)
+		}
+	}
+	return j.undo(ctx, n), nil
+}
+
+// undo reverts the steps after the first n ones, the steps which fail are not retried.
+func (j *Journal) undo(ctx context.Context, n int) []Undone {
+	var undone []Undone
+	for i := len(j.steps) - 1; i >= n; i-- {
+		s := j.steps[i]
+		undone = append(undone, Undone{Description: s.Description, Err: s.Undo(ctx)})
+	}
+	j.steps = j.steps[:n]
+	return undone
+}
+
+type journalKey struct{}
+
+// NewContext returns the context of the commands which run in the transaction block of the journal.
+func NewContext(ctx context.Context, j *Journal) context.Context {
+	return context.WithValue(ctx, journalKey{}, j)
+}
+
+// Active returns true if the commands of the context run in a transaction block.
+func Active(ctx context.Context) bool {
+	_, ok := ctx.Value(journalKey{}).(*Journal)
+	return ok
+}
+
+// Record adds a step to the journal of the context, it does nothing outside of a transaction block.
+func Record(ctx context.Context, description string, undo func(ctx context.Context) error) {
+	if j, ok := ctx.Value(journalKey{}).(*Journal); ok {
+		j.Record(description, undo)
+	}
+}
+
+// Supported marks the command as supporting transaction blocks.
+// Such commands must Record the changes they make, or return Error for the changes which cannot be reverted.
+func Supported(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[Annotation] = "true"
+	return cmd
+}
+
+// Error returns the error for the operation which is not allowed in a transaction block.
+func Error(operation string) error {
+	return hzcerrors.NewLoggableError(nil, "%s cannot be rolled back, it is not allowed in a transaction block", operation)
+}
+
+// EnableTransactions wraps the commands in the tree so that the commands which change data and do not support transaction blocks
+// return an error in a transaction block. The commands which support the dry run mode are the ones which change data.
+func EnableTransactions(root *cobra.Command) {
+	for _, c := range root.Commands() {
+		EnableTransactions(c)
+	}
+	if root.RunE == nil {
+		return
+	}
+	runE := root.RunE
+	root.RunE = func(cmd *cobra.Command, args []string) error {
+		if cmd.Annotations[dryrun.Annotation] == "true" && cmd.Annotations[Annotation] != "true" &&
+			!dryrun.Enabled(cmd) && Active(cmd.Context()) {
+			return Error(cmd.CommandPath())
+		}
+		return runE(cmd, args)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package transaction
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+)
+
+func TestJournal(t *testing.T) {
+	var reverted []string
+	step := func(j *Journal, name string, err error) {
+		j.Record(name, func(ctx context.Context) error {
+			reverted = append(reverted, name)
+			return err
+		})
+	}
+	ctx := context.Background()
+	j := NewJournal()
+	step(j, "a", nil)
+	j.Savepoint("s1")
+	step(j, "b", errors.New("cannot revert b"))
+	j.Savepoint("s2")
+	step(j, "c", nil)
+	undone, err := j.RollbackTo(ctx, "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reverted, []string{"c", "b"}) {
+		t.Fatalf("want reverted [c b] got %v", reverted)
+	}
+	if len(undone) != 2 || undone[0].Err != nil || undone[1].Err == nil {
+		t.Fatalf("unexpected undone steps: %v", undone)
+	}
+	if _, err := j.RollbackTo(ctx, "s2"); err == nil {
+		t.Fatal("the savepoint after the rolled back one must be removed")
+	}
+	// the savepoint is kept after rolling back to it
+	if undone, err = j.RollbackTo(ctx, "s1"); err != nil || len(undone) != 0 {
+		t.Fatalf("unexpected rollback to s1 again: %v, %v", undone, err)
+	}
+	reverted = nil
+	step(j, "d", nil)
+	j.Rollback(ctx)
+	if !reflect.DeepEqual(reverted, []string{"d", "a"}) {
+		t.Fatalf("want reverted [d a] got %v", reverted)
+	}
+	if undone := j.Rollback(ctx); len(undone) != 0 {
+		t.Fatalf("the journal must be empty after the rollback: %v", undone)
+	}
+}
+
+func TestEnableTransactions(t *testing.T) {
+	for _, tc := range []struct {
+		info      string
+		mutating  bool
+		supported bool
+		block     bool
+		args      []string
+		ran       bool
+		isErr     bool
+	}{
+		{info: "not in a block", mutating: true, args: []string{"sub"}, ran: true},
+		{info: "mutating", mutating: true, block: true, args: []string{"sub"}, isErr: true},
+		{info: "supported", mutating: true, supported: true, block: true, args: []string{"sub"}, ran: true},
+		{info: "dry run", mutating: true, block: true, args: []string{"sub", "--dry-run"}, ran: true},
+		{info: "not mutating", block: true, args: []string{"sub"}, ran: true},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			var ran bool
+			root := &cobra.Command{Use: "root", SilenceErrors: true, SilenceUsage: true}
+			root.PersistentFlags().Bool(dryrun.Flag, false, "")
+			sub := &cobra.Command{Use: "sub", RunE: func(cmd *cobra.Command, args []string) error {
+				ran = true
+				return nil
+			}}
+			if tc.mutating {
+				dryrun.Supported(sub)
+			}
+			if tc.supported {
+				Supported(sub)
+			}
+			root.AddCommand(sub)
+			EnableTransactions(root)
+			root.SetArgs(tc.args)
+			ctx := context.Background()
+			if tc.block {
+				ctx = NewContext(ctx, NewJournal())
+			}
+			err := root.ExecuteContext(ctx)
+			if tc.isErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ran != tc.ran {
+				t.Errorf("want ran %t got %t", tc.ran, ran)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package usage
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
+)
+
+const (
+	filename = "usage.json"
+	// Annotation marks the commands which are not recorded, such as the ones which show the stats
+	Annotation = "usagenotrecorded"
+)
+
+// Stats are the number of times each command is run.
+// Only the names of the commands are kept, such as "map get", without their arguments and flags.
+type Stats struct {
+	// Since is the time the first command is recorded
+	Since    time.Time      `json:"since"`
+	Commands map[string]int `json:"commands"`
+}
+
+// CommandCount is the number of times the command is run.
+type CommandCount struct {
+	Command string
+	Count   int
+}
+
+// Total returns the number of all the recorded runs.
+func (s *Stats) Total() int {
+	var n int
+	for _, c := range s.Commands {
+		n += c
+	}
+	return n
+}
+
+// Sorted returns the commands sorted by the number of runs, the most run first.
+func (s *Stats) Sorted() []CommandCount {
+	counts := make([]CommandCount, 0, len(s.Commands))
+	for cmd, n := range s.Commands {
+		counts = append(counts, CommandCount{Command: cmd, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Command < counts[j].Command
+	})
+	return counts
+}
+
+// mu serializes the updates of the file by the commands of this process
+var mu sync.Mutex
+
+// Path returns the path of the file the stats are kept in, it is never sent anywhere.
+func Path() string {
+	return filepath.Join(file.HZCHomePath(), filename)
+}
+
+// Load reads the stats in the file at path, the stats are empty if the file does not exist.
+func Load(path string) (*Stats, error) {
+	s := &Stats{Commands: map[string]int{}}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	if s.Commands == nil {
+		s.Commands = map[string]int{}
+	}
+	return s, nil
+}
+
+// Record adds a run of the command to the stats in the file at path.
+func Record(path, command string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	s, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if s.Since.IsZero() {
+		s.Since = time.Now()
+	}
+	s.Commands[command]++
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return file.CreateMissingDirsAndFileWithRWPerms(path, b)
+}
+
+// Clear removes the file at path with the stats.
+func Clear(path string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// CommandName returns the name of the command without the name of the root command, such as "map get".
+func CommandName(cmd *cobra.Command) string {
+	var names []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		names = append([]string{c.Name()}, names...)
+	}
+	return strings.Join(names, " ")
+}
+
+// NotRecorded marks the command as not recorded in the usage stats.
+func NotRecorded(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[Annotation] = "true"
+	return cmd
+}
+
+// EnableUsageStats wraps the commands in the tree so that their names are recorded when they run, if the usage stats are enabled in the configuration.
+// Nothing is recorded if they are disabled, and failing to record is not an error of the command.
+func EnableUsageStats(root *cobra.Command, conf *config.Config) {
+	for _, c := range root.Commands() {
+		EnableUsageStats(c, conf)
+	}
+	record := func(cmd *cobra.Command) {
+		if name := CommandName(cmd); conf.UsageStats && name != "" && cmd.Annotations[Annotation] != "true" {
+			_ = Record(Path(), name)
+		}
+	}
+	if run := root.Run; run != nil {
+		root.Run = func(cmd *cobra.Command, args []string) {
+			record(cmd)
+			run(cmd, args)
+		}
+	}
+	if runE := root.RunE; runE != nil {
+		root.RunE = func(cmd *cobra.Command, args []string) error {
+			record(cmd)
+			return runE(cmd, args)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package usage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+)
+
+func TestRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hzc-usage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, filename)
+	s, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, s.Total())
+	for _, c := range []string{"map get", "sql", "map get", "map put", "sql", "map get"} {
+		assert.NoError(t, Record(path, c))
+	}
+	s, err = Load(path)
+	assert.NoError(t, err)
+	assert.False(t, s.Since.IsZero())
+	assert.Equal(t, 6, s.Total())
+	assert.Equal(t, []CommandCount{{"map get", 3}, {"sql", 2}, {"map put", 1}}, s.Sorted())
+	assert.NoError(t, Clear(path))
+	assert.NoError(t, Clear(path))
+	s, err = Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, s.Total())
+}
+
+func TestEnableUsageStats(t *testing.T) {
+	home, err := ioutil.TempDir("", "hzc-usage-home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", home)
+	for _, enabled := range []bool{false, true} {
+		root := &cobra.Command{Use: "hzc"}
+		m := &cobra.Command{Use: "map"}
+		m.AddCommand(&cobra.Command{Use: "get", RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		}})
+		m.AddCommand(NotRecorded(&cobra.Command{Use: "stats", RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		}}))
+		root.AddCommand(m)
+		EnableUsageStats(root, &config.Config{UsageStats: enabled})
+		for _, args := range [][]string{{"map", "get"}, {"map", "stats"}} {
+			root.SetArgs(args)
+			assert.NoError(t, root.Execute())
+		}
+		s, err := Load(Path())
+		assert.NoError(t, err)
+		if !enabled {
+			assert.Equal(t, 0, s.Total())
+			continue
+		}
+		assert.Equal(t, map[string]int{"map get": 1}, s.Commands)
+	}
+}
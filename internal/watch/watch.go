@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package watch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const (
+	// Flag is the name of the global flag which sets the watch interval
+	Flag = "watch"
+	// DefaultInterval is used when the flag is given without a value
+	DefaultInterval = 2 * time.Second
+	// Annotation marks the read-only commands which can be watched
+	Annotation = "watchable"
+)
+
+// Watchable marks the command as read-only, so that it can be re-run with the watch flag.
+func Watchable(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[Annotation] = "true"
+	return cmd
+}
+
+// Interval returns the watch interval of the command, 0 if the command is not watched.
+func Interval(cmd *cobra.Command) time.Duration {
+	f := cmd.Flag(Flag)
+	if f == nil {
+		return 0
+	}
+	d, err := time.ParseDuration(f.Value.String())
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// EnableWatch wraps the commands in the tree so that they are re-run on an interval if the watch flag is given.
+// Commands which are not marked as watchable return an error if the flag is given.
+func EnableWatch(root *cobra.Command) {
+	for _, c := range root.Commands() {
+		EnableWatch(c)
+	}
+	if root.Run != nil && root.RunE == nil {
+		run := root.Run
+		root.RunE = func(cmd *cobra.Command, args []string) error {
+			run(cmd, args)
+			return nil
+		}
+		root.Run = nil
+	}
+	if root.RunE == nil {
+		return
+	}
+	if validate := root.Args; validate != nil {
+		root.Args = func(cmd *cobra.Command, args []string) error {
+			if _, rest, ok := intervalArg(cmd, args); ok {
+				args = rest
+			}
+			return validate(cmd, args)
+		}
+	}
+	runE := root.RunE
+	root.RunE = func(cmd *cobra.Command, args []string) error {
+		if d, rest, ok := intervalArg(cmd, args); ok {
+			if err := cmd.Flags().Set(Flag, d.String()); err != nil {
+				return err
+			}
+			args = rest
+		}
+		interval := Interval(cmd)
+		if interval == 0 {
+			return runE(cmd, args)
+		}
+		if cmd.Annotations[Annotation] != "true" {
+			return hzcerrors.NewLoggableError(nil, "%s cannot be watched, only read-only commands can be re-run with --%s", cmd.CommandPath(), Flag)
+		}
+		if interval < 0 {
+			return hzcerrors.NewLoggableError(nil, "Watch interval must be positive")
+		}
+		title := cmd.CommandPath()
+		if quiet.Enabled(cmd) {
+			title = ""
+		}
+		return Run(cmd.Context(), cmd.OutOrStdout(), interval, title, func(w io.Writer) error {
+			cmd.SetOut(w)
+			defer cmd.SetOut(nil)
+			return runE(cmd, args)
+		})
+	}
+}
+
+// intervalArg returns the interval given after the watch flag with a space, such as --watch 5s, and the rest of the arguments.
+// The flag has an optional value, so such an interval is parsed as the first argument of the command.
+// The first argument is taken only if the flag is given without a value, so that --watch=5s keeps it.
+func intervalArg(cmd *cobra.Command, args []string) (time.Duration, []string, bool) {
+	f := cmd.Flag(Flag)
+	if f == nil || !f.Changed || f.NoOptDefVal == "" || f.Value.String() != f.NoOptDefVal || len(args) == 0 {
+		return 0, args, false
+	}
+	d, err := time.ParseDuration(args[0])
+	if err != nil {
+		return 0, args, false
+	}
+	return d, args[1:], true
+}
+
+// Run calls run on the interval until the context is cancelled, and renders its output.
+// On a terminal, the output is redrawn in place and the fields which changed since the previous run are highlighted.
+// The header with the interval, title and time is left out if title is empty.
+func Run(ctx context.Context, out io.Writer, interval time.Duration, title string, run func(w io.Writer) error) error {
+	isTerminal := false
+	if f, ok := out.(*os.File); ok {
+		isTerminal = term.IsTerminal(int(f.Fd()))
+	}
+	changed := lipgloss.NewStyle().Reverse(true)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var prev string
+	for {
+		var buf bytes.Buffer
+		if err := run(&buf); err != nil {
+			// keep watching, the error may be temporary
+			fmt.Fprintf(&buf, "Error: %s\n", err)
+		}
+		cur := buf.String()
+		var header string
+		if title != "" {
+			header = fmt.Sprintf("Every %s: %s    %s\n\n", interval, title, time.Now().Format("2006-01-02 15:04:05"))
+		}
+		if isTerminal {
+			// move to the top left corner and clear the screen
+			fmt.Fprint(out, "\x1b[H\x1b[2J", header, highlightChanges(prev, cur, changed.Render))
+		} else {
+			fmt.Fprint(out, header, cur, "\n")
+		}
+		prev = cur
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// highlightChanges renders the fields of cur which differ from the field at the same position in prev.
+// Fields are the runs of non-space characters, so the cells of the tables and delimited output are compared one by one.
+func highlightChanges(prev, cur string, highlight func(string) string) string {
+	if prev == "" {
+		return cur
+	}
+	prevLines := strings.Split(prev, "\n")
+	curLines := strings.Split(cur, "\n")
+	for i, line := range curLines {
+		var prevFields []string
+		if i < len(prevLines) {
+			prevFields = splitFields(prevLines[i])
+		}
+		fields := splitFields(line)
+		var sb strings.Builder
+		for j, f := range fields {
+			if strings.TrimSpace(f) != "" && (j >= len(prevFields) || prevFields[j] != f) {
+				f = highlight(f)
+			}
+			sb.WriteString(f)
+		}
+		curLines[i] = sb.String()
+	}
+	return strings.Join(curLines, "\n")
+}
+
+// splitFields splits the line into the alternating runs of space and non-space characters.
+func splitFields(line string) []string {
+	var fields []string
+	start := 0
+	inSpace := false
+	for i, r := range line {
+		if space := unicode.IsSpace(r); i == 0 {
+			inSpace = space
+		} else if space != inSpace {
+			fields = append(fields, line[start:i])
+			start, inSpace = i, space
+		}
+	}
+	if start < len(line) {
+		fields = append(fields, line[start:])
+	}
+	return fields
+}
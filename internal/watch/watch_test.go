@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package watch
+
+import (
+	"context"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSplitFields(t *testing.T) {
+	for _, tc := range []struct {
+		line string
+		want []string
+	}{
+		{line: "", want: nil},
+		{line: "a", want: []string{"a"}},
+		{line: "k1\tv1", want: []string{"k1", "\t", "v1"}},
+		{line: "  │ größe │ 12 ", want: []string{"  ", "│", " ", "größe", " ", "│", " ", "12", " "}},
+	} {
+		t.Run(tc.line, func(t *testing.T) {
+			if got := splitFields(tc.line); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %q got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestHighlightChanges(t *testing.T) {
+	mark := func(s string) string {
+		return "[" + s + "]"
+	}
+	for _, tc := range []struct {
+		info string
+		prev string
+		cur  string
+		want string
+	}{
+		{info: "first run", prev: "", cur: "k1\t1\n", want: "k1\t1\n"},
+		{info: "no change", prev: "k1\t1\nk2\t2\n", cur: "k1\t1\nk2\t2\n", want: "k1\t1\nk2\t2\n"},
+		{info: "changed cell", prev: "k1\t1\nk2\t2\n", cur: "k1\t1\nk2\t3\n", want: "k1\t1\nk2\t[3]\n"},
+		{info: "new line", prev: "k1\t1\n", cur: "k1\t1\nk2\t2\n", want: "k1\t1\n[k2]\t[2]\n"},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if got := highlightChanges(tc.prev, tc.cur, mark); got != tc.want {
+				t.Errorf("want %q got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestEnableWatch_IntervalAfterSpace(t *testing.T) {
+	for _, tc := range []struct {
+		args         []string
+		wantInterval time.Duration
+		wantArgs     []string
+	}{
+		{args: []string{"sql", "--watch", "5s", "SELECT 1"}, wantInterval: 5 * time.Second, wantArgs: []string{"SELECT 1"}},
+		{args: []string{"sql", "--watch=5s", "SELECT 1"}, wantInterval: 5 * time.Second, wantArgs: []string{"SELECT 1"}},
+		{args: []string{"sql", "--watch=5s", "7s"}, wantInterval: 5 * time.Second, wantArgs: []string{"7s"}},
+		{args: []string{"sql", "--watch", "SELECT 1"}, wantInterval: DefaultInterval, wantArgs: []string{"SELECT 1"}},
+		{args: []string{"sql", "5s"}, wantInterval: 0, wantArgs: []string{"5s"}},
+	} {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var (
+				interval time.Duration
+				gotArgs  []string
+			)
+			root := &cobra.Command{Use: "hzc"}
+			root.PersistentFlags().Duration(Flag, 0, "")
+			root.PersistentFlags().Lookup(Flag).NoOptDefVal = DefaultInterval.String()
+			root.AddCommand(Watchable(&cobra.Command{
+				Use:  "sql",
+				Args: cobra.MaximumNArgs(1),
+				RunE: func(cmd *cobra.Command, args []string) error {
+					interval, gotArgs = Interval(cmd), args
+					// stop watching after the first run
+					cancel()
+					return nil
+				},
+			}))
+			EnableWatch(root)
+			root.SetArgs(tc.args)
+			root.SetOut(ioutil.Discard)
+			if err := root.ExecuteContext(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if interval != tc.wantInterval || !reflect.DeepEqual(gotArgs, tc.wantArgs) {
+				t.Errorf("want %s %q got %s %q", tc.wantInterval, tc.wantArgs, interval, gotArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package jobcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const (
+	JobNameFlag      = "name"
+	JobNameFlagShort = "n"
+	SnapshotFlag     = "snapshot"
+	SQLFlag          = "sql"
+)
+
+const JobExample = `  # Export a snapshot of the running job
+  hzc job save-snapshot --name my-job --snapshot my-snapshot
+  # Start the job again from the exported snapshot
+  hzc job restore --name my-job --snapshot my-snapshot --sql "SINK INTO orders_out SELECT * FROM orders"`
+
+func New(config *hazelcast.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "job {save-snapshot | restore} --name jobname --snapshot snapshotname",
+		Short:   "Jet job operations",
+		Example: JobExample,
+	}
+	cmd.AddCommand(NewSaveSnapshot(config), NewRestore(config))
+	return cmd
+}
+
+func NewSaveSnapshot(config *hazelcast.Config) *cobra.Command {
+	var jobName, snapshotName string
+	cmd := &cobra.Command{
+		Use:   "save-snapshot --name jobname --snapshot snapshotname",
+		Short: "Export a snapshot of the job, the job keeps running",
+		Long:  "Export a snapshot of the job, the job keeps running. An existing snapshot with the same name is replaced. Requires Hazelcast Enterprise.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q := fmt.Sprintf("CREATE OR REPLACE SNAPSHOT %s FOR JOB %s", output.QuoteIdentifier(snapshotName), output.QuoteIdentifier(jobName))
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "execute: %s", q)
+				return nil
+			}
+			if err := execSQL(cmd, config, q); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot export snapshot %s of job %s", snapshotName, jobName)
+			}
+			quiet.Printf(cmd, "Exported snapshot %s of job %s\n", snapshotName, jobName)
+			return nil
+		},
+	}
+	decorateCommandWithJobNameFlag(cmd, &jobName, "name of the job")
+	decorateCommandWithSnapshotFlag(cmd, &snapshotName, "name of the exported snapshot")
+	return dryrun.Supported(cmd)
+}
+
+func NewRestore(config *hazelcast.Config) *cobra.Command {
+	var jobName, snapshotName, sqlText string
+	cmd := &cobra.Command{
+		Use:   "restore --name jobname --snapshot snapshotname --sql statement",
+		Short: "Submit a job which starts from an exported snapshot",
+		Long: `Submit a job which starts from an exported snapshot.
+The job is defined by the given SQL statement, which must be compatible with the job the snapshot was exported from.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q := fmt.Sprintf("CREATE JOB %s OPTIONS ('initialSnapshotName'=%s) AS %s",
+				output.QuoteIdentifier(jobName), output.QuoteLiteral(snapshotName), strings.TrimSpace(sqlText))
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "execute: %s", q)
+				return nil
+			}
+			if err := execSQL(cmd, config, q); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot submit job %s from snapshot %s", jobName, snapshotName)
+			}
+			quiet.Printf(cmd, "Submitted job %s from snapshot %s\n", jobName, snapshotName)
+			return nil
+		},
+	}
+	decorateCommandWithJobNameFlag(cmd, &jobName, "name of the new job")
+	decorateCommandWithSnapshotFlag(cmd, &snapshotName, "name of the exported snapshot to start from")
+	cmd.Flags().StringVar(&sqlText, SQLFlag, "", "SQL statement which defines the job, such as SINK INTO ... SELECT ...")
+	if err := cmd.MarkFlagRequired(SQLFlag); err != nil {
+		panic(err)
+	}
+	return dryrun.Supported(cmd)
+}
+
+func execSQL(cmd *cobra.Command, config *hazelcast.Config, q string) error {
+	driver, err := internal.SQLDriver(cmd.Context(), config)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := internal.SQLContext(cmd.Context())
+	defer cancel()
+	_, err = driver.ExecContext(ctx, q)
+	return err
+}
+
+func decorateCommandWithJobNameFlag(cmd *cobra.Command, jobName *string, usage string) {
+	cmd.Flags().StringVarP(jobName, JobNameFlag, JobNameFlagShort, "", usage)
+	if err := cmd.MarkFlagRequired(JobNameFlag); err != nil {
+		panic(err)
+	}
+}
+
+func decorateCommandWithSnapshotFlag(cmd *cobra.Command, snapshotName *string, usage string) {
+	cmd.Flags().StringVar(snapshotName, SnapshotFlag, "", usage)
+	if err := cmd.MarkFlagRequired(SnapshotFlag); err != nil {
+		panic(err)
+	}
+}
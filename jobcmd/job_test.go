@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package jobcmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestSnapshotNames(t *testing.T) {
+	infos := []types.DistributedObjectInfo{
+		{Name: "__jet.exportedSnapshot.s2", ServiceName: hazelcast.ServiceNameMap},
+		{Name: "orders", ServiceName: hazelcast.ServiceNameMap},
+		{Name: "__jet.exportedSnapshot.s1", ServiceName: hazelcast.ServiceNameMap},
+		{Name: "__jet.exportedSnapshot.s3", ServiceName: hazelcast.ServiceNameQueue},
+	}
+	want := []string{"s1", "s2"}
+	if got := snapshotNames(infos); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v got %v", want, got)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package jobcmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
+)
+
+// exportedSnapshotPrefix is the prefix of the maps which the cluster stores the exported snapshots in.
+const exportedSnapshotPrefix = "__jet.exportedSnapshot."
+
+func NewSnapshot(config *hazelcast.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot {list}",
+		Short: "Exported Jet job snapshot operations",
+	}
+	cmd.AddCommand(watch.Watchable(&cobra.Command{
+		Use:   "list",
+		Short: "List the exported snapshots",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			client, err := internal.ConnectToCluster(ctx, config)
+			if err != nil {
+				return err
+			}
+			infos, err := client.GetDistributedObjectsInfo(ctx)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot list the exported snapshots")
+			}
+			for _, name := range snapshotNames(infos) {
+				fmt.Fprintln(cmd.OutOrStdout(), name)
+			}
+			return nil
+		},
+	}))
+	return cmd
+}
+
+func snapshotNames(infos []types.DistributedObjectInfo) []string {
+	var names []string
+	for _, info := range infos {
+		if info.ServiceName == hazelcast.ServiceNameMap && strings.HasPrefix(info.Name, exportedSnapshotPrefix) {
+			names = append(names, strings.TrimPrefix(info.Name, exportedSnapshotPrefix))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package listenercmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const listenerExample = `# List the listeners registered in the interactive mode
+hzc listener list
+# Remove a listener which is left behind
+hzc listener remove 6a6b7c4e-3c8e-4b43-9d6c-0e3a4f0f6b2e`
+
+// New creates the listener command, which manages the listeners registered by the commands of this session.
+// The listeners belong to the connection, so they are only listed in the interactive mode, after a command connected to the cluster.
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "listener {list | remove}",
+		Short: "List and remove the listeners registered by this session",
+		Long: `List and remove the listeners registered on the cluster by the commands of this session, such as the ones of "topic subscribe".
+The listeners belong to the connection, which is kept between the commands in the interactive mode.`,
+		Example: listenerExample,
+	}
+	cmd.AddCommand(newList(), newRemove())
+	return cmd
+}
+
+func newList() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the listeners with their IDs, kinds, objects and registration times",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			listeners := internal.Listeners()
+			if len(listeners) == 0 {
+				quiet.Println(cmd, "There are no listeners")
+				return
+			}
+			for _, l := range listeners {
+				line := fmt.Sprintf("%s\t%s\t%s\t%s", l.ID, l.Kind, l.Object, l.RegisteredAt.Format(time.RFC3339))
+				if !l.Removable {
+					line += "\t(used by hzc)"
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), line)
+			}
+		},
+	}
+}
+
+func newRemove() *cobra.Command {
+	return &cobra.Command{
+		Use:               "remove ID",
+		Short:             "Remove the listener with the ID from the cluster",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeListenerIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := internal.RemoveListener(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+			quiet.Printf(cmd, "Removed listener %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func completeListenerIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var ids []string
+	for _, l := range internal.Listeners() {
+		if l.Removable {
+			ids = append(ids, fmt.Sprintf("%s\t%s %s", l.ID, l.Kind, l.Object))
+		}
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"github.com/hazelcast/hazelcast-commandline-client/config"
+	"github.com/hazelcast/hazelcast-commandline-client/plugincmd"
 	"github.com/hazelcast/hazelcast-commandline-client/rootcmd"
 )
 
@@ -31,7 +32,7 @@ const (
 
 func main() {
 	cnfg := config.DefaultConfig()
-	rootCmd, globalFlagValues := rootcmd.New(&cnfg.Hazelcast)
+	rootCmd, globalFlagValues := rootcmd.New(cnfg)
 	programArgs := os.Args[1:]
 	// update config before running root command to make sure flags are processed
 	err := updateConfigWithFlags(rootCmd, cnfg, programArgs, globalFlagValues)
@@ -40,11 +41,12 @@ func main() {
 	defer cancel()
 	isInteractive := IsInteractiveCall(rootCmd, programArgs)
 	if isInteractive {
-		RunCmdInteractively(ctx, rootCmd, &cnfg.Hazelcast)
+		RunCmdInteractively(ctx, rootCmd, cnfg, globalFlagValues.CfgFile)
 	} else {
 		// Since the cluster config related flags has already being parsed in previous steps,
 		// there is no need for second parameter anymore. The purpose is overwriting rootCmd as it is at the beginning.
-		rootCmd, _ = rootcmd.New(&cnfg.Hazelcast)
+		rootCmd, _ = rootcmd.New(cnfg)
+		plugincmd.EnableGlobalFlags(rootCmd, programArgs)
 		err = RunCmd(ctx, rootCmd)
 		ExitOnError(err)
 	}
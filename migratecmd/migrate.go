@@ -0,0 +1,251 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package migratecmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const defaultHistoryMap = "__hzc.migrations"
+
+const MigrateExample = `  # Apply the pending migrations in the migrations directory
+  hzc migrate --dir migrations/
+  # List the pending migrations without applying them
+  hzc migrate --dir migrations/ --dry-run`
+
+type migration struct {
+	version  string
+	checksum string
+	text     string
+}
+
+// appliedMigration is the record of an applied migration in the history map.
+type appliedMigration struct {
+	Checksum  string    `json:"checksum"`
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+func New(config *hazelcast.Config) *cobra.Command {
+	var (
+		dir,
+		historyMap string
+	)
+	cmd := &cobra.Command{
+		Use:   "migrate --dir directory [--history-map mapname] [--dry-run]",
+		Short: "Apply the SQL migrations in the directory",
+		Long: `Apply the .sql files in the directory in the order of their names, such as V001__create_mappings.sql.
+Each file is applied once, the applied versions are recorded in the history map.`,
+		Example: MigrateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrations, err := loadMigrations(dir)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot load the migrations in %s", dir)
+			}
+			ctx := cmd.Context()
+			client, err := internal.ConnectToCluster(ctx, config)
+			if err != nil {
+				return err
+			}
+			history, err := client.GetMap(ctx, historyMap)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get the history map %s", historyMap)
+			}
+			var pending []migration
+			for _, m := range migrations {
+				v, err := history.Get(ctx, m.version)
+				if err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot read the history map %s", historyMap)
+				}
+				if v == nil {
+					pending = append(pending, m)
+					continue
+				}
+				applied, err := decodeAppliedMigration(v)
+				if err != nil {
+					return hzcerrors.NewLoggableError(err, "Invalid record for migration %s in the history map %s", m.version, historyMap)
+				}
+				if applied.Checksum != m.checksum {
+					return hzcerrors.NewLoggableError(nil, "Migration %s was changed after it was applied on %s", m.version, applied.AppliedAt.Format(time.RFC3339))
+				}
+			}
+			if len(pending) == 0 {
+				quiet.Println(cmd, "No pending migrations")
+				return nil
+			}
+			if dryrun.Enabled(cmd) {
+				for _, m := range pending {
+					dryrun.Print(cmd, "apply migration %s", m.version)
+				}
+				return nil
+			}
+			driver, err := internal.SQLDriver(ctx, config)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+			}
+			for _, m := range pending {
+				for i, stmt := range splitStatements(m.text) {
+					if err := execStatement(ctx, driver, stmt); err != nil {
+						return hzcerrors.NewLoggableError(err, "Migration %s failed at statement %d, the statements before it are not rolled back", m.version, i+1)
+					}
+				}
+				record, err := json.Marshal(appliedMigration{Checksum: m.checksum, AppliedAt: time.Now()})
+				if err != nil {
+					return err
+				}
+				if err := history.Set(ctx, m.version, serialization.JSON(record)); err != nil {
+					return hzcerrors.NewLoggableError(err, "Migration %s was applied, but cannot be recorded in the history map %s", m.version, historyMap)
+				}
+				quiet.Printf(cmd, "Applied: %s\n", m.version)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "", "directory of the .sql files")
+	if err := cmd.MarkFlagRequired("dir"); err != nil {
+		panic(err)
+	}
+	cmd.Flags().StringVar(&historyMap, "history-map", defaultHistoryMap, "map to record the applied migrations in")
+	// the global dry-run flag lists the pending migrations without applying them
+	return dryrun.Supported(cmd)
+}
+
+// loadMigrations reads the .sql files in the directory, sorted by their names.
+func loadMigrations(dir string) ([]migration, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no .sql files in %s", dir)
+	}
+	sort.Strings(paths)
+	migrations := make([]migration, len(paths))
+	for i, p := range paths {
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(b)
+		migrations[i] = migration{
+			version:  strings.TrimSuffix(filepath.Base(p), ".sql"),
+			checksum: hex.EncodeToString(sum[:]),
+			text:     string(b),
+		}
+	}
+	return migrations, nil
+}
+
+func decodeAppliedMigration(v interface{}) (appliedMigration, error) {
+	var applied appliedMigration
+	j, ok := v.(serialization.JSON)
+	if !ok {
+		return applied, fmt.Errorf("unexpected type %T", v)
+	}
+	err := json.Unmarshal(j, &applied)
+	return applied, err
+}
+
+// splitStatements splits the text into statements separated by semicolons.
+// Semicolons in string literals, quoted identifiers and comments do not end a statement.
+// Statements which consist of only comments are left out.
+func splitStatements(text string) []string {
+	var stmts []string
+	var sb strings.Builder
+	hasCode := false
+	flush := func() {
+		if hasCode {
+			stmts = append(stmts, strings.TrimSpace(sb.String()))
+		}
+		sb.Reset()
+		hasCode = false
+	}
+	rs := []rune(text)
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		switch {
+		case r == '\'' || r == '"':
+			// doubled quotes are escapes, which are handled as two consecutive quoted parts
+			end := i + 1
+			for end < len(rs) && rs[end] != r {
+				end++
+			}
+			sb.WriteString(string(rs[i:min(end+1, len(rs))]))
+			hasCode = true
+			i = end
+		case r == '-' && i+1 < len(rs) && rs[i+1] == '-':
+			end := i
+			for end < len(rs) && rs[end] != '\n' {
+				end++
+			}
+			sb.WriteString(string(rs[i:end]))
+			i = end - 1
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			end := i + 2
+			for end+1 < len(rs) && !(rs[end] == '*' && rs[end+1] == '/') {
+				end++
+			}
+			end = min(end+2, len(rs))
+			sb.WriteString(string(rs[i:end]))
+			i = end - 1
+		case r == ';':
+			flush()
+		default:
+			sb.WriteRune(r)
+			if !isSpace(r) {
+				hasCode = true
+			}
+		}
+	}
+	flush()
+	return stmts
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func execStatement(ctx context.Context, driver *sql.DB, stmt string) error {
+	ctx, cancel := internal.SQLContext(ctx)
+	defer cancel()
+	_, err := driver.ExecContext(ctx, stmt)
+	return err
+}
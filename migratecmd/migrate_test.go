@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package migratecmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	for _, tc := range []struct {
+		info string
+		text string
+		want []string
+	}{
+		{info: "empty", text: "", want: nil},
+		{info: "single without semicolon", text: "DROP MAPPING m", want: []string{"DROP MAPPING m"}},
+		{info: "multiple", text: "DROP MAPPING a;\nDROP MAPPING b;\n", want: []string{"DROP MAPPING a", "DROP MAPPING b"}},
+		{info: "semicolon in literal", text: "INSERT INTO m VALUES (1, 'a;b''c');", want: []string{"INSERT INTO m VALUES (1, 'a;b''c')"}},
+		{info: "semicolon in identifier", text: `SELECT "a;b" FROM m;`, want: []string{`SELECT "a;b" FROM m`}},
+		{info: "line comment", text: "-- drop; it\nDROP MAPPING a;", want: []string{"-- drop; it\nDROP MAPPING a"}},
+		{info: "block comment", text: "/* a; b */ DROP MAPPING a; /* only a comment */", want: []string{"/* a; b */ DROP MAPPING a"}},
+		{info: "unterminated block comment", text: "DROP MAPPING a; /* a;", want: []string{"DROP MAPPING a"}},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if got := splitStatements(tc.text); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %q got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, text := range map[string]string{
+		"V002__data.sql":     "INSERT INTO m VALUES (1, 'a');",
+		"V001__mappings.sql": "CREATE MAPPING m TYPE IMap OPTIONS ('keyFormat'='int', 'valueFormat'='varchar');",
+		"README.md":          "not a migration",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	migrations, err := loadMigrations(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versions []string
+	for _, m := range migrations {
+		versions = append(versions, m.version)
+	}
+	want := []string{"V001__mappings", "V002__data"}
+	if !reflect.DeepEqual(want, versions) {
+		t.Errorf("want %v got %v", want, versions)
+	}
+	if _, err := loadMigrations(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("expected an error for a directory without migrations")
+	}
+}
@@ -0,0 +1,447 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package migratedatacmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/progress"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/ratelimit"
+)
+
+const (
+	FromFlag       = "from"
+	ToFlag         = "to"
+	ObjectsFlag    = "objects"
+	BatchSizeFlag  = "batch-size"
+	RateFlag       = "rate"
+	CheckpointFlag = "checkpoint"
+)
+
+const (
+	defaultBatchSize    = 1000
+	internalObjectStart = "__"
+)
+
+// objectTypes are the types of the objects which can be migrated, by their names in the patterns.
+var objectTypes = map[string]string{
+	"map":           hazelcast.ServiceNameMap,
+	"replicatedmap": hazelcast.ServiceNameReplicatedMap,
+	"list":          hazelcast.ServiceNameList,
+	"set":           hazelcast.ServiceNameSet,
+	"queue":         hazelcast.ServiceNameQueue,
+}
+
+const migrateDataExample = `  # Migrate all the maps from the cluster of prod.yaml to the cluster of the current configuration
+  hzc migrate-data --from prod.yaml --objects 'map:*'
+  # Migrate the user maps and the orders list at 5000 entries per second, continuing from the last run if it is interrupted
+  hzc migrate-data --from old.yaml --to new.yaml --objects 'map:user*,list:orders' --rate 5000 --checkpoint migration.json`
+
+func New(cnfg *config.Config) *cobra.Command {
+	var (
+		from,
+		to,
+		checkpointPath string
+		patterns []string
+		batchSize,
+		rate int
+	)
+	cmd := &cobra.Command{
+		Use:   "migrate-data --objects type:pattern [--from config] [--to config] [--checkpoint file]",
+		Short: "Copy distributed objects from one cluster to another",
+		Long: `Copy the entries and the items of the selected distributed objects from one cluster to another in batches.
+The clusters are given with the paths of their configuration files, the current configuration is used if one is left out.
+The objects are selected with type:pattern, such as map:user*, the types are map, replicatedmap, list, set and queue.
+The internal objects, which have names starting with __, are selected only if the pattern starts with __.
+
+With a checkpoint file, the progress is saved after each batch and the next run with the same file continues
+from where the previous one stopped. The entries added to the source after the interruption may be missed,
+use "hzc compare map" to validate the migrated maps.`,
+		Example: migrateDataExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if from == to {
+				return hzcerrors.NewLoggableError(nil, "The source and the target clusters are the same, set a different --%s or --%s", FromFlag, ToFlag)
+			}
+			selectors, err := parseSelectors(patterns)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid --%s", ObjectsFlag)
+			}
+			if batchSize < 1 {
+				return hzcerrors.NewLoggableError(nil, "Batch size must be positive")
+			}
+			if rate < 0 {
+				return hzcerrors.NewLoggableError(nil, "Rate cannot be negative")
+			}
+			cp, err := loadCheckpoint(checkpointPath)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot load the checkpoint file %s", checkpointPath)
+			}
+			ctx := cmd.Context()
+			src, releaseSource, err := internal.ClientForConfig(ctx, cnfg, from)
+			if err != nil {
+				return err
+			}
+			defer releaseSource()
+			infos, err := src.GetDistributedObjectsInfo(ctx)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot list the objects of the source cluster")
+			}
+			objects := selectObjects(infos, selectors)
+			if len(objects) == 0 {
+				return hzcerrors.NewLoggableError(nil, "No objects match %s", strings.Join(patterns, ","))
+			}
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "migrate %d objects from %s to %s", len(objects), clusterName(from), clusterName(to))
+				for _, o := range objects {
+					fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", o)
+				}
+				return nil
+			}
+			dst, releaseTarget, err := internal.ClientForConfig(ctx, cnfg, to)
+			if err != nil {
+				return err
+			}
+			defer releaseTarget()
+			m := migration{src: src, dst: dst, batchSize: batchSize, limiter: ratelimit.New(rate), checkpoint: cp}
+			start := time.Now()
+			var total int
+			for _, o := range objects {
+				if p := cp.Objects[o.String()]; p != nil && p.Done {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s\t%d\tskipped, migrated before\n", o, p.Migrated)
+					continue
+				}
+				n, err := m.migrate(ctx, cmd, o)
+				if err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot migrate %s", o)
+				}
+				total += n
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\t%d\tdone\n", o, n)
+			}
+			quiet.Printf(cmd, "Migrated %d entries and items of %d objects in %s\n", total, len(objects), time.Since(start).Round(time.Millisecond))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, FromFlag, "", "configuration file of the source cluster, the current configuration is used if it is not given")
+	cmd.Flags().StringVar(&to, ToFlag, "", "configuration file of the target cluster, the current configuration is used if it is not given")
+	cmd.Flags().StringSliceVar(&patterns, ObjectsFlag, nil, "objects to migrate as type:pattern, such as map:* or list:orders")
+	if err := cmd.MarkFlagRequired(ObjectsFlag); err != nil {
+		panic(err)
+	}
+	cmd.Flags().IntVar(&batchSize, BatchSizeFlag, defaultBatchSize, "number of the entries or items copied at once")
+	cmd.Flags().IntVar(&rate, RateFlag, 0, "maximum number of the entries and items copied per second, 0 means no limit")
+	cmd.Flags().StringVar(&checkpointPath, CheckpointFlag, "", "file to save the progress to, and to continue from if it exists")
+	return dryrun.Supported(cmd)
+}
+
+func clusterName(configPath string) string {
+	if configPath == "" {
+		return "the cluster of the current configuration"
+	}
+	return fmt.Sprintf("the cluster of %s", configPath)
+}
+
+type selector struct {
+	service string
+	pattern string
+}
+
+func parseSelectors(patterns []string) ([]selector, error) {
+	var selectors []selector
+	for _, p := range patterns {
+		i := strings.Index(p, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("%s is not type:pattern, such as map:*", p)
+		}
+		service, ok := objectTypes[p[:i]]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %s in %s, the types are map, replicatedmap, list, set and queue", p[:i], p)
+		}
+		if _, err := path.Match(p[i+1:], ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", p, err)
+		}
+		selectors = append(selectors, selector{service: service, pattern: p[i+1:]})
+	}
+	return selectors, nil
+}
+
+type object struct {
+	typ     string
+	service string
+	name    string
+}
+
+func (o object) String() string {
+	return o.typ + ":" + o.name
+}
+
+// selectObjects returns the objects which match any of the selectors, in the order of their types and names.
+func selectObjects(infos []types.DistributedObjectInfo, selectors []selector) []object {
+	typeNames := make(map[string]string, len(objectTypes))
+	for t, s := range objectTypes {
+		typeNames[s] = t
+	}
+	var objects []object
+	for _, info := range infos {
+		for _, s := range selectors {
+			if info.ServiceName != s.service {
+				continue
+			}
+			if strings.HasPrefix(info.Name, internalObjectStart) && !strings.HasPrefix(s.pattern, internalObjectStart) {
+				continue
+			}
+			if ok, _ := path.Match(s.pattern, info.Name); ok {
+				objects = append(objects, object{typ: typeNames[info.ServiceName], service: info.ServiceName, name: info.Name})
+				break
+			}
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].String() < objects[j].String()
+	})
+	return objects
+}
+
+type migration struct {
+	src        *hazelcast.Client
+	dst        *hazelcast.Client
+	batchSize  int
+	limiter    *ratelimit.Limiter
+	checkpoint *checkpoint
+}
+
+// records are the entries or the items of an object, write copies the ones in [from, to) to the target.
+type records struct {
+	n     int
+	write func(ctx context.Context, from, to int) error
+}
+
+// migrate copies the object in batches, starting after the records migrated before according to the checkpoint.
+// It returns the number of the records copied by this run.
+func (m *migration) migrate(ctx context.Context, cmd *cobra.Command, o object) (int, error) {
+	rs, err := m.records(ctx, o)
+	if err != nil {
+		return 0, err
+	}
+	p := m.checkpoint.progress(o.String())
+	start := p.Migrated
+	if start > rs.n {
+		start = rs.n
+	}
+	tracker := progress.New(cmd.ErrOrStderr(), fmt.Sprintf("Migrating %s", o), int64(rs.n))
+	tracker.Add(int64(start))
+	defer tracker.Done()
+	for from := start; from < rs.n; from += m.batchSize {
+		to := from + m.batchSize
+		if to > rs.n {
+			to = rs.n
+		}
+		if err := m.limiter.Wait(ctx, to-from); err != nil {
+			return from - start, err
+		}
+		if err := rs.write(ctx, from, to); err != nil {
+			return from - start, err
+		}
+		tracker.Add(int64(to - from))
+		p.Migrated = to
+		if err := m.checkpoint.save(); err != nil {
+			return to - start, err
+		}
+	}
+	p.Done = true
+	return rs.n - start, m.checkpoint.save()
+}
+
+// records fetches the keys or the items of the object, in an order which is the same in the next runs,
+// so that the migration can continue from the checkpoint.
+func (m *migration) records(ctx context.Context, o object) (records, error) {
+	switch o.service {
+	case hazelcast.ServiceNameMap:
+		sm, err := m.src.GetMap(ctx, o.name)
+		if err != nil {
+			return records{}, err
+		}
+		dm, err := m.dst.GetMap(ctx, o.name)
+		if err != nil {
+			return records{}, err
+		}
+		keys, err := sm.GetKeySet(ctx)
+		if err != nil {
+			return records{}, err
+		}
+		sortValues(keys)
+		return records{n: len(keys), write: func(ctx context.Context, from, to int) error {
+			entries, err := sm.GetAll(ctx, keys[from:to]...)
+			if err != nil || len(entries) == 0 {
+				return err
+			}
+			return dm.PutAll(ctx, entries...)
+		}}, nil
+	case hazelcast.ServiceNameReplicatedMap:
+		sm, err := m.src.GetReplicatedMap(ctx, o.name)
+		if err != nil {
+			return records{}, err
+		}
+		dm, err := m.dst.GetReplicatedMap(ctx, o.name)
+		if err != nil {
+			return records{}, err
+		}
+		entries, err := sm.GetEntrySet(ctx)
+		if err != nil {
+			return records{}, err
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return output.TypedString(entries[i].Key) < output.TypedString(entries[j].Key)
+		})
+		return records{n: len(entries), write: func(ctx context.Context, from, to int) error {
+			return dm.PutAll(ctx, entries[from:to]...)
+		}}, nil
+	case hazelcast.ServiceNameList:
+		sl, err := m.src.GetList(ctx, o.name)
+		if err != nil {
+			return records{}, err
+		}
+		dl, err := m.dst.GetList(ctx, o.name)
+		if err != nil {
+			return records{}, err
+		}
+		// the order of the items is kept
+		return itemRecords(ctx, sl.GetAll, dl.AddAll, false)
+	case hazelcast.ServiceNameSet:
+		ss, err := m.src.GetSet(ctx, o.name)
+		if err != nil {
+			return records{}, err
+		}
+		ds, err := m.dst.GetSet(ctx, o.name)
+		if err != nil {
+			return records{}, err
+		}
+		return itemRecords(ctx, ss.GetAll, ds.AddAll, true)
+	case hazelcast.ServiceNameQueue:
+		sq, err := m.src.GetQueue(ctx, o.name)
+		if err != nil {
+			return records{}, err
+		}
+		dq, err := m.dst.GetQueue(ctx, o.name)
+		if err != nil {
+			return records{}, err
+		}
+		// the items are peeked, they are not removed from the source queue
+		return itemRecords(ctx, sq.GetAll, dq.AddAll, false)
+	}
+	return records{}, fmt.Errorf("objects of %s cannot be migrated", o.service)
+}
+
+func itemRecords(ctx context.Context, getAll func(ctx context.Context) ([]interface{}, error), addAll func(ctx context.Context, items ...interface{}) (bool, error), sorted bool) (records, error) {
+	items, err := getAll(ctx)
+	if err != nil {
+		return records{}, err
+	}
+	if sorted {
+		sortValues(items)
+	}
+	return records{n: len(items), write: func(ctx context.Context, from, to int) error {
+		_, err := addAll(ctx, items[from:to]...)
+		return err
+	}}, nil
+}
+
+func sortValues(values []interface{}) {
+	sort.Slice(values, func(i, j int) bool {
+		return output.TypedString(values[i]) < output.TypedString(values[j])
+	})
+}
+
+// checkpoint is the progress of the migration, it is saved to the file at path if path is not empty.
+type checkpoint struct {
+	path string
+	// Objects are the progress of the objects by type:name
+	Objects map[string]*objectProgress `json:"objects"`
+}
+
+type objectProgress struct {
+	// Migrated is the number of the entries or the items copied, in the order of the migration
+	Migrated int  `json:"migrated"`
+	Done     bool `json:"done"`
+}
+
+func loadCheckpoint(p string) (*checkpoint, error) {
+	c := &checkpoint{path: p, Objects: map[string]*objectProgress{}}
+	if p == "" {
+		return c, nil
+	}
+	b, err := ioutil.ReadFile(p)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	if c.Objects == nil {
+		c.Objects = map[string]*objectProgress{}
+	}
+	return c, nil
+}
+
+func (c *checkpoint) progress(name string) *objectProgress {
+	p, ok := c.Objects[name]
+	if !ok {
+		p = &objectProgress{}
+		c.Objects[name] = p
+	}
+	return p
+}
+
+func (c *checkpoint) save() error {
+	if c.path == "" {
+		return nil
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	// the file is replaced at once, so that it is not left half written if the migration is interrupted
+	tmp := c.path + ".tmp"
+	if err := file.CreateMissingDirsAndFileWithRWPerms(tmp, b); err != nil {
+		return fmt.Errorf("saving the checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("saving the checkpoint: %w", err)
+	}
+	return nil
+}
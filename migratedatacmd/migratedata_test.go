@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package migratedatacmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestParseSelectors(t *testing.T) {
+	got, err := parseSelectors([]string{"map:*", "list:orders"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []selector{
+		{service: hazelcast.ServiceNameMap, pattern: "*"},
+		{service: hazelcast.ServiceNameList, pattern: "orders"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for _, p := range []string{"orders", "topic:*", "map:[a"} {
+		if _, err := parseSelectors([]string{p}); err == nil {
+			t.Fatalf("%s must be invalid", p)
+		}
+	}
+}
+
+func TestSelectObjects(t *testing.T) {
+	infos := []types.DistributedObjectInfo{
+		{ServiceName: hazelcast.ServiceNameMap, Name: "users"},
+		{ServiceName: hazelcast.ServiceNameMap, Name: "orders"},
+		{ServiceName: hazelcast.ServiceNameMap, Name: "__sql.catalog"},
+		{ServiceName: hazelcast.ServiceNameList, Name: "users"},
+		{ServiceName: hazelcast.ServiceNameTopic, Name: "users"},
+	}
+	selectors, err := parseSelectors([]string{"map:*", "list:user*", "map:user*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, o := range selectObjects(infos, selectors) {
+		got = append(got, o.String())
+	}
+	want := []string{"list:users", "map:orders", "map:users"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	selectors, err = parseSelectors([]string{"map:__*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objects := selectObjects(infos, selectors); len(objects) != 1 || objects[0].name != "__sql.catalog" {
+		t.Fatalf("the internal map must be selected, got %v", objects)
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "checkpoint.json")
+	c, err := loadCheckpoint(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Objects) != 0 {
+		t.Fatalf("a missing checkpoint must be empty, got %v", c.Objects)
+	}
+	c.progress("map:users").Migrated = 2000
+	c.progress("list:orders").Done = true
+	if err := c.save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadCheckpoint(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Objects, c.Objects) {
+		t.Fatalf("got %v, want %v", loaded.Objects, c.Objects)
+	}
+}
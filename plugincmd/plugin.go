@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package plugincmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/readonly"
+)
+
+const (
+	// Prefix is the prefix of the names of the plugin executables, hzc-NAME on the PATH becomes the hzc NAME command
+	Prefix = "hzc-"
+	// Annotation marks the commands which run plugins
+	Annotation = "plugin"
+)
+
+// Plugin is an executable on the PATH which is run as a subcommand.
+type Plugin struct {
+	Name string
+	Path string
+}
+
+// Discover returns the plugins in the directories of the path list, such as the PATH environment variable.
+// If there is more than one executable with the same name, the one in the earlier directory is used, as the shell does.
+func Discover(pathList string) []Plugin {
+	var plugins []Plugin
+	seen := map[string]bool{}
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			// the directories which do not exist are common in PATH
+			continue
+		}
+		for _, f := range files {
+			name := strings.TrimPrefix(f.Name(), Prefix)
+			if name == f.Name() || name == "" || strings.ContainsAny(name, " \t") || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, f.Name())
+			// follow the symbolic links to check the executable they point to
+			info, err := os.Stat(path)
+			if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+	return plugins
+}
+
+// New creates the command which runs the plugin with the arguments after its name.
+// The arguments are passed to the plugin as they are, and it receives the connection details of the configuration in the environment variables.
+func New(cnfg *config.Config, p Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:                p.Name + " [args]",
+		Annotations:        map[string]string{Annotation: p.Path},
+		Short:              fmt.Sprintf("Run the %s%s plugin", Prefix, p.Name),
+		Long:               fmt.Sprintf("Run the %s%s plugin at %s, with the connection details in the HZC_ environment variables.", Prefix, p.Name, p.Path),
+		DisableFlagParsing: true,
+		// the plugin prints its own usage and errors
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := exec.CommandContext(cmd.Context(), p.Path, args...)
+			c.Stdin = cmd.InOrStdin()
+			c.Stdout = cmd.OutOrStdout()
+			c.Stderr = cmd.ErrOrStderr()
+			c.Env = append(os.Environ(), Env(cnfg, readonly.Enabled(cmd, cnfg))...)
+			if err := c.Run(); err != nil {
+				if exitErr, ok := err.(*exec.ExitError); ok {
+					return hzcerrors.NewLoggableError(err, "The %s%s plugin exited with status %d", Prefix, p.Name, exitErr.ExitCode())
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot run the %s%s plugin at %s", Prefix, p.Name, p.Path)
+			}
+			return nil
+		},
+	}
+}
+
+// IsPlugin returns true if the command runs a plugin.
+func IsPlugin(cmd *cobra.Command) bool {
+	_, ok := cmd.Annotations[Annotation]
+	return ok
+}
+
+// EnableGlobalFlags makes the root command parse the flags before the name of the plugin as its own flags, if the arguments run a plugin.
+// Then the plugin receives only the arguments after its name, otherwise it cannot tell the flags given before its name from the ones given after it.
+func EnableGlobalFlags(root *cobra.Command, args []string) {
+	if cmd, _, err := root.Find(args); err == nil && IsPlugin(cmd) {
+		root.TraverseChildren = true
+	}
+}
+
+// Env returns the environment variables which pass the connection details of the configuration to the plugins.
+// The cloud token and the credentials are only set if they are configured.
+func Env(cnfg *config.Config, readOnly bool) []string {
+	cc := &cnfg.Hazelcast.Cluster
+	addresses := cc.Network.Addresses
+	if len(addresses) == 0 && !cc.Cloud.Enabled {
+		addresses = []string{config.DefaultClusterAddress}
+	}
+	var env []string
+	if exe, err := os.Executable(); err == nil {
+		env = append(env, "HZC_BIN="+exe)
+	}
+	env = append(env,
+		"HZC_CONFIG="+cnfg.Path,
+		"HZC_CLUSTER_NAME="+cc.Name,
+		"HZC_CLUSTER_ADDRESSES="+strings.Join(addresses, ","),
+	)
+	if cc.Cloud.Enabled {
+		env = append(env, "HZC_CLOUD_TOKEN="+cc.Cloud.Token)
+	}
+	if creds := cc.Security.Credentials; creds.Username != "" {
+		env = append(env, "HZC_USERNAME="+creds.Username, "HZC_PASSWORD="+creds.Password)
+	}
+	return append(env, "HZC_READ_ONLY="+strconv.FormatBool(readOnly))
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package plugincmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+)
+
+func TestDiscover(t *testing.T) {
+	root, err := ioutil.TempDir("", "hzc-plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	first, second := filepath.Join(root, "first"), filepath.Join(root, "second")
+	files := []struct {
+		path string
+		mode os.FileMode
+	}{
+		{filepath.Join(first, "hzc-backup"), 0755},
+		{filepath.Join(first, "hzc-notes"), 0644},
+		{filepath.Join(first, "kubectl-hzc"), 0755},
+		{filepath.Join(second, "hzc-backup"), 0755},
+		{filepath.Join(second, "hzc-report.sh"), 0700},
+	}
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(f.path, []byte("#!/bin/sh\n"), f.mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(second, "hzc-dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	pathList := strings.Join([]string{first, filepath.Join(root, "missing"), second}, string(os.PathListSeparator))
+	assert.Equal(t, []Plugin{
+		{Name: "backup", Path: filepath.Join(first, "hzc-backup")},
+		{Name: "report.sh", Path: filepath.Join(second, "hzc-report.sh")},
+	}, Discover(pathList))
+}
+
+func TestEnv(t *testing.T) {
+	cnfg := config.DefaultConfig()
+	cnfg.Path = "/home/user/.local/share/hz-cli/prod.yaml"
+	cnfg.Hazelcast.Cluster.Name = "prod"
+	cnfg.Hazelcast.Cluster.Network.Addresses = []string{"10.0.0.1:5701", "10.0.0.2:5701"}
+	cnfg.Hazelcast.Cluster.Security.Credentials = cluster.CredentialsConfig{Username: "admin", Password: "secret"}
+	env := Env(cnfg, true)
+	assert.Subset(t, env, []string{
+		"HZC_CONFIG=/home/user/.local/share/hz-cli/prod.yaml",
+		"HZC_CLUSTER_NAME=prod",
+		"HZC_CLUSTER_ADDRESSES=10.0.0.1:5701,10.0.0.2:5701",
+		"HZC_USERNAME=admin",
+		"HZC_PASSWORD=secret",
+		"HZC_READ_ONLY=true",
+	})
+	env = Env(config.DefaultConfig(), false)
+	assert.Contains(t, env, "HZC_CLUSTER_ADDRESSES=localhost:5701")
+	for _, e := range env {
+		assert.False(t, strings.HasPrefix(e, "HZC_PASSWORD=") || strings.HasPrefix(e, "HZC_CLOUD_TOKEN="), e)
+	}
+}
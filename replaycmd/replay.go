@@ -0,0 +1,250 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package replaycmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/session"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/transaction"
+)
+
+// New creates the replay command, newRoot creates the command tree which runs each recorded command.
+// A new tree is required for each command, since the flag values of the previous command are kept otherwise.
+func New(newRoot func() *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay session-file",
+		Short: "Run the commands recorded with --record or saved with \\save again",
+		Long: `Run the commands recorded with --record, or saved with the \save meta-command of the interactive mode, again in the same order.
+The commands connect using the configuration and the parameters of the replay command, the connection parameters of the recorded commands are ignored.
+All the commands are run even if some of them fail.
+
+The commands between BEGIN and COMMIT lines of a script are run as a transaction block: if one of them fails, the changes of the block are rolled back and the rest of the block is skipped.
+The changes are reverted by the client, so the other clients see them until they are rolled back.
+Only the put, put-all, remove and clear map commands and the SQL queries can be run in a transaction block.
+ROLLBACK reverts the changes of the block, SAVEPOINT name and ROLLBACK TO name revert the changes after the savepoint.`,
+		Example: `hzc --record session.json map put --name m1 --key k1 --value v1
+hzc replay session.json
+hzc replay script.clc # saved with \save script.clc in the interactive mode
+hzc replay transfer.clc # with BEGIN, map put and map remove commands, and COMMIT`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := session.Load(args[0])
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot read the session file %s", args[0])
+			}
+			ctx := cmd.Context()
+			r := &replayer{cmd: cmd, newRoot: newRoot}
+			for _, c := range s.Commands {
+				if err := ctx.Err(); err != nil {
+					// the context is done, the changes are reverted without it
+					r.abort(context.Background(), "The replay is cancelled")
+					return hzcerrors.NewLoggableError(err, "Replay is cancelled")
+				}
+				r.run(ctx, c.Args)
+			}
+			r.abort(ctx, "The transaction block is not committed at the end of the script")
+			if r.failed > 0 {
+				return hzcerrors.NewLoggableError(nil, "%d of %d commands failed", r.failed, len(s.Commands))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// replayer runs the commands of a session, keeping the state of the transaction block.
+type replayer struct {
+	cmd     *cobra.Command
+	newRoot func() *cobra.Command
+	failed  int
+	// journal is the journal of the transaction block, it is nil outside of a block
+	journal *transaction.Journal
+	// rolledBack is true if a command of the block failed, the rest of the block is skipped
+	rolledBack bool
+}
+
+func (r *replayer) run(ctx context.Context, args []string) {
+	cmd := r.cmd
+	if st, ok, err := parseBlockStatement(args); ok {
+		if err == nil {
+			err = r.runBlockStatement(ctx, st)
+		}
+		if err != nil {
+			r.failed++
+			cmd.PrintErrf("Error: %s\n", err)
+			r.abort(ctx, "")
+		}
+		return
+	}
+	if r.rolledBack {
+		quiet.Printf(cmd, "Skipping, the transaction block is rolled back: %s\n", session.CommandLine(args))
+		return
+	}
+	if len(args) > 0 && args[0] == cmd.Name() {
+		quiet.Printf(cmd, "Skipping the nested replay: %s\n", session.CommandLine(args))
+		return
+	}
+	quiet.Printf(cmd, "> %s\n", session.CommandLine(args))
+	root := r.newRoot()
+	root.SetArgs(args)
+	root.SetOut(cmd.OutOrStdout())
+	root.SetErr(cmd.ErrOrStderr())
+	if r.journal != nil {
+		ctx = transaction.NewContext(ctx, r.journal)
+	}
+	if err := root.ExecuteContext(ctx); err != nil {
+		r.failed++
+		cmd.PrintErrf("Error: %s\n", err)
+		r.abort(ctx, "")
+	}
+}
+
+// blockStatement is a line of the script which starts, ends or rolls back a transaction block.
+type blockStatement struct {
+	keyword string
+	// savepoint is the name of the savepoint for SAVEPOINT and ROLLBACK TO
+	savepoint string
+}
+
+const (
+	keywordBegin      = "BEGIN"
+	keywordCommit     = "COMMIT"
+	keywordRollback   = "ROLLBACK"
+	keywordRollbackTo = "ROLLBACK TO"
+	keywordSavepoint  = "SAVEPOINT"
+)
+
+func (st blockStatement) String() string {
+	if st.savepoint == "" {
+		return st.keyword
+	}
+	return st.keyword + " " + st.savepoint
+}
+
+// parseBlockStatement returns the statement if the arguments start with one of its keywords, in any case.
+// The error is set if the keyword is used in a wrong way.
+func parseBlockStatement(args []string) (blockStatement, bool, error) {
+	if len(args) == 0 {
+		return blockStatement{}, false, nil
+	}
+	keyword := strings.ToUpper(args[0])
+	switch keyword {
+	case keywordBegin, keywordCommit:
+		if len(args) == 1 {
+			return blockStatement{keyword: keyword}, true, nil
+		}
+	case keywordRollback:
+		switch {
+		case len(args) == 1:
+			return blockStatement{keyword: keyword}, true, nil
+		case len(args) == 3 && strings.EqualFold(args[1], "TO"):
+			return blockStatement{keyword: keywordRollbackTo, savepoint: args[2]}, true, nil
+		}
+		keyword = "ROLLBACK or ROLLBACK TO savepoint"
+	case keywordSavepoint:
+		if len(args) == 2 {
+			return blockStatement{keyword: keyword, savepoint: args[1]}, true, nil
+		}
+		keyword = "SAVEPOINT savepoint"
+	default:
+		return blockStatement{}, false, nil
+	}
+	return blockStatement{}, true, hzcerrors.NewLoggableError(nil, "Invalid %s statement, expected %s", strings.ToUpper(args[0]), keyword)
+}
+
+func (r *replayer) runBlockStatement(ctx context.Context, st blockStatement) error {
+	cmd := r.cmd
+	if st.keyword == keywordBegin {
+		if r.journal != nil {
+			return hzcerrors.NewLoggableError(nil, "Transaction blocks cannot be nested")
+		}
+		quiet.Printf(cmd, "> %s\n", st)
+		r.journal = transaction.NewJournal()
+		return nil
+	}
+	if r.journal == nil {
+		return hzcerrors.NewLoggableError(nil, "%s is not in a transaction block", st.keyword)
+	}
+	if r.rolledBack {
+		// the block is already rolled back, only its end is waited for
+		quiet.Printf(cmd, "Skipping, the transaction block is rolled back: %s\n", st)
+		if st.keyword == keywordCommit || st.keyword == keywordRollback {
+			r.journal, r.rolledBack = nil, false
+		}
+		return nil
+	}
+	switch st.keyword {
+	case keywordCommit:
+		quiet.Printf(cmd, "> %s\n", st)
+		r.journal = nil
+	case keywordRollback:
+		quiet.Printf(cmd, "> %s\n", st)
+		undone := r.journal.Rollback(ctx)
+		r.journal = nil
+		r.report(cmd.OutOrStdout(), "Rolled back the transaction block", undone)
+	case keywordRollbackTo:
+		quiet.Printf(cmd, "> %s\n", st)
+		undone, err := r.journal.RollbackTo(ctx, st.savepoint)
+		if err != nil {
+			return err
+		}
+		r.report(cmd.OutOrStdout(), fmt.Sprintf("Rolled back to savepoint %s", st.savepoint), undone)
+	case keywordSavepoint:
+		quiet.Printf(cmd, "> %s\n", st)
+		r.journal.Savepoint(st.savepoint)
+	}
+	return nil
+}
+
+// abort rolls back the transaction block, if there is one which is not rolled back yet.
+// The reason is printed as an error if it is given, the error of the failed command is already printed otherwise.
+func (r *replayer) abort(ctx context.Context, reason string) {
+	if r.journal == nil || r.rolledBack {
+		return
+	}
+	if reason != "" {
+		r.failed++
+		r.cmd.PrintErrf("Error: %s\n", reason)
+	}
+	undone := r.journal.Rollback(ctx)
+	r.rolledBack = true
+	r.report(r.cmd.ErrOrStderr(), "Rolled back the transaction block", undone)
+}
+
+// report prints the reverted changes, the ones which cannot be reverted are counted as failures.
+func (r *replayer) report(w io.Writer, title string, undone []transaction.Undone) {
+	if len(undone) == 0 {
+		fmt.Fprintf(w, "%s, there were no changes\n", title)
+		return
+	}
+	fmt.Fprintf(w, "%s, reverted changes:\n", title)
+	for _, u := range undone {
+		if u.Err != nil {
+			r.failed++
+			fmt.Fprintf(w, "  cannot revert: %s: %s\n", u.Description, u.Err)
+			continue
+		}
+		fmt.Fprintf(w, "  %s\n", u.Description)
+	}
+}
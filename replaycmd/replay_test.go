@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package replaycmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/transaction"
+)
+
+// newTestRoot returns a tree with a set command which changes the values and a fail command which returns an error.
+func newTestRoot(values map[string]string) func() *cobra.Command {
+	return func() *cobra.Command {
+		root := &cobra.Command{Use: "hzc", SilenceErrors: true, SilenceUsage: true}
+		set := &cobra.Command{
+			Use:  "set",
+			Args: cobra.ExactArgs(2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				k, old := args[0], values[args[0]]
+				values[k] = args[1]
+				transaction.Record(cmd.Context(), "set "+k, func(ctx context.Context) error {
+					values[k] = old
+					return nil
+				})
+				return nil
+			},
+		}
+		fail := &cobra.Command{
+			Use: "fail",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return errors.New("failed")
+			},
+		}
+		root.AddCommand(transaction.Supported(dryrun.Supported(set)), fail)
+		return root
+	}
+}
+
+func TestReplayTransactionBlock(t *testing.T) {
+	for _, tc := range []struct {
+		info   string
+		script string
+		values map[string]string
+		isErr  bool
+		output string
+	}{
+		{
+			info:   "commit",
+			script: "begin\nset a 1\nset b 2\ncommit",
+			values: map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			info:   "failure",
+			script: "set a 1\nBEGIN\nset a 2\nset b 2\nfail\nset c 3\nCOMMIT\nset d 4",
+			values: map[string]string{"a": "1", "b": "", "d": "4"},
+			isErr:  true,
+			output: "Rolled back the transaction block, reverted changes:\n  set b\n  set a\n",
+		},
+		{
+			info:   "rollback",
+			script: "BEGIN\nset a 1\nROLLBACK",
+			values: map[string]string{"a": ""},
+			output: "Rolled back the transaction block, reverted changes:\n  set a\n",
+		},
+		{
+			info:   "savepoint",
+			script: "BEGIN\nset a 1\nSAVEPOINT s1\nset b 2\nROLLBACK TO s1\nset c 3\nCOMMIT",
+			values: map[string]string{"a": "1", "b": "", "c": "3"},
+			output: "Rolled back to savepoint s1, reverted changes:\n  set b\n",
+		},
+		{
+			info:   "unknown savepoint",
+			script: "BEGIN\nset a 1\nROLLBACK TO s1\nCOMMIT",
+			values: map[string]string{"a": ""},
+			isErr:  true,
+		},
+		{
+			info:   "not committed",
+			script: "BEGIN\nset a 1",
+			values: map[string]string{"a": ""},
+			isErr:  true,
+		},
+		{
+			info:   "commit outside of a block",
+			script: "set a 1\nCOMMIT",
+			values: map[string]string{"a": "1"},
+			isErr:  true,
+		},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			p := filepath.Join(t.TempDir(), "script.clc")
+			if err := ioutil.WriteFile(p, []byte(tc.script), 0600); err != nil {
+				t.Fatal(err)
+			}
+			values := map[string]string{}
+			cmd := New(newTestRoot(values))
+			cmd.SilenceErrors, cmd.SilenceUsage = true, true
+			cmd.PersistentFlags().Bool("quiet", true, "")
+			var out, errOut bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&errOut)
+			cmd.SetArgs([]string{p})
+			err := cmd.ExecuteContext(context.Background())
+			if tc.isErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for k, v := range tc.values {
+				if values[k] != v {
+					t.Errorf("want %s=%q got %q", k, v, values[k])
+				}
+			}
+			if tc.output != "" && !strings.Contains(out.String()+errOut.String(), tc.output) {
+				t.Errorf("want output %q got:\n%s%s", tc.output, out.String(), errOut.String())
+			}
+		})
+	}
+}
+
+func TestParseBlockStatement(t *testing.T) {
+	for _, tc := range []struct {
+		args  []string
+		st    blockStatement
+		ok    bool
+		isErr bool
+	}{
+		{args: []string{"begin"}, st: blockStatement{keyword: keywordBegin}, ok: true},
+		{args: []string{"Rollback", "to", "s1"}, st: blockStatement{keyword: keywordRollbackTo, savepoint: "s1"}, ok: true},
+		{args: []string{"SAVEPOINT", "s1"}, st: blockStatement{keyword: keywordSavepoint, savepoint: "s1"}, ok: true},
+		{args: []string{"SAVEPOINT"}, ok: true, isErr: true},
+		{args: []string{"COMMIT", "now"}, ok: true, isErr: true},
+		{args: []string{"map", "put"}},
+		{},
+	} {
+		st, ok, err := parseBlockStatement(tc.args)
+		if ok != tc.ok || tc.isErr != (err != nil) {
+			t.Fatalf("%v: unexpected result %t, %v", tc.args, ok, err)
+		}
+		if err == nil && !reflect.DeepEqual(st, tc.st) {
+			t.Errorf("%v: want %v got %v", tc.args, tc.st, st)
+		}
+	}
+}
@@ -20,22 +20,49 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
-	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/spf13/cobra"
 
+	"github.com/hazelcast/hazelcast-commandline-client/browsecmd"
 	"github.com/hazelcast/hazelcast-commandline-client/clustercmd"
+	"github.com/hazelcast/hazelcast-commandline-client/comparecmd"
 	"github.com/hazelcast/hazelcast-commandline-client/config"
+	"github.com/hazelcast/hazelcast-commandline-client/configcmd"
+	"github.com/hazelcast/hazelcast-commandline-client/findcmd"
+	"github.com/hazelcast/hazelcast-commandline-client/generatecmd"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/member"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/readonly"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/transaction"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/usage"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
+	"github.com/hazelcast/hazelcast-commandline-client/jobcmd"
+	"github.com/hazelcast/hazelcast-commandline-client/listenercmd"
+	"github.com/hazelcast/hazelcast-commandline-client/migratecmd"
+	"github.com/hazelcast/hazelcast-commandline-client/migratedatacmd"
+	"github.com/hazelcast/hazelcast-commandline-client/plugincmd"
+	"github.com/hazelcast/hazelcast-commandline-client/replaycmd"
+	"github.com/hazelcast/hazelcast-commandline-client/servecmd"
 	"github.com/hazelcast/hazelcast-commandline-client/sqlcmd"
+	"github.com/hazelcast/hazelcast-commandline-client/statscmd"
+	"github.com/hazelcast/hazelcast-commandline-client/topcmd"
 	fakeDoor "github.com/hazelcast/hazelcast-commandline-client/types/fakedoorcmd"
 	"github.com/hazelcast/hazelcast-commandline-client/types/mapcmd"
+	"github.com/hazelcast/hazelcast-commandline-client/types/queuecmd"
+	"github.com/hazelcast/hazelcast-commandline-client/types/rtopiccmd"
+	"github.com/hazelcast/hazelcast-commandline-client/types/topiccmd"
+	"github.com/hazelcast/hazelcast-commandline-client/updatecmd"
+	"github.com/hazelcast/hazelcast-commandline-client/versioncmd"
 )
 
 // New initializes root command for non-interactive mode
-func New(cnfg *hazelcast.Config) (*cobra.Command, *config.GlobalFlagValues) {
+func New(cnfg *config.Config) (*cobra.Command, *config.GlobalFlagValues) {
 	var flags config.GlobalFlagValues
 	root := &cobra.Command{
-		Use:   "hzc {cluster | map | sql | help} [--address address | --cloud-token token | --cluster-name name | --config config]",
+		Use:   "hzc {cluster | map | queue | topic | rtopic | listener | sql | snippet | query-builder | dashboard | job | snapshot | migrate | migrate-data | find | compare | generate | browse | config | serve | exporter | replay | stats | top | update | version | help} [--address address | --cloud-token token | --cluster-name name | --config config]",
 		Short: "Hazelcast command-line client",
 		Long:  "Hazelcast command-line client connects your command-line to a Hazelcast cluster",
 		Example: `hzc # starts an interactive shell 🚀
@@ -61,35 +88,123 @@ hzc help # print help`,
 		root.CompletionOptions.DisableDefaultCmd = false
 	}
 	assignPersistentFlags(root, &flags)
-	root.AddCommand(subCommands(cnfg)...)
+	root.AddCommand(subCommands(cnfg, discoverPlugins())...)
+	watch.EnableWatch(root)
+	dryrun.EnableDryRun(root)
+	member.EnableMember(root)
+	quiet.EnableQuiet(root)
+	readonly.EnableReadOnly(root, cnfg)
+	transaction.EnableTransactions(root)
+	defaults.EnableDefaults(root, cnfg)
+	usage.EnableUsageStats(root, cnfg)
 	return root, &flags
 }
 
-func subCommands(config *hazelcast.Config) []*cobra.Command {
+var (
+	pluginsOnce       sync.Once
+	discoveredPlugins []plugincmd.Plugin
+)
+
+// discoverPlugins returns the plugins on the PATH, which are discovered only once since the interactive mode creates the commands for every line.
+func discoverPlugins() []plugincmd.Plugin {
+	pluginsOnce.Do(func() {
+		discoveredPlugins = plugincmd.Discover(os.Getenv("PATH"))
+	})
+	return discoveredPlugins
+}
+
+func subCommands(cnfg *config.Config, plugins []plugincmd.Plugin) []*cobra.Command {
 	cmds := []*cobra.Command{
-		clustercmd.New(config),
-		mapcmd.New(config),
-		sqlcmd.New(config),
+		clustercmd.New(cnfg),
+		mapcmd.New(cnfg),
+		queuecmd.New(&cnfg.Hazelcast),
+		topiccmd.New(&cnfg.Hazelcast),
+		rtopiccmd.New(&cnfg.Hazelcast),
+		listenercmd.New(),
+		sqlcmd.New(cnfg),
+		sqlcmd.NewSnippet(cnfg),
+		sqlcmd.NewQueryBuilder(cnfg),
+		sqlcmd.NewDashboard(cnfg),
+		jobcmd.New(&cnfg.Hazelcast),
+		jobcmd.NewSnapshot(&cnfg.Hazelcast),
+		migratecmd.New(&cnfg.Hazelcast),
+		migratedatacmd.New(cnfg),
+		findcmd.New(&cnfg.Hazelcast),
+		comparecmd.New(cnfg),
+		generatecmd.New(&cnfg.Hazelcast),
+		browsecmd.New(cnfg),
+		configcmd.New(cnfg),
+		servecmd.New(cnfg),
+		servecmd.NewExporter(cnfg),
+		statscmd.New(cnfg),
+		topcmd.New(cnfg),
+		updatecmd.New(),
+		versioncmd.New(),
+		replaycmd.New(func() *cobra.Command {
+			root, _ := New(cnfg)
+			return root
+		}),
 	}
 	fds := []fakeDoor.FakeDoor{
 		{Name: "List", IssueNum: 48},
-		{Name: "Queue", IssueNum: 49},
 		{Name: "MultiMap", IssueNum: 50},
 		{Name: "ReplicatedMap", IssueNum: 51},
 		{Name: "Set", IssueNum: 52},
-		{Name: "Topic", IssueNum: 53},
+		{Name: "Cache", Reason: "the Go client does not implement JCache (ICache)"},
+		{Name: "Cardinality", Reason: "the Go client has no cardinality estimator"},
 	}
 	for _, fd := range fds {
 		cmds = append(cmds, fakeDoor.NewFakeCommand(fd))
 	}
+	for _, p := range plugins {
+		// the plugins cannot replace the commands of hzc
+		if !isReservedName(cmds, p.Name) {
+			cmds = append(cmds, plugincmd.New(cnfg, p))
+		}
+	}
 	return cmds
 }
 
+// isReservedName returns true if the name is the name or an alias of one of the commands, or of the commands cobra and the interactive mode add.
+func isReservedName(cmds []*cobra.Command, name string) bool {
+	switch name {
+	case "help", "completion", "exit":
+		return true
+	}
+	for _, c := range cmds {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
 // assignPersistentFlags assigns top level flags to command
 func assignPersistentFlags(cmd *cobra.Command, flags *config.GlobalFlagValues) {
 	cmd.PersistentFlags().StringVarP(&flags.CfgFile, "config", "c", config.DefaultConfigPath(), fmt.Sprintf("config file, only supports yaml for now"))
 	cmd.PersistentFlags().StringVarP(&flags.Address, "address", "a", "", fmt.Sprintf("addresses of the instances in the cluster (default is %s)", config.DefaultClusterAddress))
 	cmd.PersistentFlags().StringVar(&flags.Cluster, "cluster-name", "", fmt.Sprintf("name of the cluster that contains the instances (default is %s)", config.DefaultClusterName))
 	cmd.PersistentFlags().StringVar(&flags.Token, "cloud-token", "", "your Hazelcast Cloud token")
+	cmd.PersistentFlags().StringVar(&flags.Username, "username", "", "username for the cluster, the password is asked for if it is not configured")
+	cmd.PersistentFlags().StringVar(&flags.Password, "password", "", "password for the cluster")
+	cmd.PersistentFlags().StringVar(&flags.MCURL, "mc-url", "", "Management Center URL to run the cluster state and member list operations, the map eviction report and the top view through")
+	cmd.PersistentFlags().Duration(watch.Flag, 0, "re-run the read-only command on the given interval, such as --watch=5s")
+	cmd.PersistentFlags().Lookup(watch.Flag).NoOptDefVal = watch.DefaultInterval.String()
+	cmd.PersistentFlags().Bool(dryrun.Flag, false, "print what the command would change instead of running it")
+	cmd.PersistentFlags().String(member.Flag, "", "UUID or address of the member to run the command on, for the commands which can target a member")
+	if err := cmd.RegisterFlagCompletionFunc(member.Flag, member.Complete); err != nil {
+		panic(err)
+	}
+	cmd.PersistentFlags().BoolVar(&flags.ReadOnly, readonly.Flag, false, "block the commands which change data, such as map put and the SQL DML statements")
+	cmd.PersistentFlags().BoolP(quiet.Flag, "q", false, "print only the data, without headers, footers, progress and status messages")
+	cmd.PersistentFlags().StringVar(&flags.TraceProtocol, "trace-protocol", "", "file to write the client protocol trace logs to, for debugging connectivity and latency issues")
+	cmd.PersistentFlags().StringVar(&flags.Record, "record", "", "file to record the executed commands to, they can be run again with the replay command")
+	cmd.PersistentFlags().DurationVar(&flags.Timeout, "timeout", 0, "timeout of connecting to the cluster, each operation and each SQL statement, overrides the configuration")
+	cmd.PersistentFlags().DurationVar(&flags.InvocationTimeout, "invocation-timeout", 0, "maximum time to wait for the response of each operation, including the retries")
+	cmd.PersistentFlags().BoolVar(&flags.RedoOperation, "redo-operation", false, "retry the failed operations even if they may run twice, such as map puts")
+	cmd.PersistentFlags().DurationVar(&flags.RetryMaxBackoff, "retry-max-backoff", 0, "maximum time to wait between the attempts to connect to the cluster (default is 30s)")
+	cmd.PersistentFlags().Float64Var(&flags.RetryMultiplier, "retry-multiplier", 0, "multiplier of the wait time after each attempt to connect to the cluster (default is 1.05)")
+	cmd.PersistentFlags().Float64Var(&flags.RetryJitter, "retry-jitter", 0, "ratio of randomness added to the wait time between the attempts to connect to the cluster, between 0 and 1")
+	cmd.PersistentFlags().StringVar(&flags.LogLevel, "log-level", "", "log level of the client: off, fatal, error, warn, info, debug or trace, overrides the configuration")
 	cmd.PersistentFlags().BoolVar(&flags.Verbose, "verbose", false, "verbose output")
 }
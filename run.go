@@ -22,7 +22,9 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/spf13/cobra"
@@ -34,9 +36,15 @@ import (
 	"github.com/hazelcast/hazelcast-commandline-client/internal/cobraprompt"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
 	goprompt "github.com/hazelcast/hazelcast-commandline-client/internal/go-prompt"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/session"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/theme"
+	"github.com/hazelcast/hazelcast-commandline-client/plugincmd"
 	"github.com/hazelcast/hazelcast-commandline-client/types/mapcmd"
+	"github.com/hazelcast/hazelcast-commandline-client/updatecmd"
 )
 
+const latencyCheckInterval = 5 * time.Second
+
 func IsInteractiveCall(rootCmd *cobra.Command, args []string) bool {
 	cmd, flags, err := rootCmd.Find(args)
 	if err != nil {
@@ -56,7 +64,8 @@ func IsInteractiveCall(rootCmd *cobra.Command, args []string) bool {
 	return false
 }
 
-func RunCmdInteractively(ctx context.Context, rootCmd *cobra.Command, cnfg *hazelcast.Config) {
+func RunCmdInteractively(ctx context.Context, rootCmd *cobra.Command, clcConfig *config.Config, cfgPath string) {
+	cnfg := &clcConfig.Hazelcast
 	cmdHistoryPath := filepath.Join(file.HZCHomePath(), "history")
 	exists, err := file.Exists(cmdHistoryPath)
 	if err != nil {
@@ -68,6 +77,11 @@ func RunCmdInteractively(ctx context.Context, rootCmd *cobra.Command, cnfg *haze
 		}
 	}
 	namePersister := make(map[string]string)
+	promptTemplate := clcConfig.Prompt
+	if promptTemplate == "" {
+		promptTemplate = internal.DefaultPrompt
+	}
+	configName := strings.TrimSuffix(filepath.Base(cfgPath), filepath.Ext(cfgPath))
 	var p = &cobraprompt.CobraPrompt{
 		ShowHelpCommandAndFlags:  true,
 		ShowHiddenFlags:          true,
@@ -81,7 +95,26 @@ func RunCmdInteractively(ctx context.Context, rootCmd *cobra.Command, cnfg *haze
 				for k, v := range namePersister {
 					b.WriteString(fmt.Sprintf("&%c:%s", k[0], v))
 				}
-				return fmt.Sprintf("hzc %s@%s%s> ", config.GetClusterAddress(cnfg), cnfg.Cluster.Name, b.String()), true
+				name, address := cnfg.Cluster.Name, config.GetClusterAddress(cnfg)
+				if active := internal.ActiveClusterName(); active != "" && active != name {
+					// connected to a failover cluster
+					if cc, ok := config.FailoverClusterConfig(cnfg, active); ok {
+						name, address = active, config.GetClusterConfigAddress(cc)
+					}
+				}
+				state, members, _ := internal.ConnectionStatus()
+				return internal.RenderPrompt(promptTemplate, map[string]string{
+					"config":  configName,
+					"cluster": name,
+					"address": address,
+					"state":   connectionStateName(state),
+					"members": strconv.Itoa(members),
+					"map":     namePersister["map"],
+					"names":   b.String(),
+				}), true
+			}),
+			goprompt.OptionStatusBar(func() string {
+				return connectionStatusBar(cnfg)
 			}),
 			goprompt.OptionMaxSuggestion(10),
 			goprompt.OptionCompletionOnDown(),
@@ -93,11 +126,8 @@ func RunCmdInteractively(ctx context.Context, rootCmd *cobra.Command, cnfg *haze
 		},
 		Persister: namePersister,
 	}
-	rootCmd.Println("Connecting to the cluster ...")
-	if _, err := internal.ConnectToCluster(ctx, cnfg); err != nil {
-		rootCmd.Printf("Error: %s\n", err)
-		return
-	}
+	// the commands which need the cluster connect on demand, so that the local commands work offline
+	go internal.MonitorLatency(ctx, latencyCheckInterval)
 	var flagsToExclude []string
 	rootCmd.PersistentFlags().VisitAll(func(flag *pflag.Flag) {
 		flagsToExclude = append(flagsToExclude, flag.Name)
@@ -108,18 +138,79 @@ func RunCmdInteractively(ctx context.Context, rootCmd *cobra.Command, cnfg *haze
 	p.FlagsToExclude = flagsToExclude
 	rootCmd.Example = fmt.Sprintf("> %s\n> %s", mapcmd.MapPutExample, mapcmd.MapGetExample) + "\n> cluster version"
 	rootCmd.Use = ""
-	p.Run(ctx, rootCmd, cnfg, cmdHistoryPath)
+	if clcConfig.UpdateNotice {
+		if notice := updatecmd.Notice(ctx); notice != "" {
+			fmt.Println(notice)
+		}
+	}
+	p.Run(ctx, rootCmd, clcConfig, cmdHistoryPath)
 	return
 }
 
+func connectionStateName(state internal.ConnectionState) string {
+	switch state {
+	case internal.StateConnected:
+		return "connected"
+	case internal.StateReconnecting:
+		return "reconnecting"
+	case internal.StateShutdown:
+		return "disconnected"
+	}
+	return "not connected"
+}
+
+func connectionStatusBar(cnfg *hazelcast.Config) string {
+	state, members, latency := internal.ConnectionStatus()
+	switch state {
+	case internal.StateConnected:
+		name := cnfg.Cluster.Name
+		if active := internal.ActiveClusterName(); active != "" {
+			name = active
+		}
+		status := fmt.Sprintf(" connected to %s | %d member(s)", name, members)
+		if latency > 0 {
+			status += fmt.Sprintf(" | latency %s", latency.Round(time.Millisecond/10))
+		}
+		return status + " "
+	case internal.StateReconnecting:
+		return " connection lost, reconnecting ... "
+	case internal.StateShutdown:
+		return " disconnected, the next command will try to connect again "
+	case internal.StateNotConnected:
+		return " not connected, the commands which need the cluster will connect "
+	}
+	return ""
+}
+
 func updateConfigWithFlags(rootCmd *cobra.Command, cnfg *config.Config, programArgs []string, globalFlagValues *config.GlobalFlagValues) error {
 	// parse global persistent flags
 	subCmd, flags, _ := rootCmd.Find(programArgs)
-	// fall back to cmd.Help, even if there is error
-	_ = subCmd.ParseFlags(flags)
+	if plugincmd.IsPlugin(subCmd) {
+		// the flags after the name of the plugin are its own flags
+		_, _, _ = rootCmd.Traverse(programArgs)
+	} else {
+		// fall back to cmd.Help, even if there is error
+		_ = subCmd.ParseFlags(flags)
+	}
 	// initialize config from file
-	err := config.ReadAndMergeWithFlags(globalFlagValues, cnfg)
-	return err
+	if err := config.ReadAndMergeWithFlags(globalFlagValues, cnfg); err != nil {
+		return err
+	}
+	if err := theme.Set(cnfg.Theme); err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot apply the color theme")
+	}
+	internal.SetTimeouts(cnfg.Timeout)
+	if globalFlagValues.TraceProtocol != "" {
+		if err := internal.EnableProtocolTrace(globalFlagValues.TraceProtocol); err != nil {
+			return hzcerrors.NewLoggableError(err, "Cannot open the protocol trace file %s", globalFlagValues.TraceProtocol)
+		}
+	}
+	if globalFlagValues.Record != "" {
+		if err := session.StartRecording(globalFlagValues.Record); err != nil {
+			return hzcerrors.NewLoggableError(err, "Cannot record the session to %s", globalFlagValues.Record)
+		}
+	}
+	return nil
 }
 
 func HandleError(err error) string {
@@ -140,7 +231,12 @@ func RunCmd(ctx context.Context, rootCmd *cobra.Command) error {
 	ctx = internal.ContextWithPersistedNames(ctx, p)
 	ctx, cancel := context.WithCancel(ctx)
 	handleInterrupt(ctx, cancel)
-	return rootCmd.ExecuteContext(ctx)
+	start := time.Now()
+	err := rootCmd.ExecuteContext(ctx)
+	if recordErr := session.Record(os.Args[1:], start, err); recordErr != nil && err == nil {
+		err = hzcerrors.NewLoggableError(recordErr, "Cannot record the command")
+	}
+	return err
 }
 
 func handleInterrupt(ctx context.Context, cancel context.CancelFunc) {
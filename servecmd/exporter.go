@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package servecmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+)
+
+const (
+	metricsPath         = "/metrics"
+	metricsContentType  = "text/plain; version=0.0.4; charset=utf-8"
+	internalObjectStart = "__"
+)
+
+const exporterLong = `Export the statistics of the cluster in the Prometheus text format on /metrics.
+The statistics are collected with the client on each scrape:
+
+  hzc_up                          1 if the statistics could be collected, otherwise 0
+  hzc_cluster_connected           1 if the client is connected to the cluster, otherwise 0
+  hzc_cluster_members             number of the members in the cluster
+  hzc_cluster_latency_seconds     round trip time of listing the distributed objects
+  hzc_distributed_objects         number of the distributed objects by their type
+  hzc_map_size                    number of the entries of each map, the internal maps are left out
+  hzc_scrape_duration_seconds     time it took to collect the statistics`
+
+const exporterExample = `  # Export the metrics on the default port, 9091
+  hzc exporter
+  curl http://localhost:9091/metrics`
+
+func NewExporter(cnfg *config.Config) *cobra.Command {
+	var (
+		host string
+		port int
+	)
+	cmd := &cobra.Command{
+		Use:     "exporter [--port port] [--host host]",
+		Short:   "Export the statistics of the cluster for Prometheus",
+		Long:    exporterLong,
+		Example: exporterExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			e := &exporter{conf: cnfg, errOut: cmd.ErrOrStderr()}
+			mux := http.NewServeMux()
+			mux.Handle(metricsPath, e)
+			return listenAndServe(cmd, host, port, mux, "the Prometheus metrics on http://%s"+metricsPath)
+		},
+	}
+	decorateCommandWithListenFlags(cmd, &host, &port, defaultExporterPort)
+	return cmd
+}
+
+// metric is a gauge with its samples, the samples have at most one label.
+type metric struct {
+	name    string
+	help    string
+	label   string
+	samples []sample
+}
+
+type sample struct {
+	labelValue string
+	value      float64
+}
+
+type exporter struct {
+	conf   *config.Config
+	errOut io.Writer
+	// mu serializes the scrapes, the connection of the internal package is not safe for concurrent use
+	mu sync.Mutex
+}
+
+func (e *exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	start := time.Now()
+	metrics, err := e.collect(r.Context())
+	up := 1.0
+	if err != nil {
+		// the scrape succeeds with hzc_up 0, so that the unavailable cluster can be alerted on
+		fmt.Fprintf(e.errOut, "Cannot collect the metrics: %s\n", err)
+		up = 0
+	}
+	metrics = append([]metric{
+		{name: "hzc_up", help: "1 if the statistics could be collected, otherwise 0.", samples: []sample{{value: up}}},
+	}, metrics...)
+	metrics = append(metrics, metric{
+		name:    "hzc_scrape_duration_seconds",
+		help:    "Time it took to collect the statistics.",
+		samples: []sample{{value: time.Since(start).Seconds()}},
+	})
+	w.Header().Set("Content-Type", metricsContentType)
+	writeMetrics(w, metrics)
+}
+
+func (e *exporter) collect(ctx context.Context) ([]metric, error) {
+	client, err := internal.ConnectToCluster(ctx, &e.conf.Hazelcast)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	infos, err := client.GetDistributedObjectsInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latency := time.Since(start)
+	state, members, _ := internal.ConnectionStatus()
+	connected := 0.0
+	if state == internal.StateConnected {
+		connected = 1
+	}
+	sizes, err := mapSizes(ctx, client, infos)
+	if err != nil {
+		return nil, err
+	}
+	return []metric{
+		{name: "hzc_cluster_connected", help: "1 if the client is connected to the cluster, otherwise 0.", samples: []sample{{value: connected}}},
+		{name: "hzc_cluster_members", help: "Number of the members in the cluster.", samples: []sample{{value: float64(members)}}},
+		{name: "hzc_cluster_latency_seconds", help: "Round trip time of listing the distributed objects.", samples: []sample{{value: latency.Seconds()}}},
+		{name: "hzc_distributed_objects", help: "Number of the distributed objects by their type.", label: "type", samples: objectCounts(infos)},
+		{name: "hzc_map_size", help: "Number of the entries of the map.", label: "map", samples: sizes},
+	}, nil
+}
+
+// objectCounts returns the number of the objects of each type, in the order of the type names.
+func objectCounts(infos []types.DistributedObjectInfo) []sample {
+	counts := map[string]int{}
+	for _, info := range infos {
+		counts[objectType(info.ServiceName)]++
+	}
+	samples := make([]sample, 0, len(counts))
+	for t, n := range counts {
+		samples = append(samples, sample{labelValue: t, value: float64(n)})
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].labelValue < samples[j].labelValue
+	})
+	return samples
+}
+
+// mapSizes returns the sizes of the maps which are not internal, in the order of their names.
+func mapSizes(ctx context.Context, client *hazelcast.Client, infos []types.DistributedObjectInfo) ([]sample, error) {
+	var names []string
+	for _, info := range infos {
+		if info.ServiceName == hazelcast.ServiceNameMap && !strings.HasPrefix(info.Name, internalObjectStart) {
+			names = append(names, info.Name)
+		}
+	}
+	sort.Strings(names)
+	samples := make([]sample, len(names))
+	for i, name := range names {
+		m, err := client.GetMap(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		size, err := m.Size(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getting the size of map %s: %w", name, err)
+		}
+		samples[i] = sample{labelValue: name, value: float64(size)}
+	}
+	return samples, nil
+}
+
+// writeMetrics writes the metrics in the Prometheus text format.
+func writeMetrics(w io.Writer, metrics []metric) {
+	for _, m := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", m.name, m.help, m.name)
+		for _, s := range m.samples {
+			value := strconv.FormatFloat(s.value, 'g', -1, 64)
+			if m.label == "" {
+				fmt.Fprintf(w, "%s %s\n", m.name, value)
+				continue
+			}
+			fmt.Fprintf(w, "%s{%s=\"%s\"} %s\n", m.name, m.label, escapeLabelValue(s.labelValue), value)
+		}
+	}
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueReplacer.Replace(v)
+}
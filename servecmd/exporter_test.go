@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package servecmd
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestObjectCounts(t *testing.T) {
+	infos := []types.DistributedObjectInfo{
+		{Name: "users", ServiceName: hazelcast.ServiceNameMap},
+		{Name: "events", ServiceName: hazelcast.ServiceNameQueue},
+		{Name: "orders", ServiceName: hazelcast.ServiceNameMap},
+	}
+	want := []sample{{labelValue: "map", value: 2}, {labelValue: "queue", value: 1}}
+	if got := objectCounts(infos); !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v got %v", want, got)
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	var b bytes.Buffer
+	writeMetrics(&b, []metric{
+		{name: "hzc_up", help: "Up.", samples: []sample{{value: 1}}},
+		{name: "hzc_map_size", help: "Size.", label: "map", samples: []sample{{labelValue: `a"b\c`, value: 12}, {labelValue: "m", value: 0.5}}},
+	})
+	want := `# HELP hzc_up Up.
+# TYPE hzc_up gauge
+hzc_up 1
+# HELP hzc_map_size Size.
+# TYPE hzc_map_size gauge
+hzc_map_size{map="a\"b\\c"} 12
+hzc_map_size{map="m"} 0.5
+`
+	if got := b.String(); got != want {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,289 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package servecmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/sqlcmd"
+)
+
+const (
+	PortFlag            = "port"
+	HostFlag            = "host"
+	defaultPort         = 8090
+	defaultExporterPort = 9091
+	defaultHost         = "localhost"
+	// shutdownTimeout is the time given to the running requests once the server is stopped
+	shutdownTimeout = 5 * time.Second
+)
+
+const serveLong = `Serve the read operations over a local REST API with the connection settings of the configuration.
+All the endpoints accept only GET requests and respond with JSON:
+
+  GET /sql?q=STATEMENT           rows of the SELECT or SHOW statement, one JSON object per line
+  GET /maps/NAME?key=KEY         the value of the key, use key-type for the keys which are not strings
+  GET /objects                   the distributed objects, use type to list only one type of them, such as type=map
+
+The errors are responded with a JSON object which has the error field.`
+
+const serveExample = `  # Serve on the default port, 8090
+  hzc serve
+  curl 'http://localhost:8090/sql?q=SELECT%20*%20FROM%20employees'
+  curl 'http://localhost:8090/maps/my-map?key=2012&key-type=int16'`
+
+// objectTypes are the names of the distributed object types in the responses.
+var objectTypes = map[string]string{
+	hazelcast.ServiceNameMap:              "map",
+	hazelcast.ServiceNameReplicatedMap:    "replicatedmap",
+	hazelcast.ServiceNameMultiMap:         "multimap",
+	hazelcast.ServiceNameQueue:            "queue",
+	hazelcast.ServiceNameList:             "list",
+	hazelcast.ServiceNameSet:              "set",
+	hazelcast.ServiceNameTopic:            "topic",
+	hazelcast.ServiceNamePNCounter:        "pncounter",
+	hazelcast.ServiceNameFlakeIDGenerator: "flakeidgenerator",
+}
+
+func New(cnfg *config.Config) *cobra.Command {
+	var (
+		host string
+		port int
+	)
+	cmd := &cobra.Command{
+		Use:     "serve [--port port] [--host host]",
+		Short:   "Serve the read operations over a local REST API",
+		Long:    serveLong,
+		Example: serveExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listenAndServe(cmd, host, port, newHandler(cnfg), "the REST API on http://%s")
+		},
+	}
+	decorateCommandWithListenFlags(cmd, &host, &port, defaultPort)
+	return cmd
+}
+
+func decorateCommandWithListenFlags(cmd *cobra.Command, host *string, port *int, defPort int) {
+	cmd.Flags().IntVar(port, PortFlag, defPort, "port to listen on")
+	cmd.Flags().StringVar(host, HostFlag, defaultHost, "host to listen on, the server is not protected so make sure that only the trusted hosts can reach it")
+}
+
+// listenAndServe serves the handler until the command is cancelled, what describes the served endpoint with the address.
+func listenAndServe(cmd *cobra.Command, host string, port int, handler http.Handler, what string) error {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot listen on %s", addr)
+	}
+	srv := &http.Server{Handler: handler}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(lis)
+	}()
+	quiet.Printf(cmd, "Serving %s, press Ctrl+C to stop\n", fmt.Sprintf(what, lis.Addr()))
+	select {
+	case err := <-errCh:
+		return hzcerrors.NewLoggableError(err, "Cannot serve on %s", addr)
+	case <-cmd.Context().Done():
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
+
+type server struct {
+	conf *config.Config
+	opts output.Options
+	// mu serializes connecting, the connection of the internal package is not safe for concurrent use
+	mu sync.Mutex
+}
+
+func newHandler(conf *config.Config) http.Handler {
+	s := &server{conf: conf, opts: output.DefaultOptions()}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sql", s.handleSQL)
+	mux.HandleFunc("/maps/", s.handleMapGet)
+	mux.HandleFunc("/objects", s.handleObjects)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("only GET requests are served"))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *server) client(ctx context.Context) (*hazelcast.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return internal.ConnectToCluster(ctx, &s.conf.Hazelcast)
+}
+
+func (s *server) handleSQL(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		writeError(w, http.StatusBadRequest, errors.New("the statement is missing, set it with the q parameter"))
+		return
+	}
+	if !sqlcmd.IsQuery(q) {
+		writeError(w, http.StatusBadRequest, errors.New("only SELECT and SHOW statements are served"))
+		return
+	}
+	s.mu.Lock()
+	d, err := internal.SQLDriver(r.Context(), &s.conf.Hazelcast)
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	out := &responseWriter{w: w}
+	if _, err := sqlcmd.QueryJSON(r.Context(), d, q, out, s.opts); err != nil && !out.written {
+		// the status cannot be changed once the rows are written, the response is cut short then
+		writeError(w, http.StatusBadRequest, err)
+	}
+}
+
+func (s *server) handleMapGet(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/maps/")
+	params := r.URL.Query()
+	if _, ok := params["key"]; name == "" || !ok {
+		writeError(w, http.StatusBadRequest, errors.New("the map name and the key are required, such as /maps/my-map?key=k1"))
+		return
+	}
+	keyType := params.Get("key-type")
+	if keyType == "" {
+		keyType = internal.TypeNameString
+	}
+	key, err := internal.ConvertString(params.Get("key"), keyType)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("converting the key to %s: %w", keyType, err))
+		return
+	}
+	client, err := s.client(r.Context())
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	m, err := client.GetMap(r.Context(), name)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	value, err := m.Get(r.Context(), key)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if value == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("there is no value for the key in map %s", name))
+		return
+	}
+	b, err := s.opts.MarshalJSONObject([]string{"key", "value"}, []interface{}{key, value})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, b)
+}
+
+type object struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func (s *server) handleObjects(w http.ResponseWriter, r *http.Request) {
+	client, err := s.client(r.Context())
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	infos, err := client.GetDistributedObjectsInfo(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	typ := r.URL.Query().Get("type")
+	objects := []object{}
+	for _, info := range infos {
+		o := object{Name: info.Name, Type: objectType(info.ServiceName)}
+		if typ == "" || typ == o.Type {
+			objects = append(objects, o)
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Type != objects[j].Type {
+			return objects[i].Type < objects[j].Type
+		}
+		return objects[i].Name < objects[j].Name
+	})
+	b, err := json.Marshal(objects)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, b)
+}
+
+// objectType returns the short name of the type of the objects of the service, or the service name if it is not known.
+func objectType(service string) string {
+	if t, ok := objectTypes[service]; ok {
+		return t
+	}
+	return service
+}
+
+func writeJSON(w http.ResponseWriter, b []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(append(b, '\n'))
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	b, _ := json.Marshal(map[string]string{"error": err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(b, '\n'))
+}
+
+// responseWriter keeps track of whether the response body is started.
+type responseWriter struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.written = true
+	return rw.w.Write(b)
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package servecmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+)
+
+func TestHandler_InvalidRequests(t *testing.T) {
+	h := newHandler(&config.Config{})
+	for _, tc := range []struct {
+		info   string
+		method string
+		target string
+		status int
+		err    string
+	}{
+		{info: "post", method: http.MethodPost, target: "/sql?q=SELECT%201", status: http.StatusMethodNotAllowed, err: "only GET"},
+		{info: "missing statement", method: http.MethodGet, target: "/sql", status: http.StatusBadRequest, err: "statement is missing"},
+		{info: "dml statement", method: http.MethodGet, target: "/sql?q=DELETE%20FROM%20m", status: http.StatusBadRequest, err: "only SELECT and SHOW"},
+		{info: "missing key", method: http.MethodGet, target: "/maps/m", status: http.StatusBadRequest, err: "are required"},
+		{info: "missing map name", method: http.MethodGet, target: "/maps/?key=k1", status: http.StatusBadRequest, err: "are required"},
+		{info: "invalid key", method: http.MethodGet, target: "/maps/m?key=abc&key-type=int32", status: http.StatusBadRequest, err: "converting the key to int32"},
+		{info: "unknown path", method: http.MethodGet, target: "/unknown", status: http.StatusNotFound},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.target, nil))
+			if rec.Code != tc.status {
+				t.Fatalf("want status %d got %d: %s", tc.status, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tc.err) {
+				t.Errorf("want error containing %q got %s", tc.err, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestObjectType(t *testing.T) {
+	if got := objectType(hazelcast.ServiceNameMap); got != "map" {
+		t.Errorf("want map got %s", got)
+	}
+	if got := objectType("hz:impl:cacheService"); got != "hz:impl:cacheService" {
+		t.Errorf("want the service name got %s", got)
+	}
+}
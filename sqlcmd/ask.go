@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+// asker asks for the values of the flags which are not given, if the input of the command is a terminal.
+// Otherwise, the defaults of the flags are used.
+type asker struct {
+	cmd         *cobra.Command
+	in          *bufio.Reader
+	interactive bool
+	// fd is the file descriptor of the terminal, the secrets are read from it without echo
+	fd int
+}
+
+func newAsker(cmd *cobra.Command) *asker {
+	in := cmd.InOrStdin()
+	a := &asker{cmd: cmd, in: bufio.NewReader(in)}
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		a.interactive = true
+		a.fd = int(f.Fd())
+	}
+	return a
+}
+
+// ask asks the question for the flag if it is not given, the current value is the default answer.
+// The answer is validated with validate, and the question is asked again until the answer is valid.
+// The value is validated without asking if the flag is given or the input is not a terminal.
+func (a *asker) ask(flag, question string, value *string, validate func(string) error) error {
+	if !a.interactive || a.cmd.Flags().Changed(flag) {
+		if err := validate(*value); err != nil {
+			return hzcerrors.NewLoggableError(err, "Invalid --%s", flag)
+		}
+		return nil
+	}
+	out := a.cmd.ErrOrStderr()
+	for {
+		if *value != "" {
+			fmt.Fprintf(out, "%s [%s]: ", question, *value)
+		} else {
+			fmt.Fprintf(out, "%s: ", question)
+		}
+		line, err := a.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			fmt.Fprintln(out)
+			return hzcerrors.NewLoggableError(err, "Cannot read the answer for --%s", flag)
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = *value
+		}
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(out, "  %s\n", err)
+			continue
+		}
+		*value = answer
+		return nil
+	}
+}
+
+// askSecret is the same as ask, but the answer is not shown on the terminal and the value is not shown as the default.
+func (a *asker) askSecret(flag, question string, value *string) error {
+	if !a.interactive || a.cmd.Flags().Changed(flag) {
+		return nil
+	}
+	out := a.cmd.ErrOrStderr()
+	fmt.Fprintf(out, "%s: ", question)
+	b, err := term.ReadPassword(a.fd)
+	fmt.Fprintln(out)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot read the answer for --%s", flag)
+	}
+	if answer := strings.TrimSpace(string(b)); answer != "" {
+		*value = answer
+	}
+	return nil
+}
+
+// oneOf returns a validator which accepts only the given values.
+func oneOf(values ...string) func(string) error {
+	return func(s string) error {
+		for _, v := range values {
+			if s == v {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of %s", s, strings.Join(values, ", "))
+	}
+}
+
+// required is a validator which accepts any value which is not empty.
+func required(s string) error {
+	if s == "" {
+		return fmt.Errorf("a value is required")
+	}
+	return nil
+}
+
+// optional is a validator which accepts any value.
+func optional(string) error {
+	return nil
+}
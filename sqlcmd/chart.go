@@ -0,0 +1,384 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+const (
+	chartBar  = "bar"
+	chartLine = "line"
+	// chartWidth is the width of the longest bar and of the plot of the line chart, in characters
+	chartWidth = 60
+	// chartHeight is the height of the plot of the line chart, in lines
+	chartHeight = 12
+	// minLineChartWidth is the width of the plot of the line chart of a few points
+	minLineChartWidth = 10
+)
+
+var chartTypes = []string{chartBar, chartLine}
+
+// barEighths are the blocks which end the bars, from an eighth to seven eighths of a character
+var barEighths = []rune("▏▎▍▌▋▊▉")
+
+// chartOptions are the flags which render the result of a query as a chart instead of a table.
+type chartOptions struct {
+	kind string
+	// x and y are the names of the columns of the labels and the values, the first column and the first numeric column after it by default
+	x string
+	y string
+}
+
+func decorateCommandWithChartFlags(c *chartOptions, cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&c.kind, "chart", "", fmt.Sprintf("render the result of the query as a chart: %s", strings.Join(chartTypes, ", ")))
+	flags.StringVar(&c.x, "x", "", "column of the labels of the chart, the first column by default")
+	flags.StringVar(&c.y, "y", "", "column of the values of the chart, the first numeric column after the labels by default")
+	cmd.RegisterFlagCompletionFunc("chart", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return chartTypes, cobra.ShellCompDirectiveDefault
+	})
+}
+
+func (c chartOptions) validate() error {
+	if c.kind == "" {
+		if c.x != "" || c.y != "" {
+			return fmt.Errorf("--x and --y require --chart")
+		}
+		return nil
+	}
+	for _, t := range chartTypes {
+		if t == c.kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown chart type %s, provide one of %s", c.kind, strings.Join(chartTypes, ", "))
+}
+
+// runChart runs the query and writes the chart of its result to the output of the command.
+func runChart(cmd *cobra.Command, cnfg *config.Config, q string, c chartOptions, opts output.Options) error {
+	if !IsQuery(q) {
+		return hzcerrors.NewLoggableError(nil, "Only the results of SELECT and SHOW statements can be charted")
+	}
+	ctx := cmd.Context()
+	//todo create driver from existing client
+	driver, err := internal.SQLDriver(ctx, &cnfg.Hazelcast)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+	}
+	r, err := fetchResult(ctx, driver, q)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot execute the query")
+	}
+	points, err := chartPoints(r, c, opts)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot chart the result")
+	}
+	return writeChart(cmd.OutOrStdout(), c.kind, points)
+}
+
+// chartPoint is a row of the result, as the label and the value of the chart.
+type chartPoint struct {
+	label string
+	value float64
+}
+
+// chartPoints returns the labels and the values of the chart from the result, the rows with NULL values are skipped.
+func chartPoints(r queryResult, c chartOptions, opts output.Options) ([]chartPoint, error) {
+	if len(r.columns) == 0 {
+		return nil, fmt.Errorf("the result has no columns")
+	}
+	xi, yi := 0, -1
+	if c.x != "" {
+		if xi = indexOfColumn(r.columns, c.x); xi < 0 {
+			return nil, fmt.Errorf("column %s is not in the result, the columns are %s", c.x, strings.Join(r.columns, ", "))
+		}
+	}
+	if c.y != "" {
+		if yi = indexOfColumn(r.columns, c.y); yi < 0 {
+			return nil, fmt.Errorf("column %s is not in the result, the columns are %s", c.y, strings.Join(r.columns, ", "))
+		}
+	} else {
+		yi = firstNumericColumn(r, xi)
+		if yi < 0 {
+			return nil, fmt.Errorf("the result has no numeric column to chart, select one with --y")
+		}
+	}
+	points := make([]chartPoint, 0, len(r.rows))
+	for _, row := range r.rows {
+		if row[yi] == nil {
+			continue
+		}
+		v, ok := chartValue(row[yi])
+		if !ok {
+			return nil, fmt.Errorf("value %s of column %s is not a number", output.String(row[yi]), r.columns[yi])
+		}
+		label := opts.Null
+		if row[xi] != nil {
+			label = output.String(row[xi])
+		}
+		points = append(points, chartPoint{label: label, value: v})
+	}
+	return points, nil
+}
+
+func indexOfColumn(columns []string, name string) int {
+	for i, c := range columns {
+		if strings.EqualFold(c, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// firstNumericColumn returns the first column after the labels whose values are numbers, or the first one before them.
+func firstNumericColumn(r queryResult, labels int) int {
+	numeric := func(i int) bool {
+		found := false
+		for _, row := range r.rows {
+			if row[i] == nil {
+				continue
+			}
+			if _, ok := chartValue(row[i]); !ok {
+				return false
+			}
+			found = true
+		}
+		return found
+	}
+	for i := labels + 1; i < len(r.columns); i++ {
+		if numeric(i) {
+			return i
+		}
+	}
+	for i := 0; i < labels; i++ {
+		if numeric(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// chartValue returns the value as a number, the decimals are converted through their text.
+func chartValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string, bool:
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(output.String(v), 64)
+	return f, err == nil
+}
+
+// writeChart writes the chart of the points to out.
+func writeChart(out io.Writer, kind string, points []chartPoint) error {
+	if len(points) == 0 {
+		_, err := fmt.Fprintln(out, "No rows to chart")
+		return err
+	}
+	var lines []string
+	if kind == chartLine {
+		lines = lineChart(points, chartWidth, chartHeight)
+	} else {
+		lines = barChart(points, chartWidth)
+	}
+	_, err := fmt.Fprintln(out, strings.Join(lines, "\n"))
+	return err
+}
+
+// barChart returns a line for each point with its label, a bar proportional to its value and the value.
+// The bars start from zero, so the negative values have no bars.
+func barChart(points []chartPoint, width int) []string {
+	var labelWidth int
+	var max float64
+	for _, p := range points {
+		if n := len([]rune(p.label)); n > labelWidth {
+			labelWidth = n
+		}
+		max = math.Max(max, p.value)
+	}
+	lines := make([]string, len(points))
+	for i, p := range points {
+		lines[i] = fmt.Sprintf("%-*s │%s %s", labelWidth, p.label, bar(p.value, max, width), formatChartValue(p.value))
+	}
+	return lines
+}
+
+// bar returns the bar of the value, max fills the width, the bars are drawn in eighths of a character.
+func bar(value, max float64, width int) string {
+	if value <= 0 || max <= 0 {
+		return ""
+	}
+	eighths := int(math.Round(value / max * float64(width*8)))
+	s := strings.Repeat("█", eighths/8)
+	if r := eighths % 8; r > 0 {
+		s += string(barEighths[r-1])
+	}
+	return s
+}
+
+// lineChart returns the lines of a braille plot of the values in the order of the points, with the range of the values on the left and the first and the last labels below.
+// Each character has 2x4 dots, so the plot has twice the width and four times the height in dots.
+func lineChart(points []chartPoint, width, height int) []string {
+	min, max := valueRange(points)
+	if n := len(points) * 2; n < width {
+		// a narrower plot for a few points, so that the dots are not too far apart
+		width = int(math.Max(float64(n), minLineChartWidth))
+	}
+	dots := make([][]bool, height*4)
+	for i := range dots {
+		dots[i] = make([]bool, width*2)
+	}
+	toDot := func(i int, v float64) (int, int) {
+		x := 0
+		if len(points) > 1 {
+			x = int(math.Round(float64(i) * float64(width*2-1) / float64(len(points)-1)))
+		}
+		y := height*4 - 1
+		if max > min {
+			y = int(math.Round((max - v) / (max - min) * float64(height*4-1)))
+		}
+		return x, y
+	}
+	px, py := toDot(0, points[0].value)
+	dots[py][px] = true
+	for i := 1; i < len(points); i++ {
+		x, y := toDot(i, points[i].value)
+		drawLine(dots, px, py, x, y)
+		px, py = x, y
+	}
+	top, bottom := formatChartValue(max), formatChartValue(min)
+	axisWidth := lineChartAxisWidth(min, max)
+	lines := make([]string, 0, height+2)
+	for row := 0; row < height; row++ {
+		label := ""
+		switch row {
+		case 0:
+			label = top
+		case height - 1:
+			label = bottom
+		}
+		lines = append(lines, fmt.Sprintf("%*s ┤%s", axisWidth, label, brailleRow(dots[row*4:row*4+4])))
+	}
+	lines = append(lines, fmt.Sprintf("%*s └%s", axisWidth, "", strings.Repeat("─", width)))
+	first, last := points[0].label, points[len(points)-1].label
+	gap := width - len([]rune(first)) - len([]rune(last))
+	if gap < 1 || len(points) == 1 {
+		lines = append(lines, fmt.Sprintf("%*s  %s", axisWidth, "", first))
+		if len(points) > 1 {
+			lines = append(lines, fmt.Sprintf("%*s  %*s", axisWidth, "", width, last))
+		}
+		return lines
+	}
+	return append(lines, fmt.Sprintf("%*s  %s%s%s", axisWidth, "", first, strings.Repeat(" ", gap), last))
+}
+
+// valueRange returns the smallest and the largest values of the points.
+func valueRange(points []chartPoint) (float64, float64) {
+	min, max := points[0].value, points[0].value
+	for _, p := range points {
+		min = math.Min(min, p.value)
+		max = math.Max(max, p.value)
+	}
+	return min, max
+}
+
+// lineChartAxisWidth returns the width of the labels on the left of the line chart, which fits both the largest and the smallest value.
+func lineChartAxisWidth(min, max float64) int {
+	return int(math.Max(float64(len(formatChartValue(max))), float64(len(formatChartValue(min)))))
+}
+
+// drawLine sets the dots on the line between the two dots.
+func drawLine(dots [][]bool, x0, y0, x1, y1 int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		dots[y0][x0] = true
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * e; e2 >= dy {
+			e += dy
+			x0 += sx
+		} else {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// brailleBits are the bits of the dots of a braille character, by the row and the column of the dot.
+var brailleBits = [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+// brailleRow returns the braille characters of the four rows of dots.
+func brailleRow(rows [][]bool) string {
+	var sb strings.Builder
+	for x := 0; x < len(rows[0]); x += 2 {
+		r := rune(0x2800)
+		for y := 0; y < 4; y++ {
+			for dx := 0; dx < 2; dx++ {
+				if rows[y][x+dx] {
+					r |= brailleBits[y][dx]
+				}
+			}
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// formatChartValue returns the value rounded to two fractional digits, without the trailing zeros.
+func formatChartValue(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
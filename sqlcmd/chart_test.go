@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+func TestChartPoints(t *testing.T) {
+	r := queryResult{
+		columns: []string{"city", "name", "age"},
+		rows: [][]interface{}{
+			{"London", "Alice", int32(30)},
+			{nil, "Bob", int64(40)},
+			{"Paris", "Carol", nil},
+		},
+	}
+	opts := output.DefaultOptions()
+	for _, tc := range []struct {
+		name    string
+		c       chartOptions
+		want    []chartPoint
+		wantErr string
+	}{
+		{
+			name: "first numeric column by default",
+			c:    chartOptions{kind: chartBar},
+			want: []chartPoint{{"London", 30}, {opts.Null, 40}},
+		},
+		{
+			name: "given columns",
+			c:    chartOptions{kind: chartBar, x: "NAME", y: "age"},
+			want: []chartPoint{{"Alice", 30}, {"Bob", 40}},
+		},
+		{name: "unknown column", c: chartOptions{kind: chartBar, x: "salary"}, wantErr: "column salary is not in the result"},
+		{name: "not a number", c: chartOptions{kind: chartBar, y: "name"}, wantErr: "value Alice of column name is not a number"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := chartPoints(r, tc.c, opts)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("want error %q got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("want %v got %v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("want %v got %v", tc.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBarChart(t *testing.T) {
+	got := strings.Join(barChart([]chartPoint{{"London", 10}, {"Paris", 20.5}, {"Rome", -1}}, 20), "\n")
+	want := strings.Join([]string{
+		"London │█████████▊ 10",
+		"Paris  │████████████████████ 20.5",
+		"Rome   │ -1",
+	}, "\n")
+	if got != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestLineChart(t *testing.T) {
+	got := strings.Join(lineChart([]chartPoint{{"mon", 1}, {"tue", 2}, {"wed", 3}, {"thu", 4}, {"fri", 5}}, chartWidth, 2), "\n")
+	want := strings.Join([]string{
+		"5 ┤⠀⠀⠀⠀⠀⠀⣀⡤⠴⠚",
+		"1 ┤⣀⣠⠴⠒⠒⠋⠁⠀⠀⠀",
+		"  └──────────",
+		"   mon    fri",
+	}, "\n")
+	if got != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestChartOptionsValidate(t *testing.T) {
+	if err := (chartOptions{kind: "pie"}).validate(); err == nil {
+		t.Fatal("an unknown chart type must be an error")
+	}
+	if err := (chartOptions{x: "city"}).validate(); err == nil {
+		t.Fatal("--x without --chart must be an error")
+	}
+	if err := (chartOptions{kind: chartLine, y: "age"}).validate(); err != nil {
+		t.Fatal(err)
+	}
+}
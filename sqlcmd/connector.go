@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+// mappingOption is an option of a CREATE MAPPING statement.
+type mappingOption struct {
+	key   string
+	value string
+}
+
+// sqlTypeAliases are the other names of the SQL types accepted in the fields
+var sqlTypeAliases = map[string]string{
+	"INT":     "INTEGER",
+	"NUMERIC": "DECIMAL",
+}
+
+// parseFields parses the fields given as "name TYPE, name TYPE". The types are SQL types, such as VARCHAR or TIMESTAMP WITH TIME ZONE.
+func parseFields(s string, isRequired bool) ([]mappingColumn, error) {
+	var columns []mappingColumn
+	seen := map[string]bool{}
+	for _, f := range strings.Split(s, ",") {
+		parts := strings.Fields(f)
+		if len(parts) == 0 {
+			continue
+		}
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("field %q does not have a type, such as %s VARCHAR", strings.TrimSpace(f), parts[0])
+		}
+		name, typ := parts[0], strings.ToUpper(strings.Join(parts[1:], " "))
+		if t, ok := sqlTypeAliases[typ]; ok {
+			typ = t
+		}
+		if _, ok := primitiveFormats[typ]; !ok {
+			return nil, fmt.Errorf("field %s has unknown type %s, the types are %s", name, typ, strings.Join(sqlTypes(), ", "))
+		}
+		if name == "__key" || name == "this" || seen[name] {
+			return nil, fmt.Errorf("field name %s is not valid or used more than once", name)
+		}
+		seen[name] = true
+		columns = append(columns, mappingColumn{name: name, sqlType: typ})
+	}
+	if isRequired && len(columns) == 0 {
+		return nil, fmt.Errorf("at least one field is required")
+	}
+	return columns, nil
+}
+
+func sqlTypes() []string {
+	var types []string
+	for t := range primitiveFormats {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// sortedOptions returns the options in the order of their keys.
+func sortedOptions(options map[string]string) []mappingOption {
+	var keys []string
+	for k := range options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sorted []mappingOption
+	for _, k := range keys {
+		sorted = append(sorted, mappingOption{key: k, value: options[k]})
+	}
+	return sorted
+}
+
+// writeColumns writes the column list of a CREATE MAPPING statement, the list is left out if there are no columns.
+func writeColumns(sb *strings.Builder, columns []mappingColumn) {
+	if len(columns) == 0 {
+		return
+	}
+	sb.WriteString(" (\n")
+	for i, c := range columns {
+		fmt.Fprintf(sb, "  %s %s", output.QuoteIdentifier(c.name), c.sqlType)
+		if c.externalName != "" {
+			fmt.Fprintf(sb, " EXTERNAL NAME %s", output.QuoteIdentifier(c.externalName))
+		}
+		if i < len(columns)-1 {
+			sb.WriteByte(',')
+		}
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(")")
+}
+
+// writeOptions writes the OPTIONS clause of a CREATE MAPPING statement, ending the statement.
+func writeOptions(sb *strings.Builder, options []mappingOption) {
+	sb.WriteString("\nOPTIONS (\n")
+	for i, o := range options {
+		fmt.Fprintf(sb, "  %s = %s", output.QuoteLiteral(o.key), output.QuoteLiteral(o.value))
+		if i < len(options)-1 {
+			sb.WriteByte(',')
+		}
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(");")
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v2"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/browser"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+const dashboardExample = `  # Show the widgets defined in the file, each query is run again at its interval
+  hzc dashboard run orders.yaml`
+
+const (
+	viewTable = "table"
+	viewValue = "value"
+	// defaultWidgetInterval is the refresh interval of the widgets which do not set one
+	defaultWidgetInterval = 5 * time.Second
+)
+
+var widgetViews = []string{viewTable, viewValue, chartBar, chartLine}
+
+// dashboardConfig is the file which defines a dashboard.
+type dashboardConfig struct {
+	Title string `yaml:"title"`
+	// Columns is the number of the widgets on each row of the grid
+	Columns int               `yaml:"columns"`
+	Widgets []dashboardWidget `yaml:"widgets"`
+}
+
+// dashboardWidget is a query with its refresh interval and the way its result is shown.
+type dashboardWidget struct {
+	Title    string        `yaml:"title"`
+	Query    string        `yaml:"query"`
+	Interval time.Duration `yaml:"interval"`
+	// View is one of table, value, bar and line
+	View string `yaml:"view"`
+	// X and Y are the columns of the labels and the values of the charts
+	X string `yaml:"x"`
+	Y string `yaml:"y"`
+}
+
+func NewDashboard(cnfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dashboard {run}",
+		Short:   "Show dashboards of queries refreshed at intervals",
+		Long:    "Show a dashboard, which is a grid of widgets defined in a YAML file. Each widget runs a query at its interval and shows its result as a table, a single value, or a bar or line chart.",
+		Example: dashboardExample,
+	}
+	cmd.AddCommand(newDashboardRun(cnfg))
+	return cmd
+}
+
+func newDashboardRun(cnfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:     "run FILE",
+		Short:   "Show the dashboard defined in the file",
+		Example: dashboardExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot read the dashboard file %s", args[0])
+			}
+			dc, err := parseDashboard(b)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid dashboard file %s", args[0])
+			}
+			if f, ok := cmd.InOrStdin().(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+				return hzcerrors.NewLoggableError(nil, "The dashboard requires a terminal")
+			}
+			ctx := cmd.Context()
+			//todo create driver from existing client
+			driver, err := internal.SQLDriver(ctx, &cnfg.Hazelcast)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+			}
+			widgets := make([]browser.DashboardWidget, len(dc.Widgets))
+			for i, w := range dc.Widgets {
+				w := w
+				widgets[i] = browser.DashboardWidget{
+					Title:    w.Title,
+					Interval: w.Interval,
+					Render: func(width, height int) (string, error) {
+						return renderWidget(ctx, driver, w, width, height)
+					},
+				}
+			}
+			if err := browser.RunDashboard(dc.Title, dc.Columns, widgets); err != nil {
+				return hzcerrors.NewLoggableError(err, "Could not run the dashboard")
+			}
+			return nil
+		},
+	}
+}
+
+// parseDashboard parses and validates the dashboard file, the widgets without a title are titled with their queries.
+func parseDashboard(b []byte) (dashboardConfig, error) {
+	var dc dashboardConfig
+	if err := yaml.UnmarshalStrict(b, &dc); err != nil {
+		return dc, err
+	}
+	if len(dc.Widgets) == 0 {
+		return dc, fmt.Errorf("the dashboard has no widgets")
+	}
+	if dc.Columns < 0 {
+		return dc, fmt.Errorf("columns must not be negative: %d", dc.Columns)
+	}
+	if dc.Columns == 0 {
+		dc.Columns = int(math.Ceil(math.Sqrt(float64(len(dc.Widgets)))))
+	}
+	for i := range dc.Widgets {
+		w := &dc.Widgets[i]
+		w.Query = strings.TrimSpace(w.Query)
+		if w.Query == "" {
+			return dc, fmt.Errorf("widget %d has no query", i+1)
+		}
+		if !IsQuery(w.Query) {
+			return dc, fmt.Errorf("the query of widget %d must be a SELECT or SHOW statement", i+1)
+		}
+		if w.Title == "" {
+			w.Title = w.Query
+		}
+		if w.Interval == 0 {
+			w.Interval = defaultWidgetInterval
+		}
+		if w.Interval < time.Second {
+			return dc, fmt.Errorf("the interval of widget %d must be at least a second: %s", i+1, w.Interval)
+		}
+		if w.View == "" {
+			w.View = viewTable
+		}
+		if !isWidgetView(w.View) {
+			return dc, fmt.Errorf("unknown view %s of widget %d, provide one of %s", w.View, i+1, strings.Join(widgetViews, ", "))
+		}
+	}
+	return dc, nil
+}
+
+func isWidgetView(view string) bool {
+	for _, v := range widgetViews {
+		if v == view {
+			return true
+		}
+	}
+	return false
+}
+
+// renderWidget runs the query of the widget and renders its result to fit the width and the height.
+func renderWidget(ctx context.Context, driver *sql.DB, w dashboardWidget, width, height int) (string, error) {
+	r, err := fetchResult(ctx, driver, w.Query)
+	if err != nil {
+		return "", err
+	}
+	return renderWidgetResult(r, w, width, height)
+}
+
+// renderWidgetResult renders the result for the widget, the height includes the title line of the pane.
+func renderWidgetResult(r queryResult, w dashboardWidget, width, height int) (string, error) {
+	opts := output.DefaultOptions()
+	switch w.View {
+	case viewValue:
+		if len(r.rows) == 0 || len(r.columns) == 0 {
+			return "No rows", nil
+		}
+		return fmt.Sprintf("%s: %s", r.columns[0], opts.Format(r.rows[0][0])), nil
+	case chartBar, chartLine:
+		points, err := chartPoints(r, chartOptions{kind: w.View, x: w.X, y: w.Y}, opts)
+		if err != nil {
+			return "", err
+		}
+		if len(points) == 0 {
+			return "No rows", nil
+		}
+		if w.View == chartBar {
+			return strings.Join(barChart(points, barWidth(points, width)), "\n"), nil
+		}
+		// the axis on the left, and the axis and the labels below the plot
+		axis := lineChartAxisWidth(valueRange(points)) + 2
+		return strings.Join(lineChart(points, clamp(width-axis-1), clamp(height-4)), "\n"), nil
+	}
+	return textTable(r, opts), nil
+}
+
+// textTable returns the result as columns aligned with spaces, the pretty output is sized to the terminal rather than to the pane.
+func textTable(r queryResult, opts output.Options) string {
+	cells := make([][]string, 0, len(r.rows)+1)
+	cells = append(cells, r.columns)
+	for _, row := range r.rows {
+		values := make([]string, len(row))
+		for i, v := range row {
+			values[i] = opts.Format(v)
+		}
+		cells = append(cells, values)
+	}
+	widths := make([]int, len(r.columns))
+	for _, row := range cells {
+		for i, c := range row {
+			if w := runewidth.StringWidth(c); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	lines := make([]string, len(cells))
+	for j, row := range cells {
+		var sb strings.Builder
+		for i, c := range row {
+			if i > 0 {
+				sb.WriteString("  ")
+			}
+			sb.WriteString(runewidth.FillRight(c, widths[i]))
+		}
+		lines[j] = strings.TrimRight(sb.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// barWidth returns the width of the longest bar, so that the labels, the bars and the values fit the width.
+func barWidth(points []chartPoint, width int) int {
+	var labels, values int
+	for _, p := range points {
+		if n := len([]rune(p.label)); n > labels {
+			labels = n
+		}
+		if n := len(formatChartValue(p.value)); n > values {
+			values = n
+		}
+	}
+	return clamp(width - labels - values - 3)
+}
+
+func clamp(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
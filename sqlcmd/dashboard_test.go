@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDashboard(t *testing.T) {
+	dc, err := parseDashboard([]byte(`
+title: Orders
+widgets:
+  - title: Orders by city
+    query: SELECT city, COUNT(*) FROM orders GROUP BY city
+    interval: 10s
+    view: bar
+  - query: SELECT COUNT(*) FROM orders
+    view: value
+  - query: SHOW MAPPINGS
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dc.Columns != 2 {
+		t.Fatalf("want 2 columns for 3 widgets got %d", dc.Columns)
+	}
+	w := dc.Widgets
+	if w[0].Interval != 10*time.Second || w[1].Interval != defaultWidgetInterval {
+		t.Fatalf("unexpected intervals %s and %s", w[0].Interval, w[1].Interval)
+	}
+	if w[1].Title != "SELECT COUNT(*) FROM orders" || w[2].View != viewTable {
+		t.Fatalf("unexpected defaults %+v", w)
+	}
+	for _, tc := range []struct {
+		name string
+		file string
+		want string
+	}{
+		{name: "no widgets", file: "title: empty", want: "no widgets"},
+		{name: "unknown field", file: "widgets:\n  - sql: SELECT 1", want: "not found"},
+		{name: "not a query", file: "widgets:\n  - query: DELETE FROM orders", want: "must be a SELECT or SHOW statement"},
+		{name: "unknown view", file: "widgets:\n  - query: SELECT 1\n    view: pie", want: "unknown view pie"},
+		{name: "short interval", file: "widgets:\n  - query: SELECT 1\n    interval: 10ms", want: "at least a second"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseDashboard([]byte(tc.file))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("want error %q got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestRenderWidgetResult(t *testing.T) {
+	r := queryResult{
+		columns: []string{"city", "orders"},
+		rows:    [][]interface{}{{"London", int64(10)}, {"Paris", int64(5)}},
+	}
+	got, err := renderWidgetResult(r, dashboardWidget{View: viewValue}, 40, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "city: London" {
+		t.Fatalf("want the first value got %q", got)
+	}
+	got, err = renderWidgetResult(r, dashboardWidget{View: chartBar}, 20, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(got, "\n") {
+		if n := len([]rune(line)); n > 20 {
+			t.Fatalf("the line %q is wider than the pane", line)
+		}
+	}
+	// the axis of the line chart fits the smallest value too, which is wider than the largest one here
+	negative := queryResult{
+		columns: r.columns,
+		rows:    [][]interface{}{{"London", -12345.67}, {"Paris", int64(5)}, {"Rome", int64(3)}},
+	}
+	got, err = renderWidgetResult(negative, dashboardWidget{View: chartLine}, 30, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(got, "\n") {
+		if n := len([]rune(line)); n > 30 {
+			t.Fatalf("the line %q is wider than the pane", line)
+		}
+	}
+	got, err = renderWidgetResult(r, dashboardWidget{View: viewTable}, 40, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "London") || !strings.Contains(got, "orders") {
+		t.Fatalf("want the table got %q", got)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+const (
+	describeMappingDetailsQuery = `SELECT mapping_external_name, mapping_type, mapping_options FROM information_schema.mappings WHERE table_name = ?`
+	describeColumnsQuery        = `SELECT column_name, data_type, is_nullable, column_external_name FROM information_schema.columns WHERE table_name = ? ORDER BY ordinal_position`
+)
+
+// describeColumns are the columns of the table of the mapping columns
+var describeColumns = []string{"column", "type", "nullable", "external name"}
+
+// mappingDescription is a mapping as it is listed in information_schema.
+type mappingDescription struct {
+	name         string
+	externalName string
+	connector    string
+	// options are the raw JSON object if they cannot be decoded
+	options string
+	columns [][]interface{}
+}
+
+// parseDescribe returns the mapping name of a "DESCRIBE [MAPPING] NAME" statement.
+// It returns false if the statement is not a describe statement, and an empty name if the name is missing.
+func parseDescribe(q string) (string, bool) {
+	q = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(q), ";"))
+	rest, ok := cutKeyword(q, "describe")
+	if !ok {
+		return "", false
+	}
+	// "DESCRIBE mapping" describes the mapping named mapping
+	if r, ok := cutKeyword(rest, "mapping"); ok && r != "" {
+		rest = r
+	}
+	if len(rest) > 1 && strings.HasPrefix(rest, `"`) && strings.HasSuffix(rest, `"`) {
+		rest = strings.ReplaceAll(rest[1:len(rest)-1], `""`, `"`)
+	}
+	return rest, true
+}
+
+// cutKeyword returns the rest of the input after the case-insensitive keyword, if the input starts with it.
+func cutKeyword(in, keyword string) (string, bool) {
+	if len(in) < len(keyword) || !strings.EqualFold(in[:len(keyword)], keyword) {
+		return "", false
+	}
+	rest := in[len(keyword):]
+	if rest != "" && !strings.ContainsAny(rest[:1], " \t\n") {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// describeMapping fetches the mapping and its columns from information_schema.
+func describeMapping(ctx context.Context, d *sql.DB, name string) (mappingDescription, error) {
+	r, err := fetchResult(ctx, d, describeMappingDetailsQuery, name)
+	if err != nil {
+		return mappingDescription{}, err
+	}
+	if len(r.rows) == 0 {
+		return mappingDescription{}, fmt.Errorf("mapping %s does not exist, run \"SHOW MAPPINGS\" to list them", name)
+	}
+	row := r.rows[0]
+	desc := mappingDescription{
+		name:         name,
+		externalName: output.String(row[0]),
+		connector:    output.String(row[1]),
+		options:      output.String(row[2]),
+	}
+	r, err = fetchResult(ctx, d, describeColumnsQuery, name)
+	if err != nil {
+		return mappingDescription{}, err
+	}
+	desc.columns = r.rows
+	return desc, nil
+}
+
+// formatMappingOptions returns the options as "key = value" lines, sorted by key.
+// The options are returned as they are if they are not a JSON object of strings.
+func formatMappingOptions(options string) []string {
+	var m map[string]string
+	if err := json.Unmarshal([]byte(options), &m); err != nil {
+		return []string{options}
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := make([]string, len(keys))
+	for i, k := range keys {
+		lines[i] = fmt.Sprintf("%s = %s", k, m[k])
+	}
+	return lines
+}
+
+// writeMappingDescription writes the mapping details followed by the table of its columns.
+// Only the columns are written for the machine-readable output types and if the header is disabled, so that the output can be parsed.
+func writeMappingDescription(out io.Writer, outputType string, opts output.Options, desc mappingDescription) error {
+	if (outputType == outputPretty || outputType == outputVertical) && !opts.NoHeader {
+		fmt.Fprintf(out, "Mapping:       %s\n", desc.name)
+		fmt.Fprintf(out, "External name: %s\n", desc.externalName)
+		fmt.Fprintf(out, "Type:          %s\n", desc.connector)
+		options := formatMappingOptions(desc.options)
+		if len(options) == 0 {
+			options = []string{"-"}
+		}
+		for i, o := range options {
+			title := "Options:"
+			if i > 0 {
+				title = ""
+			}
+			fmt.Fprintf(out, "%-15s%s\n", title, o)
+		}
+		fmt.Fprintln(out)
+	}
+	f, err := output.NewFormatter(outputType, out, opts)
+	if err != nil {
+		return err
+	}
+	if err := f.WriteHeader(describeColumns); err != nil {
+		return err
+	}
+	for _, row := range desc.columns {
+		if err := f.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// printMappingDescription describes the mapping and writes it to out.
+func printMappingDescription(ctx context.Context, cnfg *config.Config, name string, out io.Writer, outputType string, opts output.Options) error {
+	if name == "" {
+		return hzcerrors.NewLoggableError(nil, "Provide the name of the mapping: DESCRIBE NAME")
+	}
+	driver, err := internal.SQLDriver(ctx, &cnfg.Hazelcast)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+	}
+	desc, err := describeMapping(ctx, driver, name)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot describe mapping %s", name)
+	}
+	return writeMappingDescription(out, outputType, opts, desc)
+}
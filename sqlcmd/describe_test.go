@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+func TestParseDescribe(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		name string
+		ok   bool
+	}{
+		{in: "DESCRIBE employees", name: "employees", ok: true},
+		{in: "  describe MAPPING employees; ", name: "employees", ok: true},
+		{in: `DESCRIBE "my ""big"" map"`, name: `my "big" map`, ok: true},
+		{in: "DESCRIBE mapping", name: "mapping", ok: true},
+		{in: "DESCRIBE", name: "", ok: true},
+		{in: "DESCRIBEemployees"},
+		{in: "SELECT * FROM employees"},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			name, ok := parseDescribe(tc.in)
+			if name != tc.name || ok != tc.ok {
+				t.Fatalf("want %q, %t got %q, %t", tc.name, tc.ok, name, ok)
+			}
+		})
+	}
+}
+
+func TestWriteMappingDescription(t *testing.T) {
+	desc := mappingDescription{
+		name:         "employees",
+		externalName: "employees",
+		connector:    "IMap",
+		options:      `{"valueFormat":"json-flat","keyFormat":"int"}`,
+		columns: [][]interface{}{
+			{"__key", "INTEGER", "true", "__key"},
+			{"name", "VARCHAR", "true", "this.name"},
+		},
+	}
+	var b bytes.Buffer
+	if err := writeMappingDescription(&b, outputPretty, output.DefaultOptions(), desc); err != nil {
+		t.Fatal(err)
+	}
+	head := "Mapping:       employees\n" +
+		"External name: employees\n" +
+		"Type:          IMap\n" +
+		"Options:       keyFormat = int\n" +
+		"               valueFormat = json-flat\n\n"
+	if !strings.HasPrefix(b.String(), head) {
+		t.Fatalf("unexpected output:\n%s", b.String())
+	}
+	b.Reset()
+	if err := writeMappingDescription(&b, outputCSV, output.DefaultOptions(), desc); err != nil {
+		t.Fatal(err)
+	}
+	want := "column,type,nullable,external name\n__key,INTEGER,true,__key\nname,VARCHAR,true,this.name\n"
+	if b.String() != want {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, b.String())
+	}
+}
+
+func TestFormatMappingOptions(t *testing.T) {
+	if o := formatMappingOptions("{}"); len(o) != 0 {
+		t.Fatalf("want no options, got %v", o)
+	}
+	if o := formatMappingOptions("not json"); len(o) != 1 || o[0] != "not json" {
+		t.Fatalf("want the raw options, got %v", o)
+	}
+}
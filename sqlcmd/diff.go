@@ -0,0 +1,354 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const diffExample = `  # Compare the employees before and after a data fix, by their ids
+  hzc sql diff "SELECT * FROM employees_backup" "SELECT * FROM employees" --key id
+  # Compare the whole rows of two queries
+  hzc sql diff "SELECT name, age FROM employees WHERE age > 30" "SELECT name, age FROM employees_v2 WHERE age > 30"`
+
+const (
+	rowAdded   = "added"
+	rowRemoved = "removed"
+	rowChanged = "changed"
+)
+
+func NewDiff(cnfg *config.Config) *cobra.Command {
+	var (
+		outputType string
+		keys       []string
+	)
+	opts := output.DefaultOptions()
+	cmd := &cobra.Command{
+		Use:   `diff "QUERY1" "QUERY2" [--key column,...]`,
+		Short: "Print the rows added, removed and changed between the results of two queries",
+		Long: `Run both queries and print the rows which are only in the result of the second query as added,
+the ones which are only in the result of the first query as removed, and the ones which have different values as changed.
+The rows are matched by the key columns, or by all the columns if no key is given. The values are compared by their representations.
+Both results are kept in memory. The command fails if there are differences.`,
+		Example: diffExample,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(cmd, cnfg, &outputType, opts); err != nil {
+				return err
+			}
+			for _, q := range args {
+				if !IsQuery(q) {
+					return hzcerrors.NewLoggableError(nil, "Only SELECT and SHOW statements can be compared")
+				}
+			}
+			ctx := cmd.Context()
+			driver, err := internal.SQLDriver(ctx, &cnfg.Hazelcast)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+			}
+			before, err := fetchResult(ctx, driver, args[0])
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot execute the first query")
+			}
+			after, err := fetchResult(ctx, driver, args[1])
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot execute the second query")
+			}
+			d, err := diffResults(before, after, keys)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot compare the results")
+			}
+			opts.NoHeader = quiet.Enabled(cmd)
+			if err := writeDiff(cmd.OutOrStdout(), outputType, opts, before.columns, d.rows); err != nil {
+				return err
+			}
+			quiet.Printf(cmd, "---\n%d added, %d removed, %d changed, %d same\n", d.count(rowAdded), d.count(rowRemoved), d.count(rowChanged), d.same)
+			if len(d.rows) > 0 {
+				return hzcerrors.NewLoggableError(nil, "The results of the queries differ")
+			}
+			return nil
+		},
+	}
+	decorateCommandWithOutputFlag(&outputType, cmd)
+	decorateCommandWithOutputOptionFlags(&opts, cmd)
+	cmd.Flags().StringSliceVar(&keys, "key", nil, "columns which identify the rows, all the columns by default")
+	return cmd
+}
+
+type queryResult struct {
+	columns []string
+	rows    [][]interface{}
+}
+
+func fetchResult(ctx context.Context, d *sql.DB, q string, args ...interface{}) (queryResult, error) {
+	ctx, cancel := internal.SQLContext(ctx)
+	defer cancel()
+	rows, err := d.QueryContext(ctx, q, args...)
+	if err != nil {
+		return queryResult{}, fmt.Errorf("querying: %w", err)
+	}
+	defer rows.Close()
+	var r queryResult
+	_, err = rowsHandler(rows, func(cols []string) error {
+		r.columns = cols
+		return nil
+	}, func(row []interface{}) error {
+		r.rows = append(r.rows, row)
+		return nil
+	})
+	return r, err
+}
+
+// rowDiff is a row which differs between the results, the values are in the order of the columns of the first result.
+type rowDiff struct {
+	change string
+	before []interface{}
+	after  []interface{}
+	// changed are true for the columns which have different values, if the row is changed
+	changed []bool
+}
+
+type resultDiff struct {
+	rows []rowDiff
+	same int
+}
+
+func (d resultDiff) count(change string) int {
+	var n int
+	for _, r := range d.rows {
+		if r.change == change {
+			n++
+		}
+	}
+	return n
+}
+
+// diffResults returns the removed and the changed rows in the order of the first result, followed by the added rows in the order of the second result.
+func diffResults(before, after queryResult, keys []string) (resultDiff, error) {
+	// the columns of the second result in the order of the first one
+	order, err := columnOrder(before.columns, after.columns)
+	if err != nil {
+		return resultDiff{}, err
+	}
+	afterRows := make([][]interface{}, len(after.rows))
+	for i, row := range after.rows {
+		afterRows[i] = make([]interface{}, len(order))
+		for j, k := range order {
+			afterRows[i][j] = row[k]
+		}
+	}
+	keyIndexes, err := keyColumns(before.columns, keys)
+	if err != nil {
+		return resultDiff{}, err
+	}
+	// the rows which have the same key, in the order of the second result
+	matches := map[string][]int{}
+	for i, row := range afterRows {
+		id := rowID(row, keyIndexes)
+		if len(keys) > 0 && len(matches[id]) > 0 {
+			return resultDiff{}, fmt.Errorf("the second query returns more than one row for key %s", rowKeyString(row, keyIndexes))
+		}
+		matches[id] = append(matches[id], i)
+	}
+	var d resultDiff
+	matched := make([]bool, len(afterRows))
+	seen := map[string]bool{}
+	for _, row := range before.rows {
+		id := rowID(row, keyIndexes)
+		if len(keys) > 0 {
+			if seen[id] {
+				return resultDiff{}, fmt.Errorf("the first query returns more than one row for key %s", rowKeyString(row, keyIndexes))
+			}
+			seen[id] = true
+		}
+		m := matches[id]
+		if len(m) == 0 {
+			d.rows = append(d.rows, rowDiff{change: rowRemoved, before: row})
+			continue
+		}
+		matches[id] = m[1:]
+		matched[m[0]] = true
+		other := afterRows[m[0]]
+		changed := make([]bool, len(row))
+		var isChanged bool
+		for i := range row {
+			if valueID(row[i]) != valueID(other[i]) {
+				changed[i] = true
+				isChanged = true
+			}
+		}
+		if !isChanged {
+			d.same++
+			continue
+		}
+		d.rows = append(d.rows, rowDiff{change: rowChanged, before: row, after: other, changed: changed})
+	}
+	for i, row := range afterRows {
+		if !matched[i] {
+			d.rows = append(d.rows, rowDiff{change: rowAdded, after: row})
+		}
+	}
+	return d, nil
+}
+
+// columnOrder returns the indexes of the columns of the first result in the second one.
+func columnOrder(before, after []string) ([]int, error) {
+	indexes := make(map[string]int, len(after))
+	for i, c := range after {
+		indexes[c] = i
+	}
+	if len(before) != len(after) {
+		return nil, fmt.Errorf("the queries return different columns: %s and %s", strings.Join(before, ", "), strings.Join(after, ", "))
+	}
+	order := make([]int, len(before))
+	for i, c := range before {
+		k, ok := indexes[c]
+		if !ok {
+			return nil, fmt.Errorf("the queries return different columns: %s and %s", strings.Join(before, ", "), strings.Join(after, ", "))
+		}
+		order[i] = k
+	}
+	return order, nil
+}
+
+// keyColumns returns the indexes of the key columns, or all the indexes if there are no keys.
+func keyColumns(columns, keys []string) ([]int, error) {
+	if len(keys) == 0 {
+		indexes := make([]int, len(columns))
+		for i := range columns {
+			indexes[i] = i
+		}
+		return indexes, nil
+	}
+	var indexes []int
+	for _, k := range keys {
+		i := indexOf(columns, k)
+		if i < 0 {
+			return nil, fmt.Errorf("key column %s is not in the results, the columns are %s", k, strings.Join(columns, ", "))
+		}
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes, nil
+}
+
+func indexOf(values []string, v string) int {
+	for i, s := range values {
+		if s == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func rowID(row []interface{}, indexes []int) string {
+	ids := make([]string, len(indexes))
+	for i, k := range indexes {
+		ids[i] = valueID(row[k])
+	}
+	return strings.Join(ids, "\x00")
+}
+
+func rowKeyString(row []interface{}, indexes []int) string {
+	values := make([]string, len(indexes))
+	for i, k := range indexes {
+		values[i] = output.String(row[k])
+	}
+	return strings.Join(values, ", ")
+}
+
+// valueID is the representation the values are compared by, NULL is different from all the other values.
+func valueID(v interface{}) string {
+	if v == nil {
+		return "\x00"
+	}
+	return output.String(v)
+}
+
+// writeDiff writes the rows with a change column followed by the columns of the results.
+// The changed values are written as "before -> after", the JSON output has the whole rows before and after the change.
+func writeDiff(out io.Writer, outputType string, opts output.Options, columns []string, rows []rowDiff) error {
+	names := append([]string{"change"}, columns...)
+	if outputType == outputJSON {
+		for _, r := range rows {
+			change, _ := json.Marshal(r.change)
+			before, err := diffJSONRow(opts, columns, r.before)
+			if err != nil {
+				return err
+			}
+			after, err := diffJSONRow(opts, columns, r.after)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(out, `{"change":%s,"before":%s,"after":%s}`+"\n", change, before, after); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	cells := make([][]interface{}, len(rows))
+	for i, r := range rows {
+		cells[i] = append(cells[i], r.change)
+		for j := range columns {
+			switch r.change {
+			case rowAdded:
+				cells[i] = append(cells[i], opts.Format(r.after[j]))
+			case rowRemoved:
+				cells[i] = append(cells[i], opts.Format(r.before[j]))
+			default:
+				v := opts.Format(r.after[j])
+				if r.changed[j] {
+					v = opts.Format(r.before[j]) + " -> " + v
+				}
+				cells[i] = append(cells[i], v)
+			}
+		}
+	}
+	f, err := output.NewFormatter(outputType, out, opts)
+	if err != nil {
+		return err
+	}
+	if err := f.WriteHeader(names); err != nil {
+		return err
+	}
+	for _, c := range cells {
+		if err := f.WriteRow(c); err != nil {
+			return err
+		}
+	}
+	return f.Close()
+}
+
+func diffJSONRow(opts output.Options, columns []string, row []interface{}) ([]byte, error) {
+	if row == nil {
+		return []byte("null"), nil
+	}
+	return opts.MarshalJSONObject(columns, row)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+func TestDiffResults(t *testing.T) {
+	before := queryResult{
+		columns: []string{"id", "name", "age"},
+		rows: [][]interface{}{
+			{int32(1), "Jane", int32(30)},
+			{int32(2), "Joe", int32(40)},
+			{int32(3), "Sam", nil},
+		},
+	}
+	// the columns are in another order, which does not matter
+	after := queryResult{
+		columns: []string{"name", "age", "id"},
+		rows: [][]interface{}{
+			{"Jane", int32(31), int32(1)},
+			{"Sam", nil, int32(3)},
+			{"Ann", int32(25), int32(4)},
+		},
+	}
+	d, err := diffResults(before, after, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.same != 1 || len(d.rows) != 3 {
+		t.Fatalf("unexpected diff: %+v", d)
+	}
+	var b bytes.Buffer
+	opts := output.DefaultOptions()
+	if err := writeDiff(&b, outputCSV, opts, before.columns, d.rows); err != nil {
+		t.Fatal(err)
+	}
+	want := `change,id,name,age
+changed,1,Jane,30 -> 31
+removed,2,Joe,40
+added,4,Ann,25
+`
+	if b.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+	b.Reset()
+	if err := writeDiff(&b, outputJSON, opts, before.columns, d.rows[1:2]); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"change":"removed","before":{"id":2,"name":"Joe","age":40},"after":null}` + "\n"; b.String() != want {
+		t.Fatalf("got %s, want %s", b.String(), want)
+	}
+}
+
+func TestDiffResultsWithoutKeys(t *testing.T) {
+	before := queryResult{columns: []string{"name"}, rows: [][]interface{}{{"Jane"}, {"Jane"}, {"Joe"}}}
+	after := queryResult{columns: []string{"name"}, rows: [][]interface{}{{"Jane"}, {"Ann"}}}
+	d, err := diffResults(before, after, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.same != 1 || d.count(rowRemoved) != 2 || d.count(rowAdded) != 1 {
+		t.Fatalf("unexpected diff: %+v", d)
+	}
+}
+
+func TestDiffResultsErrors(t *testing.T) {
+	a := queryResult{columns: []string{"id", "name"}, rows: [][]interface{}{{1, "a"}, {1, "b"}}}
+	b := queryResult{columns: []string{"id", "name"}}
+	if _, err := diffResults(a, b, []string{"id"}); err == nil {
+		t.Fatal("duplicate keys must be an error")
+	}
+	if _, err := diffResults(a, b, []string{"age"}); err == nil {
+		t.Fatal("unknown key columns must be an error")
+	}
+	if _, err := diffResults(a, queryResult{columns: []string{"id", "age"}}, nil); err == nil {
+		t.Fatal("different columns must be an error")
+	}
+}
@@ -0,0 +1,304 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/readonly"
+)
+
+const fileMappingExample = `  # Ask for the path, the format and the fields, then print the statement
+  hzc sql file-mapping
+  # Create the mapping of the CSV files in an S3 bucket, without asking
+  hzc sql file-mapping --name trades --path s3a://my-bucket/trades/ --format csv --glob '*.csv' --access-key KEY --secret-key SECRET --execute`
+
+const previewFileExample = `  # Print the first 10 rows of the mapping "trades"
+  hzc sql preview-file trades
+  # Print the first 5 rows of the JSON files in a directory of the members, without a mapping
+  hzc sql preview-file --path /data/events --format json-flat --glob '*.json' --limit 5`
+
+const (
+	fileFormatCSV      = "csv"
+	fileFormatParquet  = "parquet"
+	s3Scheme           = "s3a://"
+	defaultGlob        = "*"
+	defaultPreviewRows = 10
+)
+
+var fileFormats = []string{fileFormatCSV, kafkaFormatJSONFlat, kafkaFormatAvro, fileFormatParquet}
+
+// fileFunctions are the table functions which read the files of the formats
+var fileFunctions = map[string]string{
+	fileFormatCSV:       "csv_file",
+	kafkaFormatJSONFlat: "json_flat_file",
+	kafkaFormatAvro:     "avro_file",
+	fileFormatParquet:   "parquet_file",
+}
+
+var (
+	urlSchemeRegexp     = regexp.MustCompile(`^[a-z][a-z0-9+.-]*://`)
+	nonIdentifierRegexp = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+)
+
+// fileSource is the location and the format of the files read by the file connector.
+type fileSource struct {
+	path             string
+	glob             string
+	format           string
+	accessKey        string
+	secretKey        string
+	endpoint         string
+	sharedFileSystem bool
+	options          map[string]string
+}
+
+func decorateCommandWithFileSourceFlags(cmd *cobra.Command, s *fileSource) {
+	cmd.Flags().StringVar(&s.path, "path", "", "directory of the files, as an absolute path on the members or a URL, such as s3a://bucket/dir/")
+	cmd.Flags().StringVar(&s.glob, "glob", defaultGlob, "pattern of the names of the files in the directory")
+	cmd.Flags().StringVar(&s.format, "format", fileFormatCSV, fmt.Sprintf("format of the files, one of %s", strings.Join(fileFormats, ", ")))
+	cmd.Flags().StringVar(&s.accessKey, "access-key", "", "access key of S3, the default credentials of the members are used if it is not given")
+	cmd.Flags().StringVar(&s.secretKey, "secret-key", "", "secret key of S3")
+	cmd.Flags().StringVar(&s.endpoint, "endpoint", "", "endpoint of S3, for the services compatible with S3")
+	cmd.Flags().BoolVar(&s.sharedFileSystem, "shared-file-system", false, "the files are on a file system shared by the members, so that each file is read by only one member")
+	cmd.Flags().StringToStringVar(&s.options, "option", nil, "other options of the file connector, such as --option ignoreFileNotFound=true")
+}
+
+// ask fills in the source with the flags and the answers, in the order of the questions.
+func (s *fileSource) ask(a *asker) error {
+	if err := a.ask("path", "Path of the directory (/dir or s3a://bucket/dir/)", &s.path, validateFilePath); err != nil {
+		return err
+	}
+	if err := a.ask("glob", "Pattern of the file names", &s.glob, validateGlob); err != nil {
+		return err
+	}
+	if err := a.ask("format", "Format ("+strings.Join(fileFormats, ", ")+")", &s.format, oneOf(fileFormats...)); err != nil {
+		return err
+	}
+	if strings.HasPrefix(s.path, s3Scheme) {
+		if err := a.ask("access-key", "S3 access key, the default credentials of the members are used if empty", &s.accessKey, optional); err != nil {
+			return err
+		}
+		if s.accessKey != "" {
+			if err := a.askSecret("secret-key", "S3 secret key", &s.secretKey); err != nil {
+				return err
+			}
+		}
+		if err := a.ask("endpoint", "S3 endpoint, the AWS endpoint is used if empty", &s.endpoint, optional); err != nil {
+			return err
+		}
+	}
+	if err := s.validate(); err != nil {
+		return hzcerrors.NewLoggableError(err, "Invalid file source")
+	}
+	return nil
+}
+
+func (s *fileSource) validate() error {
+	remote := urlSchemeRegexp.MatchString(s.path)
+	if s.format == fileFormatParquet && !remote {
+		return fmt.Errorf("the %s format is supported only for the remote file systems, such as %s", fileFormatParquet, s3Scheme)
+	}
+	if (s.accessKey == "") != (s.secretKey == "") {
+		return fmt.Errorf("both the access key and the secret key are required for the S3 credentials")
+	}
+	if (s.accessKey != "" || s.endpoint != "") && !strings.HasPrefix(s.path, s3Scheme) {
+		return fmt.Errorf("the S3 options are given, but the path does not start with %s", s3Scheme)
+	}
+	for k := range s.options {
+		switch k {
+		case "path", "glob", "format", "sharedFileSystem":
+			return fmt.Errorf("option %s is set by the other flags", k)
+		}
+	}
+	return nil
+}
+
+// connectorOptions returns the options of the file connector other than the path, the glob and the format.
+func (s *fileSource) connectorOptions() []mappingOption {
+	var options []mappingOption
+	if s.sharedFileSystem {
+		options = append(options, mappingOption{"sharedFileSystem", "true"})
+	}
+	if s.accessKey != "" {
+		options = append(options, mappingOption{"fs.s3a.access.key", s.accessKey}, mappingOption{"fs.s3a.secret.key", s.secretKey})
+	}
+	if s.endpoint != "" {
+		options = append(options, mappingOption{"fs.s3a.endpoint", s.endpoint})
+	}
+	return append(options, sortedOptions(s.options)...)
+}
+
+func validateFilePath(p string) error {
+	switch {
+	case p == "":
+		return fmt.Errorf("a path is required")
+	case urlSchemeRegexp.MatchString(p):
+		if strings.HasPrefix(p, s3Scheme) && strings.TrimLeft(strings.TrimPrefix(p, s3Scheme), "/") == "" {
+			return fmt.Errorf("path %s does not have a bucket, such as %smy-bucket/dir/", p, s3Scheme)
+		}
+	case !strings.HasPrefix(p, "/"):
+		return fmt.Errorf("path %s must be absolute, it is read by the members", p)
+	}
+	return nil
+}
+
+func validateGlob(glob string) error {
+	if glob == "" {
+		return fmt.Errorf("a pattern is required, %s matches all the files", defaultGlob)
+	}
+	if _, err := path.Match(glob, ""); err != nil {
+		return fmt.Errorf("invalid pattern %s: %w", glob, err)
+	}
+	return nil
+}
+
+// defaultMappingName returns a mapping name derived from the last part of the path.
+func defaultMappingName(p string) string {
+	name := path.Base(strings.TrimRight(urlSchemeRegexp.ReplaceAllString(p, ""), "/"))
+	name = nonIdentifierRegexp.ReplaceAllString(name, "_")
+	if strings.Trim(name, "_") == "" {
+		return ""
+	}
+	return name
+}
+
+func NewFileMapping(cnfg *config.Config) *cobra.Command {
+	var (
+		name,
+		fields string
+		execute bool
+	)
+	var src fileSource
+	cmd := &cobra.Command{
+		Use:   "file-mapping [--name name] [--path path] [--format format] [--glob pattern] [--execute]",
+		Short: "Build a CREATE MAPPING statement for local or S3 files",
+		Long: `Build a CREATE MAPPING statement for the files in a directory of the members or in S3, and print it, or run it with --execute.
+The values which are not given with the flags are asked for if the input is a terminal, and all of them are validated.
+The fields are given as "name TYPE, name TYPE", such as "ticker VARCHAR, price DECIMAL". If they are left out, they are inferred from the files when the mapping is created.`,
+		Example: fileMappingExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a := newAsker(cmd)
+			if err := src.ask(a); err != nil {
+				return err
+			}
+			if name == "" {
+				name = defaultMappingName(src.path)
+			}
+			if err := a.ask("name", "Mapping name", &name, required); err != nil {
+				return err
+			}
+			if err := a.ask("fields", "Fields (name TYPE, ...), they are inferred from the files if empty", &fields, func(f string) error {
+				_, err := parseFields(f, false)
+				return err
+			}); err != nil {
+				return err
+			}
+			columns, _ := parseFields(fields, false)
+			stmt := fileMapping(name, columns, src)
+			if !execute {
+				fmt.Fprintln(cmd.OutOrStdout(), stmt)
+				return nil
+			}
+			return runStatement(cmd, cnfg, stmt, outputPretty, output.DefaultOptions())
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "name of the mapping, the name of the directory by default")
+	cmd.Flags().StringVar(&fields, "fields", "", `fields of the files, as "name TYPE, name TYPE"`)
+	decorateCommandWithFileSourceFlags(cmd, &src)
+	cmd.Flags().BoolVar(&execute, "execute", false, "run the statement instead of printing it")
+	return readonly.ChecksItself(dryrun.Supported(cmd))
+}
+
+// fileMapping returns the CREATE MAPPING statement, the source must be validated before.
+func fileMapping(name string, columns []mappingColumn, src fileSource) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "CREATE MAPPING %s", output.QuoteIdentifier(name))
+	writeColumns(&sb, columns)
+	sb.WriteString("\nTYPE File")
+	options := []mappingOption{{"path", src.path}, {"format", src.format}, {"glob", src.glob}}
+	writeOptions(&sb, append(options, src.connectorOptions()...))
+	return sb.String()
+}
+
+func NewPreviewFile(cnfg *config.Config) *cobra.Command {
+	var (
+		outputType string
+		limit      int
+		src        fileSource
+	)
+	opts := output.DefaultOptions()
+	cmd := &cobra.Command{
+		Use:   "preview-file [MAPPING | --path path --format format [--glob pattern]] [--limit rows]",
+		Short: "Print the first rows of a file mapping or of the files in a directory",
+		Long: `Print the first rows read from the files of a mapping, or from the files in a directory of the members or in S3 without a mapping.
+The rows are read with a SELECT statement with a LIMIT, so that only the given number of rows are fetched.`,
+		Example: previewFileExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(cmd, cnfg, &outputType, opts); err != nil {
+				return err
+			}
+			if limit < 1 {
+				return hzcerrors.NewLoggableError(nil, "Limit must be positive")
+			}
+			var q string
+			if len(args) == 1 {
+				if cmd.Flags().Changed("path") {
+					return hzcerrors.NewLoggableError(nil, "Either a mapping or --path is required, not both")
+				}
+				q = fmt.Sprintf("SELECT * FROM %s LIMIT %d", output.QuoteIdentifier(args[0]), limit)
+			} else {
+				// only validated, nothing is asked for the preview
+				if err := src.ask(&asker{cmd: cmd}); err != nil {
+					return err
+				}
+				q = previewQuery(src, limit)
+			}
+			return runStatement(cmd, cnfg, q, outputType, opts)
+		},
+	}
+	decorateCommandWithOutputFlag(&outputType, cmd)
+	decorateCommandWithOutputOptionFlags(&opts, cmd)
+	decorateCommandWithFileSourceFlags(cmd, &src)
+	cmd.Flags().IntVar(&limit, "limit", defaultPreviewRows, "number of the rows to print")
+	return cmd
+}
+
+// previewQuery returns the query which reads the first rows of the files with the table function of their format.
+func previewQuery(src fileSource, limit int) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "SELECT * FROM TABLE(%s(path => %s, glob => %s", fileFunctions[src.format], output.QuoteLiteral(src.path), output.QuoteLiteral(src.glob))
+	if options := src.connectorOptions(); len(options) > 0 {
+		var kv []string
+		for _, o := range options {
+			kv = append(kv, output.QuoteLiteral(o.key), output.QuoteLiteral(o.value))
+		}
+		fmt.Fprintf(&sb, ", options => MAP[%s]", strings.Join(kv, ", "))
+	}
+	fmt.Fprintf(&sb, ")) LIMIT %d", limit)
+	return sb.String()
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import "testing"
+
+func TestFileMapping(t *testing.T) {
+	src := fileSource{
+		path:             "s3a://bucket/trades/",
+		glob:             "*.csv",
+		format:           fileFormatCSV,
+		accessKey:        "key",
+		secretKey:        "it's secret",
+		sharedFileSystem: true,
+		options:          map[string]string{"ignoreFileNotFound": "true"},
+	}
+	columns := []mappingColumn{{name: "ticker", sqlType: "VARCHAR"}, {name: "price", sqlType: "DECIMAL"}}
+	want := `CREATE MAPPING "trades" (
+  "ticker" VARCHAR,
+  "price" DECIMAL
+)
+TYPE File
+OPTIONS (
+  'path' = 's3a://bucket/trades/',
+  'format' = 'csv',
+  'glob' = '*.csv',
+  'sharedFileSystem' = 'true',
+  'fs.s3a.access.key' = 'key',
+  'fs.s3a.secret.key' = 'it''s secret',
+  'ignoreFileNotFound' = 'true'
+);`
+	if got := fileMapping("trades", columns, src); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPreviewQuery(t *testing.T) {
+	src := fileSource{path: "/data/events", glob: "*.json", format: kafkaFormatJSONFlat}
+	want := `SELECT * FROM TABLE(json_flat_file(path => '/data/events', glob => '*.json')) LIMIT 5`
+	if got := previewQuery(src, 5); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	src.endpoint = "http://minio:9000"
+	src.path = "s3a://events/"
+	want = `SELECT * FROM TABLE(json_flat_file(path => 's3a://events/', glob => '*.json', options => MAP['fs.s3a.endpoint', 'http://minio:9000'])) LIMIT 5`
+	if got := previewQuery(src, 5); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestFileSourceValidation(t *testing.T) {
+	for _, p := range []string{"", "data", "s3a://", "s3a:///"} {
+		if validateFilePath(p) == nil {
+			t.Fatalf("path %q must be invalid", p)
+		}
+	}
+	for _, p := range []string{"/data", "s3a://bucket", "hdfs://namenode:8020/data"} {
+		if err := validateFilePath(p); err != nil {
+			t.Fatalf("path %q must be valid: %s", p, err)
+		}
+	}
+	for _, src := range []fileSource{
+		{path: "/data", format: fileFormatParquet},
+		{path: "s3a://bucket", format: fileFormatCSV, accessKey: "key"},
+		{path: "/data", format: fileFormatCSV, endpoint: "http://minio:9000"},
+		{path: "/data", format: fileFormatCSV, options: map[string]string{"glob": "*"}},
+	} {
+		if src.validate() == nil {
+			t.Fatalf("source %+v must be invalid", src)
+		}
+	}
+	for p, want := range map[string]string{"/data/trade-events/": "trade_events", "s3a://bucket": "bucket", "/": ""} {
+		if got := defaultMappingName(p); got != want {
+			t.Fatalf("defaultMappingName(%q) is %q, want %q", p, got, want)
+		}
+	}
+}
@@ -0,0 +1,462 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+const fmtExample = `  # Format the statements in a file
+  hzc sql fmt --file statements.sql
+  # Format a statement with lower case keywords
+  hzc sql fmt "select name, age from employees where age > 30 order by age" --keyword-case lower`
+
+func NewFmt() *cobra.Command {
+	var (
+		path        string
+		keywordCase string
+	)
+	cmd := &cobra.Command{
+		Use:   `fmt [--file file | "STATEMENT"] [--keyword-case upper|lower]`,
+		Short: "Print the SQL statements formatted, without running them",
+		Long: `Print the SQL statements formatted with one clause per line, one list item and condition per line, and the subqueries indented.
+The reserved keywords are written in the given case, the other words are kept as they are, since the unquoted identifiers are case-sensitive.`,
+		Example: fmtExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keywordCase != "upper" && keywordCase != "lower" {
+				return hzcerrors.NewLoggableError(nil, "Keyword case must be either upper or lower")
+			}
+			text := strings.Join(args, " ")
+			switch {
+			case path != "" && text != "":
+				return hzcerrors.NewLoggableError(nil, "Provide either a statement or --file")
+			case path == "-":
+				b, err := ioutil.ReadAll(cmd.InOrStdin())
+				if err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot read the statements")
+				}
+				text = string(b)
+			case path != "":
+				b, err := ioutil.ReadFile(path)
+				if err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot read the statements from %s", path)
+				}
+				text = string(b)
+			}
+			if strings.TrimSpace(text) == "" {
+				return hzcerrors.NewLoggableError(nil, "Provide a statement or --file")
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), formatSQL(text, keywordCase == "lower"))
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&path, "file", "f", "", `file which contains the statements, use "-" (dash) to read from stdin`)
+	cmd.Flags().StringVar(&keywordCase, "keyword-case", "upper", "case of the reserved keywords, upper or lower")
+	return cmd
+}
+
+// reservedKeywords are the keywords whose case is changed by the formatter.
+// The unquoted identifiers are case-sensitive, so the non-reserved keywords, such as TYPE and OPTIONS, which may be column names, are kept as they are.
+var reservedKeywords = map[string]bool{}
+
+func init() {
+	for _, k := range strings.Fields(`ALL AND AS ASC BETWEEN BY CASE CAST CREATE CROSS DELETE DESC DISTINCT DROP ELSE END ESCAPE EXISTS EXPLAIN
+		FALSE FROM FULL GROUP HAVING IF IN INNER INSERT INTERVAL INTO IS JOIN LEFT LIKE LIMIT NOT NULL OFFSET ON OR ORDER OUTER
+		REPLACE RIGHT SELECT SET SHOW SINK TABLE THEN TRUE UNION UPDATE VALUES VIEW WHEN WHERE WITH`) {
+		reservedKeywords[k] = true
+	}
+}
+
+// listClauses are the clauses whose items are written one per line
+var listClauses = map[string]bool{"SELECT": true, "GROUP BY": true, "ORDER BY": true, "SET": true}
+
+// conditionClauses are the clauses whose AND and OR conditions are written one per line
+var conditionClauses = map[string]bool{"WHERE": true, "HAVING": true, "ON": true}
+
+const formatIndent = "  "
+
+var twoCharOperators = map[string]bool{"<>": true, "<=": true, ">=": true, "!=": true, "||": true, "::": true}
+
+type sqlTokenKind int
+
+const (
+	tokenWord sqlTokenKind = iota
+	tokenQuoted
+	tokenString
+	tokenNumber
+	tokenPunct
+	tokenOperator
+	tokenComment
+	tokenLineComment
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+	// spaceBefore is true if there is white space before the token in the input
+	spaceBefore bool
+}
+
+func (t sqlToken) upper() string {
+	if t.kind != tokenWord {
+		return ""
+	}
+	return strings.ToUpper(t.text)
+}
+
+// tokenizeSQL splits the text into tokens, the strings, the quoted identifiers and the comments are kept as they are.
+// Unterminated strings and comments continue until the end of the text.
+func tokenizeSQL(text string) []sqlToken {
+	var tokens []sqlToken
+	rs := []rune(text)
+	space := false
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		start := i
+		var kind sqlTokenKind
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			i++
+			continue
+		case r == '\'' || r == '"':
+			kind = tokenString
+			if r == '"' {
+				kind = tokenQuoted
+			}
+			i++
+			for i < len(rs) {
+				if rs[i] == r {
+					// doubled quotes are escaped quotes
+					if i+1 < len(rs) && rs[i+1] == r {
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				i++
+			}
+		case r == '-' && i+1 < len(rs) && rs[i+1] == '-':
+			kind = tokenLineComment
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			kind = tokenComment
+			i += 2
+			for i < len(rs) && !(rs[i] == '*' && i+1 < len(rs) && rs[i+1] == '/') {
+				i++
+			}
+			i += 2
+			if i > len(rs) {
+				i = len(rs)
+			}
+		case unicode.IsDigit(r):
+			kind = tokenNumber
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.' || rs[i] == 'e' || rs[i] == 'E') {
+				i++
+			}
+		case unicode.IsLetter(r) || r == '_' || r == '$':
+			kind = tokenWord
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_' || rs[i] == '$') {
+				i++
+			}
+		case strings.ContainsRune("<>=!|+-*/%:", r):
+			kind = tokenOperator
+			i++
+			// the operators which are two characters, the others are single characters for unary signs and *
+			if i < len(rs) && twoCharOperators[string(rs[i-1:i+1])] {
+				i++
+			}
+		default:
+			kind = tokenPunct
+			i++
+		}
+		tokens = append(tokens, sqlToken{kind: kind, text: string(rs[start:i]), spaceBefore: space})
+		space = false
+	}
+	return tokens
+}
+
+// parenLevel is an opened parenthesis
+type parenLevel struct {
+	// block is true if the contents are on their own lines, such as for subqueries and column lists
+	block bool
+	// indent is the indent of the clauses to restore, lineIndent is the indent of the line of the parenthesis
+	indent     int
+	lineIndent int
+	clause     string
+}
+
+type sqlFormatter struct {
+	tokens []sqlToken
+	lower  bool
+	sb     strings.Builder
+	indent int
+	clause string
+	// statement is the first keyword of the current statement
+	statement string
+	parens    []parenLevel
+	prev      *sqlToken
+	lineStart bool
+	// lineIndent is the indent of the current line, it is written before the first token of the line
+	lineIndent int
+	// between is true after BETWEEN, until its AND
+	between bool
+	// unary is true if the previous token is a unary sign
+	unary bool
+}
+
+// formatSQL formats the statements with one clause per line, one list item and condition per line, and the subqueries indented.
+// The reserved keywords are upper case, or lower case if lower is true.
+func formatSQL(text string, lower bool) string {
+	f := &sqlFormatter{tokens: tokenizeSQL(text), lower: lower, lineStart: true}
+	f.format()
+	return strings.TrimSpace(f.sb.String())
+}
+
+func (f *sqlFormatter) format() {
+	for i := 0; i < len(f.tokens); i++ {
+		t := f.tokens[i]
+		word := t.upper()
+		if f.statement == "" && word != "" {
+			f.statement = word
+		}
+		inline := len(f.parens) > 0 && !f.parens[len(f.parens)-1].block
+		switch {
+		case t.kind == tokenLineComment:
+			f.write(t)
+			f.newLine(f.indent)
+		case t.text == ";":
+			f.write(t)
+			f.sb.WriteString("\n\n")
+			f.lineStart, f.lineIndent = true, 0
+			f.prev = nil
+			f.indent, f.clause, f.statement, f.parens = 0, "", "", nil
+		case t.text == "(":
+			next := f.nextWord(i)
+			level := parenLevel{indent: f.indent, lineIndent: f.lineIndent, clause: f.clause}
+			level.block = next == "SELECT" || next == "WITH" ||
+				(f.statement == "CREATE" && len(f.parens) == 0 && f.clause == "") || (f.prev != nil && strings.EqualFold(f.prev.text, "OPTIONS"))
+			f.write(t)
+			f.parens = append(f.parens, level)
+			if level.block {
+				f.indent = level.lineIndent + 1
+				f.clause = ""
+				if next != "SELECT" && next != "WITH" {
+					// the items of a column or option list are on their own lines
+					f.clause = "("
+				}
+				f.newLine(f.indent)
+			}
+		case t.text == ")":
+			if len(f.parens) == 0 {
+				f.write(t)
+				continue
+			}
+			level := f.parens[len(f.parens)-1]
+			f.parens = f.parens[:len(f.parens)-1]
+			if level.block {
+				f.indent = level.indent
+				f.newLine(level.lineIndent)
+			}
+			f.clause = level.clause
+			f.write(t)
+		case t.text == ",":
+			f.write(t)
+			if inline {
+				continue
+			}
+			if f.clause == "(" {
+				f.newLine(f.indent)
+			} else if listClauses[f.clause] {
+				f.newLine(f.indent + 1)
+			}
+		case !inline && f.isClause(i):
+			clause := f.clauseAt(i)
+			f.newLine(f.indent)
+			for n := len(strings.Fields(clause)); n > 0; n-- {
+				f.write(f.tokens[i])
+				i++
+			}
+			i--
+			f.clause = clause
+			if strings.HasSuffix(clause, "JOIN") {
+				f.clause = "JOIN"
+			}
+		case word == "ON" && !inline:
+			f.write(t)
+			f.clause = "ON"
+		case word == "BETWEEN":
+			f.between = true
+			f.write(t)
+		case (word == "AND" || word == "OR") && !inline && conditionClauses[f.clause]:
+			if word == "AND" && f.between {
+				f.between = false
+				f.write(t)
+				continue
+			}
+			f.newLine(f.indent + 1)
+			f.write(t)
+		default:
+			if word == "AND" {
+				f.between = false
+			}
+			f.write(t)
+		}
+	}
+}
+
+// nextWord returns the next word after the token at i in upper case, skipping the comments.
+func (f *sqlFormatter) nextWord(i int) string {
+	for j := i + 1; j < len(f.tokens); j++ {
+		switch f.tokens[j].kind {
+		case tokenComment, tokenLineComment:
+			continue
+		}
+		return f.tokens[j].upper()
+	}
+	return ""
+}
+
+// clauseAt returns the clause starting at the token at i in upper case, such as "ORDER BY" or "LEFT OUTER JOIN", or an empty string.
+func (f *sqlFormatter) clauseAt(i int) string {
+	word := f.tokens[i].upper()
+	words := func(n int) string {
+		var ws []string
+		for j := i; j < len(f.tokens) && len(ws) < n; j++ {
+			ws = append(ws, f.tokens[j].upper())
+		}
+		return strings.Join(ws, " ")
+	}
+	switch word {
+	case "SELECT", "FROM", "WHERE", "HAVING", "LIMIT", "OFFSET", "VALUES", "SET", "JOIN", "WITH":
+		return word
+	case "UPDATE", "DELETE":
+		if f.statement == word {
+			return word
+		}
+	case "GROUP", "ORDER":
+		if w := words(2); w == word+" BY" {
+			return w
+		}
+	case "UNION":
+		if w := words(2); w == "UNION ALL" {
+			return w
+		}
+		return word
+	case "INSERT", "SINK":
+		if w := words(2); w == word+" INTO" {
+			return w
+		}
+	case "INNER", "CROSS":
+		if w := words(2); w == word+" JOIN" {
+			return w
+		}
+	case "LEFT", "RIGHT", "FULL":
+		if w := words(2); w == word+" JOIN" {
+			return w
+		}
+		if w := words(3); w == word+" OUTER JOIN" {
+			return w
+		}
+	case "TYPE", "OPTIONS":
+		if f.statement == "CREATE" {
+			return word
+		}
+	}
+	return ""
+}
+
+func (f *sqlFormatter) isClause(i int) bool {
+	return f.clauseAt(i) != ""
+}
+
+func (f *sqlFormatter) newLine(indent int) {
+	if !f.lineStart {
+		f.sb.WriteByte('\n')
+	}
+	f.lineIndent = indent
+	f.lineStart = true
+}
+
+func (f *sqlFormatter) write(t sqlToken) {
+	if f.lineStart {
+		f.sb.WriteString(strings.Repeat(formatIndent, f.lineIndent))
+	} else if f.needsSpace(t) {
+		f.sb.WriteByte(' ')
+	}
+	text := t.text
+	if t.kind == tokenWord && reservedKeywords[strings.ToUpper(text)] {
+		text = strings.ToUpper(text)
+		if f.lower {
+			text = strings.ToLower(text)
+		}
+	}
+	f.sb.WriteString(text)
+	f.unary = (t.text == "-" || t.text == "+") && f.isOperand(f.prev)
+	f.lineStart = false
+	f.prev = &t
+}
+
+// isOperand returns true if a sign after the token is a unary sign, such as after an operator, a comma or a keyword.
+func (f *sqlFormatter) isOperand(prev *sqlToken) bool {
+	if prev == nil {
+		return true
+	}
+	switch prev.kind {
+	case tokenOperator:
+		return true
+	case tokenPunct:
+		return prev.text == "(" || prev.text == ","
+	case tokenWord:
+		return reservedKeywords[strings.ToUpper(prev.text)]
+	}
+	return false
+}
+
+func (f *sqlFormatter) needsSpace(t sqlToken) bool {
+	p := f.prev
+	if p == nil || f.unary {
+		return false
+	}
+	switch t.text {
+	case ",", ";", ")", ".":
+		return false
+	case "(":
+		// function calls stay as they are, the keywords are separated
+		if p.kind == tokenWord && !reservedKeywords[strings.ToUpper(p.text)] && !strings.EqualFold(p.text, "OPTIONS") {
+			return t.spaceBefore
+		}
+		return p.text != "("
+	}
+	if p.text == "(" || p.text == "." {
+		return false
+	}
+	if t.text == ":" || p.text == ":" {
+		// named parameters
+		return t.spaceBefore
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestFormatSQL(t *testing.T) {
+	for _, tc := range []struct {
+		info  string
+		in    string
+		lower bool
+		want  string
+	}{
+		{
+			info: "query",
+			in:   `select name, count(*) as c from employees where age between 20 and 30 and (city = 'London' or city='Paris') group by name order by c desc limit 10`,
+			want: `SELECT name,
+  count(*) AS c
+FROM employees
+WHERE age BETWEEN 20 AND 30
+  AND (city = 'London' OR city = 'Paris')
+GROUP BY name
+ORDER BY c DESC
+LIMIT 10`,
+		},
+		{
+			info: "subquery and join",
+			in:   `SELECT * FROM e LEFT JOIN d ON e.dept = d.id WHERE e.id IN (SELECT id FROM vip WHERE level >= -1)`,
+			want: `SELECT *
+FROM e
+LEFT JOIN d ON e.dept = d.id
+WHERE e.id IN (
+  SELECT id
+  FROM vip
+  WHERE level >= -1
+)`,
+		},
+		{
+			info: "mapping and statements",
+			in:   `create mapping m (__key INT, "select" VARCHAR) type IMap options ('keyFormat'='int'); insert into m values (1, 'it''s') -- note`,
+			want: `CREATE mapping m (
+  __key INT,
+  "select" VARCHAR
+)
+type IMap
+options (
+  'keyFormat' = 'int'
+);
+
+INSERT INTO m
+VALUES (1, 'it''s') -- note`,
+		},
+		{
+			info:  "lower case",
+			in:    `SELECT Name FROM t WHERE Name LIKE 'A%' AND id = :id`,
+			lower: true,
+			want: `select Name
+from t
+where Name like 'A%'
+  and id = :id`,
+		},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if got := formatSQL(tc.in, tc.lower); got != tc.want {
+				t.Errorf("want:\n%s\ngot:\n%s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatMetaCommand(t *testing.T) {
+	var b bytes.Buffer
+	s := NewMetaCommandSession(nil)
+	if err := s.Run(context.Background(), `\format select "a b" from t where x='it''s'`, &b); err != nil {
+		t.Fatal(err)
+	}
+	want := "SELECT \"a b\"\nFROM t\nWHERE x = 'it''s'\n"
+	if b.String() != want {
+		t.Fatalf("want %q got %q", want, b.String())
+	}
+	if err := s.Run(context.Background(), `\format`, &b); err == nil {
+		t.Fatal("expected an error without a statement")
+	}
+}
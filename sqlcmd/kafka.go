@@ -0,0 +1,286 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/readonly"
+)
+
+const kafkaMappingExample = `  # Ask for the topic, the brokers and the formats, then print the statement
+  hzc sql kafka-mapping
+  # Create the mapping of the topic "trades" with JSON values, without asking
+  hzc sql kafka-mapping --topic trades --brokers kafka:9092 --key-format bigint --value-format json-flat --value-fields "ticker VARCHAR, price DECIMAL" --execute`
+
+const (
+	kafkaFormatJSONFlat = "json-flat"
+	kafkaFormatAvro     = "avro"
+	kafkaFormatJava     = "java"
+	// maxKafkaTopicLength is the maximum length of the topic names accepted by Kafka
+	maxKafkaTopicLength = 249
+)
+
+// kafkaPrimitiveFormats are the formats of the keys and values which are read with the Kafka serializers of the primitive types
+var kafkaPrimitiveFormats = []string{"varchar", "boolean", "tinyint", "smallint", "int", "bigint", "real", "double"}
+
+var kafkaFormats = append([]string{kafkaFormatJSONFlat, kafkaFormatAvro, kafkaFormatJava}, kafkaPrimitiveFormats...)
+
+var (
+	kafkaTopicRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	javaClassRegexp  = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+)
+
+// kafkaSide is either the key or the value part of a Kafka mapping.
+type kafkaSide struct {
+	// path is either __key or this
+	path   string
+	format string
+	// fields are the columns of the json-flat, avro and java formats
+	fields    []mappingColumn
+	javaClass string
+}
+
+type kafkaMapping struct {
+	name           string
+	topic          string
+	brokers        string
+	schemaRegistry string
+	key            kafkaSide
+	value          kafkaSide
+	options        map[string]string
+}
+
+func NewKafkaMapping(cnfg *config.Config) *cobra.Command {
+	var (
+		keyFields,
+		valueFields string
+		execute bool
+	)
+	m := kafkaMapping{key: kafkaSide{path: "__key"}, value: kafkaSide{path: "this"}}
+	cmd := &cobra.Command{
+		Use:   "kafka-mapping [--topic topic] [--brokers host:port,...] [--key-format format] [--value-format format] [--execute]",
+		Short: "Build a CREATE MAPPING statement for a Kafka topic",
+		Long: `Build a CREATE MAPPING statement for a Kafka topic and print it, or run it with --execute.
+The values which are not given with the flags are asked for if the input is a terminal, and all of them are validated.
+The fields of the json-flat and avro formats are given as "name TYPE, name TYPE", such as "ticker VARCHAR, price DECIMAL".`,
+		Example: kafkaMappingExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a := newAsker(cmd)
+			if err := m.ask(a, &keyFields, &valueFields); err != nil {
+				return err
+			}
+			stmt := m.statement()
+			if !execute {
+				fmt.Fprintln(cmd.OutOrStdout(), stmt)
+				return nil
+			}
+			return runStatement(cmd, cnfg, stmt, outputPretty, output.DefaultOptions())
+		},
+	}
+	cmd.Flags().StringVar(&m.topic, "topic", "", "name of the Kafka topic")
+	cmd.Flags().StringVar(&m.name, "name", "", "name of the mapping, the name of the topic by default")
+	cmd.Flags().StringVar(&m.brokers, "brokers", "localhost:9092", "Kafka brokers to connect to, as comma separated host:port")
+	cmd.Flags().StringVar(&m.key.format, "key-format", "varchar", fmt.Sprintf("format of the keys, one of %s", strings.Join(kafkaFormats, ", ")))
+	cmd.Flags().StringVar(&m.value.format, "value-format", kafkaFormatJSONFlat, fmt.Sprintf("format of the values, one of %s", strings.Join(kafkaFormats, ", ")))
+	cmd.Flags().StringVar(&keyFields, "key-fields", "", `fields of the keys for the json-flat, avro and java formats, as "name TYPE, name TYPE"`)
+	cmd.Flags().StringVar(&valueFields, "value-fields", "", `fields of the values for the json-flat, avro and java formats, as "name TYPE, name TYPE"`)
+	cmd.Flags().StringVar(&m.key.javaClass, "key-class", "", "Java class of the keys for the java format")
+	cmd.Flags().StringVar(&m.value.javaClass, "value-class", "", "Java class of the values for the java format")
+	cmd.Flags().StringVar(&m.schemaRegistry, "schema-registry", "", "URL of the schema registry for the avro format")
+	cmd.Flags().StringToStringVar(&m.options, "option", nil, "other options of the Kafka consumer and producer, such as --option auto.offset.reset=earliest")
+	cmd.Flags().BoolVar(&execute, "execute", false, "run the statement instead of printing it")
+	return readonly.ChecksItself(dryrun.Supported(cmd))
+}
+
+// ask fills in the mapping with the flags and the answers, in the order of the questions.
+func (m *kafkaMapping) ask(a *asker, keyFields, valueFields *string) error {
+	if err := a.ask("topic", "Kafka topic", &m.topic, validateKafkaTopic); err != nil {
+		return err
+	}
+	if m.name == "" {
+		m.name = m.topic
+	}
+	if err := a.ask("name", "Mapping name", &m.name, required); err != nil {
+		return err
+	}
+	if err := a.ask("brokers", "Brokers (host:port, ...)", &m.brokers, validateBrokers); err != nil {
+		return err
+	}
+	for _, s := range []struct {
+		side     *kafkaSide
+		fields   *string
+		name     string
+		question string
+	}{{&m.key, keyFields, "key", "Key"}, {&m.value, valueFields, "value", "Value"}} {
+		question := s.question
+		if err := a.ask(s.name+"-format", question+" format ("+strings.Join(kafkaFormats, ", ")+")", &s.side.format, oneOf(kafkaFormats...)); err != nil {
+			return err
+		}
+		switch s.side.format {
+		case kafkaFormatJSONFlat, kafkaFormatAvro:
+			if err := a.ask(s.name+"-fields", question+" fields (name TYPE, ...)", s.fields, func(f string) error {
+				_, err := parseFields(f, true)
+				return err
+			}); err != nil {
+				return err
+			}
+		case kafkaFormatJava:
+			if err := a.ask(s.name+"-class", question+" Java class", &s.side.javaClass, validateJavaClass); err != nil {
+				return err
+			}
+			if err := a.ask(s.name+"-fields", question+" fields (name TYPE, ...), the fields of the class are used if empty", s.fields, func(f string) error {
+				_, err := parseFields(f, false)
+				return err
+			}); err != nil {
+				return err
+			}
+		}
+		// the fields are validated above
+		s.side.fields, _ = parseFields(*s.fields, false)
+	}
+	if m.key.format == kafkaFormatAvro || m.value.format == kafkaFormatAvro {
+		if err := a.ask("schema-registry", "Schema registry URL, the schema is derived from the fields if empty", &m.schemaRegistry, optional); err != nil {
+			return err
+		}
+	}
+	if err := m.validate(); err != nil {
+		return hzcerrors.NewLoggableError(err, "Invalid mapping")
+	}
+	return nil
+}
+
+func (m *kafkaMapping) validate() error {
+	names := map[string]bool{}
+	for _, s := range []kafkaSide{m.key, m.value} {
+		if s.format != kafkaFormatJava && s.javaClass != "" {
+			return fmt.Errorf("the Java class of %s is given, but its format is %s", s.path, s.format)
+		}
+		if _, ok := kafkaPrimitiveType(s.format); ok && len(s.fields) > 0 {
+			return fmt.Errorf("the fields of %s are given, but its format is %s, which has no fields", s.path, s.format)
+		}
+		for _, c := range s.fields {
+			if names[c.name] {
+				return fmt.Errorf("both the key and the value have the field %s, rename one of the columns", c.name)
+			}
+			names[c.name] = true
+		}
+	}
+	for k := range m.options {
+		if k == "bootstrap.servers" || strings.HasSuffix(k, "Format") {
+			return fmt.Errorf("option %s is set by the other flags", k)
+		}
+	}
+	return nil
+}
+
+func validateKafkaTopic(topic string) error {
+	switch {
+	case topic == "":
+		return fmt.Errorf("a topic is required")
+	case topic == "." || topic == "..":
+		return fmt.Errorf("topic cannot be %q", topic)
+	case len(topic) > maxKafkaTopicLength:
+		return fmt.Errorf("topic cannot be longer than %d characters", maxKafkaTopicLength)
+	case !kafkaTopicRegexp.MatchString(topic):
+		return fmt.Errorf("topic %q can have only letters, digits, '.', '_' and '-'", topic)
+	}
+	return nil
+}
+
+func validateBrokers(brokers string) error {
+	if strings.TrimSpace(brokers) == "" {
+		return fmt.Errorf("at least one broker is required")
+	}
+	for _, b := range strings.Split(brokers, ",") {
+		b = strings.TrimSpace(b)
+		host, port, err := net.SplitHostPort(b)
+		if err != nil || host == "" {
+			return fmt.Errorf("broker %q is not host:port", b)
+		}
+		if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+			return fmt.Errorf("broker %q does not have a valid port", b)
+		}
+	}
+	return nil
+}
+
+func validateJavaClass(class string) error {
+	if !javaClassRegexp.MatchString(class) {
+		return fmt.Errorf("%q is not a Java class name, such as com.example.Trade", class)
+	}
+	return nil
+}
+
+// statement returns the CREATE MAPPING statement, the mapping must be validated before.
+func (m *kafkaMapping) statement() string {
+	var columns []mappingColumn
+	for _, s := range []kafkaSide{m.key, m.value} {
+		if len(s.fields) == 0 {
+			if t, ok := kafkaPrimitiveType(s.format); ok {
+				columns = append(columns, mappingColumn{name: s.path, sqlType: t})
+			}
+			continue
+		}
+		for _, c := range s.fields {
+			if s.path == "__key" {
+				c.externalName = "__key." + c.name
+			}
+			columns = append(columns, c)
+		}
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "CREATE MAPPING %s", output.QuoteIdentifier(m.name))
+	if m.name != m.topic {
+		fmt.Fprintf(&sb, " EXTERNAL NAME %s", output.QuoteIdentifier(m.topic))
+	}
+	writeColumns(&sb, columns)
+	options := []mappingOption{{"keyFormat", m.key.format}}
+	if m.key.javaClass != "" {
+		options = append(options, mappingOption{"keyJavaClass", m.key.javaClass})
+	}
+	options = append(options, mappingOption{"valueFormat", m.value.format})
+	if m.value.javaClass != "" {
+		options = append(options, mappingOption{"valueJavaClass", m.value.javaClass})
+	}
+	options = append(options, mappingOption{"bootstrap.servers", strings.Join(strings.Fields(strings.ReplaceAll(m.brokers, ",", " ")), ",")})
+	if m.schemaRegistry != "" {
+		options = append(options, mappingOption{"schema.registry.url", m.schemaRegistry})
+	}
+	sb.WriteString("\nTYPE Kafka")
+	writeOptions(&sb, append(options, sortedOptions(m.options)...))
+	return sb.String()
+}
+
+func kafkaPrimitiveType(format string) (string, bool) {
+	for t, f := range primitiveFormats {
+		if f == format {
+			return t, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestKafkaMappingStatement(t *testing.T) {
+	m := kafkaMapping{
+		name:    "trades",
+		topic:   "trades-v2",
+		brokers: "kafka1:9092, kafka2:9092",
+		key: kafkaSide{path: "__key", format: kafkaFormatJSONFlat, fields: []mappingColumn{
+			{name: "id", sqlType: "BIGINT"},
+		}},
+		value: kafkaSide{path: "this", format: kafkaFormatAvro, fields: []mappingColumn{
+			{name: "ticker", sqlType: "VARCHAR"},
+			{name: "price", sqlType: "DECIMAL"},
+		}},
+		schemaRegistry: "http://registry:8081",
+		options:        map[string]string{"auto.offset.reset": "earliest"},
+	}
+	want := `CREATE MAPPING "trades" EXTERNAL NAME "trades-v2" (
+  "id" BIGINT EXTERNAL NAME "__key.id",
+  "ticker" VARCHAR,
+  "price" DECIMAL
+)
+TYPE Kafka
+OPTIONS (
+  'keyFormat' = 'json-flat',
+  'valueFormat' = 'avro',
+  'bootstrap.servers' = 'kafka1:9092,kafka2:9092',
+  'schema.registry.url' = 'http://registry:8081',
+  'auto.offset.reset' = 'earliest'
+);`
+	if got := m.statement(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestKafkaMappingStatementJava(t *testing.T) {
+	m := kafkaMapping{
+		name:    "events",
+		topic:   "events",
+		brokers: "localhost:9092",
+		key:     kafkaSide{path: "__key", format: "int"},
+		value:   kafkaSide{path: "this", format: kafkaFormatJava, javaClass: "com.example.Event"},
+	}
+	want := `CREATE MAPPING "events" (
+  "__key" INTEGER
+)
+TYPE Kafka
+OPTIONS (
+  'keyFormat' = 'int',
+  'valueFormat' = 'java',
+  'valueJavaClass' = 'com.example.Event',
+  'bootstrap.servers' = 'localhost:9092'
+);`
+	if got := m.statement(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestKafkaValidation(t *testing.T) {
+	for _, topic := range []string{"", "..", "a b", strings.Repeat("t", maxKafkaTopicLength+1)} {
+		if validateKafkaTopic(topic) == nil {
+			t.Fatalf("topic %q must be invalid", topic)
+		}
+	}
+	if err := validateKafkaTopic("orders.v1_eu-west"); err != nil {
+		t.Fatal(err)
+	}
+	for _, brokers := range []string{"", "kafka", "kafka:0", "kafka:9092,:9092", "kafka:port"} {
+		if validateBrokers(brokers) == nil {
+			t.Fatalf("brokers %q must be invalid", brokers)
+		}
+	}
+	if err := validateBrokers("kafka1:9092, [::1]:9093"); err != nil {
+		t.Fatal(err)
+	}
+	columns, err := parseFields("ticker varchar, at TIMESTAMP WITH TIME ZONE", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) != 2 || columns[1].name != "at" || columns[1].sqlType != "TIMESTAMP WITH TIME ZONE" {
+		t.Fatalf("unexpected columns: %v", columns)
+	}
+	for _, fields := range []string{"", "ticker", "ticker TEXT", "a INT, a INT", "this VARCHAR"} {
+		if _, err := parseFields(fields, true); err == nil {
+			t.Fatalf("fields %q must be invalid", fields)
+		}
+	}
+}
+
+func TestAsker(t *testing.T) {
+	cmd := &cobra.Command{}
+	var topic, brokers string
+	cmd.Flags().StringVar(&topic, "topic", "", "")
+	cmd.Flags().StringVar(&brokers, "brokers", "localhost:9092", "")
+	var out bytes.Buffer
+	cmd.SetErr(&out)
+	a := &asker{cmd: cmd, in: bufio.NewReader(strings.NewReader("a b\norders\n\n")), interactive: true}
+	if err := a.ask("topic", "Kafka topic", &topic, validateKafkaTopic); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ask("brokers", "Brokers", &brokers, validateBrokers); err != nil {
+		t.Fatal(err)
+	}
+	if topic != "orders" || brokers != "localhost:9092" {
+		t.Fatalf("unexpected answers: %s, %s", topic, brokers)
+	}
+	if !strings.Contains(out.String(), `topic "a b" can have only`) {
+		t.Fatalf("the invalid answer must be reported: %s", out.String())
+	}
+	if err := a.ask("topic", "Kafka topic", new(string), validateKafkaTopic); err == nil {
+		t.Fatal("the end of the input must be an error")
+	}
+}
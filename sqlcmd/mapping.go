@@ -0,0 +1,321 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+const defaultSampleKeys = 100
+
+const generateMappingExample = `  # Print the mapping of the map "orders" inferred from 100 entries
+  hzc sql generate-mapping --map orders --sample-keys 100`
+
+const (
+	sqlVarchar = "VARCHAR"
+	sqlBigint  = "BIGINT"
+	sqlDouble  = "DOUBLE"
+	// sqlNested marks JSON objects and arrays, which are left out of the mapping
+	sqlNested = "nested"
+)
+
+// primitive SQL types and the corresponding key and value formats
+var primitiveFormats = map[string]string{
+	"VARCHAR":                  "varchar",
+	"BOOLEAN":                  "boolean",
+	"TINYINT":                  "tinyint",
+	"SMALLINT":                 "smallint",
+	"INTEGER":                  "int",
+	"BIGINT":                   "bigint",
+	"REAL":                     "real",
+	"DOUBLE":                   "double",
+	"DECIMAL":                  "decimal",
+	"DATE":                     "date",
+	"TIME":                     "time",
+	"TIMESTAMP":                "timestamp",
+	"TIMESTAMP WITH TIME ZONE": "timestamp with time zone",
+}
+
+func NewGenerateMapping(config *hazelcast.Config) *cobra.Command {
+	var (
+		mapName    string
+		sampleKeys int
+	)
+	cmd := &cobra.Command{
+		Use:     "generate-mapping --map mapname [--sample-keys count]",
+		Short:   "Print a CREATE MAPPING statement inferred from the entries of the map",
+		Long:    "Print a CREATE MAPPING statement with the columns and types inferred from a sample of the entries of the map. Primitive keys and values, and JSON values are supported.",
+		Example: generateMappingExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if sampleKeys < 1 {
+				return hzcerrors.NewLoggableError(nil, "Number of sample keys must be positive")
+			}
+			ctx := cmd.Context()
+			client, err := internal.ConnectToCluster(ctx, config)
+			if err != nil {
+				return err
+			}
+			m, err := client.GetMap(ctx, mapName)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get map %s", mapName)
+			}
+			// the client cannot fetch a limited number of keys, so the sample is taken from the whole key set
+			keys, err := m.GetKeySet(ctx)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get the keys of map %s. Keys which cannot be deserialized, such as Portable or Compact ones, are not supported", mapName)
+			}
+			if len(keys) == 0 {
+				return hzcerrors.NewLoggableError(nil, "Map %s is empty, the mapping cannot be inferred", mapName)
+			}
+			if len(keys) > sampleKeys {
+				keys = keys[:sampleKeys]
+			}
+			entries, err := m.GetAll(ctx, keys...)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get the entries of map %s. Values which cannot be deserialized, such as Portable or Compact ones, are not supported", mapName)
+			}
+			stmt, err := generateMapping(mapName, entries)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot infer the mapping of map %s", mapName)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), stmt)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&mapName, "map", "", "name of the map")
+	if err := cmd.MarkFlagRequired("map"); err != nil {
+		panic(err)
+	}
+	cmd.Flags().IntVar(&sampleKeys, "sample-keys", defaultSampleKeys, "number of entries to infer the mapping from")
+	return cmd
+}
+
+type mappingColumn struct {
+	name         string
+	externalName string
+	sqlType      string
+}
+
+// mappingSide infers either the key or the value part of the mapping.
+type mappingSide struct {
+	// path is either __key or this
+	path string
+	// primitive is the SQL type of non-JSON objects
+	primitive string
+	json      bool
+	fields    []string
+	types     map[string]string
+}
+
+func (s *mappingSide) add(v interface{}) error {
+	if j, ok := v.(serialization.JSON); ok {
+		if s.primitive != "" {
+			return fmt.Errorf("%s has both JSON and %s values", s.path, s.primitive)
+		}
+		s.json = true
+		return s.addJSONFields(j)
+	}
+	t, ok := primitiveType(v)
+	if !ok {
+		return fmt.Errorf("%s has values of unsupported type %T", s.path, v)
+	}
+	switch {
+	case s.json:
+		return fmt.Errorf("%s has both JSON and %s values", s.path, t)
+	case s.primitive != "" && s.primitive != t:
+		return fmt.Errorf("%s has both %s and %s values", s.path, s.primitive, t)
+	}
+	s.primitive = t
+	return nil
+}
+
+// addJSONFields adds the top level fields of the JSON object, keeping their order.
+func (s *mappingSide) addJSONFields(j serialization.JSON) error {
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("%s has JSON values which are not objects", s.path)
+	}
+	if s.types == nil {
+		s.types = map[string]string{}
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name := tok.(string)
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		prev, seen := s.types[name]
+		if !seen {
+			s.fields = append(s.fields, name)
+		}
+		s.types[name] = mergeJSONTypes(prev, jsonType(v))
+	}
+	return nil
+}
+
+func (s *mappingSide) format() string {
+	if s.json {
+		return "json-flat"
+	}
+	return primitiveFormats[s.primitive]
+}
+
+func generateMapping(mapName string, entries []types.Entry) (string, error) {
+	if len(entries) == 0 {
+		return "", fmt.Errorf("no entries")
+	}
+	key := &mappingSide{path: "__key"}
+	value := &mappingSide{path: "this"}
+	for _, e := range entries {
+		if err := key.add(e.Key); err != nil {
+			return "", err
+		}
+		if err := value.add(e.Value); err != nil {
+			return "", err
+		}
+	}
+	var columns []mappingColumn
+	var skipped []string
+	names := map[string]bool{}
+	for _, s := range []*mappingSide{key, value} {
+		if !s.json {
+			names[s.path] = true
+			columns = append(columns, mappingColumn{name: s.path, sqlType: s.primitive})
+			continue
+		}
+		for _, f := range s.fields {
+			t := s.types[f]
+			if t == sqlNested {
+				skipped = append(skipped, s.path+"."+f)
+				continue
+			}
+			if t == "" {
+				// only null values were seen
+				t = sqlVarchar
+			}
+			c := mappingColumn{name: f, externalName: s.path + "." + f, sqlType: t}
+			if names[c.name] {
+				// both the key and the value have the field
+				c.name = s.path + "_" + f
+			}
+			names[c.name] = true
+			columns = append(columns, c)
+		}
+	}
+	var sb strings.Builder
+	if len(skipped) > 0 {
+		sort.Strings(skipped)
+		fmt.Fprintf(&sb, "-- nested fields are left out: %s\n", strings.Join(skipped, ", "))
+	}
+	fmt.Fprintf(&sb, "CREATE MAPPING %s (\n", output.QuoteIdentifier(mapName))
+	for i, c := range columns {
+		fmt.Fprintf(&sb, "  %s %s", output.QuoteIdentifier(c.name), c.sqlType)
+		if c.externalName != "" && c.externalName != "this."+c.name {
+			fmt.Fprintf(&sb, " EXTERNAL NAME %s", output.QuoteIdentifier(c.externalName))
+		}
+		if i < len(columns)-1 {
+			sb.WriteByte(',')
+		}
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(")\nTYPE IMap\nOPTIONS (\n")
+	fmt.Fprintf(&sb, "  'keyFormat' = '%s',\n", key.format())
+	fmt.Fprintf(&sb, "  'valueFormat' = '%s'\n", value.format())
+	sb.WriteString(");")
+	return sb.String(), nil
+}
+
+func primitiveType(v interface{}) (string, bool) {
+	switch v.(type) {
+	case string:
+		return "VARCHAR", true
+	case bool:
+		return "BOOLEAN", true
+	case int8:
+		return "TINYINT", true
+	case int16:
+		return "SMALLINT", true
+	case int32:
+		return "INTEGER", true
+	case int64:
+		return "BIGINT", true
+	case float32:
+		return "REAL", true
+	case float64:
+		return "DOUBLE", true
+	case types.Decimal, *types.Decimal:
+		return "DECIMAL", true
+	case types.LocalDate:
+		return "DATE", true
+	case types.LocalTime:
+		return "TIME", true
+	case types.LocalDateTime:
+		return "TIMESTAMP", true
+	case types.OffsetDateTime:
+		return "TIMESTAMP WITH TIME ZONE", true
+	}
+	return "", false
+}
+
+func jsonType(v interface{}) string {
+	switch vv := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return sqlVarchar
+	case bool:
+		return "BOOLEAN"
+	case json.Number:
+		if _, err := vv.Int64(); err == nil {
+			return sqlBigint
+		}
+		return sqlDouble
+	}
+	return sqlNested
+}
+
+// mergeJSONTypes returns the type which can hold the values of both types.
+func mergeJSONTypes(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "", a == b:
+		return a
+	case a == sqlNested || b == sqlNested:
+		return sqlNested
+	case (a == sqlBigint || a == sqlDouble) && (b == sqlBigint || b == sqlDouble):
+		return sqlDouble
+	}
+	return sqlVarchar
+}
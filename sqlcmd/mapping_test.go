@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestGenerateMapping(t *testing.T) {
+	for _, tc := range []struct {
+		info    string
+		entries []types.Entry
+		want    string
+		isErr   bool
+	}{
+		{
+			info:    "primitive key and value",
+			entries: []types.Entry{{Key: int64(1), Value: "one"}, {Key: int64(2), Value: "two"}},
+			want: `CREATE MAPPING "m" (
+  "__key" BIGINT,
+  "this" VARCHAR
+)
+TYPE IMap
+OPTIONS (
+  'keyFormat' = 'bigint',
+  'valueFormat' = 'varchar'
+);`,
+		},
+		{
+			info: "JSON value",
+			entries: []types.Entry{
+				{Key: "k1", Value: serialization.JSON(`{"name": "Jane", "age": 30, "address": {"city": "X"}, "note": null}`)},
+				{Key: "k2", Value: serialization.JSON(`{"name": "Joe", "age": 30.5, "active": true}`)},
+			},
+			want: `-- nested fields are left out: this.address
+CREATE MAPPING "m" (
+  "__key" VARCHAR,
+  "name" VARCHAR,
+  "age" DOUBLE,
+  "note" VARCHAR,
+  "active" BOOLEAN
+)
+TYPE IMap
+OPTIONS (
+  'keyFormat' = 'varchar',
+  'valueFormat' = 'json-flat'
+);`,
+		},
+		{
+			info: "JSON key and value with the same field",
+			entries: []types.Entry{
+				{Key: serialization.JSON(`{"id": 1}`), Value: serialization.JSON(`{"id": "a"}`)},
+			},
+			want: `CREATE MAPPING "m" (
+  "id" BIGINT EXTERNAL NAME "__key.id",
+  "this_id" VARCHAR EXTERNAL NAME "this.id"
+)
+TYPE IMap
+OPTIONS (
+  'keyFormat' = 'json-flat',
+  'valueFormat' = 'json-flat'
+);`,
+		},
+		{
+			info:    "mixed value types",
+			entries: []types.Entry{{Key: "a", Value: int32(1)}, {Key: "b", Value: "x"}},
+			isErr:   true,
+		},
+		{
+			info:    "JSON array value",
+			entries: []types.Entry{{Key: "a", Value: serialization.JSON(`[1, 2]`)}},
+			isErr:   true,
+		},
+		{
+			info:    "unsupported value type",
+			entries: []types.Entry{{Key: "a", Value: []string{"x"}}},
+			isErr:   true,
+		},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			got, err := generateMapping("m", tc.entries)
+			if tc.isErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("want:\n%s\ngot:\n%s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/shlex"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/session"
+)
+
+const metaCommandPrefix = `\`
+
+const (
+	metaHelp            = `\?`
+	metaDescribe        = `\d`
+	metaListMappings    = `\dm`
+	metaDescribeMapping = `\dm+`
+	metaListJobs        = `\dj`
+	metaConnect         = `\c`
+	metaOutput          = `\o`
+	metaTiming          = `\timing`
+	metaExpanded        = `\x`
+	metaSave            = `\save`
+	metaFormat          = `\format`
+	metaSnapshot        = `\snapshot`
+)
+
+const metaCommandsHelp = `Meta-commands:
+  \?                 show this help
+  \d NAME            show the external name, type, options and columns of the mapping, same as DESCRIBE NAME
+  \dm                list mappings
+  \dm+ NAME          show the columns and types of the mapping
+  \dj                list jobs
+  \c [CONFIG]        connect to the cluster in the given configuration file, or show the current cluster
+  \o [FILE]          send the command output to the file, or back to the standard output if no file is given
+  \timing [on|off]   toggle printing the elapsed time after each statement
+  \x [on|off]        toggle printing each row as a block of "column | value" lines
+  \save FILE         save the commands which succeeded in this session to the file, run it with "hzc replay FILE"
+  \format STATEMENT  print the statement formatted, without running it
+  \snapshot take NAME QUERY
+                     run the query and keep its result with the name
+  \snapshot diff NAME [KEY,...]
+                     run the query of the snapshot again and print the rows changed since, matched by the key columns
+  \snapshot list     list the snapshots of this session
+`
+
+const describeMappingQuery = `SELECT column_name, data_type, is_nullable FROM information_schema.columns WHERE table_name = ? ORDER BY ordinal_position`
+
+// IsMetaCommand returns true if the shell input is a meta-command instead of a regular command.
+func IsMetaCommand(in string) bool {
+	return strings.HasPrefix(strings.TrimSpace(in), metaCommandPrefix)
+}
+
+// parseMetaCommand splits the meta-command into its name and arguments.
+// The name is taken verbatim since backslash is an escape character for the arguments.
+func parseMetaCommand(in string) (string, []string, error) {
+	name, rest := splitMetaCommand(in)
+	args, err := shlex.Split(rest)
+	if err != nil {
+		return "", nil, err
+	}
+	return name, args, nil
+}
+
+// splitMetaCommand splits the meta-command into its name and the rest of the input.
+func splitMetaCommand(in string) (string, string) {
+	in = strings.TrimSpace(in)
+	if i := strings.IndexAny(in, " \t\n"); i >= 0 {
+		return in[:i], strings.TrimSpace(in[i+1:])
+	}
+	return in, ""
+}
+
+// MetaCommandSession keeps the shell state that is changed by meta-commands between inputs.
+type MetaCommandSession struct {
+	config    *config.Config
+	out       *os.File
+	snapshots resultSnapshots
+}
+
+func NewMetaCommandSession(config *config.Config) *MetaCommandSession {
+	return &MetaCommandSession{config: config, snapshots: resultSnapshots{}}
+}
+
+// Out returns the writer the command output is redirected to, or nil if it is not redirected.
+func (s *MetaCommandSession) Out() io.Writer {
+	if s.out == nil {
+		return nil
+	}
+	return s.out
+}
+
+// Close closes the output file, if there is one.
+func (s *MetaCommandSession) Close() error {
+	if s.out == nil {
+		return nil
+	}
+	err := s.out.Close()
+	s.out = nil
+	return err
+}
+
+// Run executes the meta-command and writes its output to out.
+func (s *MetaCommandSession) Run(ctx context.Context, in string, out io.Writer) error {
+	switch name, statement := splitMetaCommand(in); name {
+	case metaFormat:
+		// the statement is taken verbatim, since its quotes are not shell quotes
+		if statement == "" {
+			return hzcerrors.NewLoggableError(nil, "Provide the statement to format: %s STATEMENT", metaFormat)
+		}
+		fmt.Fprintln(out, formatSQL(statement, false))
+		return nil
+	case metaSnapshot:
+		return s.snapshot(ctx, statement, out)
+	}
+	name, args, err := parseMetaCommand(in)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot parse the meta-command")
+	}
+	switch name {
+	case metaHelp:
+		fmt.Fprint(out, metaCommandsHelp)
+		return nil
+	case metaDescribe:
+		if len(args) != 1 {
+			return hzcerrors.NewLoggableError(nil, "Provide the name of the mapping: %s NAME", metaDescribe)
+		}
+		return printMappingDescription(ctx, s.config, args[0], out, s.outputType(), output.DefaultOptions())
+	case metaListMappings:
+		return s.query(ctx, out, "SHOW MAPPINGS")
+	case metaDescribeMapping:
+		if len(args) != 1 {
+			return hzcerrors.NewLoggableError(nil, "Provide the name of the mapping: %s NAME", metaDescribeMapping)
+		}
+		return s.query(ctx, out, describeMappingQuery, args[0])
+	case metaListJobs:
+		return s.query(ctx, out, "SHOW JOBS")
+	case metaConnect:
+		if len(args) == 0 {
+			fmt.Fprintf(out, "Connected to %s@%s\n", s.config.Hazelcast.Cluster.Name, config.GetClusterAddress(&s.config.Hazelcast))
+			return nil
+		}
+		return s.connect(ctx, args[0], out)
+	case metaOutput:
+		if len(args) == 0 {
+			return s.Close()
+		}
+		return s.redirect(args[0])
+	case metaTiming:
+		return toggle(metaTiming, "Timing", &s.config.SQL.Timing, args, out)
+	case metaExpanded:
+		return toggle(metaExpanded, "Expanded display", &s.config.SQL.Expanded, args, out)
+	case metaSave:
+		if len(args) != 1 {
+			return hzcerrors.NewLoggableError(nil, "Provide the file to save the commands to: %s FILE", metaSave)
+		}
+		n, err := session.SaveScript(args[0])
+		if err != nil {
+			return hzcerrors.NewLoggableError(err, "Cannot save the commands to %s", args[0])
+		}
+		fmt.Fprintf(out, "Saved %d command(s) to %s\n", n, args[0])
+		return nil
+	}
+	return hzcerrors.NewLoggableError(nil, `Unknown meta-command %s, run \? to see the available ones`, name)
+}
+
+func (s *MetaCommandSession) query(ctx context.Context, out io.Writer, text string, args ...interface{}) error {
+	start := time.Now()
+	driver, err := internal.SQLDriver(ctx, &s.config.Hazelcast)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+	}
+	n, err := query(ctx, driver, text, out, s.outputType(), output.DefaultOptions(), args...)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot execute the query")
+	}
+	printQueryFooter(out, n, time.Since(start), s.config.SQL.Timing)
+	return nil
+}
+
+// outputType returns the output type of the meta-commands, which is vertical if the expanded display is on.
+func (s *MetaCommandSession) outputType() string {
+	if s.config.SQL.Expanded {
+		return outputVertical
+	}
+	return outputPretty
+}
+
+// toggle flips the setting, or sets it if on or off is given.
+func toggle(metaCmd, title string, setting *bool, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		*setting = !*setting
+	} else {
+		switch strings.ToLower(args[0]) {
+		case "on":
+			*setting = true
+		case "off":
+			*setting = false
+		default:
+			return hzcerrors.NewLoggableError(nil, "Provide either on or off: %s [on|off]", metaCmd)
+		}
+	}
+	if *setting {
+		fmt.Fprintf(out, "%s is on.\n", title)
+	} else {
+		fmt.Fprintf(out, "%s is off.\n", title)
+	}
+	return nil
+}
+
+func (s *MetaCommandSession) connect(ctx context.Context, path string, out io.Writer) error {
+	c := config.DefaultConfig()
+	if err := config.ReadAndMergeWithFlags(&config.GlobalFlagValues{CfgFile: path}, c); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "Connecting to the cluster ...")
+	closeErr, err := internal.SwitchConnection(ctx, &c.Hazelcast)
+	if err != nil {
+		return err
+	}
+	// the previous connection is kept until the new one is established, so the configuration is swapped only now
+	*s.config = *c
+	if closeErr != nil {
+		fmt.Fprintf(os.Stderr, "Cannot close the previous connection: %s\n", closeErr)
+	}
+	return nil
+}
+
+func (s *MetaCommandSession) redirect(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return hzcerrors.NewLoggableError(err, "Not enough permissions to write to %s", path)
+		}
+		return hzcerrors.NewLoggableError(err, "Cannot open %s for writing", path)
+	}
+	if err := s.Close(); err != nil {
+		f.Close()
+		return hzcerrors.NewLoggableError(err, "Cannot close the previous output file")
+	}
+	s.out = f
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMetaCommand(t *testing.T) {
+	for _, tc := range []struct {
+		info string
+		in   string
+		name string
+		args []string
+	}{
+		{"no args", `\dm`, `\dm`, nil},
+		{"surrounding spaces", `  \dj  `, `\dj`, nil},
+		{"single arg", `\dm+ myMap`, `\dm+`, []string{"myMap"}},
+		{"quoted arg", `\o "my results.txt"`, `\o`, []string{"my results.txt"}},
+		{"tab separated", "\\c\tprod.yaml", `\c`, []string{"prod.yaml"}},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			name, args, err := parseMetaCommand(tc.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name != tc.name {
+				t.Errorf("want name %s got %s", tc.name, name)
+			}
+			if len(tc.args) != 0 || len(args) != 0 {
+				if !reflect.DeepEqual(tc.args, args) {
+					t.Errorf("want args %v got %v", tc.args, args)
+				}
+			}
+		})
+	}
+}
@@ -19,63 +19,143 @@ package sqlcmd
 import (
 	"context"
 	"database/sql"
-	"encoding/csv"
 	"fmt"
 	"io"
+	"time"
 
-	"github.com/hazelcast/hazelcast-commandline-client/internal/table"
+	"github.com/hazelcast/hazelcast-go-client"
+	hzsql "github.com/hazelcast/hazelcast-go-client/sql"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
 )
 
-func query(ctx context.Context, d *sql.DB, text string, out io.Writer, outputType string) error {
-	rows, err := d.QueryContext(ctx, text)
+// query runs the query, writes the rows to out as they arrive and returns the number of rows.
+func query(ctx context.Context, d *sql.DB, text string, out io.Writer, outputType string, opts output.Options, args ...interface{}) (int, error) {
+	ctx, cancel := internal.SQLContext(ctx)
+	defer cancel()
+	rows, err := d.QueryContext(ctx, text, args...)
 	if err != nil {
-		return fmt.Errorf("querying: %w", err)
+		return 0, fmt.Errorf("querying: %w", err)
 	}
 	defer rows.Close()
-	switch outputType {
-	case outputPretty:
-		tWriter := table.NewTableWriter(out)
-		return rowsHandler(rows, func(cols []string) error {
-			icols := make([]interface{}, len(cols))
-			for i, v := range cols {
-				icols[i] = v
-			}
-			return tWriter.WriteHeader(icols...)
-		}, func(row []interface{}) error {
-			return tWriter.Write(row...)
-		})
-	case outputCSV:
-		csvWriter := csv.NewWriter(out)
-		return rowsHandler(rows, func(cols []string) error {
-			if err := csvWriter.Write(cols); err != nil {
-				return err
-			}
-			csvWriter.Flush()
-			return nil
-		}, func(values []interface{}) error {
-			strValues := make([]string, len(values))
-			for i, v := range values {
-				strValues[i] = fmt.Sprint(v)
-			}
-			if err := csvWriter.Write(strValues); err != nil {
-				return err
+	f, err := output.NewFormatter(outputType, out, opts)
+	if err != nil {
+		return 0, err
+	}
+	n, err := rowsHandler(rows, f.WriteHeader, f.WriteRow)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+// sqlTypeNames are the names of the column types, as they are written in the statements
+var sqlTypeNames = map[hzsql.ColumnType]string{
+	hzsql.ColumnTypeVarchar:               "VARCHAR",
+	hzsql.ColumnTypeBoolean:               "BOOLEAN",
+	hzsql.ColumnTypeTinyInt:               "TINYINT",
+	hzsql.ColumnTypeSmallInt:              "SMALLINT",
+	hzsql.ColumnTypeInt:                   "INTEGER",
+	hzsql.ColumnTypeBigInt:                "BIGINT",
+	hzsql.ColumnTypeDecimal:               "DECIMAL",
+	hzsql.ColumnTypeReal:                  "REAL",
+	hzsql.ColumnTypeDouble:                "DOUBLE",
+	hzsql.ColumnTypeDate:                  "DATE",
+	hzsql.ColumnTypeTime:                  "TIME",
+	hzsql.ColumnTypeTimestamp:             "TIMESTAMP",
+	hzsql.ColumnTypeTimestampWithTimeZone: "TIMESTAMP WITH TIME ZONE",
+	hzsql.ColumnTypeObject:                "OBJECT",
+	hzsql.ColumnTypeNull:                  "NULL",
+	hzsql.ColumnTypeJSON:                  "JSON",
+}
+
+func sqlTypeName(t hzsql.ColumnType) string {
+	if n, ok := sqlTypeNames[t]; ok {
+		return n
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", t)
+}
+
+// queryWithTypes runs the query like query does, with the column types in the header.
+// It runs the query on the SQL service of the client, since the SQL driver does not report the column types.
+func queryWithTypes(ctx context.Context, ci *hazelcast.Client, text string, out io.Writer, outputType string, opts output.Options, args ...interface{}) (int, error) {
+	ctx, cancel := internal.SQLContext(ctx)
+	defer cancel()
+	res, err := ci.SQL().Execute(ctx, text, args...)
+	if err != nil {
+		return 0, fmt.Errorf("querying: %w", err)
+	}
+	defer res.Close()
+	md, err := res.RowMetadata()
+	if err != nil {
+		return 0, fmt.Errorf("retrieving columns: %w", err)
+	}
+	cols := md.Columns()
+	names := make([]string, len(cols))
+	types := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.Name()
+		types[i] = sqlTypeName(c.Type())
+	}
+	f, err := output.NewFormatter(outputType, out, opts)
+	if err != nil {
+		return 0, err
+	}
+	n, err := typedRowsHandler(res, names, types, f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+// typedRowsHandler writes the typed header and the rows of the result to the formatter.
+// Returns the number of rows written.
+func typedRowsHandler(res hzsql.Result, names, types []string, f output.Formatter) (int, error) {
+	if err := output.WriteTypedHeader(f, names, types); err != nil {
+		return 0, err
+	}
+	it, err := res.Iterator()
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	for it.HasNext() {
+		row, err := it.Next()
+		if err != nil {
+			return n, fmt.Errorf("scanning row: %w", err)
+		}
+		values := make([]interface{}, len(names))
+		for i := range values {
+			if values[i], err = row.Get(i); err != nil {
+				return n, fmt.Errorf("scanning row: %w", err)
 			}
-			csvWriter.Flush()
-			return nil
-		})
+		}
+		if err := f.WriteRow(values); err != nil {
+			return n, err
+		}
+		n++
 	}
-	return nil
+	return n, nil
+}
+
+// QueryJSON runs the query and writes each row to out as a JSON object on its own line.
+// It returns the number of rows.
+func QueryJSON(ctx context.Context, d *sql.DB, text string, out io.Writer, opts output.Options, args ...interface{}) (int, error) {
+	return query(ctx, d, text, out, outputJSON, opts, args...)
 }
 
 // Reads columns and rows calls handlers. rowHandler is called per row.
-func rowsHandler(rows *sql.Rows, columnHandler func(cols []string) error, rowHandler func([]interface{}) error) error {
+// Returns the number of rows handled.
+func rowsHandler(rows *sql.Rows, columnHandler func(cols []string) error, rowHandler func([]interface{}) error) (int, error) {
 	cols, err := rows.Columns()
 	if err != nil {
-		return fmt.Errorf("retrieving columns: %w", err)
+		return 0, fmt.Errorf("retrieving columns: %w", err)
 	}
 	if err = columnHandler(cols); err != nil {
-		return err
+		return 0, err
 	}
+	var n int
 	emptyRow := make([]interface{}, len(cols))
 	for i := 0; i < len(cols); i++ {
 		emptyRow[i] = new(interface{})
@@ -84,20 +164,25 @@ func rowsHandler(rows *sql.Rows, columnHandler func(cols []string) error, rowHan
 		row := make([]interface{}, len(emptyRow))
 		copy(row, emptyRow)
 		if err := rows.Scan(row...); err != nil {
-			return fmt.Errorf("scanning row: %w", err)
+			return n, fmt.Errorf("scanning row: %w", err)
 		}
 		for i := range row {
 			row[i] = *(row[i].(*interface{}))
 		}
 		if err := rowHandler(row); err != nil {
-			return err
+			return n, err
 		}
+		n++
 	}
-	return nil
+	return n, rows.Err()
 }
 
-func execute(ctx context.Context, d *sql.DB, text string) error {
-	r, err := d.ExecContext(ctx, text)
+// execute runs the statement and writes the number of affected rows to out.
+// The elapsed time since start is written as well if timing is enabled.
+func execute(ctx context.Context, d *sql.DB, text string, out io.Writer, start time.Time, timing bool, args ...interface{}) error {
+	ctx, cancel := internal.SQLContext(ctx)
+	defer cancel()
+	r, err := d.ExecContext(ctx, text, args...)
 	if err != nil {
 		return fmt.Errorf("executing: %w", err)
 	}
@@ -105,6 +190,24 @@ func execute(ctx context.Context, d *sql.DB, text string) error {
 	if err != nil {
 		return nil
 	}
-	fmt.Printf("---\nAffected rows: %d\n\n", ra)
+	fmt.Fprintf(out, "---\nAffected rows: %d\n", ra)
+	if timing {
+		printElapsedTime(out, time.Since(start))
+	}
+	fmt.Fprintln(out)
 	return nil
 }
+
+// printQueryFooter writes the number of returned rows to out, and the elapsed time if timing is enabled.
+// The Go client does not report the execution time on the cluster, so it is measured on the client side.
+func printQueryFooter(out io.Writer, rows int, elapsed time.Duration, timing bool) {
+	fmt.Fprintf(out, "---\nReturned rows: %d\n", rows)
+	if timing {
+		printElapsedTime(out, elapsed)
+	}
+	fmt.Fprintln(out)
+}
+
+func printElapsedTime(out io.Writer, elapsed time.Duration) {
+	fmt.Fprintf(out, "Elapsed time: %s\n", elapsed.Round(time.Microsecond))
+}
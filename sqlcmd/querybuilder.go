@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/browser"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const queryBuilderExample = `  # Build a SELECT statement and print it
+  hzc query-builder
+  # Build a SELECT statement and run it, printing the results as CSV
+  hzc query-builder --execute -o csv`
+
+func NewQueryBuilder(cnfg *config.Config) *cobra.Command {
+	var (
+		outputType string
+		execute    bool
+	)
+	opts := output.DefaultOptions()
+	cmd := &cobra.Command{
+		Use:   "query-builder [--execute]",
+		Short: "Build a SELECT statement step by step in a form",
+		Long: `Build a SELECT statement by selecting a mapping, its columns, the filter, the sort order and the limit in a form, such as to learn SQL.
+The statement is printed, or run with --execute.`,
+		Example: queryBuilderExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(cmd, cnfg, &outputType, opts); err != nil {
+				return err
+			}
+			if f, ok := cmd.InOrStdin().(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+				return hzcerrors.NewLoggableError(nil, "The query builder requires a terminal")
+			}
+			ctx := cmd.Context()
+			driver, err := internal.SQLDriver(ctx, &cnfg.Hazelcast)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+			}
+			q, err := browser.RunQueryBuilder(browser.QueryBuilderSource{
+				Mappings: schemaSource(ctx, driver).Mappings,
+				Columns: func(mapping string) ([]string, error) {
+					r, err := fetchResult(ctx, driver, describeMappingQuery, mapping)
+					if err != nil {
+						return nil, err
+					}
+					return firstColumn(r), nil
+				},
+			})
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Could not run the query builder")
+			}
+			if q == "" {
+				quiet.Println(cmd, "No statement was built")
+				return nil
+			}
+			if !execute {
+				fmt.Fprintln(cmd.OutOrStdout(), q)
+				return nil
+			}
+			quiet.Println(cmd, q)
+			return runStatement(cmd, cnfg, q, outputType, opts)
+		},
+	}
+	decorateCommandWithOutputFlag(&outputType, cmd)
+	decorateCommandWithOutputOptionFlags(&opts, cmd)
+	cmd.Flags().BoolVar(&execute, "execute", false, "run the statement instead of printing it")
+	return cmd
+}
+
+// schemaSource lists the mappings and their columns with their types for the schema browser of the SQL browser.
+func schemaSource(ctx context.Context, driver *sql.DB) browser.SchemaSource {
+	return browser.SchemaSource{
+		Mappings: func() ([]string, error) {
+			r, err := fetchResult(ctx, driver, "SHOW MAPPINGS")
+			if err != nil {
+				return nil, err
+			}
+			return firstColumn(r), nil
+		},
+		Columns: func(mapping string) ([]string, error) {
+			r, err := fetchResult(ctx, driver, describeMappingQuery, mapping)
+			if err != nil {
+				return nil, err
+			}
+			columns := make([]string, len(r.rows))
+			for i, row := range r.rows {
+				columns[i] = fmt.Sprintf("%s %s", output.String(row[0]), output.String(row[1]))
+			}
+			return columns, nil
+		},
+	}
+}
+
+// firstColumn returns the values of the first column of the result as strings.
+func firstColumn(r queryResult) []string {
+	values := make([]string, len(r.rows))
+	for i, row := range r.rows {
+		values[i] = output.String(row[0])
+	}
+	return values
+}
@@ -17,73 +17,281 @@ package sqlcmd
 
 import (
 	"fmt"
+	"io/ioutil"
 	"strings"
+	"time"
 
+	"github.com/hazelcast/hazelcast-commandline-client/config"
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/browser"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/readonly"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/transaction"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
 
-	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/spf13/cobra"
 )
 
 const (
-	outputPretty = "pretty"
-	outputCSV    = "csv"
+	outputPretty   = output.TypePretty
+	outputCSV      = output.TypeCSV
+	outputJSON     = output.TypeJSON
+	outputVertical = output.TypeVertical
+	outputTemplate = output.TypeTemplate
 )
 
-func New(config *hazelcast.Config) *cobra.Command {
-	var outputType string
+var outputTypes = output.Types
+
+func New(cnfg *config.Config) *cobra.Command {
+	config := &cnfg.Hazelcast
+	var (
+		outputType string
+		chart      chartOptions
+		noMouse    bool
+		pinColumns int
+	)
+	opts := output.DefaultOptions()
 	cmd := &cobra.Command{
-		Use:   "sql [query]",
+		Use:   "sql [query] [--chart bar|line [--x column] [--y column]]",
 		Short: "Start SQL Browser or execute given SQL query",
 		Example: `sql 	# starts the SQL Browser
-sql "CREATE MAPPING IF NOT EXISTS myMap (__key VARCHAR, this VARCHAR) TYPE IMAP OPTIONS ( 'keyFormat' = 'varchar', 'valueFormat' = 'varchar')" 	# executes the query`,
+sql "CREATE MAPPING IF NOT EXISTS myMap (__key VARCHAR, this VARCHAR) TYPE IMAP OPTIONS ( 'keyFormat' = 'varchar', 'valueFormat' = 'varchar')" 	# executes the query
+sql "SELECT city, COUNT(*) AS n FROM employees GROUP BY city" --chart bar 	# draws a bar for each city`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if outputType != outputPretty && outputType != outputCSV {
-				return hzcerrors.NewLoggableError(nil,
-					"Provided output type parameter (%s) is not a known type. Provide either '%s' or '%s'",
-					outputType, outputPretty, outputCSV)
+			if err := validateOutput(cmd, cnfg, &outputType, opts); err != nil {
+				return err
+			}
+			if err := chart.validate(); err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid chart options")
+			}
+			q := strings.TrimSpace(strings.Join(args, " "))
+			if chart.kind != "" {
+				if q == "" {
+					return hzcerrors.NewLoggableError(nil, "A query is required for --chart")
+				}
+				return runChart(cmd, cnfg, q, chart, opts)
+			}
+			if len(q) > 0 {
+				// If a statement is provided, run it in non-interactive mode
+				return runStatement(cmd, cnfg, q, outputType, opts)
+			}
+			if watch.Interval(cmd) > 0 {
+				return hzcerrors.NewLoggableError(nil, "Only SELECT and SHOW statements can be watched")
+			}
+			if dryrun.Enabled(cmd) {
+				return hzcerrors.NewLoggableError(nil, "A statement is required for --%s", dryrun.Flag)
+			}
+			if pinColumns < 0 {
+				return hzcerrors.NewLoggableError(nil, "The number of the pinned columns cannot be negative: %d", pinColumns)
 			}
-			ctx := cmd.Context()
 			//todo create driver from existing client
-			driver, err := internal.SQLDriver(ctx, config)
+			driver, err := internal.SQLDriver(cmd.Context(), config)
 			if err != nil {
 				return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
 			}
-			q := strings.Join(args, " ")
-			q = strings.TrimSpace(q)
-			if len(q) == 0 {
-				// If no queries given, run sql browser
-				p := browser.InitSQLBrowser(driver)
-				if err := p.Start(); err != nil {
-					fmt.Println("could not run sql browser:", err)
-					return err
-				}
-				return nil
-			}
-			// If a statement is provided, run it in non-interactive mode
-			lt := strings.ToLower(q)
-			if strings.HasPrefix(lt, "select") || strings.HasPrefix(lt, "show") {
-				if err := query(ctx, driver, q, cmd.OutOrStdout(), outputType); err != nil {
-					return hzcerrors.NewLoggableError(err, "Cannot execute the query")
-				}
-			} else {
-				if err := execute(ctx, driver, q); err != nil {
-					return hzcerrors.NewLoggableError(err, "Cannot execute the query")
-				}
+			// If no queries given, run sql browser
+			p := browser.InitSQLBrowser(driver, readonly.Enabled(cmd, cnfg), schemaSource(cmd.Context(), driver), !noMouse, pinColumns)
+			if err := p.Start(); err != nil {
+				fmt.Println("could not run sql browser:", err)
+				return err
 			}
 			return nil
 		},
 	}
 	decorateCommandWithOutputFlag(&outputType, cmd)
-	return cmd
+	decorateCommandWithOutputOptionFlags(&opts, cmd)
+	cmd.Flags().BoolVar(&opts.ShowTypes, "show-types", false, "show the SQL types of the columns in the header, JSON output writes them in a line before the rows")
+	decorateCommandWithChartFlags(&chart, cmd)
+	cmd.Flags().BoolVar(&noMouse, "no-mouse", false, "do not capture the mouse in the SQL browser, so that the terminal selects the text itself")
+	cmd.Flags().IntVar(&pinColumns, "pin-columns", 0, "number of the leftmost columns of the SQL browser results which stay visible while scrolling the columns, such as 1 for the key column")
+	cmd.AddCommand(NewGenerateMapping(config), NewKafkaMapping(cnfg), NewFileMapping(cnfg), NewPreviewFile(cnfg), NewDiff(cnfg), NewSummarize(cnfg), NewFmt())
+	return transaction.Supported(readonly.ChecksItself(dryrun.Supported(watch.Watchable(cmd))))
+}
+
+// validateOutput checks the output flags, the output type is set to template if a template is given
+// and to vertical if the expanded display is enabled, unless the output type is set explicitly.
+func validateOutput(cmd *cobra.Command, cnfg *config.Config, outputType *string, opts output.Options) error {
+	if !cmd.Flags().Changed("output-type") {
+		if opts.Template != "" {
+			*outputType = outputTemplate
+		} else if cnfg.SQL.Expanded {
+			*outputType = outputVertical
+		}
+	}
+	if !isKnownOutputType(*outputType) {
+		return hzcerrors.NewLoggableError(nil,
+			"Provided output type parameter (%s) is not a known type. Provide one of %s",
+			*outputType, strings.Join(outputTypes, ", "))
+	}
+	if *outputType == outputTemplate && opts.Template == "" {
+		return hzcerrors.NewLoggableError(nil, "Template output requires a template, set it with --template")
+	}
+	if err := opts.Validate(); err != nil {
+		return hzcerrors.NewLoggableError(err, "Invalid output options")
+	}
+	return nil
+}
+
+// runStatement runs the statement with the given parameters and writes its results to the output of the command.
+// It handles the watch, read-only, dry run and quiet modes of the command, only the queries are run in a transaction block.
+func runStatement(cmd *cobra.Command, cnfg *config.Config, q, outputType string, opts output.Options, args ...interface{}) error {
+	if name, ok := parseDescribe(q); ok {
+		// describing only reads information_schema, so it is not affected by the dry run, read-only and transaction modes
+		opts.NoHeader = quiet.Enabled(cmd)
+		return printMappingDescription(cmd.Context(), cnfg, name, cmd.OutOrStdout(), outputType, opts)
+	}
+	isQuery := IsQuery(q)
+	if watch.Interval(cmd) > 0 && !isQuery {
+		return hzcerrors.NewLoggableError(nil, "Only SELECT and SHOW statements can be watched")
+	}
+	if dryrun.Enabled(cmd) && !isQuery {
+		// queries do not change the data, so they are run as usual
+		if len(args) > 0 {
+			dryrun.Print(cmd, "execute: %s with parameters: %s", q, formatParams(args))
+			return nil
+		}
+		dryrun.Print(cmd, "execute: %s", q)
+		return nil
+	}
+	if readonly.Enabled(cmd, cnfg) && !isQuery {
+		return readonly.Error("The statement")
+	}
+	if transaction.Active(cmd.Context()) && !isQuery {
+		return transaction.Error("The statement")
+	}
+	isQuiet := quiet.Enabled(cmd)
+	opts.NoHeader = isQuiet
+	if isQuery {
+		return runQuery(cmd, cnfg, q, outputType, opts, args...)
+	}
+	ctx := cmd.Context()
+	//todo create driver from existing client
+	driver, err := internal.SQLDriver(ctx, &cnfg.Hazelcast)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+	}
+	start := time.Now()
+	out := cmd.OutOrStdout()
+	if isQuiet {
+		// the number of affected rows is the only output
+		out = ioutil.Discard
+	}
+	if err := execute(ctx, driver, q, out, start, cnfg.SQL.Timing, args...); err != nil {
+		return hzcerrors.NewLoggableError(err, "Cannot execute the query")
+	}
+	return nil
+}
+
+// runQuery runs the query and writes its rows to the output of the command, followed by the footer for the human-readable output types.
+// The query is run on the client instead of the SQL driver if the column types are shown, since the driver does not report them.
+func runQuery(cmd *cobra.Command, cnfg *config.Config, q, outputType string, opts output.Options, args ...interface{}) error {
+	ctx := cmd.Context()
+	out := cmd.OutOrStdout()
+	var n int
+	var start time.Time
+	if opts.ShowTypes {
+		ci, err := internal.ConnectToCluster(ctx, &cnfg.Hazelcast)
+		if err != nil {
+			return err
+		}
+		start = time.Now()
+		n, err = queryWithTypes(ctx, ci, q, out, outputType, opts, args...)
+		if err != nil {
+			return hzcerrors.NewLoggableError(err, "Cannot execute the query")
+		}
+	} else {
+		//todo create driver from existing client
+		driver, err := internal.SQLDriver(ctx, &cnfg.Hazelcast)
+		if err != nil {
+			return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+		}
+		start = time.Now()
+		n, err = query(ctx, driver, q, out, outputType, opts, args...)
+		if err != nil {
+			return hzcerrors.NewLoggableError(err, "Cannot execute the query")
+		}
+	}
+	if !opts.NoHeader && (outputType == outputPretty || outputType == outputVertical) {
+		printQueryFooter(out, n, time.Since(start), cnfg.SQL.Timing)
+	}
+	return nil
+}
+
+// IsQuery returns true if the statement only reads data, that is a SELECT or SHOW statement.
+func IsQuery(q string) bool {
+	lt := strings.ToLower(strings.TrimSpace(q))
+	return strings.HasPrefix(lt, "select") || strings.HasPrefix(lt, "show")
+}
+
+func formatParams(args []interface{}) string {
+	params := make([]string, len(args))
+	for i, a := range args {
+		params[i] = output.String(a)
+	}
+	return strings.Join(params, ", ")
+}
+
+func isKnownOutputType(outputType string) bool {
+	for _, t := range outputTypes {
+		if t == outputType {
+			return true
+		}
+	}
+	return false
 }
 
 func decorateCommandWithOutputFlag(outputType *string, cmd *cobra.Command) {
 	flags := cmd.Flags()
-	flags.StringVarP(outputType, "output-type", "o", outputPretty, fmt.Sprintf("%s or %s", outputPretty, outputCSV))
+	flags.StringVarP(outputType, "output-type", "o", outputPretty, strings.Join(outputTypes, ", "))
+	defaults.SetValues(cmd, "output-type", outputTypes)
 	cmd.RegisterFlagCompletionFunc("output-type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{outputPretty, outputCSV}, cobra.ShellCompDirectiveDefault
+		return outputTypes, cobra.ShellCompDirectiveDefault
+	})
+}
+
+func decorateCommandWithOutputOptionFlags(opts *output.Options, cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Null, "null-string", opts.Null, "string shown for NULL values, JSON output always uses null")
+	flags.IntVar(&opts.MaxWidth, "max-column-width", opts.MaxWidth, "maximum number of characters shown for a value, 0 means no limit")
+	flags.StringVar(&opts.Binary, "binary-format", opts.Binary, fmt.Sprintf("rendering of binary values: %s", strings.Join(output.BinaryFormats, ", ")))
+	flags.StringVar(&opts.Template, "template", opts.Template, `Go template applied to each row in the template output, such as '{{.name}} is {{.age}}'`)
+	flags.StringVar(&opts.TimeFormat, "time-format", opts.TimeFormat, fmt.Sprintf("rendering of date and time values: %s or a Go time layout such as '2006-01-02 15:04'", strings.Join(output.TimeFormats, ", ")))
+	flags.Var(&timeZoneValue{loc: &opts.TimeZone}, "time-zone", "time zone the TIMESTAMP WITH TIME ZONE values are converted to, such as UTC or Europe/Istanbul")
+	flags.StringVar(&opts.DecimalSeparator, "decimal-separator", opts.DecimalSeparator, "decimal separator of the numbers, JSON output always uses '.'")
+	flags.StringVar(&opts.ThousandsSeparator, "thousands-separator", opts.ThousandsSeparator, "separator which groups the digits of the numbers by three, JSON output never groups them")
+	cmd.RegisterFlagCompletionFunc("binary-format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return output.BinaryFormats, cobra.ShellCompDirectiveDefault
+	})
+	cmd.RegisterFlagCompletionFunc("time-format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return output.TimeFormats, cobra.ShellCompDirectiveDefault
 	})
 }
+
+// timeZoneValue is the flag value of a time zone, which is loaded when the flag is set so that an unknown zone is a flag error.
+type timeZoneValue struct {
+	loc **time.Location
+}
+
+func (v *timeZoneValue) String() string {
+	if *v.loc == nil {
+		return ""
+	}
+	return (*v.loc).String()
+}
+
+func (v *timeZoneValue) Set(s string) error {
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return fmt.Errorf("unknown time zone %s", s)
+	}
+	*v.loc = loc
+	return nil
+}
+
+func (v *timeZoneValue) Type() string {
+	return "zone"
+}
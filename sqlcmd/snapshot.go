@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+const metaSnapshotUsage = `\snapshot {take NAME QUERY | diff NAME [KEY,...] | list}`
+
+// resultSnapshot is the result of a query kept by \snapshot take, to compare the result of running the query again with it.
+type resultSnapshot struct {
+	query  string
+	result queryResult
+	taken  time.Time
+}
+
+// resultSnapshots are the snapshots of the session by their names, they are kept in memory until the shell exits.
+type resultSnapshots map[string]resultSnapshot
+
+type fetchFunc func(q string) (queryResult, error)
+
+// take runs the query and keeps its result with the name, replacing the snapshot with the same name.
+func (ss resultSnapshots) take(name, q string, fetch fetchFunc) (resultSnapshot, error) {
+	if !IsQuery(q) {
+		return resultSnapshot{}, hzcerrors.NewLoggableError(nil, "Only the results of SELECT and SHOW statements can be kept")
+	}
+	r, err := fetch(q)
+	if err != nil {
+		return resultSnapshot{}, hzcerrors.NewLoggableError(err, "Cannot execute the query")
+	}
+	snap := resultSnapshot{query: q, result: r, taken: time.Now()}
+	ss[name] = snap
+	return snap, nil
+}
+
+// diff runs the query of the snapshot again and compares its result with the snapshot, the snapshot is not changed.
+func (ss resultSnapshots) diff(name string, keys []string, fetch fetchFunc) (resultSnapshot, resultDiff, error) {
+	snap, ok := ss[name]
+	if !ok {
+		return resultSnapshot{}, resultDiff{}, hzcerrors.NewLoggableError(nil, `There is no snapshot %s, take one with \snapshot take NAME QUERY`, name)
+	}
+	r, err := fetch(snap.query)
+	if err != nil {
+		return resultSnapshot{}, resultDiff{}, hzcerrors.NewLoggableError(err, "Cannot execute the query")
+	}
+	d, err := diffResults(snap.result, r, keys)
+	if err != nil {
+		return resultSnapshot{}, resultDiff{}, hzcerrors.NewLoggableError(err, "Cannot compare the results")
+	}
+	return snap, d, nil
+}
+
+func (ss resultSnapshots) names() []string {
+	names := make([]string, 0, len(ss))
+	for name := range ss {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// snapshot runs the \snapshot meta-command, the query of take is the rest of the input taken verbatim.
+func (s *MetaCommandSession) snapshot(ctx context.Context, in string, out io.Writer) error {
+	sub, rest := splitMetaCommand(in)
+	name, rest := splitMetaCommand(rest)
+	fetch := func(q string) (queryResult, error) {
+		driver, err := internal.SQLDriver(ctx, &s.config.Hazelcast)
+		if err != nil {
+			return queryResult{}, err
+		}
+		return fetchResult(ctx, driver, q)
+	}
+	switch sub {
+	case "take":
+		if name == "" || rest == "" {
+			return hzcerrors.NewLoggableError(nil, `Provide the name and the query: \snapshot take NAME QUERY`)
+		}
+		snap, err := s.snapshots.take(name, rest, fetch)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Took snapshot %s with %d row(s)\n", name, len(snap.result.rows))
+		return nil
+	case "diff":
+		if name == "" {
+			return hzcerrors.NewLoggableError(nil, `Provide the name of the snapshot: \snapshot diff NAME [KEY,...]`)
+		}
+		keys := strings.FieldsFunc(rest, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+		snap, d, err := s.snapshots.diff(name, keys, fetch)
+		if err != nil {
+			return err
+		}
+		if err := writeDiff(out, s.outputType(), output.DefaultOptions(), snap.result.columns, d.rows); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "---\n%d added, %d removed, %d changed, %d same since %s\n",
+			d.count(rowAdded), d.count(rowRemoved), d.count(rowChanged), d.same, snap.taken.Format("15:04:05"))
+		return nil
+	case "list":
+		for _, n := range s.snapshots.names() {
+			snap := s.snapshots[n]
+			fmt.Fprintf(out, "%s\t%s\t%d row(s)\t%s\n", n, snap.taken.Format("15:04:05"), len(snap.result.rows), strings.Join(strings.Fields(snap.query), " "))
+		}
+		return nil
+	}
+	return hzcerrors.NewLoggableError(nil, "Provide one of: %s", metaSnapshotUsage)
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestResultSnapshots(t *testing.T) {
+	results := []queryResult{
+		{columns: []string{"name", "count"}, rows: [][]interface{}{{"orders", int64(10)}, {"users", int64(3)}}},
+		{columns: []string{"name", "count"}, rows: [][]interface{}{{"orders", int64(12)}, {"users", int64(3)}, {"carts", int64(1)}}},
+	}
+	var queries []string
+	fetch := func(q string) (queryResult, error) {
+		queries = append(queries, q)
+		return results[len(queries)-1], nil
+	}
+	ss := resultSnapshots{}
+	if _, err := ss.take("counts", "INSERT INTO counts VALUES ('a', 1)", fetch); err == nil {
+		t.Fatal("expected an error for a statement which is not a query")
+	}
+	q := "SELECT name, count FROM counts"
+	if _, err := ss.take("counts", q, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ss.diff("unknown", nil, fetch); err == nil {
+		t.Fatal("expected an error for an unknown snapshot")
+	}
+	_, d, err := ss.diff("counts", []string{"name"}, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(queries, []string{q, q}) {
+		t.Fatalf("unexpected queries: %v", queries)
+	}
+	if d.same != 1 || d.count(rowChanged) != 1 || d.count(rowAdded) != 1 {
+		t.Fatalf("unexpected diff: %+v", d)
+	}
+	// the snapshot is kept as it is taken
+	if got := ss["counts"].result.rows[0][1]; got != int64(10) {
+		t.Fatalf("snapshot changed: %v", got)
+	}
+	fetchErr := func(q string) (queryResult, error) {
+		return queryResult{}, errors.New("not connected")
+	}
+	if _, _, err := ss.diff("counts", nil, fetchErr); err == nil {
+		t.Fatal("expected an error")
+	}
+	if names := ss.names(); !reflect.DeepEqual(names, []string{"counts"}) {
+		t.Fatalf("unexpected names: %v", names)
+	}
+}
@@ -0,0 +1,333 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/readonly"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/transaction"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
+)
+
+const snippetExtension = ".sql"
+
+const snippetExample = `hzc snippet save adults "SELECT name, age FROM employees WHERE age >= :age"
+hzc snippet list
+hzc snippet run adults --param age=18`
+
+var snippetNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// snippetDir is the directory the snippets are stored in, one file for each snippet.
+func snippetDir() string {
+	return filepath.Join(file.HZCHomePath(), "snippets")
+}
+
+func snippetPath(name string) (string, error) {
+	if !snippetNameRegexp.MatchString(name) {
+		return "", hzcerrors.NewLoggableError(nil, "Snippet name %s is not valid, use only letters, digits, '_', '.' and '-'", name)
+	}
+	return filepath.Join(snippetDir(), name+snippetExtension), nil
+}
+
+func NewSnippet(cnfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snippet {save | list | run | delete}",
+		Short:   "Named SQL snippets",
+		Long:    "Save SQL statements with a name and run them later. The statements can have named parameters, such as :age, which are given to the run command.",
+		Example: snippetExample,
+	}
+	cmd.AddCommand(newSnippetSave(), newSnippetList(), newSnippetRun(cnfg), newSnippetDelete())
+	return cmd
+}
+
+func newSnippetSave() *cobra.Command {
+	return &cobra.Command{
+		Use:   "save NAME STATEMENT",
+		Short: "Save the statement with the name, an existing snippet with the same name is replaced",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			path, err := snippetPath(name)
+			if err != nil {
+				return err
+			}
+			stmt := strings.TrimSpace(strings.Join(args[1:], " "))
+			if err := file.CreateMissingDirsAndFileWithRWPerms(path, []byte(stmt+"\n")); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot save snippet %s", name)
+			}
+			return nil
+		},
+	}
+}
+
+func newSnippetList() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the snippets with their statements",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snippets, err := ListSnippets()
+			if err != nil {
+				return err
+			}
+			for _, s := range snippets {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", s.Name, strings.Join(strings.Fields(s.Statement), " "))
+			}
+			return nil
+		},
+	}
+}
+
+func newSnippetRun(cnfg *config.Config) *cobra.Command {
+	var (
+		params     []string
+		outputType string
+	)
+	opts := output.DefaultOptions()
+	cmd := &cobra.Command{
+		Use:               "run NAME [--param name=value]...",
+		Short:             "Run the snippet with the given parameters",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSnippetNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(cmd, cnfg, &outputType, opts); err != nil {
+				return err
+			}
+			stmt, err := loadSnippet(args[0])
+			if err != nil {
+				return err
+			}
+			values, err := parseSnippetParams(params)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid snippet parameter")
+			}
+			q, qArgs, err := bindSnippetParams(stmt, values)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot run snippet %s", args[0])
+			}
+			return runStatement(cmd, cnfg, q, outputType, opts, qArgs...)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&params, "param", "p", nil, "value of a parameter as name=value, numbers and true/false are passed as numbers and booleans, or as name:type=value with a value type such as decimal")
+	decorateCommandWithOutputFlag(&outputType, cmd)
+	decorateCommandWithOutputOptionFlags(&opts, cmd)
+	return transaction.Supported(readonly.ChecksItself(dryrun.Supported(watch.Watchable(cmd))))
+}
+
+func newSnippetDelete() *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete NAME",
+		Short:             "Delete the snippet",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSnippetNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := snippetPath(args[0])
+			if err != nil {
+				return err
+			}
+			if err := os.Remove(path); err != nil {
+				if os.IsNotExist(err) {
+					return hzcerrors.NewLoggableError(nil, "Snippet %s does not exist", args[0])
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot delete snippet %s", args[0])
+			}
+			return nil
+		},
+	}
+}
+
+func completeSnippetNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, _ := snippetNames()
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// Snippet is a saved SQL statement.
+type Snippet struct {
+	Name      string
+	Statement string
+}
+
+// ListSnippets returns the saved snippets in alphabetical order of their names.
+func ListSnippets() ([]Snippet, error) {
+	names, err := snippetNames()
+	if err != nil {
+		return nil, hzcerrors.NewLoggableError(err, "Cannot list the snippets")
+	}
+	snippets := make([]Snippet, 0, len(names))
+	for _, name := range names {
+		stmt, err := loadSnippet(name)
+		if err != nil {
+			return nil, err
+		}
+		snippets = append(snippets, Snippet{Name: name, Statement: stmt})
+	}
+	return snippets, nil
+}
+
+// snippetNames returns the names of the saved snippets in alphabetical order.
+func snippetNames() ([]string, error) {
+	infos, err := ioutil.ReadDir(snippetDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, info := range infos {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), snippetExtension) {
+			names = append(names, strings.TrimSuffix(info.Name(), snippetExtension))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func loadSnippet(name string) (string, error) {
+	path, err := snippetPath(name)
+	if err != nil {
+		return "", err
+	}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", hzcerrors.NewLoggableError(nil, "Snippet %s does not exist, run \"hzc snippet list\" to see the saved ones", name)
+	}
+	if err != nil {
+		return "", hzcerrors.NewLoggableError(err, "Cannot read snippet %s", name)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// parseSnippetParams parses the name=value parameters.
+// The values which are integers, decimals or booleans are converted, so that they can be compared with the numeric and boolean columns.
+// The integers which do not fit in 64 bits are passed as decimals, so that they keep their digits.
+// The type of the value can be given explicitly with name:type=value, using the value types of the map commands.
+func parseSnippetParams(params []string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(params))
+	for _, p := range params {
+		i := strings.Index(p, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("%s is not in name=value form", p)
+		}
+		name, v := p[:i], p[i+1:]
+		if k := strings.Index(name, ":"); k >= 0 {
+			cv, err := internal.ConvertString(v, name[k+1:])
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", name[:k], err)
+			}
+			values[name[:k]] = cv
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err == nil {
+			values[name] = n
+			continue
+		}
+		if errors.Is(err, strconv.ErrRange) {
+			// the integer has only digits, so it is always a valid decimal
+			values[name], _ = internal.ConvertString(v, internal.TypeNameDecimal)
+			continue
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			values[name] = f
+		} else if v == "true" || v == "false" {
+			values[name] = v == "true"
+		} else {
+			values[name] = v
+		}
+	}
+	return values, nil
+}
+
+// bindSnippetParams replaces the named parameters, such as :age, with the positional parameters of the SQL driver
+// and returns the values in the order of the parameters.
+// The colons in the string literals and quoted identifiers are left as they are.
+func bindSnippetParams(stmt string, values map[string]interface{}) (string, []interface{}, error) {
+	var (
+		b       strings.Builder
+		args    []interface{}
+		missing []string
+		quote   rune
+	)
+	runes := []rune(stmt)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ':' && i+1 < len(runes) && isParamStart(runes[i+1]):
+			j := i + 1
+			for j < len(runes) && isParamPart(runes[j]) {
+				j++
+			}
+			name := string(runes[i+1 : j])
+			v, ok := values[name]
+			if !ok && !containsString(missing, name) {
+				missing = append(missing, name)
+			}
+			args = append(args, v)
+			b.WriteRune('?')
+			i = j - 1
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if len(missing) > 0 {
+		return "", nil, fmt.Errorf("missing parameters: %s, provide them with --param name=value", strings.Join(missing, ", "))
+	}
+	return b.String(), args, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isParamStart(r rune) bool {
+	return r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+func isParamPart(r rune) bool {
+	return isParamStart(r) || r >= '0' && r <= '9'
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+func TestBindSnippetParams(t *testing.T) {
+	for _, tc := range []struct {
+		info   string
+		stmt   string
+		values map[string]interface{}
+		want   string
+		args   []interface{}
+		isErr  bool
+	}{
+		{
+			info: "no parameters",
+			stmt: "SELECT * FROM m",
+			want: "SELECT * FROM m",
+		},
+		{
+			info:   "repeated parameter",
+			stmt:   "SELECT * FROM m WHERE age >= :age AND score > :score OR age = :age",
+			values: map[string]interface{}{"age": int64(18), "score": 2.5},
+			want:   "SELECT * FROM m WHERE age >= ? AND score > ? OR age = ?",
+			args:   []interface{}{int64(18), 2.5, int64(18)},
+		},
+		{
+			info:   "colons in literals",
+			stmt:   `SELECT ':x', "a:b" FROM m WHERE k = :k`,
+			values: map[string]interface{}{"k": "k1"},
+			want:   `SELECT ':x', "a:b" FROM m WHERE k = ?`,
+			args:   []interface{}{"k1"},
+		},
+		{
+			info:  "missing parameter",
+			stmt:  "SELECT * FROM m WHERE k = :k",
+			isErr: true,
+		},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			q, args, err := bindSnippetParams(tc.stmt, tc.values)
+			if tc.isErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if q != tc.want {
+				t.Errorf("want %s got %s", tc.want, q)
+			}
+			if !reflect.DeepEqual(tc.args, args) {
+				t.Errorf("want args %v got %v", tc.args, args)
+			}
+		})
+	}
+}
+
+func TestParseSnippetParams(t *testing.T) {
+	values, err := parseSnippetParams([]string{"n=3", "f=1.5", "b=true", "s=hello=world", "e="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"n": int64(3), "f": 1.5, "b": true, "s": "hello=world", "e": ""}
+	if !reflect.DeepEqual(want, values) {
+		t.Errorf("want %v got %v", want, values)
+	}
+	if _, err := parseSnippetParams([]string{"noequals"}); err == nil {
+		t.Error("expected an error")
+	}
+	values, err = parseSnippetParams([]string{"big=123456789012345678901234567890", "price:decimal=0.1", "id:string=42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = map[string]interface{}{
+		"big":   types.NewDecimal(bigInt("123456789012345678901234567890"), 0),
+		"price": types.NewDecimal(big.NewInt(1), 1),
+		"id":    "42",
+	}
+	if !reflect.DeepEqual(want, values) {
+		t.Errorf("want %v got %v", want, values)
+	}
+	if _, err := parseSnippetParams([]string{"n:int8=300"}); err == nil {
+		t.Error("expected an error for a value out of the range of the type")
+	}
+}
+
+func bigInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid integer " + s)
+	}
+	return n
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const summarizeExample = `  # Print the statistics of all the columns of the employees mapping
+  hzc sql summarize employees
+  # Print the statistics of some of the columns as CSV
+  hzc sql summarize employees --columns age,salary -o csv`
+
+// summaryColumns are the columns of the profile table
+var summaryColumns = []string{"column", "type", "count", "nulls", "distinct", "min", "max", "avg"}
+
+// summaryStats are the aggregates run for a column
+type summaryStats struct {
+	distinct bool
+	minMax   bool
+	avg      bool
+}
+
+// statsFor returns the aggregates supported by the SQL type.
+// OBJECT and JSON values can only be counted.
+func statsFor(sqlType string) summaryStats {
+	switch sqlType {
+	case "TINYINT", "SMALLINT", "INTEGER", "BIGINT", "REAL", "DOUBLE", "DECIMAL":
+		return summaryStats{distinct: true, minMax: true, avg: true}
+	case "VARCHAR", "BOOLEAN", "DATE", "TIME", "TIMESTAMP", "TIMESTAMP WITH TIME ZONE":
+		return summaryStats{distinct: true, minMax: true}
+	}
+	return summaryStats{}
+}
+
+func NewSummarize(cnfg *config.Config) *cobra.Command {
+	var (
+		outputType string
+		columns    []string
+	)
+	opts := output.DefaultOptions()
+	cmd := &cobra.Command{
+		Use:   "summarize MAPPING [--columns column,...]",
+		Short: "Print the count, nulls, distinct values, minimum, maximum and average of the columns of a mapping",
+		Long: `Print a profile of the columns of the mapping, computed by a single aggregate query over all of its rows.
+The distinct values are counted exactly, since the SQL engine has no approximate distinct count, which may be slow for large mappings.
+The statistics which are not supported by the type of a column, such as the average of a VARCHAR column, are NULL.`,
+		Example: summarizeExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(cmd, cnfg, &outputType, opts); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			driver, err := internal.SQLDriver(ctx, &cnfg.Hazelcast)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+			}
+			mapping := args[0]
+			r, err := fetchResult(ctx, driver, describeMappingQuery, mapping)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get the columns of mapping %s", mapping)
+			}
+			cols, err := summaryTargets(mapping, r.rows, columns)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid --columns")
+			}
+			r, err = fetchResult(ctx, driver, summaryQuery(mapping, cols))
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot summarize mapping %s", mapping)
+			}
+			if len(r.rows) != 1 {
+				return hzcerrors.NewLoggableError(nil, "Cannot summarize mapping %s: the aggregate query returned %d rows", mapping, len(r.rows))
+			}
+			opts.NoHeader = quiet.Enabled(cmd)
+			return writeSummary(cmd.OutOrStdout(), outputType, opts, cols, r.rows[0])
+		},
+	}
+	decorateCommandWithOutputFlag(&outputType, cmd)
+	decorateCommandWithOutputOptionFlags(&opts, cmd)
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "columns to summarize, all the columns by default")
+	return cmd
+}
+
+// summaryTargets returns the columns to summarize, from the rows of the describe mapping query.
+func summaryTargets(mapping string, described [][]interface{}, names []string) ([]mappingColumn, error) {
+	if len(described) == 0 {
+		return nil, fmt.Errorf("mapping %s does not exist or has no columns", mapping)
+	}
+	all := make([]mappingColumn, len(described))
+	allNames := make([]string, len(described))
+	for i, row := range described {
+		all[i] = mappingColumn{name: output.String(row[0]), sqlType: output.String(row[1])}
+		allNames[i] = all[i].name
+	}
+	if len(names) == 0 {
+		return all, nil
+	}
+	cols := make([]mappingColumn, len(names))
+	for i, n := range names {
+		k := indexOf(allNames, n)
+		if k < 0 {
+			return nil, fmt.Errorf("column %s is not in mapping %s, the columns are %s", n, mapping, strings.Join(allNames, ", "))
+		}
+		cols[i] = all[k]
+	}
+	return cols, nil
+}
+
+// summaryQuery returns the aggregate query of the columns.
+// Its first column is the number of rows, followed by the supported aggregates of each column in the order of summaryColumns.
+func summaryQuery(mapping string, cols []mappingColumn) string {
+	aggs := []string{"COUNT(*)"}
+	for _, c := range cols {
+		name := output.QuoteIdentifier(c.name)
+		stats := statsFor(c.sqlType)
+		aggs = append(aggs, fmt.Sprintf("COUNT(%s)", name))
+		if stats.distinct {
+			aggs = append(aggs, fmt.Sprintf("COUNT(DISTINCT %s)", name))
+		}
+		if stats.minMax {
+			aggs = append(aggs, fmt.Sprintf("MIN(%s)", name), fmt.Sprintf("MAX(%s)", name))
+		}
+		if stats.avg {
+			aggs = append(aggs, fmt.Sprintf("AVG(%s)", name))
+		}
+	}
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(aggs, ", "), output.QuoteIdentifier(mapping))
+}
+
+// summaryRows converts the result of the summary query to the rows of the profile table.
+func summaryRows(cols []mappingColumn, result []interface{}) ([][]interface{}, error) {
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no row count in the summary")
+	}
+	total, ok := result[0].(int64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected row count: %v", result[0])
+	}
+	next := 1
+	take := func(ok bool) interface{} {
+		if !ok {
+			return nil
+		}
+		next++
+		if next > len(result) {
+			return nil
+		}
+		return result[next-1]
+	}
+	rows := make([][]interface{}, len(cols))
+	for i, c := range cols {
+		stats := statsFor(c.sqlType)
+		count := take(true)
+		var nulls interface{}
+		if n, ok := count.(int64); ok {
+			nulls = total - n
+		}
+		distinct := take(stats.distinct)
+		min := take(stats.minMax)
+		max := take(stats.minMax)
+		avg := take(stats.avg)
+		rows[i] = []interface{}{c.name, c.sqlType, count, nulls, distinct, min, max, avg}
+	}
+	if next != len(result) {
+		return nil, fmt.Errorf("the summary has %d values, expected %d", len(result), next)
+	}
+	return rows, nil
+}
+
+func writeSummary(out io.Writer, outputType string, opts output.Options, cols []mappingColumn, result []interface{}) error {
+	rows, err := summaryRows(cols, result)
+	if err != nil {
+		return err
+	}
+	f, err := output.NewFormatter(outputType, out, opts)
+	if err != nil {
+		return err
+	}
+	if err := f.WriteHeader(summaryColumns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := f.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return f.Close()
+}
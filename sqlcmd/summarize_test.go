@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlcmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+func TestSummarize(t *testing.T) {
+	described := [][]interface{}{
+		{"__key", "INTEGER", "YES"},
+		{"name", "VARCHAR", "YES"},
+		{"extra", "OBJECT", "YES"},
+	}
+	cols, err := summaryTargets("employees", described, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := summaryQuery("employees", cols)
+	want := `SELECT COUNT(*), COUNT("__key"), COUNT(DISTINCT "__key"), MIN("__key"), MAX("__key"), AVG("__key"), ` +
+		`COUNT("name"), COUNT(DISTINCT "name"), MIN("name"), MAX("name"), COUNT("extra") FROM "employees"`
+	if q != want {
+		t.Fatalf("unexpected query:\n%s\nwant:\n%s", q, want)
+	}
+	result := []interface{}{int64(3), int64(3), int64(3), int32(1), int32(3), 2.0, int64(2), int64(2), "Jane", "Joe", int64(0)}
+	var b bytes.Buffer
+	if err := writeSummary(&b, outputCSV, output.DefaultOptions(), cols, result); err != nil {
+		t.Fatal(err)
+	}
+	wantOut := `column,type,count,nulls,distinct,min,max,avg
+__key,INTEGER,3,0,3,1,3,2
+name,VARCHAR,2,1,2,Jane,Joe,NULL
+extra,OBJECT,0,3,NULL,NULL,NULL,NULL
+`
+	if b.String() != wantOut {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", b.String(), wantOut)
+	}
+	if _, err := summaryRows(cols, result[:5]); err == nil {
+		t.Fatal("expected an error for a short summary")
+	}
+}
+
+func TestSummaryTargets(t *testing.T) {
+	described := [][]interface{}{
+		{"__key", "INTEGER", "YES"},
+		{"name", "VARCHAR", "YES"},
+	}
+	cols, err := summaryTargets("employees", described, []string{"name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 1 || cols[0].name != "name" || cols[0].sqlType != "VARCHAR" {
+		t.Fatalf("unexpected columns: %+v", cols)
+	}
+	if _, err := summaryTargets("employees", described, []string{"age"}); err == nil {
+		t.Fatal("expected an error for an unknown column")
+	}
+	if _, err := summaryTargets("missing", nil, nil); err == nil {
+		t.Fatal("expected an error for a missing mapping")
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package statscmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/usage"
+)
+
+const StatsLocalExample = `  # Show the 10 commands run the most
+  hzc stats local --top 10`
+
+const StatsClearExample = `  # Remove the recorded usage stats
+  hzc stats clear`
+
+const (
+	topFlag = "top"
+	// maxBarWidth is the width of the bar of the command run the most
+	maxBarWidth = 40
+)
+
+// New creates the stats command, which shows the usage stats recorded on this computer.
+// Nothing is recorded unless usagestats is enabled in the configuration, and the stats are never sent anywhere.
+func New(cnfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "stats {local | clear}",
+		Short:   "Show how often each command is run",
+		Long:    "Show how often each command is run, recorded on this computer if usagestats is enabled in the configuration. Only the names of the commands are recorded, without their arguments, and they are never sent anywhere.",
+		Example: fmt.Sprintf("%s\n%s", StatsLocalExample, StatsClearExample),
+	}
+	cmd.AddCommand(newLocal(cnfg), newClear())
+	return cmd
+}
+
+func newLocal(cnfg *config.Config) *cobra.Command {
+	var top int
+	cmd := &cobra.Command{
+		Use:     "local [--top count]",
+		Short:   "Print the histogram of the commands run the most",
+		Example: StatsLocalExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if top < 0 {
+				return hzcerrors.NewLoggableError(nil, "--%s must not be negative", topFlag)
+			}
+			stats, err := usage.Load(usage.Path())
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot read the usage stats in %s", usage.Path())
+			}
+			if !cnfg.UsageStats {
+				quiet.Println(cmd, "The usage stats are disabled, set usagestats to true in the configuration to record them")
+			}
+			if len(stats.Commands) == 0 {
+				quiet.Println(cmd, "No commands are recorded yet")
+				return nil
+			}
+			quiet.Printf(cmd, "%d command(s) run since %s:\n", stats.Total(), stats.Since.Format("2006-01-02"))
+			counts := stats.Sorted()
+			if top > 0 && top < len(counts) {
+				counts = counts[:top]
+			}
+			writeHistogram(cmd.OutOrStdout(), counts)
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&top, topFlag, 0, "number of the commands to print, 0 prints all of them")
+	return usage.NotRecorded(cmd)
+}
+
+func newClear() *cobra.Command {
+	return usage.NotRecorded(&cobra.Command{
+		Use:     "clear",
+		Short:   "Remove the recorded usage stats",
+		Example: StatsClearExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := usage.Clear(usage.Path()); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot remove the usage stats in %s", usage.Path())
+			}
+			quiet.Println(cmd, "Removed the usage stats")
+			return nil
+		},
+	})
+}
+
+// writeHistogram writes a line for each command with its count and a bar proportional to it, the counts must be sorted.
+func writeHistogram(w io.Writer, counts []usage.CommandCount) {
+	if len(counts) == 0 {
+		return
+	}
+	var width int
+	for _, c := range counts {
+		if len(c.Command) > width {
+			width = len(c.Command)
+		}
+	}
+	most := counts[0].Count
+	for _, c := range counts {
+		bar := c.Count * maxBarWidth / most
+		if bar == 0 {
+			bar = 1
+		}
+		fmt.Fprintf(w, "%-*s  %6d  %s\n", width, c.Command, c.Count, strings.Repeat("#", bar))
+	}
+}
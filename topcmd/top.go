@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package topcmd
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/browser"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/partition"
+)
+
+const TopExample = `  # List the largest maps, refreshed every 3 seconds
+  hzc top --mc-url http://localhost:8080
+  # Refresh every 10 seconds
+  hzc top --interval 10s`
+
+// New creates the top command, which lists the largest maps in a view refreshed at an interval, like the top command of unix.
+func New(cnfg *config.Config) *cobra.Command {
+	var (
+		interval       time.Duration
+		partitionCount int
+	)
+	cmd := &cobra.Command{
+		Use:   "top [--interval duration]",
+		Short: "List the largest maps, refreshed at an interval",
+		Long: `List the maps by their entry counts and estimated heap costs summed over the members, refreshed at an interval, like the top command of unix.
+The statistics of the maps are read through the Management Center REST API, so its URL must be set with --mc-url or in the configuration.
+Press Enter on a map to show the heatmap of its partitions by their entry counts or sizes, which are found from the keys of the map.
+The partitions are found only for the string and integer keys.`,
+		Example: TopExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < time.Second {
+				return hzcerrors.NewLoggableError(nil, "Interval must be at least a second: %s", interval)
+			}
+			if partitionCount <= 0 {
+				return hzcerrors.NewLoggableError(nil, "Partition count must be positive: %d", partitionCount)
+			}
+			if cnfg.ManagementCenter.URL == "" {
+				return hzcerrors.NewLoggableError(nil, "The top view reads the map statistics from the Management Center, set its URL with --mc-url")
+			}
+			if f, ok := cmd.InOrStdin().(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+				return hzcerrors.NewLoggableError(nil, "The top view requires a terminal")
+			}
+			ctx := cmd.Context()
+			client, err := internal.ConnectToCluster(ctx, &cnfg.Hazelcast)
+			if err != nil {
+				return err
+			}
+			src := browser.TopSource{
+				Maps: func() ([]browser.MapUsage, error) {
+					names, err := mapNames(ctx, client)
+					if err != nil {
+						return nil, err
+					}
+					stats, err := internal.GetManagementCenterMapsStats(&cnfg.ManagementCenter, &cnfg.Hazelcast, names)
+					if err != nil {
+						return nil, err
+					}
+					return mapUsages(stats), nil
+				},
+				Partitions: func(mapName string, bySize bool) ([]browser.PartitionUsage, error) {
+					return partitionUsages(ctx, client, mapName, int32(partitionCount), bySize)
+				},
+			}
+			if err := browser.RunTop(src, interval); err != nil {
+				return hzcerrors.NewLoggableError(err, "Could not run the top view")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&interval, "interval", 3*time.Second, "interval to refresh the maps at")
+	cmd.Flags().IntVar(&partitionCount, "partition-count", partition.DefaultCount, "partition count of the cluster, used to find the partitions of the keys in the partition heatmap")
+	return cmd
+}
+
+// mapNames returns the names of the maps, without the internal ones such as the SQL catalog.
+func mapNames(ctx context.Context, client *hazelcast.Client) ([]string, error) {
+	objects, err := client.GetDistributedObjectsInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, o := range objects {
+		if o.ServiceName == hazelcast.ServiceNameMap && !strings.HasPrefix(o.Name, "__") {
+			names = append(names, o.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// mapUsages sums the statistics of each map over the members.
+func mapUsages(stats map[string][]internal.MemberMapStats) []browser.MapUsage {
+	usages := make([]browser.MapUsage, 0, len(stats))
+	for name, ms := range stats {
+		u := browser.MapUsage{Name: name, Members: len(ms)}
+		for _, s := range ms {
+			u.Entries += s.OwnedEntryCount
+			u.BackupEntries += s.BackupEntryCount
+			u.Cost += s.OwnedEntryMemoryCost + s.BackupEntryMemoryCost
+		}
+		usages = append(usages, u)
+	}
+	return usages
+}
+
+// partitionUsages returns the partitions of the map with their entry counts, and their memory costs if bySize is true.
+// The memory costs are read from the entry views, which takes a call for each entry.
+func partitionUsages(ctx context.Context, client *hazelcast.Client, mapName string, partitionCount int32, bySize bool) ([]browser.PartitionUsage, error) {
+	m, err := client.GetMap(ctx, mapName)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := m.GetKeySet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	partitions := make([]browser.PartitionUsage, partitionCount)
+	for i := range partitions {
+		partitions[i].ID = i
+	}
+	for _, k := range keys {
+		id, err := partition.ID(k, partitionCount)
+		if err != nil {
+			return nil, err
+		}
+		p := &partitions[id]
+		p.Entries++
+		// one more key than shown is kept, so that the heatmap knows whether there are more
+		if len(p.Keys) <= browser.MaxPartitionKeys {
+			p.Keys = append(p.Keys, k)
+		}
+		if bySize {
+			ev, err := m.GetEntryView(ctx, k)
+			if err != nil {
+				return nil, err
+			}
+			// the entry may be removed after the keys are read
+			if ev != nil {
+				p.Size += ev.Cost
+			}
+		}
+	}
+	return partitions, nil
+}
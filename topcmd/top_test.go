@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package topcmd
+
+import (
+	"testing"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/browser"
+)
+
+func TestMapUsages(t *testing.T) {
+	got := mapUsages(map[string][]internal.MemberMapStats{
+		"orders": {
+			{Member: "127.0.0.1:5701", OwnedEntryCount: 10, BackupEntryCount: 5, OwnedEntryMemoryCost: 1000, BackupEntryMemoryCost: 500},
+			{Member: "127.0.0.1:5702", OwnedEntryCount: 5, BackupEntryCount: 10, OwnedEntryMemoryCost: 500, BackupEntryMemoryCost: 1000},
+		},
+	})
+	want := browser.MapUsage{Name: "orders", Entries: 15, BackupEntries: 15, Cost: 3000, Members: 2}
+	if len(got) != 1 || got[0] != want {
+		t.Fatalf("want %+v got %+v", want, got)
+	}
+}
@@ -24,14 +24,18 @@ import (
 )
 
 const (
-	messageFmt   = "The support for %s hasn't been implemented yet.\n\nIf you would like us to implement it, please drop by at:\n%v and add a thumbs up %s.\nWe're happy to implement it quickly based on demand!"
-	IssueURLFmt  = "https://github.com/hazelcast/hazelcast-commandline-client/issues/%d"
-	thumbsUpSign = "\U0001F44D"
+	messageFmt = "The support for %s hasn't been implemented yet.\n\nIf you would like us to implement it, please drop by at:\n%v and add a thumbs up %s.\nWe're happy to implement it quickly based on demand!"
+	// unsupportedMessageFmt is the message of the operations which cannot be implemented with the Go client
+	unsupportedMessageFmt = "%s operations are not supported, %s."
+	IssueURLFmt           = "https://github.com/hazelcast/hazelcast-commandline-client/issues/%d"
+	thumbsUpSign          = "\U0001F44D"
 )
 
 type FakeDoor struct {
 	Name     string
 	IssueNum int
+	// Reason is why the operations are not supported, it is set instead of IssueNum if the Go client does not support them
+	Reason string
 }
 
 func NewFakeCommand(fd FakeDoor) *cobra.Command {
@@ -45,6 +49,9 @@ func NewFakeCommand(fd FakeDoor) *cobra.Command {
 }
 
 func newFakeDoorMessage(m FakeDoor) string {
+	if m.Reason != "" {
+		return fmt.Sprintf(unsupportedMessageFmt, m.Name, m.Reason)
+	}
 	issueNum := fmt.Sprintf(IssueURLFmt, m.IssueNum)
 	return fmt.Sprintf(messageFmt, m.Name, issueNum, thumbsUpSign)
 }
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mapcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/aggregate"
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
+)
+
+const MapAggregateExample = `  # Count the entries of the map
+  hzc map aggregate -n mapname --agg count
+  # Average of the age attribute of the values which are older than 30
+  hzc map aggregate -n mapname --agg avg --attribute age --predicate "age > 30"
+  # Sum of the price attribute, which is a double
+  hzc map aggregate -n mapname --agg sum --attribute price --number-type double`
+
+var (
+	aggregationNames = []string{"count", "sum", "avg", "min", "max", "distinct"}
+	numberTypeNames  = []string{"int", "long", "double"}
+)
+
+func NewAggregate(config *hazelcast.Config) *cobra.Command {
+	var (
+		mapName    string
+		agg        string
+		attribute  string
+		numberType string
+		pred       string
+	)
+	cmd := &cobra.Command{
+		Use:   "aggregate [--name mapname] --agg aggregation [--attribute attribute] [--predicate expression]",
+		Short: "Aggregate the entries of the map on the cluster",
+		Long: `Run a built-in aggregation on the entries of the map, filtered by the predicate if given, on the cluster.
+It does not require the SQL engine. The aggregations are on the attribute of the values, or on the values themselves if no attribute is given.
+The sum and the average require the type of the numbers, which must match the type of the attribute on the cluster.`,
+		Example: MapAggregateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a, err := newAggregator(agg, attribute, numberType)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid aggregation")
+			}
+			ctx := cmd.Context()
+			m, err := getMap(ctx, config, mapName)
+			if err != nil {
+				return err
+			}
+			var result interface{}
+			if pred != "" {
+				result, err = m.AggregateWithPredicate(ctx, a, predicate.SQL(pred))
+			} else {
+				result, err = m.Aggregate(ctx, a)
+			}
+			if err != nil {
+				handled, err := isCloudIssue(err, config)
+				if handled {
+					return err
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot aggregate map %s", mapName)
+			}
+			printAggregate(cmd, result)
+			return nil
+		},
+	}
+	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
+	flags := cmd.Flags()
+	flags.StringVar(&agg, "agg", "", fmt.Sprintf("aggregation, one of: %s", strings.Join(aggregationNames, ", ")))
+	if err := cmd.MarkFlagRequired("agg"); err != nil {
+		panic(err)
+	}
+	if err := cmd.RegisterFlagCompletionFunc("agg", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return aggregationNames, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		panic(err)
+	}
+	flags.StringVar(&attribute, "attribute", "", "attribute of the values to aggregate, such as age or __key.id, the values themselves by default")
+	flags.StringVar(&numberType, "number-type", "long", fmt.Sprintf("type of the numbers for sum and avg, one of: %s", strings.Join(numberTypeNames, ", ")))
+	flags.StringVar(&pred, "predicate", "", `filter of the entries in the predicate SQL syntax, such as "active AND age > 30"`)
+	return watch.Watchable(cmd)
+}
+
+func newAggregator(agg, attribute, numberType string) (aggregate.Aggregator, error) {
+	switch agg {
+	case "count":
+		return aggregate.Count(attribute), nil
+	case "min":
+		return aggregate.Min(attribute), nil
+	case "max":
+		return aggregate.Max(attribute), nil
+	case "distinct":
+		return aggregate.DistinctValues(attribute), nil
+	case "sum":
+		switch numberType {
+		case "int":
+			return aggregate.IntSum(attribute), nil
+		case "long":
+			return aggregate.LongSum(attribute), nil
+		case "double":
+			return aggregate.DoubleSum(attribute), nil
+		}
+	case "avg":
+		switch numberType {
+		case "int":
+			return aggregate.IntAverage(attribute), nil
+		case "long":
+			return aggregate.LongAverage(attribute), nil
+		case "double":
+			return aggregate.DoubleAverage(attribute), nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown aggregation %s, the aggregations are %s", agg, strings.Join(aggregationNames, ", "))
+	}
+	return nil, fmt.Errorf("unknown number type %s, the types are %s", numberType, strings.Join(numberTypeNames, ", "))
+}
+
+// printAggregate prints the result of the aggregation, the distinct values are printed one per line.
+func printAggregate(cmd *cobra.Command, result interface{}) {
+	switch v := result.(type) {
+	case nil:
+		quiet.Println(cmd, "The aggregation has no value, since there are no matching entries")
+	case []interface{}:
+		for _, item := range v {
+			fmt.Fprintln(cmd.OutOrStdout(), output.String(item))
+		}
+	default:
+		fmt.Fprintln(cmd.OutOrStdout(), output.String(v))
+	}
+}
@@ -17,10 +17,15 @@
 package mapcmd
 
 import (
+	"fmt"
+
 	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
 	"github.com/spf13/cobra"
 
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/transaction"
 )
 
 const MapClearExample = `  # Clear all entries of given map.
@@ -33,11 +38,23 @@ func NewClear(config *hazelcast.Config) *cobra.Command {
 		Short:   "Clear entries of the map",
 		Example: MapClearExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "clear all entries of map %s", mapName)
+				return nil
+			}
 			var err error
 			m, err := getMap(cmd.Context(), config, mapName)
 			if err != nil {
 				return err
 			}
+			var entries []types.Entry
+			if transaction.Active(cmd.Context()) {
+				// the entries are kept to put them back if the transaction block is rolled back
+				entries, err = m.GetEntrySet(cmd.Context())
+				if err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot get the entries of map %s to roll back clearing it", mapName)
+				}
+			}
 			err = m.Clear(cmd.Context())
 			if err != nil {
 				var handled bool
@@ -47,9 +64,13 @@ func NewClear(config *hazelcast.Config) *cobra.Command {
 				}
 				return hzcerrors.NewLoggableError(err, "Cannot clear map %s", mapName)
 			}
+			// the step is recorded only after the map is cleared, since there is nothing to roll back if clearing fails
+			if transaction.Active(cmd.Context()) {
+				transaction.Record(cmd.Context(), fmt.Sprintf("clear %d entries of map %s", len(entries), mapName), restoreEntries(m, nil, entries))
+			}
 			return nil
 		},
 	}
 	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
-	return cmd
+	return transaction.Supported(dryrun.Supported(cmd))
 }
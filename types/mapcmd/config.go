@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mapcmd
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+const MapConfigExample = `  # Show the configuration of the map in YAML
+  hzc map config --name myMap --mc-url http://localhost:8080
+
+  # Show the configuration of the map in JSON
+  hzc map config --name myMap --mc-url http://localhost:8080 -o json`
+
+// mapConfigTypeYAML is the output type of the map configuration with the names of the member configuration
+const mapConfigTypeYAML = "yaml"
+
+var mapConfigOutputTypes = []string{mapConfigTypeYAML, output.TypeJSON}
+
+func NewConfig(cnfg *config.Config) *cobra.Command {
+	var mapName, outputType string
+	cmd := &cobra.Command{
+		Use:   "config [--name mapname]",
+		Short: "Show the configuration of the map",
+		Long: `Show the configuration of the map on the members, such as the backup counts, the expiration, the in-memory format, the eviction and the indexes.
+The configuration is read through the Management Center REST API, so its URL must be set with --mc-url or in the configuration.`,
+		Example: MapConfigExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isMapConfigOutputType(outputType) {
+				return hzcerrors.NewLoggableError(nil, "Provided output type parameter (%s) is not a known type. Provide either '%s'",
+					outputType, strings.Join(mapConfigOutputTypes, "' or '"))
+			}
+			if cnfg.ManagementCenter.URL == "" {
+				return hzcerrors.NewLoggableError(nil, "The map configuration is read from the Management Center, set its URL with --mc-url")
+			}
+			mc, err := internal.GetManagementCenterMapConfig(&cnfg.ManagementCenter, &cnfg.Hazelcast, mapName)
+			if err != nil {
+				return err
+			}
+			b, err := marshalMapConfig(mc, outputType)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot format the configuration of map %s", mapName)
+			}
+			_, err = cmd.OutOrStdout().Write(b)
+			return err
+		},
+	}
+	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
+	cmd.Flags().StringVarP(&outputType, OutputTypeFlag, "o", mapConfigTypeYAML, strings.Join(mapConfigOutputTypes, ", "))
+	defaults.SetValues(cmd, OutputTypeFlag, mapConfigOutputTypes)
+	if err := cmd.RegisterFlagCompletionFunc(OutputTypeFlag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return mapConfigOutputTypes, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func marshalMapConfig(mc internal.MapConfig, outputType string) ([]byte, error) {
+	if outputType == output.TypeJSON {
+		b, err := json.MarshalIndent(mc, "", "  ")
+		return append(b, '\n'), err
+	}
+	return yaml.Marshal(mc)
+}
+
+func isMapConfigOutputType(outputType string) bool {
+	for _, t := range mapConfigOutputTypes {
+		if t == outputType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mapcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/pipeline"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/progress"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
+)
+
+const MapEntrySetExample = `  # Print all the entries of the map, one entry per line
+  hzc map entry-set -n mapname
+  # Export the entries of a large map as JSON, fetching 8 chunks at once
+  hzc map entry-set -n mapname -o json --parallelism 8 > entries.json
+  # Print the keys and the name attribute of the JSON values
+  hzc map entry-set -n mapname --project __key,name
+  # Print the 10 oldest employees, sorted by the age attribute of the JSON values
+  hzc map entry-set -n employees --order-by age --desc --limit 10`
+
+const (
+	ParallelismFlag = "parallelism"
+	ChunkSizeFlag   = "chunk-size"
+	OrderByFlag     = "order-by"
+	DescFlag        = "desc"
+	LimitFlag       = "limit"
+)
+
+const (
+	defaultEntrySetParallelism = 4
+	defaultEntrySetChunkSize   = 1000
+)
+
+func NewEntrySet(config *hazelcast.Config) *cobra.Command {
+	var (
+		delim,
+		mapName,
+		outputType,
+		tmpl,
+		orderBy,
+		binaryFormat string
+		attributes []string
+		parallelism,
+		chunkSize,
+		limit int
+		desc bool
+	)
+	cmd := &cobra.Command{
+		Use:   "entry-set [--name mapname | --output-type type | --parallelism count | --chunk-size size | --order-by attribute [--desc] | --limit count]",
+		Short: "Print all the entries of the map",
+		Long: `Print all the entries of the map, such as to export them to a file.
+The keys are fetched first, and then the entries are fetched in chunks of keys, with the given number of chunks at once.
+Each chunk is written as soon as it is fetched, so that only the keys and the chunks in flight are kept in memory.
+The entries are not in any particular order, unless they are sorted with --order-by.
+Sorting is done on the client, so all the entries are kept in memory before they are written.`,
+		Example: MapEntrySetExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateGetAllOutput(cmd, &outputType, tmpl); err != nil {
+				return err
+			}
+			if parallelism < 1 || chunkSize < 1 {
+				return hzcerrors.NewLoggableError(nil, "Parallelism and chunk size must be positive")
+			}
+			if err := validateBinaryFormat(binaryFormat, binaryOutputFormats); err != nil {
+				return err
+			}
+			if limit < 0 {
+				return hzcerrors.NewLoggableError(nil, "Limit cannot be negative")
+			}
+			if desc && orderBy == "" {
+				return hzcerrors.NewLoggableError(nil, "--%s requires --%s", DescFlag, OrderByFlag)
+			}
+			ctx := cmd.Context()
+			m, err := getMap(ctx, config, mapName)
+			if err != nil {
+				return err
+			}
+			// the Go client cannot iterate the partitions, so the entries are fetched by the keys
+			keys, err := m.GetKeySet(ctx)
+			if err != nil {
+				if handled, err := isCloudIssue(err, config); handled {
+					return err
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot get the keys of map %s", mapName)
+			}
+			w, err := newEntryWriter(cmd, outputType, delim, entryOutputOptions(tmpl, binaryFormat), attributes)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot output the entries of map %s", mapName)
+			}
+			write := w.write
+			var all []types.Entry
+			if orderBy != "" {
+				write = func(entries []types.Entry) error {
+					all = append(all, entries...)
+					return nil
+				}
+			} else if limit > 0 && limit < len(keys) {
+				// any entries will do, so only the entries of the first keys are fetched
+				keys = keys[:limit]
+			}
+			if err := fetchEntries(ctx, cmd, m, mapName, keys, write, parallelism, chunkSize); err != nil {
+				if handled, err := isCloudIssue(err, config); handled {
+					return err
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot get the entries of map %s", mapName)
+			}
+			if orderBy == "" {
+				return nil
+			}
+			if err := sortEntries(all, orderBy, desc); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot sort the entries of map %s by %s", mapName, orderBy)
+			}
+			if limit > 0 && limit < len(all) {
+				all = all[:limit]
+			}
+			if err := w.write(all); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot output the entries of map %s", mapName)
+			}
+			return nil
+		},
+	}
+	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
+	decorateCommandWithDelimiter(cmd, &delim, false, "delimiter of printed key, value pairs")
+	decorateCommandWithProject(cmd, &attributes)
+	decorateCommandWithTemplate(cmd, &tmpl)
+	decorateCommandWithBinaryFormat(cmd, &binaryFormat, binaryOutputFormats, "rendering of binary values")
+	cmd.Flags().StringVarP(&outputType, OutputTypeFlag, "o", getAllOutputDelimited, strings.Join(getAllOutputTypes, ", "))
+	defaults.SetValues(cmd, OutputTypeFlag, getAllOutputTypes)
+	if err := cmd.RegisterFlagCompletionFunc(OutputTypeFlag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return getAllOutputTypes, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		panic(err)
+	}
+	cmd.Flags().IntVar(&parallelism, ParallelismFlag, defaultEntrySetParallelism, "number of the chunks fetched at once")
+	cmd.Flags().IntVar(&chunkSize, ChunkSizeFlag, defaultEntrySetChunkSize, "number of the entries fetched with each request")
+	cmd.Flags().StringVar(&orderBy, OrderByFlag, "", "attribute to sort the entries by, such as __key, this or age, the attributes are the same as for --project")
+	cmd.Flags().BoolVar(&desc, DescFlag, false, "sort the entries in descending order")
+	cmd.Flags().IntVar(&limit, LimitFlag, 0, "maximum number of the entries printed, 0 means no limit")
+	return watch.Watchable(cmd)
+}
+
+// fetchEntries fetches the entries of the keys in chunks, with at most parallelism chunks in flight, and writes each chunk when it is fetched.
+func fetchEntries(ctx context.Context, cmd *cobra.Command, m *hazelcast.Map, mapName string, keys []interface{}, write func([]types.Entry) error, parallelism, chunkSize int) error {
+	tracker := progress.New(cmd.ErrOrStderr(), fmt.Sprintf("Fetching the entries of map %s", mapName), int64(len(keys)))
+	defer tracker.Done()
+	// the chunks are written one at a time, so that the lines of the entries are not mixed
+	var mu sync.Mutex
+	p := pipeline.New(ctx, parallelism)
+	for len(keys) > 0 {
+		n := chunkSize
+		if n > len(keys) {
+			n = len(keys)
+		}
+		chunk := keys[:n]
+		p.Go(func(ctx context.Context) error {
+			entries, err := m.GetAll(ctx, chunk...)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err := write(entries); err != nil {
+				return err
+			}
+			tracker.Add(int64(len(chunk)))
+			return nil
+		})
+		keys = keys[n:]
+	}
+	_, err := p.Wait()
+	return err
+}
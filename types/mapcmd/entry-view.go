@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mapcmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
+)
+
+const MapEntryViewExample = `  # Show the metadata of the entry, such as its creation time, hits and the remaining time to live
+  hzc map entry-view --name myMap --key k1
+  # Show the metadata as JSON
+  hzc map entry-view --name myMap --key-type int32 --key 2012 -o json`
+
+// entryViewNotSet is shown for the times which have not happened yet and the limits which are not set
+const entryViewNotSet = "-"
+
+var entryViewOutputTypes = []string{output.TypeVertical, output.TypePretty, output.TypeCSV, output.TypeJSON}
+
+func NewEntryView(config *hazelcast.Config) *cobra.Command {
+	var mapName, mapKey, mapKeyType, binaryFormat, outputType string
+	cmd := &cobra.Command{
+		Use:   "entry-view [--name mapname | --key keyname]",
+		Short: "Show the metadata of the entry, such as its access times and expiration",
+		Long: `Show the metadata of the entry: its creation, last access, last update and last store times, hits, version, cost in bytes,
+time to live, maximum idle time and the time remaining until it expires. The times and limits which are not set are shown as "-".`,
+		Example: MapEntryViewExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isEntryViewOutputType(outputType) {
+				return hzcerrors.NewLoggableError(nil, "Provided output type parameter (%s) is not a known type. Provide either '%s'",
+					outputType, strings.Join(entryViewOutputTypes, "' or '"))
+			}
+			if err := validateBinaryFormat(binaryFormat, binaryOutputFormats); err != nil {
+				return err
+			}
+			key, err := internal.ConvertString(mapKey, mapKeyType)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Conversion error on key %s to type %s", mapKey, mapKeyType)
+			}
+			m, err := getMap(cmd.Context(), config, mapName)
+			if err != nil {
+				return err
+			}
+			ev, err := m.GetEntryView(cmd.Context(), key)
+			if err != nil {
+				var handled bool
+				handled, err = isCloudIssue(err, config)
+				if handled {
+					return err
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot get the entry view for key %s from map %s", mapKey, mapName)
+			}
+			if ev == nil {
+				// print nothing in the quiet mode, as map get does for the missing values
+				if !quiet.Enabled(cmd) {
+					fmt.Fprintln(cmd.OutOrStdout(), "There is no entry corresponding to the provided key")
+				}
+				return nil
+			}
+			opts := output.DefaultOptions()
+			opts.Null = entryViewNotSet
+			opts.Binary = binaryFormat
+			f, err := output.NewFormatter(outputType, cmd.OutOrStdout(), opts)
+			if err != nil {
+				return err
+			}
+			names, values := entryViewFields(ev, time.Now())
+			if err := f.WriteHeader(names); err != nil {
+				return err
+			}
+			if err := f.WriteRow(values); err != nil {
+				return err
+			}
+			return f.Close()
+		},
+	}
+	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
+	decorateCommandWithMapKeyFlags(cmd, &mapKey, true, "key of the entry")
+	decorateCommandWithMapKeyTypeFlags(cmd, &mapKeyType, false)
+	decorateCommandWithBinaryFormat(cmd, &binaryFormat, binaryOutputFormats, "rendering of binary keys and values")
+	cmd.Flags().StringVarP(&outputType, OutputTypeFlag, "o", output.TypeVertical, strings.Join(entryViewOutputTypes, ", "))
+	defaults.SetValues(cmd, OutputTypeFlag, entryViewOutputTypes)
+	if err := cmd.RegisterFlagCompletionFunc(OutputTypeFlag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return entryViewOutputTypes, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		panic(err)
+	}
+	return watch.Watchable(cmd)
+}
+
+func isEntryViewOutputType(outputType string) bool {
+	for _, t := range entryViewOutputTypes {
+		if t == outputType {
+			return true
+		}
+	}
+	return false
+}
+
+// entryViewFields returns the names and the values of the entry view fields, the values which are not set are nil.
+// The times of the member are in milliseconds since the epoch, zero means the event has not happened and
+// math.MaxInt64 means there is no limit.
+func entryViewFields(ev *types.SimpleEntryView, now time.Time) ([]string, []interface{}) {
+	names := []string{
+		"key", "value", "creation time", "last access time", "last update time", "last stored time",
+		"hits", "version", "cost", "ttl", "max idle", "expiration time", "ttl remaining",
+	}
+	var remaining interface{}
+	if isEntryViewTimeSet(ev.ExpirationTime) {
+		left := epochMillis(ev.ExpirationTime).Sub(now)
+		if left < 0 {
+			left = 0
+		}
+		remaining = left.Truncate(time.Millisecond)
+	}
+	values := []interface{}{
+		ev.Key,
+		ev.Value,
+		entryViewTime(ev.CreationTime),
+		entryViewTime(ev.LastAccessTime),
+		entryViewTime(ev.LastUpdateTime),
+		entryViewTime(ev.LastStoredTime),
+		ev.Hits,
+		ev.Version,
+		ev.Cost,
+		entryViewDuration(ev.TTL),
+		entryViewDuration(ev.MaxIdle),
+		entryViewTime(ev.ExpirationTime),
+		remaining,
+	}
+	return names, values
+}
+
+func isEntryViewTimeSet(ms int64) bool {
+	return ms > 0 && ms != math.MaxInt64
+}
+
+func entryViewTime(ms int64) interface{} {
+	if !isEntryViewTimeSet(ms) {
+		return nil
+	}
+	return types.OffsetDateTime(epochMillis(ms))
+}
+
+func entryViewDuration(ms int64) interface{} {
+	if !isEntryViewTimeSet(ms) {
+		return nil
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+func epochMillis(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
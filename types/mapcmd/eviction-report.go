@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mapcmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/member"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
+)
+
+const MapEvictionReportExample = `  # Show how close the members are to evicting the entries of the map
+  hzc map eviction-report --name myMap --mc-url http://localhost:8080`
+
+// defaultPartitionCount is the partition count of the clusters with the default configuration
+const defaultPartitionCount = 271
+
+// the max size policies which limit the number of entries or the memory cost of the map, the other policies depend on the heap of the members
+const (
+	maxSizePerNode              = "PER_NODE"
+	maxSizePerPartition         = "PER_PARTITION"
+	maxSizeUsedHeapSize         = "USED_HEAP_SIZE"
+	maxSizeUsedNativeMemorySize = "USED_NATIVE_MEMORY_SIZE"
+)
+
+var evictionReportColumns = []string{"member", "entries", "backup entries", "memory cost", "limit", "used", "entries until eviction"}
+
+var evictionReportOutputTypes = []string{output.TypePretty, output.TypeCSV, output.TypeJSON, output.TypeVertical}
+
+func NewEvictionReport(cnfg *config.Config) *cobra.Command {
+	var (
+		mapName, outputType string
+		partitionCount      int
+	)
+	cmd := &cobra.Command{
+		Use:   "eviction-report [--name mapname]",
+		Short: "Estimate how close the members are to evicting the entries of the map",
+		Long: `Estimate how close the members are to evicting the entries of the map, from the entry counts and memory costs on each member and the eviction configuration of the map.
+The statistics and the configuration are read through the Management Center REST API, so its URL must be set with --mc-url or in the configuration.`,
+		Example: MapEvictionReportExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isEvictionReportOutputType(outputType) {
+				return hzcerrors.NewLoggableError(nil, "Provided output type parameter (%s) is not a known type. Provide either '%s'",
+					outputType, strings.Join(evictionReportOutputTypes, "' or '"))
+			}
+			if partitionCount <= 0 {
+				return hzcerrors.NewLoggableError(nil, "Partition count must be positive: %d", partitionCount)
+			}
+			if cnfg.ManagementCenter.URL == "" {
+				return hzcerrors.NewLoggableError(nil, "The eviction report reads the map statistics from the Management Center, set its URL with --mc-url")
+			}
+			ec, err := internal.GetManagementCenterMapEvictionConfig(&cnfg.ManagementCenter, &cnfg.Hazelcast, mapName)
+			if err != nil {
+				return err
+			}
+			stats, err := internal.GetManagementCenterMemberMapStats(&cnfg.ManagementCenter, &cnfg.Hazelcast, mapName)
+			if err != nil {
+				return err
+			}
+			address, err := member.Address(cmd.Context(), &cnfg.Hazelcast, cmd)
+			if err != nil {
+				return err
+			}
+			// the limits are estimated with all the members, but only the given member is reported
+			total := len(stats)
+			if address != "" {
+				stats = memberMapStats(stats, address)
+				if len(stats) == 0 {
+					return hzcerrors.NewLoggableError(nil, "The Management Center does not report member %s", address)
+				}
+			}
+			quiet.Printf(cmd, "Map %s: %s\n", mapName, describeEviction(ec))
+			f, err := output.NewFormatter(outputType, cmd.OutOrStdout(), output.DefaultOptions())
+			if err != nil {
+				return err
+			}
+			if err := f.WriteHeader(evictionReportColumns); err != nil {
+				return err
+			}
+			for _, s := range stats {
+				e := estimateEviction(ec, s, total, partitionCount)
+				row := []interface{}{s.Member, s.OwnedEntryCount, s.BackupEntryCount, s.OwnedEntryMemoryCost + s.BackupEntryMemoryCost, e.limit, e.used, e.headroom}
+				if err := f.WriteRow(row); err != nil {
+					return err
+				}
+			}
+			return f.Close()
+		},
+	}
+	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
+	flags := cmd.Flags()
+	flags.IntVar(&partitionCount, "partition-count", defaultPartitionCount, "partition count of the cluster, used to estimate the limit of the PER_PARTITION policy")
+	flags.StringVarP(&outputType, OutputTypeFlag, "o", output.TypePretty, strings.Join(evictionReportOutputTypes, ", "))
+	defaults.SetValues(cmd, OutputTypeFlag, evictionReportOutputTypes)
+	if err := cmd.RegisterFlagCompletionFunc(OutputTypeFlag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return evictionReportOutputTypes, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		panic(err)
+	}
+	return member.Targetable(watch.Watchable(cmd))
+}
+
+func memberMapStats(stats []internal.MemberMapStats, address string) []internal.MemberMapStats {
+	for _, s := range stats {
+		if s.Member == address {
+			return []internal.MemberMapStats{s}
+		}
+	}
+	return nil
+}
+
+func isEvictionReportOutputType(outputType string) bool {
+	for _, t := range evictionReportOutputTypes {
+		if t == outputType {
+			return true
+		}
+	}
+	return false
+}
+
+// evictionEstimate is the estimate for a member, the values are nil if they cannot be estimated for the max size policy.
+type evictionEstimate struct {
+	// limit is the number of entries or the memory cost in bytes at which the eviction starts
+	limit interface{}
+	// used is the percentage of the limit which is used
+	used interface{}
+	// headroom is the approximate number of entries which can be added before the eviction starts
+	headroom interface{}
+}
+
+func isEvictionEnabled(ec internal.MapEvictionConfig) bool {
+	// the size is the maximum int by default, which means no limit
+	return ec.EvictionPolicy != "" && !strings.EqualFold(ec.EvictionPolicy, "NONE") && ec.Size > 0 && ec.Size < math.MaxInt32
+}
+
+func describeEviction(ec internal.MapEvictionConfig) string {
+	if !isEvictionEnabled(ec) {
+		return "eviction is disabled, the entries are removed only when they expire"
+	}
+	policy := strings.ToUpper(ec.MaxSizePolicy)
+	switch policy {
+	case maxSizeUsedHeapSize, maxSizeUsedNativeMemorySize:
+		return fmt.Sprintf("%s eviction when the memory cost on a member reaches %d MB (%s)", ec.EvictionPolicy, ec.Size, policy)
+	case maxSizePerNode:
+		return fmt.Sprintf("%s eviction when the entries on a member reach %d (%s)", ec.EvictionPolicy, ec.Size, policy)
+	case maxSizePerPartition:
+		return fmt.Sprintf("%s eviction when the entries in a partition reach %d (%s)", ec.EvictionPolicy, ec.Size, policy)
+	}
+	return fmt.Sprintf("%s eviction at size %d of %s, which depends on the memory of the members and is not estimated", ec.EvictionPolicy, ec.Size, policy)
+}
+
+// estimateEviction returns the estimate for the member.
+// The members check the entry limits per partition, so the eviction may start earlier if the entries are not spread evenly.
+func estimateEviction(ec internal.MapEvictionConfig, s internal.MemberMapStats, members, partitionCount int) evictionEstimate {
+	if !isEvictionEnabled(ec) {
+		return evictionEstimate{}
+	}
+	var limit, used int64
+	switch strings.ToUpper(ec.MaxSizePolicy) {
+	case maxSizePerNode:
+		limit, used = ec.Size, s.OwnedEntryCount
+	case maxSizePerPartition:
+		if members == 0 {
+			return evictionEstimate{}
+		}
+		// the partitions are spread evenly on the members
+		limit, used = ec.Size*int64(partitionCount)/int64(members), s.OwnedEntryCount
+	case maxSizeUsedHeapSize, maxSizeUsedNativeMemorySize:
+		limit = ec.Size * 1024 * 1024
+		// the backups are kept in the same memory
+		used = s.OwnedEntryMemoryCost + s.BackupEntryMemoryCost
+		e := evictionEstimate{limit: limit, used: usedPercentage(used, limit)}
+		if entries := s.OwnedEntryCount + s.BackupEntryCount; entries > 0 {
+			e.headroom = headroom(limit, used) * entries / used
+		}
+		return e
+	default:
+		return evictionEstimate{}
+	}
+	return evictionEstimate{limit: limit, used: usedPercentage(used, limit), headroom: headroom(limit, used)}
+}
+
+func usedPercentage(used, limit int64) string {
+	return fmt.Sprintf("%.1f%%", float64(used)*100/float64(limit))
+}
+
+func headroom(limit, used int64) int64 {
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
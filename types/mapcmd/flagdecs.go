@@ -17,17 +17,30 @@
 package mapcmd
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
 )
 
 // common flags
 const (
-	JSONEntryFlag = "json-entry"
-	TTLFlag       = "ttl"
-	MaxIdleFlag   = "max-idle"
-	DelimiterFlag = "delim"
+	JSONEntryFlag  = "json-entry"
+	TTLFlag        = "ttl"
+	MaxIdleFlag    = "max-idle"
+	DelimiterFlag  = "delim"
+	KeyFileFlag    = "key-file"
+	OutputTypeFlag = "output-type"
+	PipelineFlag   = "pipeline-depth"
+	ProjectFlag    = "project"
+	TemplateFlag   = "template"
+	// BinaryFormatFlag is the encoding of the binary values in the input and in the output
+	BinaryFormatFlag = "binary-format"
+	// KeyRandomUUIDFlag generates a random UUID key instead of the given key
+	KeyRandomUUIDFlag = "key-random-uuid"
 )
 
 func decorateCommandWithJSONEntryFlag(cmd *cobra.Command, jsonEntry *string, required bool, usage string) {
@@ -65,3 +78,39 @@ func decorateCommandWithDelimiter(cmd *cobra.Command, delimiter *string, require
 		}
 	}
 }
+
+func decorateCommandWithKeyFile(cmd *cobra.Command, keyFile *string, required bool, usage string) {
+	cmd.Flags().StringVar(keyFile, KeyFileFlag, "", usage)
+	if required {
+		if err := cmd.MarkFlagRequired(KeyFileFlag); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func decorateCommandWithPipelineDepth(cmd *cobra.Command, depth *int, usage string) {
+	cmd.Flags().IntVar(depth, PipelineFlag, 1, usage)
+}
+
+func decorateCommandWithProject(cmd *cobra.Command, attributes *[]string) {
+	cmd.Flags().StringSliceVar(attributes, ProjectFlag, nil,
+		"attributes to print instead of the whole entries, such as __key,name,address.city, extracted from the JSON keys and values")
+}
+
+func decorateCommandWithTemplate(cmd *cobra.Command, template *string) {
+	cmd.Flags().StringVar(template, TemplateFlag, "",
+		`Go template applied to each entry in the template output, such as '{{.key}}={{.value.name}}'`)
+}
+
+func decorateCommandWithBinaryFormat(cmd *cobra.Command, format *string, formats []string, usage string) {
+	cmd.Flags().StringVar(format, BinaryFormatFlag, output.BinaryBase64, fmt.Sprintf("%s: %s", usage, strings.Join(formats, ", ")))
+	if err := cmd.RegisterFlagCompletionFunc(BinaryFormatFlag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return formats, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		panic(err)
+	}
+}
+
+func decorateCommandWithKeyRandomUUID(cmd *cobra.Command, randomUUID *bool, usage string) {
+	cmd.Flags().BoolVar(randomUUID, KeyRandomUUIDFlag, false, usage)
+}
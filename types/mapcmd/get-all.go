@@ -17,46 +17,83 @@
 package mapcmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
 	"github.com/hazelcast/hazelcast-go-client/types"
 	"github.com/spf13/cobra"
 
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
 )
 
 const MapGetAllExample = `  # Get matched entries from the map with default delimiter. Default delimiter is the tab character.
-  hzc get-all -n mapname -k 12 -k 25 --key-type int16 --delim ":"`
+  hzc get-all -n mapname -k 12 -k 25 --key-type int16 --delim ":"
+  # Get the entries for the keys in the file, one key per line, as JSON.
+  hzc get-all -n mapname --key-file keys.txt --key-type int64 -o json
+  # Get only the name and the age attributes of the JSON values as CSV.
+  hzc get-all -n mapname -k 12 -k 25 --project name,age -o csv
+  # Print the keys and the names in the JSON values with a Go template.
+  hzc get-all -n mapname -k 12 -k 25 --template '{{.key}}={{.value.name}}'`
+
+const (
+	getAllOutputDelimited = "delimited"
+	getAllOutputCSV       = output.TypeCSV
+	getAllOutputJSON      = output.TypeJSON
+	getAllOutputTemplate  = output.TypeTemplate
+)
+
+var getAllOutputTypes = []string{getAllOutputDelimited, getAllOutputCSV, getAllOutputJSON, getAllOutputTemplate}
 
 func NewGetAll(config *hazelcast.Config) *cobra.Command {
 	var (
 		delim,
+		keyFile,
 		mapKeyType,
-		mapName string
-		mapKeys []string
+		mapName,
+		outputType,
+		tmpl,
+		binaryFormat string
+		mapKeys,
+		attributes []string
 	)
-	validateFlags := func() error {
+	validateFlags := func(cmd *cobra.Command) error {
 		if len(mapKeys) == 0 {
-			return hzcerrors.NewLoggableError(nil, "At least one key must be given")
+			return hzcerrors.NewLoggableError(nil, "At least one key must be given with --%s or --%s", MapKeyFlag, KeyFileFlag)
 		}
-		return nil
+		if err := validateBinaryFormat(binaryFormat, binaryOutputFormats); err != nil {
+			return err
+		}
+		return validateGetAllOutput(cmd, &outputType, tmpl)
 	}
 	cmd := &cobra.Command{
-		Use:     "get-all [--name mapname | [--key keyname]... [--delim delimiter]]",
+		Use:     "get-all [--name mapname | [--key keyname]... | [--key-file file] [--delim delimiter] [--output-type type]]",
 		Short:   "Get all matched entries from the map",
 		Example: MapGetAllExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
-			if err = validateFlags(); err != nil {
+			if keyFile != "" {
+				var fileKeys []string
+				if fileKeys, err = loadKeyFile(cmd.InOrStdin(), keyFile); err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot load the key file. Make sure file exists and process has correct access rights")
+				}
+				mapKeys = append(mapKeys, fileKeys...)
+			}
+			if err = validateFlags(cmd); err != nil {
 				return err
 			}
 			keys := make([]interface{}, len(mapKeys))
 			for i := range mapKeys {
 				keys[i], err = internal.ConvertString(mapKeys[i], mapKeyType)
 				if err != nil {
-					return hzcerrors.NewLoggableError(err, "key type does cannot be converted")
+					return hzcerrors.NewLoggableError(err, "Conversion error on key %s to key-type %s", mapKeys[i], mapKeyType)
 				}
 			}
 			var entries []types.Entry
@@ -74,16 +111,165 @@ func NewGetAll(config *hazelcast.Config) *cobra.Command {
 				}
 				return hzcerrors.NewLoggableError(err, "Cannot get entries for the given keys for map %s", mapName)
 			}
-			for _, entry := range entries {
-				fmt.Print(entry.Key, delim)
-				printValueBasedOnType(cmd, entry.Value)
+			w, err := newEntryWriter(cmd, outputType, delim, entryOutputOptions(tmpl, binaryFormat), attributes)
+			if err == nil {
+				err = w.write(entries)
+			}
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot output the entries of map %s", mapName)
 			}
 			return nil
 		},
 	}
 	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
-	decorateCommandWithMapKeyArrayFlags(cmd, &mapKeys, true, "key(s) of the entry")
+	decorateCommandWithMapKeyArrayFlags(cmd, &mapKeys, false, "key(s) of the entry")
+	decorateCommandWithKeyFile(cmd, &keyFile, false, `path to the file that contains the keys, one key per line. Use "-" (dash) to read from stdin`)
 	decorateCommandWithMapKeyTypeFlags(cmd, &mapKeyType, false)
 	decorateCommandWithDelimiter(cmd, &delim, false, "delimiter of printed key, value pairs")
-	return cmd
+	decorateCommandWithProject(cmd, &attributes)
+	decorateCommandWithTemplate(cmd, &tmpl)
+	decorateCommandWithBinaryFormat(cmd, &binaryFormat, binaryOutputFormats, "rendering of binary values")
+	cmd.Flags().StringVarP(&outputType, OutputTypeFlag, "o", getAllOutputDelimited, strings.Join(getAllOutputTypes, ", "))
+	defaults.SetValues(cmd, OutputTypeFlag, getAllOutputTypes)
+	if err := cmd.RegisterFlagCompletionFunc(OutputTypeFlag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return getAllOutputTypes, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		panic(err)
+	}
+	return watch.Watchable(cmd)
+}
+
+// validateGetAllOutput checks the output flags of get-all and entry-set,
+// the output type is set to template if a template is given and the output type is not set explicitly.
+func validateGetAllOutput(cmd *cobra.Command, outputType *string, tmpl string) error {
+	if tmpl != "" && !cmd.Flags().Changed(OutputTypeFlag) {
+		*outputType = getAllOutputTemplate
+	}
+	if !isGetAllOutputType(*outputType) {
+		return hzcerrors.NewLoggableError(nil, "Provided output type parameter (%s) is not a known type. Provide either '%s'",
+			*outputType, strings.Join(getAllOutputTypes, "' or '"))
+	}
+	if *outputType == getAllOutputTemplate && tmpl == "" {
+		return hzcerrors.NewLoggableError(nil, "Template output requires a template, set it with --%s", TemplateFlag)
+	}
+	return nil
+}
+
+func isGetAllOutputType(outputType string) bool {
+	for _, t := range getAllOutputTypes {
+		if t == outputType {
+			return true
+		}
+	}
+	return false
+}
+
+// loadKeyFile reads the keys in the file, or in if the path is "-", one key per line. Empty lines are skipped.
+func loadKeyFile(in io.Reader, path string) ([]string, error) {
+	content, err := loadValueFile(in, path)
+	if err != nil {
+		return nil, err
+	}
+	return parseKeyLines(content), nil
+}
+
+func parseKeyLines(content string) []string {
+	var keys []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		keys = append(keys, line)
+	}
+	return keys
+}
+
+// entryWriter writes the entries in one of the get-all output types, the entries can be written in chunks.
+type entryWriter struct {
+	cmd   *cobra.Command
+	delim string
+	opts  output.Options
+	// f is the formatter of the CSV, JSON and template outputs, the delimited output is written by the writer
+	f      output.Formatter
+	isJSON bool
+	// p is the projection of the entries, the whole entries are written if it is nil
+	p *projection
+}
+
+// entryOutputOptions returns the output options of the entry writer.
+func entryOutputOptions(tmpl, binaryFormat string) output.Options {
+	opts := output.DefaultOptions()
+	opts.Template = tmpl
+	opts.Binary = binaryFormat
+	return opts
+}
+
+// newEntryWriter returns a writer for the output type, the CSV header is written at once.
+// If attributes are given, only their values are written.
+func newEntryWriter(cmd *cobra.Command, outputType, delim string, opts output.Options, attributes []string) (*entryWriter, error) {
+	w := &entryWriter{cmd: cmd, delim: delim, opts: opts, isJSON: outputType == getAllOutputJSON}
+	header := []string{"key", "value"}
+	if len(attributes) > 0 {
+		w.p = &projection{attributes: attributes}
+		header = attributes
+	}
+	if outputType == getAllOutputDelimited {
+		return w, nil
+	}
+	f, err := output.NewFormatter(outputType, cmd.OutOrStdout(), opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.WriteHeader(header); err != nil {
+		return nil, err
+	}
+	w.f = f
+	return w, nil
+}
+
+func (w *entryWriter) write(entries []types.Entry) error {
+	if w.f == nil && w.p == nil {
+		for _, entry := range entries {
+			fmt.Fprint(w.cmd.OutOrStdout(), valueString(entry.Key, w.opts.Binary), w.delim)
+			printValueBasedOnType(w.cmd, entry.Value, w.opts.Binary)
+		}
+		return nil
+	}
+	for _, entry := range entries {
+		values := []interface{}{entry.Key, entry.Value}
+		if w.p != nil {
+			var err error
+			if values, err = w.p.project(entry); err != nil {
+				return err
+			}
+		}
+		if w.f == nil {
+			cells := make([]string, len(values))
+			for i, v := range values {
+				// the missing attributes are empty
+				if v != nil {
+					cells[i] = valueString(v, w.opts.Binary)
+				}
+			}
+			fmt.Fprintln(w.cmd.OutOrStdout(), strings.Join(cells, w.delim))
+			continue
+		}
+		for i, v := range values {
+			if !w.isJSON {
+				continue
+			}
+			// JSON values and the numbers in them are embedded as they are instead of as strings
+			switch vv := v.(type) {
+			case serialization.JSON:
+				values[i] = json.RawMessage(vv)
+			case json.Number:
+				values[i] = json.RawMessage(vv)
+			}
+		}
+		if err := w.f.WriteRow(values); err != nil {
+			return err
+		}
+	}
+	return nil
 }
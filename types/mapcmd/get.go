@@ -22,18 +22,24 @@ import (
 
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/watch"
 )
 
 const MapGetExample = `  # Get value of the given key from the map.
-  hzc map get --key-type int16 --key 2012 --name myMap   # default key-type is string`
+  hzc map get --key-type int16 --key 2012 --name myMap   # default key-type is string
+  # Save the binary value of the given key to a file.
+  hzc map get --key photo --name myMap --binary-format raw > photo.png`
 
 func NewGet(config *hazelcast.Config) *cobra.Command {
-	var mapName, mapKey, mapKeyType string
+	var mapName, mapKey, mapKeyType, binaryFormat string
 	cmd := &cobra.Command{
 		Use:     "get [--name mapname | --key keyname]",
 		Short:   "Get single entry from the map",
 		Example: MapGetExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBinaryFormat(binaryFormat, binaryInputFormats); err != nil {
+				return err
+			}
 			key, err := internal.ConvertString(mapKey, mapKeyType)
 			if err != nil {
 				return hzcerrors.NewLoggableError(err, "Conversion error on key %s to type %s", mapKey, mapKeyType)
@@ -51,12 +57,13 @@ func NewGet(config *hazelcast.Config) *cobra.Command {
 				}
 				return hzcerrors.NewLoggableError(err, "Cannot get value for key %s from map %s", mapKey, mapName)
 			}
-			printValueBasedOnType(cmd, value)
+			printValueBasedOnType(cmd, value, binaryFormat)
 			return nil
 		},
 	}
 	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
 	decorateCommandWithMapKeyFlags(cmd, &mapKey, true, "key of the entry")
 	decorateCommandWithMapKeyTypeFlags(cmd, &mapKeyType, false)
-	return cmd
+	decorateCommandWithBinaryFormat(cmd, &binaryFormat, binaryInputFormats, "rendering of binary values, raw writes the bytes as they are")
+	return watch.Watchable(cmd)
 }
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mapcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+)
+
+const MapIndexAddExample = `  # Add a sorted index on the age attribute of the values
+  hzc map index add -n mapname --attributes age
+  # Add a hash index on two attributes with a name
+  hzc map index add -n mapname --attributes name,city --type hash --index-name name_city
+  # Add a bitmap index on the gender attribute, with the id attribute as the unique key
+  hzc map index add -n mapname --attributes gender --type bitmap --unique-key id --unique-key-transformation long`
+
+var (
+	indexTypeNames               = []string{"sorted", "hash", "bitmap"}
+	uniqueKeyTransformationNames = []string{"object", "long", "raw"}
+)
+
+var indexTypes = map[string]types.IndexType{
+	"sorted": types.IndexTypeSorted,
+	"hash":   types.IndexTypeHash,
+	"bitmap": types.IndexTypeBitmap,
+}
+
+var uniqueKeyTransformations = map[string]types.UniqueKeyTransformation{
+	"object": types.UniqueKeyTransformationObject,
+	"long":   types.UniqueKeyTransformationLong,
+	"raw":    types.UniqueKeyTransformationRaw,
+}
+
+func NewIndex(config *hazelcast.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "index {add}",
+		Short: "Map index operations",
+		Long: `Map index operations.
+The cluster does not provide the configurations or the statistics of the indexes to the clients, and the indexes cannot be removed,
+so only adding indexes is supported.`,
+		Example: MapIndexAddExample,
+	}
+	cmd.AddCommand(NewIndexAdd(config))
+	return cmd
+}
+
+func NewIndexAdd(config *hazelcast.Config) *cobra.Command {
+	var (
+		mapName        string
+		indexName      string
+		attributes     []string
+		indexType      string
+		uniqueKey      string
+		transformation string
+	)
+	cmd := &cobra.Command{
+		Use:     "add [--name mapname] --attributes attribute,... [--type sorted|hash|bitmap] [--index-name name]",
+		Short:   "Add an index to the map",
+		Long:    "Add an index on the given attributes of the entries to the map. Adding an index which already exists has no effect.",
+		Example: MapIndexAddExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			ic, err := IndexConfig(indexName, attributes, indexType, uniqueKey, transformation,
+				flags.Changed("unique-key") || flags.Changed("unique-key-transformation"))
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid index")
+			}
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "add %s index on %s to map %s", indexType, strings.Join(ic.Attributes, ", "), mapName)
+				return nil
+			}
+			ctx := cmd.Context()
+			m, err := getMap(ctx, config, mapName)
+			if err != nil {
+				return err
+			}
+			if err := m.AddIndex(ctx, ic); err != nil {
+				handled, err := isCloudIssue(err, config)
+				if handled {
+					return err
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot add the index to map %s", mapName)
+			}
+			return nil
+		},
+	}
+	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
+	flags := cmd.Flags()
+	flags.StringSliceVar(&attributes, "attributes", nil, "attributes of the index, such as age or __key.id")
+	if err := cmd.MarkFlagRequired("attributes"); err != nil {
+		panic(err)
+	}
+	flags.StringVar(&indexType, "type", "sorted", fmt.Sprintf("type of the index, one of: %s", strings.Join(indexTypeNames, ", ")))
+	flags.StringVar(&indexName, "index-name", "", "name of the index, generated from the map name and the attributes by default")
+	flags.StringVar(&uniqueKey, "unique-key", "__key", "attribute which identifies the entries in a bitmap index")
+	flags.StringVar(&transformation, "unique-key-transformation", "object",
+		fmt.Sprintf("conversion of the unique keys of a bitmap index, one of: %s", strings.Join(uniqueKeyTransformationNames, ", ")))
+	return dryrun.Supported(cmd)
+}
+
+// IndexConfig returns the index configuration for the index options, isBitmapSet is true if the bitmap options are given explicitly.
+func IndexConfig(name string, attributes []string, indexType, uniqueKey, transformation string, isBitmapSet bool) (types.IndexConfig, error) {
+	it, ok := indexTypes[indexType]
+	if !ok {
+		return types.IndexConfig{}, fmt.Errorf("unknown index type %s, the types are %s", indexType, strings.Join(indexTypeNames, ", "))
+	}
+	var attrs []string
+	for _, a := range attributes {
+		if a = strings.TrimSpace(a); a != "" {
+			attrs = append(attrs, a)
+		}
+	}
+	if len(attrs) == 0 {
+		return types.IndexConfig{}, fmt.Errorf("at least one attribute is required")
+	}
+	ic := types.IndexConfig{Name: name, Attributes: attrs, Type: it}
+	if it != types.IndexTypeBitmap {
+		if isBitmapSet {
+			return types.IndexConfig{}, fmt.Errorf("the unique key options are only for the bitmap indexes")
+		}
+		return ic, nil
+	}
+	tr, ok := uniqueKeyTransformations[transformation]
+	if !ok {
+		return types.IndexConfig{}, fmt.Errorf("unknown unique key transformation %s, the transformations are %s",
+			transformation, strings.Join(uniqueKeyTransformationNames, ", "))
+	}
+	ic.BitmapIndexOptions = types.BitmapIndexOptions{UniqueKey: uniqueKey, UniqueKeyTransformation: tr}
+	return ic, nil
+}
@@ -18,8 +18,11 @@ package mapcmd
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"strings"
@@ -28,10 +31,16 @@ import (
 	"github.com/alecthomas/chroma/quick"
 	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
+	"github.com/hazelcast/hazelcast-commandline-client/config"
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/theme"
 )
 
 const (
@@ -49,9 +58,10 @@ const (
 	MapResetFlag          = "reset"
 )
 
-func New(config *hazelcast.Config) *cobra.Command {
+func New(cnfg *config.Config) *cobra.Command {
+	hzConfig := &cnfg.Hazelcast
 	var cmd = &cobra.Command{
-		Use:     "map {get | put | clear | put-all | get-all | remove} --name mapname --key keyname [--value-type type | --value-file file | --value value]",
+		Use:     "map {get | put | clear | put-all | get-all | entry-set | entry-view | eviction-report | config | aggregate | remove | remove-all | index} --name mapname --key keyname [--value-type type | --value-file file | --value value]",
 		Short:   "Map operations",
 		Example: fmt.Sprintf("%s\n%s\n%s", MapPutExample, MapGetExample, MapUseExample),
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
@@ -80,12 +90,19 @@ func New(config *hazelcast.Config) *cobra.Command {
 		},
 	}
 	cmd.AddCommand(
-		NewPut(config),
-		NewPutAll(config),
-		NewGet(config),
-		NewGetAll(config),
-		NewRemove(config),
-		NewClear(config),
+		NewPut(hzConfig),
+		NewPutAll(hzConfig),
+		NewGet(hzConfig),
+		NewGetAll(hzConfig),
+		NewEntrySet(hzConfig),
+		NewAggregate(hzConfig),
+		NewIndex(hzConfig),
+		NewRemove(hzConfig),
+		NewRemoveAll(hzConfig),
+		NewEntryView(hzConfig),
+		NewEvictionReport(cnfg),
+		NewConfig(cnfg),
+		NewClear(hzConfig),
 		NewUse())
 	return cmd
 }
@@ -109,24 +126,79 @@ func validateTTL(d time.Duration) error {
 	return nil
 }
 
-func printValueBasedOnType(cmd *cobra.Command, value interface{}) {
+// binaryRaw is the binary format which reads and writes the bytes as they are
+const binaryRaw = "raw"
+
+var (
+	// binaryInputFormats are the encodings of the binary values given to the commands
+	binaryInputFormats = []string{output.BinaryBase64, output.BinaryHex, binaryRaw}
+	// binaryOutputFormats are the renderings of the binary values in the outputs with many values
+	binaryOutputFormats = []string{output.BinaryBase64, output.BinaryHex}
+)
+
+func validateBinaryFormat(format string, formats []string) error {
+	for _, f := range formats {
+		if f == format {
+			return nil
+		}
+	}
+	return hzcerrors.NewLoggableError(nil, "Unknown binary format %s, provide one of %s", format, strings.Join(formats, ", "))
+}
+
+// decodeBinary returns the bytes of the binary value in the format, the surrounding spaces are ignored unless the format is raw.
+func decodeBinary(value, format string) ([]byte, error) {
+	switch format {
+	case binaryRaw:
+		return []byte(value), nil
+	case output.BinaryHex:
+		return hex.DecodeString(strings.TrimSpace(value))
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+}
+
+// valueString renders the value as text, the binary values are rendered in the binary format.
+func valueString(v interface{}, binaryFormat string) string {
+	if b, ok := v.([]byte); ok && binaryFormat != binaryRaw {
+		return output.Options{Binary: binaryFormat}.Format(b)
+	}
+	return output.String(v)
+}
+
+func printValueBasedOnType(cmd *cobra.Command, value interface{}, binaryFormat string) {
 	var err error
 	switch v := value.(type) {
 	case serialization.JSON:
+		style := theme.Current().Syntax
+		if style == "" {
+			fmt.Fprintln(cmd.OutOrStdout(), v.String())
+			break
+		}
 		if err = quick.Highlight(cmd.OutOrStdout(), fmt.Sprintln(v),
-			"json", "terminal", "tango"); err != nil {
-			fmt.Println(v.String())
+			"json", "terminal", style); err != nil {
+			fmt.Fprintln(cmd.OutOrStdout(), v.String())
 		}
+	case []byte:
+		// the raw bytes are written without a new line, so that they can be redirected to a file as they are
+		if binaryFormat == binaryRaw {
+			cmd.OutOrStdout().Write(v)
+			break
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), valueString(v, binaryFormat))
 	default:
 		if v == nil {
-			fmt.Println("There is no value corresponding to the provided key")
+			// print nothing in the quiet mode, so that the missing value is an empty string in scripts
+			if !quiet.Enabled(cmd) {
+				fmt.Fprintln(cmd.OutOrStdout(), "There is no value corresponding to the provided key")
+			}
 			break
 		}
-		fmt.Println(v)
+		fmt.Fprintln(cmd.OutOrStdout(), output.String(v))
 	}
 }
 
-func normalizeMapValue(v, vFile, vType string) (interface{}, error) {
+// normalizeMapValue converts the value, or the content of the value file, to the value type.
+// The binary values are decoded with the binary format.
+func normalizeMapValue(in io.Reader, v, vFile, vType, binaryFormat string) (interface{}, error) {
 	var valueStr string
 	var err error
 	switch {
@@ -135,7 +207,7 @@ func normalizeMapValue(v, vFile, vType string) (interface{}, error) {
 	case v != "":
 		valueStr = v
 	case vFile != "":
-		if valueStr, err = loadValueFile(vFile); err != nil {
+		if valueStr, err = loadValueFile(in, vFile); err != nil {
 			err = hzcerrors.NewLoggableError(err, "Cannot load the value file. Make sure file exists and process has correct access rights")
 		}
 	default:
@@ -144,6 +216,13 @@ func normalizeMapValue(v, vFile, vType string) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if vType == internal.TypeNameBinary {
+		b, err := decodeBinary(valueStr, binaryFormat)
+		if err != nil {
+			return nil, hzcerrors.NewLoggableError(err, "Cannot decode the binary value as %s", binaryFormat)
+		}
+		return b, nil
+	}
 	mapValue, err := internal.ConvertString(valueStr, vType)
 	if err != nil {
 		err = hzcerrors.NewLoggableError(err, "Conversion error on value %s to value-type %s", valueStr, vType)
@@ -151,12 +230,17 @@ func normalizeMapValue(v, vFile, vType string) (interface{}, error) {
 	return mapValue, err
 }
 
-func loadValueFile(path string) (string, error) {
+// loadValueFile reads the file at path, or in if the path is "-".
+func loadValueFile(in io.Reader, path string) (string, error) {
 	if path == "" {
 		return "", errors.New("path cannot be empty")
 	}
 	if path == "-" {
-		if value, err := ioutil.ReadAll(os.Stdin); err != nil {
+		// reading the terminal would compete with the prompt in the interactive mode, so the value must be piped
+		if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+			return "", errors.New(`stdin is a terminal, pipe the value to read it from "-"`)
+		}
+		if value, err := ioutil.ReadAll(in); err != nil {
 			return "", err
 		} else {
 			return string(value), nil
@@ -169,6 +253,17 @@ func loadValueFile(path string) (string, error) {
 	}
 }
 
+// countStdin returns the number of the paths which are "-".
+func countStdin(paths []string) int {
+	var n int
+	for _, p := range paths {
+		if p == "-" {
+			n++
+		}
+	}
+	return n
+}
+
 func isCloudIssue(err error, config *hazelcast.Config) (bool, error) {
 	isCloudCluster := config.Cluster.Cloud.Enabled
 	if networkErrMsg, handled := hzcerrors.TranslateNetworkError(err, isCloudCluster); handled {
@@ -225,6 +320,32 @@ func getMap(ctx context.Context, clientConfig *hazelcast.Config, mapName string)
 	return
 }
 
+// restoreEntry returns the operation which reverts the change of an entry, old is its previous value or nil if there was no entry.
+func restoreEntry(m *hazelcast.Map, key, old interface{}) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if old == nil {
+			return m.Delete(ctx, key)
+		}
+		return m.Set(ctx, key, old)
+	}
+}
+
+// restoreEntries returns the operation which reverts the change of the entries with the keys, old are the entries before the change.
+// The expiration of the previous entries is not restored.
+func restoreEntries(m *hazelcast.Map, keys []interface{}, old []types.Entry) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for _, k := range keys {
+			if err := m.Delete(ctx, k); err != nil {
+				return err
+			}
+		}
+		if len(old) == 0 {
+			return nil
+		}
+		return m.PutAll(ctx, old...)
+	}
+}
+
 func decorateCommandWithValueFlags(cmd *cobra.Command, mapValue, mapValueFile *string) {
 	flags := cmd.Flags()
 	flags.StringVarP(mapValue, MapValueFlag, MapValueFlagShort, "", "value of the map")
@@ -240,6 +361,18 @@ func decorateCommandWithMapNameFlags(cmd *cobra.Command, mapName *string, requir
 	}
 }
 
+// validateRandomUUIDKey checks that the key is not given together with --key-random-uuid,
+// and that the key type is uuid if it is given explicitly.
+func validateRandomUUIDKey(cmd *cobra.Command, keyType string) error {
+	if cmd.Flags().Changed(MapKeyFlag) {
+		return hzcerrors.NewLoggableError(nil, "--%s and --%s cannot be given together", MapKeyFlag, KeyRandomUUIDFlag)
+	}
+	if cmd.Flags().Changed(MapKeyTypeFlag) && keyType != internal.TypeNameUUID {
+		return hzcerrors.NewLoggableError(nil, "The key type must be %s for --%s", internal.TypeNameUUID, KeyRandomUUIDFlag)
+	}
+	return nil
+}
+
 func decorateCommandWithMapKeyTypeFlags(cmd *cobra.Command, mapKeyType *string, required bool) {
 	cmd.Flags().StringVar(mapKeyType, MapKeyTypeFlag, "string", fmt.Sprintf("type of the key, one of: %s", strings.Join(internal.SupportedTypeNames, ",")))
 	if required {
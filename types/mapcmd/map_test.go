@@ -18,8 +18,21 @@ package mapcmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
 )
 
 func TestObtainOrderingOfValues(t *testing.T) {
@@ -65,3 +78,436 @@ func TestValidateTTL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseKeyLines(t *testing.T) {
+	for _, tc := range []struct {
+		info    string
+		content string
+		want    []string
+	}{
+		{"empty", "", nil},
+		{"single key without newline", "k1", []string{"k1"}},
+		{"keys", "k1\nk2\n", []string{"k1", "k2"}},
+		{"windows line endings", "k1\r\nk2\r\n", []string{"k1", "k2"}},
+		{"blank lines are skipped", "k1\n\n  \nk2", []string{"k1", "k2"}},
+		{"surrounding spaces are kept", " k1 \n", []string{" k1 "}},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if got := parseKeyLines(tc.content); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %q got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestEntryWriterChunks(t *testing.T) {
+	for _, tc := range []struct {
+		outputType string
+		want       string
+	}{
+		{getAllOutputCSV, "key,value\nk1,v1\nk2,2\nk3,\"a, b\"\nk4,\"{\"\"a\"\":1}\"\n"},
+		{getAllOutputJSON, `{"key":"k1","value":"v1"}` + "\n" + `{"key":"k2","value":2}` + "\n" + `{"key":"k3","value":"a, b"}` + "\n" + `{"key":"k4","value":{"a":1}}` + "\n"},
+		{getAllOutputDelimited, "k1:v1\nk2:2\nk3:a, b\n"},
+		{getAllOutputTemplate, `k1="v1"` + "\n" + "k2=2\n" + `k3="a, b"` + "\n" + `k4={"a":1}` + "\n"},
+	} {
+		t.Run(tc.outputType, func(t *testing.T) {
+			var b bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&b)
+			// the template is used only in the template output
+			w, err := newEntryWriter(cmd, tc.outputType, ":", entryOutputOptions("{{.key}}={{json .value}}", output.BinaryBase64), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunks := [][]types.Entry{
+				{{Key: "k1", Value: "v1"}},
+				{{Key: "k2", Value: int32(2)}, {Key: "k3", Value: "a, b"}},
+			}
+			if tc.outputType != getAllOutputDelimited {
+				// the delimited output highlights the JSON values
+				chunks = append(chunks, []types.Entry{{Key: "k4", Value: serialization.JSON(`{"a":1}`)}})
+			}
+			// the header is written only once for the chunks
+			for _, chunk := range chunks {
+				if err := w.write(chunk); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if b.String() != tc.want {
+				t.Fatalf("want %q got %q", tc.want, b.String())
+			}
+		})
+	}
+}
+
+func TestIndexConfig(t *testing.T) {
+	ic, err := IndexConfig("", []string{"name", " city "}, "hash", "__key", "object", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := types.IndexConfig{Attributes: []string{"name", "city"}, Type: types.IndexTypeHash}
+	if !reflect.DeepEqual(ic, want) {
+		t.Fatalf("unexpected index: %+v", ic)
+	}
+	ic, err = IndexConfig("by_gender", []string{"gender"}, "bitmap", "id", "long", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = types.IndexConfig{
+		Name:               "by_gender",
+		Attributes:         []string{"gender"},
+		Type:               types.IndexTypeBitmap,
+		BitmapIndexOptions: types.BitmapIndexOptions{UniqueKey: "id", UniqueKeyTransformation: types.UniqueKeyTransformationLong},
+	}
+	if !reflect.DeepEqual(ic, want) {
+		t.Fatalf("unexpected index: %+v", ic)
+	}
+	for _, tc := range []struct {
+		info        string
+		attributes  []string
+		indexType   string
+		tr          string
+		isBitmapSet bool
+	}{
+		{"unknown type", []string{"age"}, "btree", "object", false},
+		{"no attributes", []string{" "}, "sorted", "object", false},
+		{"bitmap options for sorted", []string{"age"}, "sorted", "object", true},
+		{"unknown transformation", []string{"age"}, "bitmap", "int", true},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if _, err := IndexConfig("", tc.attributes, tc.indexType, "__key", tc.tr, tc.isBitmapSet); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestNewAggregator(t *testing.T) {
+	for _, tc := range []struct {
+		agg        string
+		attribute  string
+		numberType string
+		want       string
+	}{
+		{"count", "", "long", "Count()"},
+		{"count", "age", "long", "Count(age)"},
+		{"min", "age", "long", "Min(age)"},
+		{"max", "__key.id", "long", "Max(__key.id)"},
+		{"distinct", "city", "long", "DistinctValues(city)"},
+		{"sum", "age", "int", "IntSum(age)"},
+		{"sum", "age", "long", "LongSum(age)"},
+		{"avg", "price", "double", "DoubleAverage(price)"},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			a, err := newAggregator(tc.agg, tc.attribute, tc.numberType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.String() != tc.want {
+				t.Fatalf("got %s, want %s", a.String(), tc.want)
+			}
+		})
+	}
+	if _, err := newAggregator("median", "age", "long"); err == nil {
+		t.Fatal("expected an error for an unknown aggregation")
+	}
+	if _, err := newAggregator("sum", "age", "float"); err == nil {
+		t.Fatal("expected an error for an unknown number type")
+	}
+}
+
+func TestEntryWriterProjection(t *testing.T) {
+	entries := []types.Entry{
+		{Key: "k1", Value: serialization.JSON(`{"name":"Jane","age":30,"address":{"city":"London","zip":["N1"]}}`)},
+		{Key: "k2", Value: serialization.JSON(`{"name":"Joe"}`)},
+	}
+	for _, tc := range []struct {
+		outputType string
+		want       string
+	}{
+		{getAllOutputDelimited, "k1:Jane:30:London\nk2:Joe::\n"},
+		{getAllOutputCSV, "__key,name,this.age,address.city\nk1,Jane,30,London\nk2,Joe,NULL,NULL\n"},
+		{getAllOutputJSON, `{"__key":"k1","name":"Jane","this.age":30,"address.city":"London"}` + "\n" + `{"__key":"k2","name":"Joe","this.age":null,"address.city":null}` + "\n"},
+	} {
+		t.Run(tc.outputType, func(t *testing.T) {
+			var b bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&b)
+			w, err := newEntryWriter(cmd, tc.outputType, ":", entryOutputOptions("", output.BinaryBase64), []string{"__key", "name", "this.age", "address.city"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.write(entries); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tc.want {
+				t.Fatalf("want %q got %q", tc.want, b.String())
+			}
+		})
+	}
+}
+
+func TestProjection(t *testing.T) {
+	p := projection{attributes: []string{"__key.id", "address", "address.zip"}}
+	values, err := p.project(types.Entry{
+		Key:   serialization.JSON(`{"id":1}`),
+		Value: serialization.JSON(`{"address":{"zip":["N1"]}}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{json.Number("1"), serialization.JSON(`{"zip":["N1"]}`), serialization.JSON(`["N1"]`)}
+	if !reflect.DeepEqual(values, want) {
+		t.Fatalf("want %#v got %#v", want, values)
+	}
+	if _, err := p.project(types.Entry{Key: "k1", Value: serialization.JSON(`{}`)}); err == nil {
+		t.Fatal("expected an error for a non-JSON key")
+	}
+}
+
+func TestSortEntries(t *testing.T) {
+	entries := func() []types.Entry {
+		return []types.Entry{
+			{Key: "k1", Value: serialization.JSON(`{"age":9}`)},
+			{Key: "k2", Value: serialization.JSON(`{"name":"Joe"}`)},
+			{Key: "k3", Value: serialization.JSON(`{"age":41}`)},
+			{Key: "k4", Value: serialization.JSON(`{"age":10.5}`)},
+		}
+	}
+	keys := func(entries []types.Entry) []interface{} {
+		var keys []interface{}
+		for _, e := range entries {
+			keys = append(keys, e.Key)
+		}
+		return keys
+	}
+	for _, tc := range []struct {
+		name string
+		attr string
+		desc bool
+		want []interface{}
+	}{
+		// the numbers are sorted by their values and the entries without the attribute are last
+		{name: "ascending", attr: "age", want: []interface{}{"k1", "k4", "k3", "k2"}},
+		{name: "descending", attr: "age", desc: true, want: []interface{}{"k3", "k4", "k1", "k2"}},
+		{name: "key descending", attr: "__key", desc: true, want: []interface{}{"k4", "k3", "k2", "k1"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			es := entries()
+			if err := sortEntries(es, tc.attr, tc.desc); err != nil {
+				t.Fatal(err)
+			}
+			if got := keys(es); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %v got %v", tc.want, got)
+			}
+		})
+	}
+	if err := sortEntries([]types.Entry{{Key: "k1", Value: "v1"}}, "age", false); err == nil {
+		t.Fatal("sorting by an attribute of a non-JSON value must be an error")
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	big1, _ := internal.ConvertString("12345678901234567890.000000001", internal.TypeNameDecimal)
+	big2, _ := internal.ConvertString("12345678901234567890.000000002", internal.TypeNameDecimal)
+	huge, _ := internal.ConvertString("123456789012345678901234567890", internal.TypeNameBigInt)
+	for _, tc := range []struct {
+		info string
+		a, b interface{}
+		want int
+	}{
+		{"decimals beyond float precision", big1, big2, -1},
+		{"big integer and int64", huge, int64(1), 1},
+		{"decimal and float", big1, float64(1.5), 1},
+		{"json number", json.Number("10"), int32(9), 1},
+		{"text", "b", "a", 1},
+	} {
+		if got := compareValues(tc.a, tc.b); got != tc.want {
+			t.Errorf("%s: want %d got %d", tc.info, tc.want, got)
+		}
+	}
+}
+
+func TestLoadValueFile(t *testing.T) {
+	// the binary content is kept as it is
+	value := "{\"name\": \"Jane\"}\n\x00\xff"
+	got, err := loadValueFile(strings.NewReader(value), "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != value {
+		t.Errorf("want %q got %q", value, got)
+	}
+	f, err := ioutil.TempFile("", "value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(value); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	// the input is read only for "-"
+	got, err = loadValueFile(strings.NewReader("ignored"), f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != value {
+		t.Errorf("want %q got %q", value, got)
+	}
+	if countStdin([]string{"a.json", "-", "b.json", "-"}) != 2 {
+		t.Error("want 2 stdin paths")
+	}
+}
+
+func TestBinaryValues(t *testing.T) {
+	want := []byte{0, 1, 0xfe, 0xff}
+	for _, tc := range []struct {
+		format string
+		value  string
+	}{
+		{output.BinaryBase64, "AAH+/w==\n"},
+		{output.BinaryHex, " 0001feff "},
+		{binaryRaw, "\x00\x01\xfe\xff"},
+	} {
+		v, err := normalizeMapValue(nil, tc.value, "", internal.TypeNameBinary, tc.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(want, v.([]byte)) {
+			t.Errorf("%s: want %v got %v", tc.format, want, v)
+		}
+		var b bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetOut(&b)
+		printValueBasedOnType(cmd, v, tc.format)
+		// the encoded values are printed on their own lines, the raw bytes as they are
+		printed := strings.TrimSpace(tc.value) + "\n"
+		if tc.format == binaryRaw {
+			printed = tc.value
+		}
+		if b.String() != printed {
+			t.Errorf("%s: want %q got %q", tc.format, printed, b.String())
+		}
+	}
+	if _, err := normalizeMapValue(nil, "zz", "", internal.TypeNameBinary, output.BinaryHex); err == nil {
+		t.Fatal("invalid hex must be an error")
+	}
+	if err := validateBinaryFormat(binaryRaw, binaryOutputFormats); err == nil {
+		t.Fatal("raw must not be an output format for many values")
+	}
+}
+
+func TestValidateRandomUUIDKey(t *testing.T) {
+	for _, tc := range []struct {
+		info  string
+		args  []string
+		isErr bool
+	}{
+		{info: "no key", args: []string{"--key-random-uuid"}},
+		{info: "uuid key type", args: []string{"--key-random-uuid", "--key-type", "uuid"}},
+		{info: "other key type", args: []string{"--key-random-uuid", "--key-type", "int32"}, isErr: true},
+		{info: "given key", args: []string{"--key-random-uuid", "--key", "k1"}, isErr: true},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			var key, keyType string
+			var randomUUID bool
+			cmd := &cobra.Command{}
+			decorateCommandWithMapKeyFlags(cmd, &key, false, "key")
+			decorateCommandWithMapKeyTypeFlags(cmd, &keyType, false)
+			decorateCommandWithKeyRandomUUID(cmd, &randomUUID, "random key")
+			if err := cmd.ParseFlags(tc.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := validateRandomUUIDKey(cmd, keyType); (err != nil) != tc.isErr {
+				t.Fatalf("want error %t got %v", tc.isErr, err)
+			}
+		})
+	}
+}
+
+func TestEntryViewFields(t *testing.T) {
+	now := time.Unix(1654000000, 0)
+	ms := func(tm time.Time) int64 {
+		return tm.UnixNano() / int64(time.Millisecond)
+	}
+	ev := types.NewSimpleEntryView("k1", "v1", 120, ms(now.Add(-time.Hour)), ms(now.Add(90*time.Second)),
+		3, ms(now.Add(-time.Minute)), 0, ms(now.Add(-time.Minute)), 2, 120000, math.MaxInt64)
+	names, values := entryViewFields(ev, now)
+	if len(names) != len(values) {
+		t.Fatalf("%d names for %d values", len(names), len(values))
+	}
+	field := func(name string) interface{} {
+		for i, n := range names {
+			if n == name {
+				return values[i]
+			}
+		}
+		t.Fatalf("no field %s", name)
+		return nil
+	}
+	if want := types.OffsetDateTime(now.Add(-time.Hour)); !time.Time(field("creation time").(types.OffsetDateTime)).Equal(time.Time(want)) {
+		t.Errorf("want creation time %v got %v", want, field("creation time"))
+	}
+	for name, want := range map[string]interface{}{
+		"key":              "k1",
+		"hits":             int64(3),
+		"cost":             int64(120),
+		"last stored time": nil,
+		"ttl":              2 * time.Minute,
+		"max idle":         nil,
+		"ttl remaining":    90 * time.Second,
+	} {
+		if got := field(name); got != want {
+			t.Errorf("%s: want %v got %v", name, want, got)
+		}
+	}
+	// expired entries have no time remaining, and the entries without expiration have no value
+	ev.ExpirationTime = ms(now.Add(-time.Second))
+	if _, values = entryViewFields(ev, now); values[len(values)-1] != time.Duration(0) {
+		t.Errorf("want no time remaining got %v", values[len(values)-1])
+	}
+	ev.ExpirationTime = math.MaxInt64
+	if _, values = entryViewFields(ev, now); values[len(values)-1] != nil {
+		t.Errorf("want no expiration got %v", values[len(values)-1])
+	}
+}
+
+func TestEstimateEviction(t *testing.T) {
+	stats := internal.MemberMapStats{OwnedEntryCount: 600, BackupEntryCount: 400, OwnedEntryMemoryCost: 3 * 1024 * 1024, BackupEntryMemoryCost: 2 * 1024 * 1024}
+	for _, tc := range []struct {
+		info string
+		ec   internal.MapEvictionConfig
+		want evictionEstimate
+	}{
+		{info: "no eviction", ec: internal.MapEvictionConfig{EvictionPolicy: "NONE", MaxSizePolicy: "PER_NODE", Size: 1000}},
+		{info: "no limit", ec: internal.MapEvictionConfig{EvictionPolicy: "LRU", MaxSizePolicy: "PER_NODE", Size: math.MaxInt32}},
+		{
+			info: "per node",
+			ec:   internal.MapEvictionConfig{EvictionPolicy: "LRU", MaxSizePolicy: "PER_NODE", Size: 1000},
+			want: evictionEstimate{limit: int64(1000), used: "60.0%", headroom: int64(400)},
+		},
+		{
+			info: "per node full",
+			ec:   internal.MapEvictionConfig{EvictionPolicy: "LFU", MaxSizePolicy: "PER_NODE", Size: 500},
+			want: evictionEstimate{limit: int64(500), used: "120.0%", headroom: int64(0)},
+		},
+		{
+			info: "per partition",
+			ec:   internal.MapEvictionConfig{EvictionPolicy: "LRU", MaxSizePolicy: "PER_PARTITION", Size: 10},
+			want: evictionEstimate{limit: int64(1000), used: "60.0%", headroom: int64(400)},
+		},
+		{
+			info: "used heap size",
+			ec:   internal.MapEvictionConfig{EvictionPolicy: "LRU", MaxSizePolicy: "USED_HEAP_SIZE", Size: 10},
+			want: evictionEstimate{limit: int64(10 * 1024 * 1024), used: "50.0%", headroom: int64(1000)},
+		},
+		{info: "heap percentage", ec: internal.MapEvictionConfig{EvictionPolicy: "LRU", MaxSizePolicy: "USED_HEAP_PERCENTAGE", Size: 80}},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			if e := estimateEviction(tc.ec, stats, 2, 200); e != tc.want {
+				t.Errorf("want %+v got %+v", tc.want, e)
+			}
+		})
+	}
+}
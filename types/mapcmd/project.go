@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mapcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+const (
+	keyAttribute   = "__key"
+	valueAttribute = "this"
+)
+
+// projection extracts the attributes from the entries.
+// The Go client does not support the projections on the cluster, so the attributes are extracted from the JSON keys and values on the client.
+type projection struct {
+	attributes []string
+}
+
+// project returns the values of the attributes of the entry, in the order of the attributes.
+// The attributes which are not in the entry are nil.
+func (p projection) project(entry types.Entry) ([]interface{}, error) {
+	var key, value *decodedJSON
+	values := make([]interface{}, len(p.attributes))
+	for i, attr := range p.attributes {
+		target, decoded, path := entry.Value, &value, attr
+		switch {
+		case attr == keyAttribute:
+			values[i] = entry.Key
+			continue
+		case attr == valueAttribute:
+			values[i] = entry.Value
+			continue
+		case strings.HasPrefix(attr, keyAttribute+"."):
+			target, decoded, path = entry.Key, &key, strings.TrimPrefix(attr, keyAttribute+".")
+		case strings.HasPrefix(attr, valueAttribute+"."):
+			path = strings.TrimPrefix(attr, valueAttribute+".")
+		}
+		if *decoded == nil {
+			d, err := decodeJSON(target, attr)
+			if err != nil {
+				return nil, err
+			}
+			*decoded = d
+		}
+		v, err := (*decoded).extract(path)
+		if err != nil {
+			return nil, fmt.Errorf("extracting %s: %w", attr, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+type decodedJSON struct {
+	v interface{}
+}
+
+func decodeJSON(target interface{}, attr string) (*decodedJSON, error) {
+	if target == nil {
+		return &decodedJSON{}, nil
+	}
+	j, ok := target.(serialization.JSON)
+	if !ok {
+		return nil, fmt.Errorf("attribute %s cannot be extracted from a value of type %T, only from JSON values", attr, target)
+	}
+	d := json.NewDecoder(bytes.NewReader(j))
+	// keep the numbers as they are, instead of converting them to float64
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("decoding JSON for %s: %w", attr, err)
+	}
+	return &decodedJSON{v: v}, nil
+}
+
+// extract returns the value at the dotted path, the objects and arrays are returned as JSON.
+func (d *decodedJSON) extract(path string) (interface{}, error) {
+	v := d.v
+	for _, name := range strings.Split(path, ".") {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		v = obj[name]
+	}
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return serialization.JSON(b), nil
+	}
+	return v, nil
+}
@@ -30,6 +30,12 @@ import (
 
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/pipeline"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/progress"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/transaction"
 )
 
 const MapPutAllExample = `  # Put key, value pairs while specifying types of both keys and values
@@ -38,8 +44,47 @@ const MapPutAllExample = `  # Put key, value pairs while specifying types of bot
 
   # Put all key, value pairs to map from the entry json file. Null values are ignored.
   hzc map put-all -n mapname --json-entry entries.json
+
+  # Put the values with random UUID keys, the keys are printed in the order of the values
+  hzc map put-all -n mapname --key-random-uuid --value-type json -v '{"name":"Jane"}' -v '{"name":"Joe"}'
+
+  # Put the entries with 8 batches in flight, and print the latency statistics
+  hzc map put-all -n mapname --json-entry entries.json --pipeline-depth 8
 `
 
+// putAllBatchSize is the number of entries sent at once, progress is shown if there are more entries
+const putAllBatchSize = 1000
+
+// putAllInBatches puts the entries in batches, with at most depth PutAll invocations in flight.
+func putAllInBatches(ctx context.Context, cmd *cobra.Command, m *hazelcast.Map, entries []types.Entry, depth int) (pipeline.Stats, error) {
+	tracker := progress.New(cmd.ErrOrStderr(), "Putting entries", int64(len(entries)))
+	defer tracker.Done()
+	p := pipeline.New(ctx, depth)
+	for len(entries) > 0 {
+		n := putAllBatchSize
+		if n > len(entries) {
+			n = len(entries)
+		}
+		batch := entries[:n]
+		p.Go(func(ctx context.Context) error {
+			if err := m.PutAll(ctx, batch...); err != nil {
+				return err
+			}
+			tracker.Add(int64(len(batch)))
+			return nil
+		})
+		entries = entries[n:]
+	}
+	return p.Wait()
+}
+
+func printPutAllDryRun(cmd *cobra.Command, mapName string, entries []types.Entry) {
+	dryrun.Print(cmd, "put %d entries to map %s", len(entries), mapName)
+	for _, e := range entries {
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s: %s\n", output.String(e.Key), valueString(e.Value, output.BinaryOmit))
+	}
+}
+
 func NewPutAll(config *hazelcast.Config) *cobra.Command {
 	var (
 		entries []types.Entry
@@ -51,15 +96,19 @@ func NewPutAll(config *hazelcast.Config) *cobra.Command {
 	var (
 		mapKeyType,
 		mapValueType,
-		mapName string
+		mapName,
+		binaryFormat string
 		mapKeys,
 		mapValues,
 		mapValueFiles []string
+		pipelineDepth int
+		randomUUID    bool
 	)
 	validateJsonEntryFlag := func() error {
 		if len(mapKeys) != 0 ||
 			len(mapValues) != 0 ||
 			len(mapValueFiles) != 0 ||
+			randomUUID ||
 			mapKeyType != "" ||
 			mapValueType != "" {
 			return hzcerrors.NewLoggableError(nil, fmt.Sprintf("%s is already set, there cannot be additional flags", JSONEntryFlag))
@@ -75,7 +124,27 @@ func NewPutAll(config *hazelcast.Config) *cobra.Command {
 	}
 	executePutAll := func(ctx context.Context, cmd *cobra.Command, m *hazelcast.Map, entries []types.Entry) error {
 		var err error
-		err = m.PutAll(ctx, entries...)
+		if transaction.Active(ctx) {
+			// the entries are recorded before putting them, since some of the batches may be put if the others fail
+			keys := make([]interface{}, len(entries))
+			for i, e := range entries {
+				keys[i] = e.Key
+			}
+			old, err := m.GetAll(ctx, keys...)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get the entries of map %s to roll back putting them", mapName)
+			}
+			transaction.Record(ctx, fmt.Sprintf("put %d entries to map %s", len(entries), mapName), restoreEntries(m, keys, old))
+		}
+		if len(entries) <= putAllBatchSize && !cmd.Flags().Changed(PipelineFlag) {
+			err = m.PutAll(ctx, entries...)
+		} else {
+			var stats pipeline.Stats
+			stats, err = putAllInBatches(ctx, cmd, m, entries, pipelineDepth)
+			if err == nil && cmd.Flags().Changed(PipelineFlag) {
+				quiet.Printf(cmd, "Put %d entries with %s\n", len(entries), stats)
+			}
+		}
 		if err != nil {
 			cmd.Println("Cannot put given entries")
 			isCloudCluster := config.Cluster.Cloud.Enabled
@@ -95,6 +164,12 @@ func NewPutAll(config *hazelcast.Config) *cobra.Command {
 		Example:    MapPutAllExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
+			if pipelineDepth < 1 {
+				return hzcerrors.NewLoggableError(nil, "Pipeline depth must be positive")
+			}
+			if err = validateBinaryFormat(binaryFormat, binaryInputFormats); err != nil {
+				return err
+			}
 			if jsonEntryPath != "" {
 				if err := validateJsonEntryFlag(); err != nil {
 					return err
@@ -131,6 +206,10 @@ func NewPutAll(config *hazelcast.Config) *cobra.Command {
 						return hzcerrors.NewLoggableError(nil, "Unknown data type in json file")
 					}
 				}
+				if dryrun.Enabled(cmd) {
+					printPutAllDryRun(cmd, mapName, entries)
+					return nil
+				}
 				m, err := getMap(cmd.Context(), config, mapName)
 				if err != nil {
 					return err
@@ -138,9 +217,23 @@ func NewPutAll(config *hazelcast.Config) *cobra.Command {
 				return executePutAll(cmd.Context(), cmd, m, entries)
 			}
 			valueNumber := len(mapValues) + len(mapValueFiles)
+			if randomUUID {
+				if err = validateRandomUUIDKey(cmd, mapKeyType); err != nil {
+					return err
+				}
+				// a key is generated for each value, they are converted like the given keys
+				mapKeys = make([]string, valueNumber)
+				for i := range mapKeys {
+					mapKeys[i] = types.NewUUID().String()
+				}
+				mapKeyType = internal.TypeNameUUID
+			}
 			if valueNumber != len(mapKeys) {
 				return hzcerrors.NewLoggableError(nil, "number of keys and values do not match")
 			}
+			if countStdin(mapValueFiles) > 1 {
+				return hzcerrors.NewLoggableError(nil, `stdin can be read only once, "-" can be given as only one of the value files`)
+			}
 			var vOrder []byte
 			vOrder, err = validateValuesFlag()
 			if err != nil {
@@ -151,13 +244,13 @@ func NewPutAll(config *hazelcast.Config) *cobra.Command {
 				var normalizedValue interface{}
 				if curr == 's' {
 					v := mapValues[0]
-					if normalizedValue, err = normalizeMapValue(v, "", mapValueType); err != nil {
+					if normalizedValue, err = normalizeMapValue(cmd.InOrStdin(), v, "", mapValueType, binaryFormat); err != nil {
 						return err
 					}
 					mapValues = mapValues[1:]
 				} else {
 					v := mapValueFiles[0]
-					if normalizedValue, err = normalizeMapValue("", v, mapValueType); err != nil {
+					if normalizedValue, err = normalizeMapValue(cmd.InOrStdin(), "", v, mapValueType, binaryFormat); err != nil {
 						return err
 					}
 					mapValueFiles = mapValueFiles[1:]
@@ -174,20 +267,36 @@ func NewPutAll(config *hazelcast.Config) *cobra.Command {
 				}
 				entries = append(entries, types.Entry{Key: normalizedKey, Value: normalizedValue})
 			}
+			if dryrun.Enabled(cmd) {
+				printPutAllDryRun(cmd, mapName, entries)
+				return nil
+			}
 			m, err := getMap(cmd.Context(), config, mapName)
 			if err != nil {
 				return err
 			}
-			return executePutAll(cmd.Context(), cmd, m, entries)
+			if err := executePutAll(cmd.Context(), cmd, m, entries); err != nil {
+				return err
+			}
+			if randomUUID {
+				// the generated keys are the output, in the order of the values
+				for _, e := range entries {
+					fmt.Fprintln(cmd.OutOrStdout(), output.String(e.Key))
+				}
+			}
+			return nil
 		},
 	}
 	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
 	decorateCommandWithMapKeyArrayFlags(cmd, &mapKeys, false, "key(s) of the map")
 	decorateCommandWithMapKeyTypeFlags(cmd, &mapKeyType, false)
+	decorateCommandWithKeyRandomUUID(cmd, &randomUUID, "generate a random UUID key for each value, the keys are printed in the order of the values after the entries are put")
 	decorateCommandWithMapValueArrayFlags(cmd, &mapValues, false, "value(s) of the map")
 	decorateCommandWithMapValueFileArrayFlags(cmd, &mapValueFiles, false,
 		`path to the file that contains the value. Use "-" (dash) to read from stdin`)
 	decorateCommandWithMapValueTypeFlags(cmd, &mapValueType, false)
+	decorateCommandWithBinaryFormat(cmd, &binaryFormat, binaryInputFormats, "encoding of the values for the binary value type, raw reads the bytes as they are")
 	decorateCommandWithJSONEntryFlag(cmd, &jsonEntryPath, false, `path to json file that contains entries`)
-	return cmd
+	decorateCommandWithPipelineDepth(cmd, &pipelineDepth, fmt.Sprintf("number of the batches of %d entries put at once, the latency statistics are printed if it is given", putAllBatchSize))
+	return transaction.Supported(dryrun.Supported(cmd))
 }
@@ -17,17 +17,26 @@
 package mapcmd
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
 	"github.com/spf13/cobra"
 
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/transaction"
 )
 
 const MapPutExample = `  # Put key, value pair to map. The unit for ttl/max-idle is one of (ns,us,ms,s,m,h)
-  map put --key-type string --key hello --value-type float32 --value 19.94 --name myMap --ttl 1300ms --max-idle 1400ms`
+  map put --key-type string --key hello --value-type float32 --value 19.94 --name myMap --ttl 1300ms --max-idle 1400ms
+  # Put the content of a file as a binary value.
+  map put --key photo --value-type binary --binary-format raw --value-file photo.png --name myMap
+  # Put a value with a random UUID key, the key is printed
+  map put --key-random-uuid --value-type json --value '{"name": "Jane"}' --name myMap`
 
 func NewPut(config *hazelcast.Config) *cobra.Command {
 	var (
@@ -36,20 +45,36 @@ func NewPut(config *hazelcast.Config) *cobra.Command {
 		mapKeyType,
 		mapValue,
 		mapValueType,
-		mapValueFile string
+		mapValueFile,
+		binaryFormat string
+		randomUUID bool
 	)
 	var (
 		ttl,
 		maxIdle time.Duration
 	)
 	cmd := &cobra.Command{
-		Use:     "put [--name mapname | --key keyname | --value-type type | {--value-file file | --value value} | --ttl ttl | --max-idle max-idle]",
+		Use:     "put [--name mapname | {--key keyname | --key-random-uuid} | --value-type type | {--value-file file | --value value} | --ttl ttl | --max-idle max-idle]",
 		Short:   "Put value to map",
 		Example: MapPutExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			key, err := internal.ConvertString(mapKey, mapKeyType)
-			if err != nil {
-				return hzcerrors.NewLoggableError(err, "Conversion error on key %s to type %s", mapKey, mapKeyType)
+			if err := validateBinaryFormat(binaryFormat, binaryInputFormats); err != nil {
+				return err
+			}
+			var key interface{}
+			var err error
+			if randomUUID {
+				if err = validateRandomUUIDKey(cmd, mapKeyType); err != nil {
+					return err
+				}
+				key = types.NewUUID()
+			} else {
+				if !cmd.Flags().Changed(MapKeyFlag) {
+					return hzcerrors.NewLoggableError(nil, "Provide the key with --%s, or generate it with --%s", MapKeyFlag, KeyRandomUUIDFlag)
+				}
+				if key, err = internal.ConvertString(mapKey, mapKeyType); err != nil {
+					return hzcerrors.NewLoggableError(err, "Conversion error on key %s to type %s", mapKey, mapKeyType)
+				}
 			}
 			var (
 				ttlE,
@@ -68,22 +93,27 @@ func NewPut(config *hazelcast.Config) *cobra.Command {
 				maxIdleE = true
 			}
 			var normalizedValue interface{}
-			if normalizedValue, err = normalizeMapValue(mapValue, mapValueFile, mapValueType); err != nil {
+			if normalizedValue, err = normalizeMapValue(cmd.InOrStdin(), mapValue, mapValueFile, mapValueType, binaryFormat); err != nil {
 				return err
 			}
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "put the entry (key: %s, value: %s) to map %s%s", output.String(key), valueString(normalizedValue, output.BinaryOmit), mapName, expiryInfo(ttl, maxIdle))
+				return nil
+			}
 			m, err := getMap(cmd.Context(), config, mapName)
 			if err != nil {
 				return err
 			}
+			var old interface{}
 			switch {
 			case ttlE && maxIdleE:
-				_, err = m.PutWithTTLAndMaxIdle(cmd.Context(), key, normalizedValue, ttl, maxIdle)
+				old, err = m.PutWithTTLAndMaxIdle(cmd.Context(), key, normalizedValue, ttl, maxIdle)
 			case ttlE:
-				_, err = m.PutWithTTL(cmd.Context(), key, normalizedValue, ttl)
+				old, err = m.PutWithTTL(cmd.Context(), key, normalizedValue, ttl)
 			case maxIdleE:
-				_, err = m.PutWithMaxIdle(cmd.Context(), key, normalizedValue, maxIdle)
+				old, err = m.PutWithMaxIdle(cmd.Context(), key, normalizedValue, maxIdle)
 			default:
-				_, err = m.Put(cmd.Context(), key, normalizedValue)
+				old, err = m.Put(cmd.Context(), key, normalizedValue)
 			}
 			if err != nil {
 				var handled bool
@@ -93,15 +123,39 @@ func NewPut(config *hazelcast.Config) *cobra.Command {
 				}
 				return hzcerrors.NewLoggableError(err, "Cannot put given entry to the map %s", mapName)
 			}
+			transaction.Record(cmd.Context(), fmt.Sprintf("put key %s to map %s", output.String(key), mapName), restoreEntry(m, key, old))
+			if randomUUID {
+				// the generated key is the output, so that it can be used in scripts
+				fmt.Fprintln(cmd.OutOrStdout(), output.String(key))
+			}
 			return nil
 		},
 	}
 	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
-	decorateCommandWithMapKeyFlags(cmd, &mapKey, true, "key of the entry")
+	decorateCommandWithMapKeyFlags(cmd, &mapKey, false, "key of the entry")
 	decorateCommandWithMapKeyTypeFlags(cmd, &mapKeyType, false)
+	decorateCommandWithKeyRandomUUID(cmd, &randomUUID, "generate a random UUID key, which is printed after the entry is put")
 	decorateCommandWithValueFlags(cmd, &mapValue, &mapValueFile)
 	decorateCommandWithMapValueTypeFlags(cmd, &mapValueType, false)
+	decorateCommandWithBinaryFormat(cmd, &binaryFormat, binaryInputFormats, "encoding of the value for the binary value type, raw reads the bytes as they are")
 	decorateCommandWithTTL(cmd, &ttl, false, "ttl value of the entry")
 	decorateCommandWithMaxIdle(cmd, &maxIdle, false, "max-idle value of the entry")
-	return cmd
+	return transaction.Supported(dryrun.Supported(cmd))
+}
+
+// expiryInfo describes the non-zero ttl and max-idle values of an entry.
+func expiryInfo(ttl, maxIdle time.Duration) string {
+	var s string
+	if ttl != 0 {
+		s += fmt.Sprintf(" with ttl %s", ttl)
+	}
+	if maxIdle != 0 {
+		if s == "" {
+			s += " with"
+		} else {
+			s += " and"
+		}
+		s += fmt.Sprintf(" max-idle %s", maxIdle)
+	}
+	return s
 }
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mapcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/aggregate"
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/confirmation"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/transaction"
+)
+
+const MapRemoveAllExample = `  # Remove the entries whose values are younger than 18, after confirming the number of the entries
+  hzc map remove-all -n mapname --predicate "age < 18"
+  # Remove the entries without asking, such as in scripts
+  hzc map remove-all -n mapname --predicate "active = false" --confirm=false`
+
+func NewRemoveAll(config *hazelcast.Config) *cobra.Command {
+	var (
+		mapName string
+		pred    string
+		confirm bool
+	)
+	cmd := &cobra.Command{
+		Use:   "remove-all [--name mapname] --predicate expression [--confirm=false]",
+		Short: "Remove the entries matching the predicate on the cluster",
+		Long: `Remove the entries of the map matching the predicate on the cluster, without fetching them to the client.
+The matching entries are counted first, and the removal is asked for confirmation unless --confirm=false is given. Use clear to remove all the entries.`,
+		Example: MapRemoveAllExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(pred) == "" {
+				return hzcerrors.NewLoggableError(nil, "Provide the predicate with --predicate, use clear to remove all the entries")
+			}
+			ctx := cmd.Context()
+			m, err := getMap(ctx, config, mapName)
+			if err != nil {
+				return err
+			}
+			p := predicate.SQL(pred)
+			// counting does not change the data, so it is run in the dry run mode as well
+			count, err := m.AggregateWithPredicate(ctx, aggregate.Count(""), p)
+			if err != nil {
+				handled, err := isCloudIssue(err, config)
+				if handled {
+					return err
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot count the entries of map %s matching the predicate", mapName)
+			}
+			n, _ := count.(int64)
+			if n == 0 {
+				quiet.Printf(cmd, "No entries of map %s match the predicate\n", mapName)
+				return nil
+			}
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "remove %d entries matching the predicate from map %s", n, mapName)
+				return nil
+			}
+			if confirm {
+				ok, err := confirmation.Ask(cmd.InOrStdin(), cmd.ErrOrStderr(), fmt.Sprintf("Remove %d entries of map %s?", n, mapName))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					quiet.Printf(cmd, "Nothing is removed\n")
+					return nil
+				}
+			}
+			if transaction.Active(ctx) {
+				// the entries are kept to put them back if the transaction block is rolled back
+				entries, err := m.GetEntrySetWithPredicate(ctx, p)
+				if err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot get the entries of map %s to roll back removing them", mapName)
+				}
+				transaction.Record(ctx, fmt.Sprintf("remove %d entries of map %s", len(entries), mapName), restoreEntries(m, nil, entries))
+			}
+			if err := m.RemoveAll(ctx, p); err != nil {
+				handled, err := isCloudIssue(err, config)
+				if handled {
+					return err
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot remove the entries of map %s", mapName)
+			}
+			quiet.Printf(cmd, "Removed the %d entries matching the predicate from map %s\n", n, mapName)
+			return nil
+		},
+	}
+	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
+	flags := cmd.Flags()
+	flags.StringVar(&pred, "predicate", "", `filter of the entries to remove in the predicate SQL syntax, such as "age < 18"`)
+	if err := cmd.MarkFlagRequired("predicate"); err != nil {
+		panic(err)
+	}
+	flags.BoolVar(&confirm, defaults.ConfirmFlag, true, "ask before removing the matching entries")
+	return transaction.Supported(dryrun.Supported(cmd))
+}
@@ -17,11 +17,16 @@
 package mapcmd
 
 import (
+	"fmt"
+
 	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/spf13/cobra"
 
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/transaction"
 )
 
 func NewRemove(config *hazelcast.Config) *cobra.Command {
@@ -40,11 +45,15 @@ func NewRemove(config *hazelcast.Config) *cobra.Command {
 			if err != nil {
 				return hzcerrors.NewLoggableError(err, "Conversion error on key %s to type %s", mapKey, mapKeyType)
 			}
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "remove key %s from map %s", output.String(key), mapName)
+				return nil
+			}
 			m, err := getMap(cmd.Context(), config, mapName)
 			if err != nil {
 				return err
 			}
-			_, err = m.Remove(cmd.Context(), key)
+			old, err := m.Remove(cmd.Context(), key)
 			if err != nil {
 				var handled bool
 				handled, err = isCloudIssue(err, config)
@@ -53,11 +62,14 @@ func NewRemove(config *hazelcast.Config) *cobra.Command {
 				}
 				return hzcerrors.NewLoggableError(err, "Cannot remove given key from map %s", mapName)
 			}
+			if old != nil {
+				transaction.Record(cmd.Context(), fmt.Sprintf("remove key %s from map %s", output.String(key), mapName), restoreEntry(m, key, old))
+			}
 			return nil
 		},
 	}
 	decorateCommandWithMapNameFlags(cmd, &mapName, true, "specify the map name")
 	decorateCommandWithMapKeyFlags(cmd, &mapKey, true, "key of the entry")
 	decorateCommandWithMapKeyTypeFlags(cmd, &mapKeyType, false)
-	return cmd
+	return transaction.Supported(dryrun.Supported(cmd))
 }
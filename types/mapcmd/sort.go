@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mapcmd
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+// sortEntries sorts the entries by the attribute, which is extracted the same way as the projected attributes.
+// The entries without the attribute come last in both orders.
+func sortEntries(entries []types.Entry, attr string, desc bool) error {
+	p := projection{attributes: []string{attr}}
+	values := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		v, err := p.project(entry)
+		if err != nil {
+			return err
+		}
+		values[i] = v[0]
+	}
+	sort.Stable(&entrySorter{entries: entries, values: values, desc: desc})
+	return nil
+}
+
+type entrySorter struct {
+	entries []types.Entry
+	values  []interface{}
+	desc    bool
+}
+
+func (s *entrySorter) Len() int {
+	return len(s.entries)
+}
+
+func (s *entrySorter) Less(i, j int) bool {
+	a, b := s.values[i], s.values[j]
+	if a == nil || b == nil {
+		return a != nil
+	}
+	if s.desc {
+		return compareValues(b, a) < 0
+	}
+	return compareValues(a, b) < 0
+}
+
+func (s *entrySorter) Swap(i, j int) {
+	s.entries[i], s.entries[j] = s.entries[j], s.entries[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+// compareValues compares the numbers by their exact values and the other values by their text.
+func compareValues(a, b interface{}) int {
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			return x.Cmp(y)
+		}
+	}
+	return strings.Compare(output.String(a), output.String(b))
+}
+
+// number returns the exact value of the number, so that the decimals and big integers are compared without losing precision.
+func number(v interface{}) (*big.Rat, bool) {
+	switch n := v.(type) {
+	case int8:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int16:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int32:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int64:
+		return new(big.Rat).SetInt64(n), true
+	case int:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case float32:
+		return floatNumber(float64(n))
+	case float64:
+		return floatNumber(n)
+	case json.Number:
+		return new(big.Rat).SetString(n.String())
+	case *big.Int:
+		if n != nil {
+			return new(big.Rat).SetInt(n), true
+		}
+	case types.Decimal:
+		return decimalNumber(n), true
+	case *types.Decimal:
+		if n != nil {
+			return decimalNumber(*n), true
+		}
+	}
+	return nil, false
+}
+
+// floatNumber returns the value of the float, NaN and infinities are not numbers to compare.
+func floatNumber(f float64) (*big.Rat, bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, false
+	}
+	return new(big.Rat).SetFloat64(f), true
+}
+
+func decimalNumber(d types.Decimal) *big.Rat {
+	r := new(big.Rat).SetInt(d.UnscaledValue())
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(d.Scale()))), nil)
+	if d.Scale() >= 0 {
+		return r.Quo(r, new(big.Rat).SetInt(scale))
+	}
+	return r.Mul(r, new(big.Rat).SetInt(scale))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queuecmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/progress"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const OutFlag = "out"
+
+func NewDrain(config *hazelcast.Config) *cobra.Command {
+	var (
+		queueName,
+		out string
+		count int
+	)
+	cmd := &cobra.Command{
+		Use:   "drain --name queuename [--out file] [--count count]",
+		Short: "Remove the items of the queue and write them to a file",
+		Long: `Remove the items of the queue and write them to a file, one JSON object with the value on each line.
+The items are removed in batches. If a batch cannot be written, its items are put back to the end of the queue.`,
+		Example: `  # Write the items of the queue to a file, removing them from the queue
+  hzc queue drain --name orders-dlq --out orders-dlq.jsonl
+  # Print the first 10 items, removing them from the queue
+  hzc queue drain --name orders-dlq --count 10`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dest := out
+			if out == "-" {
+				dest = "the standard output"
+			}
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "remove %s of queue %s and write them to %s", countInfo(count), queueName, dest)
+				return nil
+			}
+			w := cmd.OutOrStdout()
+			if out != "-" {
+				f, err := os.Create(out)
+				if err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot create the file %s", out)
+				}
+				defer f.Close()
+				w = f
+			}
+			q, err := getQueue(cmd.Context(), config, queueName)
+			if err != nil {
+				return err
+			}
+			tracker := progress.New(cmd.ErrOrStderr(), "Draining items", int64(count))
+			n, err := drainItems(cmd.Context(), q, count, func(items []interface{}) error {
+				if err := writeItems(w, items); err != nil {
+					return err
+				}
+				tracker.Add(int64(len(items)))
+				return nil
+			})
+			tracker.Done()
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot drain queue %s, %d items are written to %s", queueName, n, dest)
+			}
+			if out != "-" {
+				quiet.Printf(cmd, "Drained %d items of queue %s to %s\n", n, queueName, out)
+			}
+			return nil
+		},
+	}
+	decorateCommandWithQueueNameFlag(cmd, &queueName)
+	cmd.Flags().StringVarP(&out, OutFlag, "o", "-", `file to write the items to, use "-" (dash) for the standard output`)
+	decorateCommandWithCountFlag(cmd, &count, "maximum number of items to drain, all of them by default")
+	return dryrun.Supported(cmd)
+}
+
+// writeItems writes each item as a JSON object with the value on a line, the whole batch is flushed at once.
+func writeItems(w io.Writer, items []interface{}) error {
+	bw := bufio.NewWriter(w)
+	opts := output.DefaultOptions()
+	for _, item := range items {
+		b, err := opts.MarshalJSONObject([]string{"value"}, []interface{}{item})
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(bw, "%s\n", b); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func decorateCommandWithQueueNameFlag(cmd *cobra.Command, queueName *string) {
+	cmd.Flags().StringVarP(queueName, QueueNameFlag, QueueNameFlagShort, "", "name of the queue")
+	if err := cmd.MarkFlagRequired(QueueNameFlag); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queuecmd
+
+import (
+	"fmt"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/progress"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const (
+	FromFlag = "from"
+	ToFlag   = "to"
+)
+
+func NewMove(config *hazelcast.Config) *cobra.Command {
+	var (
+		from,
+		to string
+		count int
+	)
+	cmd := &cobra.Command{
+		Use:   "move --from queuename --to queuename [--count count]",
+		Short: "Move the items of a queue to the end of another queue",
+		Long: `Move the items of a queue to the end of another queue, in the same order.
+The items are moved in batches. If a batch cannot be added to the target queue, its items are put back to the end of the source queue.`,
+		Example: `  # Move at most 100 items from the dead letter queue back to the queue
+  hzc queue move --from orders-dlq --to orders --count 100`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if from == to {
+				return hzcerrors.NewLoggableError(nil, "The source and the target queues must be different")
+			}
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "move %s from queue %s to queue %s", countInfo(count), from, to)
+				return nil
+			}
+			ctx := cmd.Context()
+			source, err := getQueue(ctx, config, from)
+			if err != nil {
+				return err
+			}
+			target, err := getQueue(ctx, config, to)
+			if err != nil {
+				return err
+			}
+			tracker := progress.New(cmd.ErrOrStderr(), "Moving items", int64(count))
+			n, err := drainItems(ctx, source, count, func(items []interface{}) error {
+				added, err := target.AddAll(ctx, items...)
+				if err != nil {
+					return err
+				}
+				if !added {
+					return fmt.Errorf("the items cannot be added to queue %s", to)
+				}
+				tracker.Add(int64(len(items)))
+				return nil
+			})
+			tracker.Done()
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot move the items of queue %s, %d items are moved to %s", from, n, to)
+			}
+			quiet.Printf(cmd, "Moved %d items from queue %s to %s\n", n, from, to)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, FromFlag, "", "name of the queue to remove the items from")
+	cmd.Flags().StringVar(&to, ToFlag, "", "name of the queue to add the items to")
+	for _, f := range []string{FromFlag, ToFlag} {
+		if err := cmd.MarkFlagRequired(f); err != nil {
+			panic(err)
+		}
+	}
+	decorateCommandWithCountFlag(cmd, &count, "maximum number of items to move, all of them by default")
+	return dryrun.Supported(cmd)
+}
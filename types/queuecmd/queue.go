@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queuecmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+)
+
+const (
+	QueueNameFlag      = "name"
+	QueueNameFlagShort = "n"
+	CountFlag          = "count"
+)
+
+const QueueExample = `  # Write the items of the queue to a file, removing them from the queue
+  hzc queue drain --name orders-dlq --out orders-dlq.jsonl
+  # Move at most 100 items from the dead letter queue back to the queue
+  hzc queue move --from orders-dlq --to orders --count 100`
+
+// drainBatchSize is the number of items removed from a queue at once
+const drainBatchSize = 1000
+
+func New(config *hazelcast.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "queue {drain | move}",
+		Short:   "Queue operations",
+		Example: QueueExample,
+	}
+	cmd.AddCommand(NewDrain(config), NewMove(config))
+	return cmd
+}
+
+// itemQueue is the part of the queue the commands use to remove and add items.
+type itemQueue interface {
+	DrainWithMaxSize(ctx context.Context, maxSize int) ([]interface{}, error)
+	AddAll(ctx context.Context, values ...interface{}) (bool, error)
+}
+
+// drainItems removes at most count items from the queue in batches and passes each batch to handle, count <= 0 means all of them.
+// It stops once the queue is empty, so the items added meanwhile may be left.
+// If handle fails, the items of the batch are put back to the end of the queue, so that they are not lost.
+func drainItems(ctx context.Context, q itemQueue, count int, handle func(items []interface{}) error) (int, error) {
+	var n int
+	for count <= 0 || n < count {
+		size := drainBatchSize
+		if count > 0 && count-n < size {
+			size = count - n
+		}
+		items, err := q.DrainWithMaxSize(ctx, size)
+		if err != nil {
+			return n, err
+		}
+		if len(items) == 0 {
+			break
+		}
+		if err := handle(items); err != nil {
+			if _, addErr := q.AddAll(ctx, items...); addErr != nil {
+				return n, fmt.Errorf("%w, and %d removed items cannot be put back: %s", err, len(items), addErr)
+			}
+			return n, fmt.Errorf("%w, %d removed items are put back to the end of the queue", err, len(items))
+		}
+		n += len(items)
+		if len(items) < size {
+			break
+		}
+	}
+	return n, nil
+}
+
+func getQueue(ctx context.Context, clientConfig *hazelcast.Config, name string) (*hazelcast.Queue, error) {
+	hzcClient, err := internal.ConnectToCluster(ctx, clientConfig)
+	if err != nil {
+		return nil, hzcerrors.NewLoggableError(err, "Cannot get initialize client")
+	}
+	q, err := hzcClient.GetQueue(ctx, name)
+	if err != nil {
+		if msg, isHandled := hzcerrors.TranslateNetworkError(err, clientConfig.Cluster.Cloud.Enabled); isHandled {
+			err = hzcerrors.NewLoggableError(err, msg)
+		}
+		return nil, err
+	}
+	return q, nil
+}
+
+// countInfo describes the number of items the command processes.
+func countInfo(count int) string {
+	if count <= 0 {
+		return "all items"
+	}
+	return fmt.Sprintf("at most %d items", count)
+}
+
+func decorateCommandWithCountFlag(cmd *cobra.Command, count *int, usage string) {
+	cmd.Flags().IntVar(count, CountFlag, 0, usage)
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queuecmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+)
+
+type fakeQueue struct {
+	items  []interface{}
+	drains int
+}
+
+func (q *fakeQueue) DrainWithMaxSize(ctx context.Context, maxSize int) ([]interface{}, error) {
+	q.drains++
+	if maxSize > len(q.items) {
+		maxSize = len(q.items)
+	}
+	items := q.items[:maxSize]
+	q.items = q.items[maxSize:]
+	return items, nil
+}
+
+func (q *fakeQueue) AddAll(ctx context.Context, values ...interface{}) (bool, error) {
+	q.items = append(q.items, values...)
+	return true, nil
+}
+
+func newFakeQueue(n int) *fakeQueue {
+	q := &fakeQueue{}
+	for i := 0; i < n; i++ {
+		q.items = append(q.items, i)
+	}
+	return q
+}
+
+func TestDrainItems(t *testing.T) {
+	for _, tc := range []struct {
+		info   string
+		size   int
+		count  int
+		n      int
+		drains int
+	}{
+		{info: "all", size: 2500, n: 2500, drains: 3},
+		{info: "all in full batches", size: 2000, n: 2000, drains: 3},
+		{info: "count", size: 2500, count: 1200, n: 1200, drains: 2},
+		{info: "count more than size", size: 10, count: 50, n: 10, drains: 1},
+		{info: "empty", drains: 1},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			q := newFakeQueue(tc.size)
+			var handled []interface{}
+			n, err := drainItems(context.Background(), q, tc.count, func(items []interface{}) error {
+				handled = append(handled, items...)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != tc.n || len(handled) != tc.n || len(q.items) != tc.size-tc.n {
+				t.Fatalf("want %d items drained got %d, handled %d, left %d", tc.n, n, len(handled), len(q.items))
+			}
+			if q.drains != tc.drains {
+				t.Errorf("want %d drains got %d", tc.drains, q.drains)
+			}
+			for i, item := range handled {
+				if item != i {
+					t.Fatalf("want item %d got %v", i, item)
+				}
+			}
+		})
+	}
+}
+
+func TestDrainItemsPutsBackFailedBatch(t *testing.T) {
+	q := newFakeQueue(1500)
+	var batches int
+	n, err := drainItems(context.Background(), q, 0, func(items []interface{}) error {
+		batches++
+		if batches == 2 {
+			return errors.New("disk full")
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatal("want error")
+	}
+	if n != drainBatchSize {
+		t.Errorf("want %d items drained got %d", drainBatchSize, n)
+	}
+	// the failed batch is back at the end of the queue
+	if len(q.items) != 500 || q.items[0] != 1000 {
+		t.Errorf("unexpected items left: %d starting with %v", len(q.items), q.items[0])
+	}
+}
+
+func TestWriteItems(t *testing.T) {
+	var b bytes.Buffer
+	items := []interface{}{"order-1", int64(42), serialization.JSON(`{"id":1}`)}
+	if err := writeItems(&b, items); err != nil {
+		t.Fatal(err)
+	}
+	want := "{\"value\":\"order-1\"}\n{\"value\":42}\n{\"value\":{\"id\":1}}\n"
+	if b.String() != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, b.String())
+	}
+}
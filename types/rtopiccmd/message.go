@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rtopiccmd
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The messages of a reliable topic are kept in its ringbuffer as the ReliableTopicMessage objects of the members,
+// which are identified data serializable objects holding the publish time, the address of the publisher and the serialized payload.
+const (
+	// typeDataSerializable is the serialization type of the identified data serializable objects
+	typeDataSerializable = -2
+	// typeNull is the serialization type written for the nil objects
+	typeNull = 0
+	// topicFactoryID and reliableTopicMessageClassID identify ReliableTopicMessage
+	topicFactoryID              = -18
+	reliableTopicMessageClassID = 2
+	// the flags of the header of the data serializable objects
+	identifiedFlag = 1 << 0
+	versionedFlag  = 1 << 1
+	// dataHeaderSize is the size of the partition hash and the serialization type before the serialized object
+	dataHeaderSize = 8
+)
+
+// topicMessage is a message kept in the ringbuffer of a reliable topic.
+type topicMessage struct {
+	publishTime time.Time
+	// payload is the serialized value of the message
+	payload []byte
+}
+
+var errShortMessage = errors.New("the message ends unexpectedly")
+
+// encodeTopicMessage serializes the message as the clients do, without the address of the publisher.
+func encodeTopicMessage(payload []byte, publishTime time.Time) []byte {
+	b := make([]byte, 0, dataHeaderSize+1+4+4+8+4+4+len(payload))
+	// the partition hash is not used for the items of the ringbuffer
+	b = appendInt(b, 0)
+	b = appendInt(b, typeDataSerializable)
+	b = append(b, identifiedFlag)
+	b = appendInt(b, topicFactoryID)
+	b = appendInt(b, reliableTopicMessageClassID)
+	b = appendLong(b, publishTime.UnixNano()/int64(time.Millisecond))
+	b = appendInt(b, typeNull)
+	b = appendInt(b, int32(len(payload)))
+	return append(b, payload...)
+}
+
+// decodeTopicMessage deserializes the message kept in the ringbuffer.
+func decodeTopicMessage(item []byte) (topicMessage, error) {
+	var m topicMessage
+	r := &reader{b: item}
+	r.skip(4)
+	if t := r.int(); t != typeDataSerializable {
+		return m, fmt.Errorf("unexpected serialization type %d of the message", t)
+	}
+	if err := r.dataSerializableHeader(topicFactoryID, reliableTopicMessageClassID); err != nil {
+		return m, err
+	}
+	m.publishTime = time.Unix(0, r.long()*int64(time.Millisecond))
+	switch t := r.int(); t {
+	case typeNull:
+	case typeDataSerializable:
+		// the address of the member which published the message, its port, type and host are skipped
+		if err := r.dataSerializableHeader(0, 1); err != nil {
+			return m, err
+		}
+		r.skip(4 + 1)
+		r.string()
+	default:
+		return m, fmt.Errorf("unexpected serialization type %d of the publisher", t)
+	}
+	if n := r.int(); n >= 0 {
+		m.payload = r.bytes(int(n))
+	}
+	return m, r.err
+}
+
+func appendInt(b []byte, v int32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(v))
+	return append(b, buf[:]...)
+}
+
+func appendLong(b []byte, v int64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(v))
+	return append(b, buf[:]...)
+}
+
+// reader reads the big endian fields of a serialized object, err is set once the object ends before a field.
+type reader struct {
+	b   []byte
+	err error
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.b) {
+		r.err = errShortMessage
+		return nil
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *reader) skip(n int) {
+	r.bytes(n)
+}
+
+func (r *reader) int() int32 {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return int32(binary.BigEndian.Uint32(b))
+}
+
+func (r *reader) long() int64 {
+	b := r.bytes(8)
+	if b == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(b))
+}
+
+func (r *reader) string() string {
+	n := r.int()
+	if n < 0 {
+		return ""
+	}
+	return string(r.bytes(int(n)))
+}
+
+// dataSerializableHeader reads the header of an identified data serializable object and checks its factory and class IDs.
+func (r *reader) dataSerializableHeader(factoryID, classID int32) error {
+	flags := r.bytes(1)
+	if r.err != nil {
+		return r.err
+	}
+	if flags[0]&identifiedFlag == 0 {
+		return errors.New("the message is not an identified data serializable object")
+	}
+	f, c := r.int(), r.int()
+	if flags[0]&versionedFlag != 0 {
+		r.skip(2)
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if f != factoryID || c != classID {
+		return fmt.Errorf("unexpected object with factory ID %d and class ID %d", f, c)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+//go:build hazelcastinternal
+// +build hazelcastinternal
+
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rtopiccmd
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/hazelcast/hazelcast-go-client"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+)
+
+// The Go client has no ringbuffer proxy, so the requests of the ringbuffer are encoded as the client protocol defines them.
+const (
+	ringbufferTailSequenceRequest = int32(0x170200)
+	ringbufferHeadSequenceRequest = int32(0x170300)
+	ringbufferAddRequest          = int32(0x170600)
+	ringbufferReadManyRequest     = int32(0x170900)
+	// overflowOverwrite is the overflow policy which overwrites the oldest item if the ringbuffer is full
+	overflowOverwrite = 0
+	// responseValueOffset is the offset of the first fixed size field of the responses
+	responseValueOffset = hazelcast.ResponseBackupAcksOffset + hazelcast.ByteSizeInBytes
+)
+
+type clientRingbuffer struct {
+	ci   *hazelcast.ClientInternal
+	name string
+	// key is the serialized partition key of the ringbuffer, the requests are sent to the owner of its partition
+	key hazelcast.Data
+}
+
+func getRingbuffer(ctx context.Context, clientConfig *hazelcast.Config, topicName string) (ringbuffer, error) {
+	hzcClient, err := internal.ConnectToCluster(ctx, clientConfig)
+	if err != nil {
+		return nil, hzcerrors.NewLoggableError(err, "Cannot get initialize client")
+	}
+	ci := hazelcast.NewClientInternal(hzcClient)
+	name := ringbufferName(topicName)
+	key, err := ci.EncodeData(partitionKey(name))
+	if err != nil {
+		return nil, err
+	}
+	return &clientRingbuffer{ci: ci, name: name, key: key}, nil
+}
+
+// newRequest returns the request with the initial frame of the given size and the name of the ringbuffer.
+func (rb *clientRingbuffer) newRequest(messageType int32, frameSize int, retryable bool) (*hazelcast.ClientMessage, hazelcast.Frame) {
+	msg := hazelcast.NewClientMessageForEncode()
+	msg.SetRetryable(retryable)
+	initial := hazelcast.NewFrameWith(make([]byte, frameSize), hazelcast.UnfragmentedMessage)
+	msg.AddFrame(initial)
+	msg.SetMessageType(messageType)
+	msg.SetPartitionId(-1)
+	msg.AddFrame(hazelcast.NewFrame([]byte(rb.name)))
+	return msg, initial
+}
+
+func (rb *clientRingbuffer) invoke(ctx context.Context, msg *hazelcast.ClientMessage) (*hazelcast.ClientMessage, error) {
+	resp, err := rb.ci.InvokeOnKey(ctx, msg, rb.key, nil)
+	if err != nil {
+		if msg, handled := hzcerrors.TranslateNetworkError(err, false); handled {
+			return nil, hzcerrors.NewLoggableError(err, msg)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (rb *clientRingbuffer) sequence(ctx context.Context, messageType int32) (int64, error) {
+	msg, _ := rb.newRequest(messageType, hazelcast.PartitionIDOffset+hazelcast.IntSizeInBytes, true)
+	resp, err := rb.invoke(ctx, msg)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.LittleEndian.Uint64(resp.FrameIterator().Next().Content[responseValueOffset:])), nil
+}
+
+func (rb *clientRingbuffer) HeadSequence(ctx context.Context) (int64, error) {
+	return rb.sequence(ctx, ringbufferHeadSequenceRequest)
+}
+
+func (rb *clientRingbuffer) TailSequence(ctx context.Context) (int64, error) {
+	return rb.sequence(ctx, ringbufferTailSequenceRequest)
+}
+
+func (rb *clientRingbuffer) Add(ctx context.Context, item []byte) (int64, error) {
+	// the overflow policy follows the partition ID in the initial frame
+	msg, initial := rb.newRequest(ringbufferAddRequest, hazelcast.PartitionIDOffset+2*hazelcast.IntSizeInBytes, false)
+	binary.LittleEndian.PutUint32(initial.Content[hazelcast.PartitionIDOffset+hazelcast.IntSizeInBytes:], overflowOverwrite)
+	msg.AddFrame(hazelcast.NewFrame(item))
+	resp, err := rb.invoke(ctx, msg)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.LittleEndian.Uint64(resp.FrameIterator().Next().Content[responseValueOffset:])), nil
+}
+
+func (rb *clientRingbuffer) ReadMany(ctx context.Context, from int64, maxCount int32) ([][]byte, int64, error) {
+	// the start sequence, the minimum and the maximum counts follow the partition ID in the initial frame
+	offset := hazelcast.PartitionIDOffset + hazelcast.IntSizeInBytes
+	msg, initial := rb.newRequest(ringbufferReadManyRequest, offset+hazelcast.LongSizeInBytes+2*hazelcast.IntSizeInBytes, true)
+	binary.LittleEndian.PutUint64(initial.Content[offset:], uint64(from))
+	// the minimum count is 0, so that the member responds with the items it has instead of waiting for more
+	binary.LittleEndian.PutUint32(initial.Content[offset+hazelcast.LongSizeInBytes+hazelcast.IntSizeInBytes:], uint32(maxCount))
+	// no filter
+	msg.AddFrame(hazelcast.NullFrame.Copy())
+	resp, err := rb.invoke(ctx, msg)
+	if err != nil {
+		return nil, 0, err
+	}
+	it := resp.FrameIterator()
+	// the read count precedes the next sequence in the initial frame
+	next := int64(binary.LittleEndian.Uint64(it.Next().Content[responseValueOffset+hazelcast.IntSizeInBytes:]))
+	var items [][]byte
+	// the items are a list of data frames between the begin and the end frames
+	it.Next()
+	for !it.PeekNext().IsEndFrame() {
+		items = append(items, it.Next().Content)
+	}
+	return items, next, nil
+}
+
+func (rb *clientRingbuffer) Encode(v interface{}) ([]byte, error) {
+	data, err := rb.ci.EncodeData(v)
+	if err != nil {
+		return nil, err
+	}
+	return data.ToByteArray(), nil
+}
+
+func (rb *clientRingbuffer) Decode(b []byte) (interface{}, error) {
+	return rb.ci.DecodeData(hazelcast.Data(b))
+}
@@ -0,0 +1,33 @@
+//go:build !hazelcastinternal
+// +build !hazelcastinternal
+
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rtopiccmd
+
+import (
+	"context"
+
+	"github.com/hazelcast/hazelcast-go-client"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+// getRingbuffer returns an error, since the requests of the ringbuffer are sent with the internal API of the client,
+// which is built only with the hazelcastinternal tag.
+func getRingbuffer(ctx context.Context, clientConfig *hazelcast.Config, topicName string) (ringbuffer, error) {
+	return nil, hzcerrors.NewLoggableError(nil, "Reliable topics are not supported by this build of hzc, build it with \"make build\" or with the hazelcastinternal tag")
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rtopiccmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	TopicNameFlag      = "name"
+	TopicNameFlagShort = "n"
+	// ringbufferPrefix is the prefix of the name of the ringbuffer which keeps the messages of a reliable topic
+	ringbufferPrefix = "_hz_rb_"
+)
+
+const ReliableTopicExample = `  # Print the messages kept by the reliable topic and the ones published later, until Ctrl+C is pressed
+  hzc rtopic subscribe --name orders --from-oldest
+  # Publish a JSON message to the reliable topic
+  hzc rtopic publish --name orders --value-type json --value '{"id": 1}'`
+
+// ringbuffer is the ringbuffer which keeps the messages of a reliable topic, the items are the serialized messages.
+type ringbuffer interface {
+	// HeadSequence returns the sequence of the oldest item, which is one more than the tail if the ringbuffer is empty.
+	HeadSequence(ctx context.Context) (int64, error)
+	// TailSequence returns the sequence of the newest item, -1 if nothing is added yet.
+	TailSequence(ctx context.Context) (int64, error)
+	// Add adds the item and returns its sequence, the oldest item is overwritten if the ringbuffer is full.
+	Add(ctx context.Context, item []byte) (int64, error)
+	// ReadMany returns up to maxCount items from the sequence without waiting for them, and the sequence to read next.
+	ReadMany(ctx context.Context, from int64, maxCount int32) ([][]byte, int64, error)
+	// Encode serializes the value with the serialization of the client.
+	Encode(v interface{}) ([]byte, error)
+	// Decode deserializes the value serialized with Encode or by the other clients and the members.
+	Decode(b []byte) (interface{}, error)
+}
+
+func New(config *hazelcast.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rtopic {publish | subscribe} --name topicname",
+		Short: "Reliable topic operations",
+		Long: `Reliable topic operations. Reliable topics keep their messages in a ringbuffer,
+so the messages published before subscribing can be read as long as they are not overwritten by the newer ones.`,
+		Example: ReliableTopicExample,
+	}
+	cmd.AddCommand(NewPublish(config), NewSubscribe(config))
+	return cmd
+}
+
+// ringbufferName returns the name of the ringbuffer of the reliable topic.
+func ringbufferName(topicName string) string {
+	return ringbufferPrefix + topicName
+}
+
+// partitionKey returns the part of the name which determines the partition of the ringbuffer, as the members do.
+func partitionKey(name string) string {
+	if i := strings.IndexByte(name, '@'); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func decorateCommandWithTopicNameFlag(cmd *cobra.Command, topicName *string) {
+	cmd.Flags().StringVarP(topicName, TopicNameFlag, TopicNameFlagShort, "", "name of the reliable topic")
+	if err := cmd.MarkFlagRequired(TopicNameFlag); err != nil {
+		panic(err)
+	}
+}
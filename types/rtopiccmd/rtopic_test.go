@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rtopiccmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeRingbuffer keeps the items from the head sequence, the payloads are the values as they are.
+type fakeRingbuffer struct {
+	head  int64
+	items [][]byte
+}
+
+func (f *fakeRingbuffer) HeadSequence(ctx context.Context) (int64, error) {
+	return f.head, nil
+}
+
+func (f *fakeRingbuffer) TailSequence(ctx context.Context) (int64, error) {
+	return f.head + int64(len(f.items)) - 1, nil
+}
+
+func (f *fakeRingbuffer) Add(ctx context.Context, item []byte) (int64, error) {
+	f.items = append(f.items, item)
+	return f.TailSequence(ctx)
+}
+
+func (f *fakeRingbuffer) ReadMany(ctx context.Context, from int64, maxCount int32) ([][]byte, int64, error) {
+	if from < f.head {
+		from = f.head
+	}
+	start := int(from - f.head)
+	end := start + int(maxCount)
+	if end > len(f.items) {
+		end = len(f.items)
+	}
+	return f.items[start:end], from + int64(end-start), nil
+}
+
+func (f *fakeRingbuffer) Encode(v interface{}) ([]byte, error) {
+	return []byte(v.(string)), nil
+}
+
+func (f *fakeRingbuffer) Decode(b []byte) (interface{}, error) {
+	return string(b), nil
+}
+
+func TestTopicMessage(t *testing.T) {
+	publishTime := time.Date(2022, 6, 1, 10, 30, 0, 125000000, time.UTC)
+	m, err := decodeTopicMessage(encodeTopicMessage([]byte("payload"), publishTime))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.publishTime.Equal(publishTime) || string(m.payload) != "payload" {
+		t.Fatalf("unexpected message %v", m)
+	}
+	// a message published by a member has the address of the member
+	member := []byte{0, 0, 0, 0, 0xff, 0xff, 0xff, 0xfe, 1, 0xff, 0xff, 0xff, 0xee, 0, 0, 0, 2}
+	member = appendLong(member, publishTime.UnixNano()/int64(time.Millisecond))
+	member = appendInt(member, typeDataSerializable)
+	member = append(member, identifiedFlag)
+	member = appendInt(appendInt(member, 0), 1)
+	member = append(appendInt(member, 5701), 4)
+	member = append(appendInt(member, 9), "127.0.0.1"...)
+	member = append(appendInt(member, 3), "abc"...)
+	if m, err = decodeTopicMessage(member); err != nil {
+		t.Fatal(err)
+	}
+	if string(m.payload) != "abc" {
+		t.Fatalf("want abc got %q", m.payload)
+	}
+	if _, err := decodeTopicMessage(member[:len(member)-1]); err == nil {
+		t.Fatal("want an error for a truncated message")
+	}
+}
+
+func TestReadMessages(t *testing.T) {
+	publishTime := time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC)
+	rb := &fakeRingbuffer{head: 10}
+	for _, v := range []string{"order-1", "order-2", "order-3"} {
+		if _, err := rb.Add(context.Background(), encodeTopicMessage([]byte(v), publishTime)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var b bytes.Buffer
+	if err := readMessages(context.Background(), &b, rb, 11, 2, "json"); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"sequence":11,"publishTime":"2022-06-01T10:30:00Z","value":"order-2"}
+{"sequence":12,"publishTime":"2022-06-01T10:30:00Z","value":"order-3"}
+`
+	if b.String() != want {
+		t.Fatalf("want %q got %q", want, b.String())
+	}
+	// the overwritten messages are skipped
+	b.Reset()
+	if err := readMessages(context.Background(), &b, rb, 5, 1, "pretty"); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "order-1\n" {
+		t.Fatalf("want order-1 got %q", b.String())
+	}
+}
+
+func TestStartSequence(t *testing.T) {
+	rb := &fakeRingbuffer{head: 10, items: make([][]byte, 3)}
+	for _, tc := range []struct {
+		info         string
+		fromOldest   bool
+		fromSequence *int64
+		want         int64
+		isErr        bool
+	}{
+		{info: "new messages", want: 13},
+		{info: "oldest", fromOldest: true, want: 10},
+		{info: "sequence", fromSequence: int64Ptr(11), want: 11},
+		{info: "next sequence", fromSequence: int64Ptr(13), want: 13},
+		{info: "overwritten", fromSequence: int64Ptr(9), isErr: true},
+		{info: "after the newest", fromSequence: int64Ptr(14), isErr: true},
+	} {
+		t.Run(tc.info, func(t *testing.T) {
+			var from int64
+			if tc.fromSequence != nil {
+				from = *tc.fromSequence
+			}
+			got, err := startSequence(context.Background(), rb, tc.fromOldest, tc.fromSequence != nil, from)
+			if (err != nil) != tc.isErr {
+				t.Fatalf("want error %t got %v", tc.isErr, err)
+			}
+			if got != tc.want {
+				t.Fatalf("want %d got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rtopiccmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/defaults"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const (
+	CountFlag        = "count"
+	FromOldestFlag   = "from-oldest"
+	FromSequenceFlag = "from-sequence"
+	// maxReadCount is the largest number of the messages read from the ringbuffer at once
+	maxReadCount = 100
+	// pollInterval is the time waited before reading again once all the messages are read
+	pollInterval = 500 * time.Millisecond
+)
+
+// subscribeOutputTypes are the output types of the messages, pretty prints the value of each message on a line
+var subscribeOutputTypes = []string{output.TypePretty, output.TypeJSON}
+
+func NewSubscribe(config *hazelcast.Config) *cobra.Command {
+	var (
+		topicName    string
+		outputType   string
+		count        int
+		fromOldest   bool
+		fromSequence int64
+	)
+	cmd := &cobra.Command{
+		Use:   "subscribe --name topicname [--from-oldest | --from-sequence sequence] [--count count] [--output-type pretty|json]",
+		Short: "Print the messages of the reliable topic",
+		Long: `Print the messages published to the reliable topic, one message on each line, until Ctrl+C is pressed.
+The messages published after subscribing are printed by default. The messages kept in the ringbuffer of the topic are printed first
+with --from-oldest, or from the given sequence with --from-sequence.`,
+		Example: `  # Print the messages kept by the topic and the ones published later
+  hzc rtopic subscribe --name orders --from-oldest
+  # Print 10 messages from sequence 42 with their sequences and publish times as JSON objects
+  hzc rtopic subscribe --name orders --from-sequence 42 --count 10 -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isSubscribeOutputType(outputType) {
+				return hzcerrors.NewLoggableError(nil, "Provided output type parameter (%s) is not a known type. Provide either '%s'",
+					outputType, strings.Join(subscribeOutputTypes, "' or '"))
+			}
+			if count < 0 {
+				return hzcerrors.NewLoggableError(nil, "Count must not be negative")
+			}
+			fromSequenceGiven := cmd.Flags().Changed(FromSequenceFlag)
+			if fromOldest && fromSequenceGiven {
+				return hzcerrors.NewLoggableError(nil, "Provide either --%s or --%s", FromOldestFlag, FromSequenceFlag)
+			}
+			if fromSequenceGiven && fromSequence < 0 {
+				return hzcerrors.NewLoggableError(nil, "Sequence must not be negative")
+			}
+			ctx := cmd.Context()
+			rb, err := getRingbuffer(ctx, config, topicName)
+			if err != nil {
+				return err
+			}
+			from, err := startSequence(ctx, rb, fromOldest, fromSequenceGiven, fromSequence)
+			if err != nil {
+				return err
+			}
+			quiet.Printf(cmd, "Subscribed to reliable topic %s from sequence %d, press Ctrl+C to stop\n", topicName, from)
+			return readMessages(ctx, cmd.OutOrStdout(), rb, from, count, outputType)
+		},
+	}
+	decorateCommandWithTopicNameFlag(cmd, &topicName)
+	cmd.Flags().BoolVar(&fromOldest, FromOldestFlag, false, "print the messages from the oldest one kept by the topic")
+	cmd.Flags().Int64Var(&fromSequence, FromSequenceFlag, 0, "print the messages from the given sequence")
+	cmd.Flags().IntVar(&count, CountFlag, 0, "number of messages to print before stopping, 0 means until Ctrl+C is pressed")
+	cmd.Flags().StringVarP(&outputType, defaults.OutputTypeFlag, "o", output.TypePretty, strings.Join(subscribeOutputTypes, ", "))
+	defaults.SetValues(cmd, defaults.OutputTypeFlag, subscribeOutputTypes)
+	if err := cmd.RegisterFlagCompletionFunc(defaults.OutputTypeFlag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return subscribeOutputTypes, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func isSubscribeOutputType(outputType string) bool {
+	for _, t := range subscribeOutputTypes {
+		if t == outputType {
+			return true
+		}
+	}
+	return false
+}
+
+// startSequence returns the sequence of the first message to print, the one after the newest message unless a start is given.
+func startSequence(ctx context.Context, rb ringbuffer, fromOldest, fromSequenceGiven bool, fromSequence int64) (int64, error) {
+	if fromOldest {
+		head, err := rb.HeadSequence(ctx)
+		if err != nil {
+			return 0, hzcerrors.NewLoggableError(err, "Cannot get the sequence of the oldest message")
+		}
+		return head, nil
+	}
+	tail, err := rb.TailSequence(ctx)
+	if err != nil {
+		return 0, hzcerrors.NewLoggableError(err, "Cannot get the sequence of the newest message")
+	}
+	if !fromSequenceGiven {
+		return tail + 1, nil
+	}
+	if fromSequence > tail+1 {
+		return 0, hzcerrors.NewLoggableError(nil, "Sequence %d is after the newest message, which is at sequence %d", fromSequence, tail)
+	}
+	head, err := rb.HeadSequence(ctx)
+	if err != nil {
+		return 0, hzcerrors.NewLoggableError(err, "Cannot get the sequence of the oldest message")
+	}
+	if fromSequence < head {
+		return 0, hzcerrors.NewLoggableError(nil, "The message at sequence %d is overwritten, the oldest message is at sequence %d", fromSequence, head)
+	}
+	return fromSequence, nil
+}
+
+// readMessages prints the messages from the sequence until count messages are printed, or until the context is done if count is 0.
+func readMessages(ctx context.Context, w io.Writer, rb ringbuffer, from int64, count int, outputType string) error {
+	for n := 0; count == 0 || n < count; {
+		items, next, err := rb.ReadMany(ctx, from, maxReadCount)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return hzcerrors.NewLoggableError(err, "Cannot read the messages from sequence %d", from)
+		}
+		// the member skips the overwritten messages, so the sequences are counted back from the next one
+		first := next - int64(len(items))
+		for i, item := range items {
+			if count > 0 && n == count {
+				return nil
+			}
+			sequence := first + int64(i)
+			m, err := decodeTopicMessage(item)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot read the message at sequence %d", sequence)
+			}
+			value, err := rb.Decode(m.payload)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot deserialize the message at sequence %d", sequence)
+			}
+			if err := printMessage(w, sequence, m.publishTime, value, outputType); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot print the message")
+			}
+			n++
+		}
+		from = next
+		if len(items) > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+	return nil
+}
+
+// printMessage writes the value of the message on a line, or a JSON object with its sequence, publish time and value.
+func printMessage(w io.Writer, sequence int64, publishTime time.Time, value interface{}, outputType string) error {
+	if outputType != output.TypeJSON {
+		_, err := fmt.Fprintln(w, output.String(value))
+		return err
+	}
+	b, err := output.DefaultOptions().MarshalJSONObject([]string{"sequence", "publishTime", "value"},
+		[]interface{}{sequence, publishTime.UTC().Format(time.RFC3339Nano), value})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package topiccmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/dryrun"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+)
+
+const (
+	ValueFlag          = "value"
+	ValueFlagShort     = "v"
+	ValueTypeFlag      = "value-type"
+	ValueTypeFlagShort = "t"
+)
+
+func NewPublish(config *hazelcast.Config) *cobra.Command {
+	var (
+		topicName,
+		valueType string
+		values []string
+	)
+	cmd := &cobra.Command{
+		Use:   "publish --name topicname --value value [--value value]... [--value-type type]",
+		Short: "Publish messages to the topic",
+		Example: `  # Publish two messages, in the given order
+  hzc topic publish --name orders --value order-1 --value order-2
+  # Publish a JSON message
+  hzc topic publish --name orders --value-type json --value '{"id": 1}'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			messages := make([]interface{}, len(values))
+			for i, v := range values {
+				m, err := internal.ConvertString(v, valueType)
+				if err != nil {
+					return hzcerrors.NewLoggableError(err, "Conversion error on value %s to value-type %s", v, valueType)
+				}
+				messages[i] = m
+			}
+			if dryrun.Enabled(cmd) {
+				dryrun.Print(cmd, "publish %d messages to topic %s", len(messages), topicName)
+				for _, m := range messages {
+					fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", output.String(m))
+				}
+				return nil
+			}
+			t, err := getTopic(cmd.Context(), config, topicName)
+			if err != nil {
+				return err
+			}
+			if len(messages) == 1 {
+				err = t.Publish(cmd.Context(), messages[0])
+			} else {
+				err = t.PublishAll(cmd.Context(), messages...)
+			}
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot publish the messages to topic %s", topicName)
+			}
+			return nil
+		},
+	}
+	decorateCommandWithTopicNameFlag(cmd, &topicName)
+	cmd.Flags().StringArrayVarP(&values, ValueFlag, ValueFlagShort, nil, "message to publish, can be repeated")
+	if err := cmd.MarkFlagRequired(ValueFlag); err != nil {
+		panic(err)
+	}
+	cmd.Flags().StringVarP(&valueType, ValueTypeFlag, ValueTypeFlagShort, "string", fmt.Sprintf("type of the messages, one of: %s", strings.Join(internal.SupportedTypeNames, ",")))
+	if err := cmd.RegisterFlagCompletionFunc(ValueTypeFlag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return internal.SupportedTypeNames, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		panic(err)
+	}
+	return dryrun.Supported(cmd)
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package topiccmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/output"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+)
+
+const (
+	CountFlag = "count"
+	JSONFlag  = "json"
+)
+
+func NewSubscribe(config *hazelcast.Config) *cobra.Command {
+	var (
+		topicName string
+		count     int
+		asJSON    bool
+	)
+	cmd := &cobra.Command{
+		Use:   "subscribe --name topicname [--count count] [--json]",
+		Short: "Print the messages published to the topic",
+		Long: `Print the messages published to the topic after subscribing, one message on each line, until Ctrl+C is pressed.
+The messages published before subscribing are not printed.`,
+		Example: `  # Print the messages until Ctrl+C is pressed
+  hzc topic subscribe --name orders
+  # Print the next 10 messages with their publish times as JSON objects
+  hzc topic subscribe --name orders --count 10 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if count < 0 {
+				return hzcerrors.NewLoggableError(nil, "Count must not be negative")
+			}
+			ctx := cmd.Context()
+			t, err := getTopic(ctx, config, topicName)
+			if err != nil {
+				return err
+			}
+			// the messages are printed in the command goroutine, so that the output is not written concurrently
+			messages := make(chan *hazelcast.MessagePublished, 1024)
+			// done stops the messages received after the command returns from blocking the listener
+			done := make(chan struct{})
+			defer close(done)
+			id, err := t.AddMessageListener(ctx, func(event *hazelcast.MessagePublished) {
+				select {
+				case messages <- event:
+				case <-done:
+				}
+			})
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot subscribe to topic %s", topicName)
+			}
+			internal.RegisterListener("topic", topicName, id, func(ctx context.Context) error {
+				return t.RemoveListener(ctx, id)
+			})
+			defer func() {
+				// a listener which cannot be removed stays listed, so that it can be removed later with "listener remove"
+				if err := t.RemoveListener(context.Background(), id); err != nil {
+					cmd.PrintErrf("Cannot remove the listener %s of topic %s, remove it with \"hzc listener remove %s\"\n", id, topicName, id)
+					return
+				}
+				internal.UnregisterListener(id)
+			}()
+			quiet.Printf(cmd, "Subscribed to topic %s, press Ctrl+C to stop\n", topicName)
+			for n := 0; count == 0 || n < count; n++ {
+				select {
+				case <-ctx.Done():
+					return nil
+				case m := <-messages:
+					if err := printMessage(cmd.OutOrStdout(), m.PublishTime, m.Value, asJSON); err != nil {
+						return hzcerrors.NewLoggableError(err, "Cannot print the message")
+					}
+				}
+			}
+			return nil
+		},
+	}
+	decorateCommandWithTopicNameFlag(cmd, &topicName)
+	cmd.Flags().IntVar(&count, CountFlag, 0, "number of messages to print before stopping, 0 means until Ctrl+C is pressed")
+	cmd.Flags().BoolVar(&asJSON, JSONFlag, false, "print each message as a JSON object with its publish time and value")
+	return cmd
+}
+
+// printMessage writes the value of the message on a line, or a JSON object with its publish time and value.
+func printMessage(w io.Writer, publishTime time.Time, value interface{}, asJSON bool) error {
+	if !asJSON {
+		_, err := fmt.Fprintln(w, output.String(value))
+		return err
+	}
+	b, err := output.DefaultOptions().MarshalJSONObject([]string{"publishTime", "value"}, []interface{}{publishTime.Format(time.RFC3339Nano), value})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
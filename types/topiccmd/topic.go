@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package topiccmd
+
+import (
+	"context"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal"
+)
+
+const (
+	TopicNameFlag      = "name"
+	TopicNameFlagShort = "n"
+)
+
+const TopicExample = `  # Print the messages published to the topic until Ctrl+C is pressed
+  hzc topic subscribe --name orders
+  # Publish a JSON message to the topic
+  hzc topic publish --name orders --value-type json --value '{"id": 1}'`
+
+func New(config *hazelcast.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "topic {publish | subscribe} --name topicname",
+		Short:   "Topic operations",
+		Example: TopicExample,
+	}
+	cmd.AddCommand(NewPublish(config), NewSubscribe(config))
+	return cmd
+}
+
+func getTopic(ctx context.Context, clientConfig *hazelcast.Config, name string) (*hazelcast.Topic, error) {
+	hzcClient, err := internal.ConnectToCluster(ctx, clientConfig)
+	if err != nil {
+		return nil, hzcerrors.NewLoggableError(err, "Cannot get initialize client")
+	}
+	t, err := hzcClient.GetTopic(ctx, name)
+	if err != nil {
+		if msg, isHandled := hzcerrors.TranslateNetworkError(err, clientConfig.Cluster.Cloud.Enabled); isHandled {
+			err = hzcerrors.NewLoggableError(err, msg)
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func decorateCommandWithTopicNameFlag(cmd *cobra.Command, topicName *string) {
+	cmd.Flags().StringVarP(topicName, TopicNameFlag, TopicNameFlagShort, "", "name of the topic")
+	if err := cmd.MarkFlagRequired(TopicNameFlag); err != nil {
+		panic(err)
+	}
+}
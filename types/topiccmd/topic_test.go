@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package topiccmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+)
+
+func TestPrintMessage(t *testing.T) {
+	publishTime := time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		value  interface{}
+		asJSON bool
+		want   string
+	}{
+		{value: "order-1", want: "order-1\n"},
+		{value: int64(42), want: "42\n"},
+		{value: "order-1", asJSON: true, want: "{\"publishTime\":\"2022-06-01T10:30:00Z\",\"value\":\"order-1\"}\n"},
+		{value: serialization.JSON(`{"id":1}`), asJSON: true, want: "{\"publishTime\":\"2022-06-01T10:30:00Z\",\"value\":{\"id\":1}}\n"},
+	} {
+		var b bytes.Buffer
+		if err := printMessage(&b, publishTime, tc.value, tc.asJSON); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tc.want {
+			t.Errorf("want %q got %q", tc.want, b.String())
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package updatecmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
+	"github.com/hazelcast/hazelcast-commandline-client/versioncmd"
+)
+
+const (
+	checkFilename = "update-check.json"
+	// checkInterval is the time between the checks of the latest release for the notice
+	checkInterval = 24 * time.Hour
+)
+
+// updateCheck is the result of the last check of the latest release, it is saved to show the notice without waiting for GitHub.
+type updateCheck struct {
+	Checked time.Time `json:"checked"`
+	Latest  string    `json:"latest"`
+}
+
+func checkPath() string {
+	return filepath.Join(file.HZCHomePath(), checkFilename)
+}
+
+func loadCheck(path string) updateCheck {
+	var c updateCheck
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	// a corrupt file is checked again
+	_ = json.Unmarshal(b, &c)
+	return c
+}
+
+func saveCheck(path string, c updateCheck) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	// the notice is only informational, failing to save the check is not an error
+	_ = file.CreateMissingDirsAndFileWithRWPerms(path, b)
+}
+
+// Notice returns the notice of the newer release found by the last check, it is empty if there is none.
+// If the last check is older than a day, the latest release is checked again in the background, so a new release is noticed in the next session.
+// There is no notice for the development builds.
+func Notice(ctx context.Context) string {
+	current := versioncmd.Version
+	if _, _, ok := parseVersion(current); !ok {
+		return ""
+	}
+	path := checkPath()
+	last := loadCheck(path)
+	if time.Since(last.Checked) > checkInterval {
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			c := updateCheck{Checked: time.Now(), Latest: last.Latest}
+			// the failed checks are saved as well, so that they are not retried in each session while offline
+			if r, err := latestRelease(ctx, &http.Client{}, latestReleaseURL); err == nil {
+				c.Latest = r.Tag
+			}
+			saveCheck(path, c)
+		}()
+	}
+	return notice(last.Latest, current)
+}
+
+func notice(latest, current string) string {
+	if !isNewer(latest, current) {
+		return ""
+	}
+	return fmt.Sprintf("hzc %s is available, the current version is %s. Run \"hzc update\" to update.", latest, current)
+}
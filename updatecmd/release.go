@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package updatecmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	latestReleaseURL = "https://api.github.com/repos/hazelcast/hazelcast-commandline-client/releases/latest"
+	checksumsName    = "checksums.txt"
+	binaryName       = "hzc"
+	// maxDownloadSize limits the size of the downloaded archives, the archives of the releases are a few megabytes
+	maxDownloadSize = 256 << 20
+)
+
+// describeSuffix matches the suffix git describe adds to the versions of the builds after a release, such as v5.2.0-3-g1a2b3c4
+var describeSuffix = regexp.MustCompile(`(^|-)[0-9]+-g[0-9a-f]+(-dirty)?$`)
+
+type release struct {
+	Tag    string         `json:"tag_name"`
+	Assets []releaseAsset `json:"assets"`
+}
+
+type releaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+func (r *release) asset(name string) (releaseAsset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return releaseAsset{}, false
+}
+
+// latestRelease returns the latest release published on GitHub, the pre-releases are not included.
+func latestRelease(ctx context.Context, client *http.Client, url string) (*release, error) {
+	b, err := download(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	var r release
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("invalid release information: %w", err)
+	}
+	if r.Tag == "" {
+		return nil, errors.New("the release has no tag")
+	}
+	return &r, nil
+}
+
+func download(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status of %s: %s", url, resp.Status)
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxDownloadSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", url, maxDownloadSize)
+	}
+	return b, nil
+}
+
+// archiveName returns the name of the release archive for the platform, following the naming of the release builds.
+func archiveName(version, goos, goarch string) (string, error) {
+	var osName, archName string
+	switch goos {
+	case "linux":
+		osName = "Linux"
+	case "darwin":
+		osName = "Darwin"
+	default:
+		return "", fmt.Errorf("there are no releases for %s", goos)
+	}
+	switch goarch {
+	case "amd64":
+		archName = "x86_64"
+	case "arm64":
+		archName = "arm64"
+	default:
+		return "", fmt.Errorf("there are no releases for %s", goarch)
+	}
+	return fmt.Sprintf("hazelcast-commandline-client_%s_%s_%s.tar.gz", strings.TrimPrefix(version, "v"), osName, archName), nil
+}
+
+// verifyChecksum checks the SHA-256 checksum of the file against the one in the checksums file of the release.
+func verifyChecksum(checksums []byte, name string, b []byte) error {
+	s := bufio.NewScanner(bytes.NewReader(checksums))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 || fields[1] != name {
+			continue
+		}
+		sum := sha256.Sum256(b)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("the checksum of %s does not match, the download may be corrupt", name)
+		}
+		return nil
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("%s has no checksum in %s", name, checksumsName)
+}
+
+// extractBinary returns the hzc binary in the gzipped tar archive.
+func extractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("the archive does not contain %s", binaryName)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag == tar.TypeReg && path.Clean(h.Name) == binaryName {
+			return ioutil.ReadAll(tr)
+		}
+	}
+}
+
+// replaceExecutable replaces the file with the new binary.
+// The binary is written next to the file first and renamed over it, so that the file is either the old or the new binary.
+func replaceExecutable(path string, b []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+binaryName+"-update-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0100); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// isNewer returns true if the latest version is newer than the current one.
+// It returns false if either of them is not a release version, such as the version of a development build.
+func isNewer(latest, current string) bool {
+	l, lpre, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, cpre, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	// a release is newer than its pre-releases
+	return lpre == "" && cpre != ""
+}
+
+// parseVersion parses versions such as v5.2.0 and 5.2.0-BETA-1 into their numbers and the pre-release part.
+// The versions of the development builds are not parsed.
+func parseVersion(v string) ([3]int, string, bool) {
+	var nums [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	var pre string
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		v, pre = v[:i], v[i+1:]
+	}
+	if describeSuffix.MatchString(pre) || strings.HasSuffix(pre, "-dirty") {
+		return nums, "", false
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nums, "", false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nums, "", false
+		}
+		nums[i] = n
+	}
+	return nums, pre, true
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package updatecmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNewer(t *testing.T) {
+	for _, tc := range []struct {
+		latest  string
+		current string
+		newer   bool
+	}{
+		{latest: "v1.2.0", current: "v1.1.9", newer: true},
+		{latest: "v1.10.0", current: "v1.9.0", newer: true},
+		{latest: "v2.0.0", current: "1.9.9", newer: true},
+		{latest: "v1.2.0", current: "v1.2.0"},
+		{latest: "v1.1.0", current: "v1.2.0"},
+		{latest: "v1.2.0", current: "v1.2.0-BETA-1", newer: true},
+		{latest: "v1.2.0-BETA-2", current: "v1.2.0"},
+		{latest: "v1.2.0", current: "unknown"},
+		{latest: "v1.2.0", current: "v1.1.0-3-g1a2b3c4"},
+		{latest: "v1.2.0", current: "v1.1.0-BETA-1-3-g1a2b3c4-dirty"},
+		{latest: "", current: "v1.1.0"},
+	} {
+		t.Run(fmt.Sprintf("%s %s", tc.latest, tc.current), func(t *testing.T) {
+			assert.Equal(t, tc.newer, isNewer(tc.latest, tc.current))
+		})
+	}
+}
+
+func TestArchiveName(t *testing.T) {
+	name, err := archiveName("v5.2.0", "darwin", "arm64")
+	assert.NoError(t, err)
+	assert.Equal(t, "hazelcast-commandline-client_5.2.0_Darwin_arm64.tar.gz", name)
+	name, err = archiveName("v5.2.0", "linux", "amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, "hazelcast-commandline-client_5.2.0_Linux_x86_64.tar.gz", name)
+	_, err = archiveName("v5.2.0", "windows", "amd64")
+	assert.Error(t, err)
+}
+
+func TestDownloadBinary(t *testing.T) {
+	const name = "hazelcast-commandline-client_5.2.0_Linux_x86_64.tar.gz"
+	archive := tarGz(t, map[string]string{"README.md": "readme", "hzc": "new binary"})
+	sum := sha256.Sum256(archive)
+	checksums := fmt.Sprintf("0000  other.tar.gz\n%s  %s\n", hex.EncodeToString(sum[:]), name)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest":
+			fmt.Fprintf(w, `{"tag_name": "v5.2.0", "assets": [{"name": %q, "browser_download_url": "http://%s/archive"}, {"name": "checksums.txt", "browser_download_url": "http://%s/checksums"}]}`, name, r.Host, r.Host)
+		case "/archive":
+			w.Write(archive)
+		case "/checksums":
+			w.Write([]byte(checksums))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	r, err := latestRelease(ctx, srv.Client(), srv.URL+"/latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "v5.2.0", r.Tag)
+	archiveURL, _ := r.asset(name)
+	b, err := download(ctx, srv.Client(), archiveURL.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, verifyChecksum([]byte(checksums), name, b))
+	assert.Error(t, verifyChecksum([]byte(checksums), name, append(b, 0)))
+	assert.Error(t, verifyChecksum([]byte(checksums), "missing.tar.gz", b))
+	bin, err := extractBinary(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "new binary", string(bin))
+	_, err = extractBinary(tarGz(t, map[string]string{"README.md": "readme"}))
+	assert.Error(t, err)
+	_, err = latestRelease(ctx, srv.Client(), srv.URL+"/missing")
+	assert.Error(t, err)
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hzc-update")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "hzc")
+	if err := ioutil.WriteFile(path, []byte("old binary"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, replaceExecutable(path, []byte("new binary")))
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "new binary", string(b))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestNotice(t *testing.T) {
+	assert.Equal(t, `hzc v1.2.0 is available, the current version is v1.1.0. Run "hzc update" to update.`, notice("v1.2.0", "v1.1.0"))
+	assert.Equal(t, "", notice("v1.1.0", "v1.1.0"))
+	assert.Equal(t, "", notice("", "v1.1.0"))
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package updatecmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/quiet"
+	"github.com/hazelcast/hazelcast-commandline-client/versioncmd"
+)
+
+const UpdateExample = `  # Check whether a newer release is available, without updating
+  hzc update --check-only
+
+  # Replace hzc with the latest release
+  hzc update`
+
+const (
+	checkOnlyFlag = "check-only"
+	// checkTimeout limits the time to get the latest release, the downloads are only limited by the context
+	checkTimeout = 30 * time.Second
+)
+
+// New creates the update command, which does not connect to the cluster.
+func New() *cobra.Command {
+	var checkOnly bool
+	cmd := &cobra.Command{
+		Use:     "update [--check-only]",
+		Short:   "Update hzc to the latest release",
+		Long:    "Update hzc to the latest release on GitHub. The checksum of the downloaded archive is verified before the binary is replaced in place.",
+		Example: UpdateExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			client := &http.Client{}
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			r, err := latestRelease(checkCtx, client, latestReleaseURL)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get the latest release of hzc")
+			}
+			saveCheck(checkPath(), updateCheck{Checked: time.Now(), Latest: r.Tag})
+			current := versioncmd.Version
+			if _, _, ok := parseVersion(current); !ok {
+				return hzcerrors.NewLoggableError(nil, "hzc %s is not a release build, the latest release is %s", current, r.Tag)
+			}
+			out := cmd.OutOrStdout()
+			if !isNewer(r.Tag, current) {
+				fmt.Fprintf(out, "hzc %s is the latest release\n", current)
+				return nil
+			}
+			if checkOnly {
+				fmt.Fprintf(out, "hzc %s is available, the current version is %s\n", r.Tag, current)
+				return nil
+			}
+			exe, err := executablePath()
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot find the path of hzc")
+			}
+			if isHomebrew(exe) {
+				return hzcerrors.NewLoggableError(nil, "hzc is installed with Homebrew, update it with: brew upgrade hazelcast-commandline-client")
+			}
+			quiet.Printf(cmd, "Downloading hzc %s\n", r.Tag)
+			b, err := downloadBinary(ctx, client, r)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot download hzc %s", r.Tag)
+			}
+			if err := replaceExecutable(exe, b); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot replace %s, make sure it is writable", exe)
+			}
+			fmt.Fprintf(out, "Updated hzc from %s to %s\n", current, r.Tag)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&checkOnly, checkOnlyFlag, false, "only print whether a newer release is available")
+	return cmd
+}
+
+// downloadBinary downloads the release archive for the platform, verifies its checksum and returns the binary in it.
+func downloadBinary(ctx context.Context, client *http.Client, r *release) ([]byte, error) {
+	name, err := archiveName(r.Tag, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	archive, ok := r.asset(name)
+	if !ok {
+		return nil, fmt.Errorf("the release has no %s", name)
+	}
+	sums, ok := r.asset(checksumsName)
+	if !ok {
+		return nil, fmt.Errorf("the release has no %s to verify the download", checksumsName)
+	}
+	checksums, err := download(ctx, client, sums.URL)
+	if err != nil {
+		return nil, err
+	}
+	b, err := download(ctx, client, archive.URL)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksum(checksums, name, b); err != nil {
+		return nil, err
+	}
+	return extractBinary(b)
+}
+
+// executablePath returns the path of the running binary, with the symbolic links resolved.
+func executablePath() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(exe)
+}
+
+func isHomebrew(path string) bool {
+	return strings.Contains(filepath.ToSlash(path), "/Cellar/")
+}